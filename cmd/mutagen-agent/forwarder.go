@@ -32,7 +32,15 @@ func forwarderMain(_ *cobra.Command, _ []string) error {
 			logLevel = l
 		}
 	}
-	logger := logging.NewLogger(logLevel, os.Stderr)
+	logFormat := logging.FormatText
+	if forwarderConfiguration.logFormat != "" {
+		if f, ok := logging.NameToFormat(forwarderConfiguration.logFormat); !ok {
+			return fmt.Errorf("invalid log format specified: %s", forwarderConfiguration.logFormat)
+		} else {
+			logFormat = f
+		}
+	}
+	logger := logging.NewFormattedLogger(logLevel, logFormat, os.Stderr)
 
 	// Create a stream using standard input/output.
 	stream := newStdioStream()
@@ -78,6 +86,8 @@ var forwarderConfiguration struct {
 	help bool
 	// logLevel indicates the log level to use.
 	logLevel string
+	// logFormat indicates the log output format to use.
+	logFormat string
 }
 
 func init() {
@@ -93,4 +103,5 @@ func init() {
 
 	// Wire up logging flags.
 	flags.StringVar(&forwarderConfiguration.logLevel, agent.FlagLogLevel, "", "Set the log level")
+	flags.StringVar(&forwarderConfiguration.logFormat, agent.FlagLogFormat, "", "Set the log output format")
 }
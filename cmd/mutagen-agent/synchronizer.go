@@ -64,7 +64,15 @@ func synchronizerMain(_ *cobra.Command, _ []string) error {
 			logLevel = l
 		}
 	}
-	logger := logging.NewLogger(logLevel, os.Stderr)
+	logFormat := logging.FormatText
+	if synchronizerConfiguration.logFormat != "" {
+		if f, ok := logging.NameToFormat(synchronizerConfiguration.logFormat); !ok {
+			return fmt.Errorf("invalid log format specified: %s", synchronizerConfiguration.logFormat)
+		} else {
+			logFormat = f
+		}
+	}
+	logger := logging.NewFormattedLogger(logLevel, logFormat, os.Stderr)
 
 	// Set up regular housekeeping and defer its shutdown.
 	ctx, cancel := context.WithCancel(context.Background())
@@ -115,6 +123,8 @@ var synchronizerConfiguration struct {
 	help bool
 	// logLevel indicates the log level to use.
 	logLevel string
+	// logFormat indicates the log output format to use.
+	logFormat string
 }
 
 func init() {
@@ -130,4 +140,5 @@ func init() {
 
 	// Wire up logging flags.
 	flags.StringVar(&synchronizerConfiguration.logLevel, agent.FlagLogLevel, "", "Set the log level")
+	flags.StringVar(&synchronizerConfiguration.logFormat, agent.FlagLogFormat, "", "Set the log output format")
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"os"
 	"os/signal"
 
@@ -8,6 +9,7 @@ import (
 
 	"github.com/mutagen-io/mutagen/cmd"
 
+	"github.com/mutagen-io/mutagen/pkg/logging"
 	"github.com/mutagen-io/mutagen/pkg/mutagen"
 )
 
@@ -17,8 +19,29 @@ func rootMain(_ *cobra.Command, _ []string) error {
 	signalTermination := make(chan os.Signal, 1)
 	signal.Notify(signalTermination, cmd.TerminationSignals...)
 
+	// Set up a logger on the standard error stream.
+	logLevel := logging.LevelInfo
+	if envLogLevel := os.Getenv("MUTAGEN_LOG_LEVEL"); envLogLevel != "" {
+		if l, ok := logging.NameToLevel(envLogLevel); !ok {
+			return fmt.Errorf("invalid log level specified in environment: %s", envLogLevel)
+		} else {
+			logLevel = l
+		}
+	}
+	logFormat := logging.FormatText
+	if envLogFormat := os.Getenv("MUTAGEN_LOG_FORMAT"); envLogFormat != "" {
+		if f, ok := logging.NameToFormat(envLogFormat); !ok {
+			return fmt.Errorf("invalid log format specified in environment: %s", envLogFormat)
+		} else {
+			logFormat = f
+		}
+	}
+	logger := logging.NewFormattedLogger(logLevel, logFormat, os.Stderr)
+	logger.Info("Sidecar started, version", mutagen.Version)
+
 	// Wait for termination.
-	<-signalTermination
+	s := <-signalTermination
+	logger.Info("Terminating due to signal:", s)
 
 	// Success.
 	return nil
@@ -1,34 +1,33 @@
 package daemon
 
 import (
-	"context"
-	"errors"
 	"fmt"
 	"os"
-	"time"
+
+	"github.com/spf13/pflag"
 
 	"google.golang.org/grpc"
 
 	"github.com/mutagen-io/mutagen/cmd"
 
+	"github.com/mutagen-io/mutagen/pkg/client"
 	"github.com/mutagen-io/mutagen/pkg/daemon"
-	"github.com/mutagen-io/mutagen/pkg/grpcutil"
-	"github.com/mutagen-io/mutagen/pkg/ipc"
-	"github.com/mutagen-io/mutagen/pkg/mutagen"
-	daemonsvc "github.com/mutagen-io/mutagen/pkg/service/daemon"
 )
 
-const (
-	// dialTimeout is the timeout to use when attempting to connect to the
-	// daemon IPC endpoint.
-	dialTimeout = 500 * time.Millisecond
-	// autostartWaitInterval is the wait period between reconnect attempts after
-	// autostarting the daemon.
-	autostartWaitInterval = 100 * time.Millisecond
-	// autostartRetryCount is the number of times to try reconnecting after
-	// autostarting the daemon.
-	autostartRetryCount = 10
-)
+// remoteConfiguration stores configuration for connecting to a remote daemon.
+var remoteConfiguration struct {
+	// address is the TCP address of the remote daemon, if any.
+	address string
+	// credentials is the path to the remote daemon credentials, if any.
+	credentials string
+}
+
+// RegisterRemoteFlags registers flags for connecting to a remote daemon in the
+// specified flag set.
+func RegisterRemoteFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&remoteConfiguration.address, "daemon", "", "Connect to a remote daemon at the specified address (<host>:<port>)")
+	flags.StringVar(&remoteConfiguration.credentials, "daemon-credentials", "", "Use remote daemon credentials from the specified path")
+}
 
 // autostartDisabled controls whether or not daemon autostart is disabled for
 // Mutagen. It is set automatically based on the MUTAGEN_DISABLE_AUTOSTART
@@ -41,98 +40,71 @@ func init() {
 }
 
 // Connect creates a new daemon client connection and optionally verifies that
-// the daemon version matches the current process' version.
+// the daemon version matches the current process' version. If a remote daemon
+// address has been specified (either via the --daemon flag or the
+// MUTAGEN_DAEMON_ADDRESS environment variable), then the connection is made to
+// that daemon and autostart is disabled.
 func Connect(autostart, enforceVersionMatch bool) (*grpc.ClientConn, error) {
-	// Compute the path to the daemon IPC endpoint.
-	endpoint, err := daemon.EndpointPath()
-	if err != nil {
-		return nil, fmt.Errorf("unable to compute endpoint path: %w", err)
+	// Create the connection configuration.
+	configuration := &client.Configuration{
+		Autostart:        autostart && !autostartDisabled,
+		SkipVersionCheck: !enforceVersionMatch,
 	}
 
-	// Check if autostart has been disabled by an environment variable.
-	if autostartDisabled {
-		autostart = false
+	// Check whether or not a remote daemon has been specified. If so, then load
+	// the credentials needed to access it.
+	address := remoteConfiguration.address
+	if address == "" {
+		address = os.Getenv("MUTAGEN_DAEMON_ADDRESS")
+	}
+	if address != "" {
+		// Compute the path to the remote daemon credentials.
+		path := remoteConfiguration.credentials
+		if path == "" {
+			path = os.Getenv("MUTAGEN_DAEMON_CREDENTIALS")
+		}
+		if path == "" {
+			if p, err := daemon.RemoteCredentialsPath(); err != nil {
+				return nil, fmt.Errorf("unable to compute remote daemon credentials path: %w", err)
+			} else {
+				path = p
+			}
+		}
+
+		// Load the credentials.
+		remoteCredentials, err := daemon.LoadRemoteCredentials(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load remote daemon credentials: %w", err)
+		}
+
+		// Connect to the remote daemon.
+		configuration.Address = address
+		configuration.Credentials = remoteCredentials
+		return client.Dial(configuration)
 	}
 
 	// Create a status line printer and defer a clear.
 	statusLinePrinter := &cmd.StatusLinePrinter{UseStandardError: true}
 	defer statusLinePrinter.BreakIfPopulated()
 
-	// Perform dialing in a loop until failure or success.
-	remainingPostAutostatAttempts := autostartRetryCount
+	// Set up autostart to report its progress.
 	invokedStart := false
-	var connection *grpc.ClientConn
-	for {
-		// Create a context to timeout the dial.
-		ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
-
-		// Attempt to dial.
-		connection, err = grpc.DialContext(
-			ctx, endpoint,
-			grpc.WithInsecure(),
-			grpc.WithContextDialer(ipc.DialContext),
-			grpc.WithBlock(),
-			grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(grpcutil.MaximumMessageSize)),
-			grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(grpcutil.MaximumMessageSize)),
-		)
-
-		// Cancel the dialing context. If the dialing operation has already
-		// succeeded, this has no effect, but it is necessary to clean up the
-		// Goroutine that backs the context.
-		cancel()
-
-		// Check for errors.
-		if err != nil {
-			// Handle failure due to timeouts.
-			if err == context.DeadlineExceeded {
-				// If autostart is enabled, and we have attempts remaining, then
-				// try autostarting, waiting, and retrying.
-				if autostart && remainingPostAutostatAttempts > 0 {
-					if !invokedStart {
-						statusLinePrinter.Print("Attempting to start Mutagen daemon...")
-						startMain(nil, nil)
-						invokedStart = true
-					}
-					time.Sleep(autostartWaitInterval)
-					remainingPostAutostatAttempts--
-					continue
-				}
-
-				// Otherwise just fail due to the timeout.
-				return nil, errors.New("connection timed out (is the daemon running?)")
-			}
-
-			// If we failed for any other reason, then bail.
-			return nil, err
-		}
-
-		// Print a notice if we started the daemon.
-		if invokedStart {
-			statusLinePrinter.Clear()
-			statusLinePrinter.Print("Started Mutagen daemon in background (terminate with \"mutagen daemon stop\")")
-		}
+	configuration.Start = func() error {
+		statusLinePrinter.Print("Attempting to start Mutagen daemon...")
+		invokedStart = true
+		return startMain(nil, nil)
+	}
 
-		// We've successfully dialed, so break out of the dialing loop.
-		break
+	// Connect to the local daemon.
+	connection, err := client.Dial(configuration)
+	if err != nil {
+		return nil, err
 	}
 
-	// If requested, verify that the daemon version matches the current process'
-	// version.
-	if enforceVersionMatch {
-		daemonService := daemonsvc.NewDaemonClient(connection)
-		version, err := daemonService.Version(context.Background(), &daemonsvc.VersionRequest{})
-		if err != nil {
-			connection.Close()
-			return nil, fmt.Errorf("unable to query daemon version: %w", err)
-		}
-		versionMatch := version.Major == mutagen.VersionMajor &&
-			version.Minor == mutagen.VersionMinor &&
-			version.Patch == mutagen.VersionPatch &&
-			version.Tag == mutagen.VersionTag
-		if !versionMatch {
-			connection.Close()
-			return nil, errors.New("client/daemon version mismatch (daemon restart recommended)")
-		}
+	// Print a notice if we started the daemon.
+	if invokedStart {
+		statusLinePrinter.Clear()
+		statusLinePrinter.Print("Started Mutagen daemon in background (terminate with \"mutagen daemon stop\")")
 	}
 
 	// Success.
@@ -0,0 +1,75 @@
+package daemon
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/mutagen-io/mutagen/cmd"
+
+	"github.com/mutagen-io/mutagen/pkg/daemon"
+	"github.com/mutagen-io/mutagen/pkg/encoding"
+)
+
+// credentialsMain is the entry point for the credentials command. It prints the
+// daemon's certificate and bearer token, which clients use (by default from
+// the daemon subdirectory of their own Mutagen data directory) to access the
+// daemon remotely. The token grants full control over the daemon.
+func credentialsMain(_ *cobra.Command, _ []string) error {
+	// Load the daemon's remote access credentials, generating them if needed.
+	credentials, err := daemon.LoadOrCreateRemoteServerCredentials()
+	if err != nil {
+		return fmt.Errorf("unable to load remote access credentials: %w", err)
+	}
+
+	// Write the client-facing credentials.
+	if credentialsConfiguration.output != "" {
+		if err := encoding.MarshalAndSaveYAML(credentialsConfiguration.output, &credentials.RemoteCredentials); err != nil {
+			return fmt.Errorf("unable to save credentials: %w", err)
+		}
+	} else {
+		data, err := yaml.Marshal(&credentials.RemoteCredentials)
+		if err != nil {
+			return fmt.Errorf("unable to marshal credentials: %w", err)
+		}
+		os.Stdout.Write(data)
+	}
+
+	// Success.
+	return nil
+}
+
+// credentialsCommand is the credentials command.
+var credentialsCommand = &cobra.Command{
+	Use:          "credentials",
+	Short:        "Show the credentials used by clients to access this daemon remotely",
+	Args:         cmd.DisallowArguments,
+	RunE:         credentialsMain,
+	SilenceUsage: true,
+}
+
+// credentialsConfiguration stores configuration for the credentials command.
+var credentialsConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// output is the path to which the credentials should be written.
+	output string
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := credentialsCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&credentialsConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up credentials flags.
+	flags.StringVarP(&credentialsConfiguration.output, "output", "o", "", "Write the credentials to the specified path instead of standard output")
+}
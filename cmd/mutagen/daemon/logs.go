@@ -0,0 +1,229 @@
+package daemon
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/cmd"
+
+	"github.com/mutagen-io/mutagen/pkg/daemon"
+	"github.com/mutagen-io/mutagen/pkg/identifier"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+)
+
+const (
+	// logsFollowPollingInterval is the interval at which the log file is polled
+	// for new content when following.
+	logsFollowPollingInterval = 250 * time.Millisecond
+)
+
+// logFilter filters daemon log lines.
+type logFilter struct {
+	// level is the maximum log level to display.
+	level logging.Level
+	// scope is the scope component required for display, if any.
+	scope string
+	// session is the session identifier required for display, if any. Lines
+	// are displayed if they match either scope or session.
+	session string
+	// include tracks whether or not the most recent log line was displayed.
+	// Lines that aren't logger output (e.g. panic traces) inherit this decision
+	// from the preceding line.
+	include bool
+}
+
+// process processes a single log line, printing it if it passes the filter.
+func (f *logFilter) process(line string) {
+	if parsed, ok := logging.ParseLine(line); ok {
+		f.include = parsed.Level <= f.level &&
+			(f.scope == "" || parsed.InScope(f.scope) || parsed.Session == f.session)
+	}
+	if f.include {
+		fmt.Print(line)
+	}
+}
+
+// processReader processes lines from a reader until EOF is reached, returning
+// any partial line at the end of the stream.
+func (f *logFilter) processReader(reader *bufio.Reader, partial string) (string, error) {
+	for {
+		line, err := reader.ReadString('\n')
+		line = partial + line
+		partial = ""
+		if errors.Is(err, io.EOF) {
+			return line, nil
+		} else if err != nil {
+			return "", err
+		}
+		f.process(line)
+	}
+}
+
+// logsMain is the entry point for the logs command.
+func logsMain(_ *cobra.Command, _ []string) error {
+	// Create the filter.
+	filter := &logFilter{level: logging.LevelTrace, include: true}
+	if logsConfiguration.level != "" {
+		if level, ok := logging.NameToLevel(logsConfiguration.level); !ok {
+			return fmt.Errorf("invalid log level: %s", logsConfiguration.level)
+		} else {
+			filter.level = level
+		}
+	}
+	if logsConfiguration.session != "" {
+		if truncated := identifier.Truncated(logsConfiguration.session); truncated != "" {
+			filter.scope = truncated
+		} else {
+			filter.scope = logsConfiguration.session
+		}
+		filter.session = logsConfiguration.session
+		filter.include = false
+	}
+
+	// Compute the log file path.
+	path, err := daemon.LogPath()
+	if err != nil {
+		return fmt.Errorf("unable to compute log file path: %w", err)
+	}
+
+	// Process rotated log files, oldest first.
+	for _, rotated := range logging.RotatedPaths(path) {
+		file, err := os.Open(rotated)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return fmt.Errorf("unable to open rotated log file: %w", err)
+		}
+		partial, err := filter.processReader(bufio.NewReader(file), "")
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("unable to read rotated log file: %w", err)
+		} else if partial != "" {
+			filter.process(partial + "\n")
+		}
+	}
+
+	// Open the active log file. If it doesn't exist and we're not following,
+	// then there's nothing more to print.
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) && !logsConfiguration.follow {
+			return nil
+		} else if !os.IsNotExist(err) {
+			return fmt.Errorf("unable to open log file: %w", err)
+		}
+	}
+	defer func() {
+		if file != nil {
+			file.Close()
+		}
+	}()
+
+	// Process the active log file.
+	var partial string
+	if file != nil {
+		if partial, err = filter.processReader(bufio.NewReader(file), ""); err != nil {
+			return fmt.Errorf("unable to read log file: %w", err)
+		}
+	}
+
+	// If we're not following, then print any partial line and we're done.
+	if !logsConfiguration.follow {
+		if partial != "" {
+			filter.process(partial + "\n")
+		}
+		return nil
+	}
+
+	// Set up termination signal handling.
+	signalTermination := make(chan os.Signal, 1)
+	signal.Notify(signalTermination, cmd.TerminationSignals...)
+
+	// Poll for new content, handling rotation by reopening the log file when it
+	// is replaced.
+	ticker := time.NewTicker(logsFollowPollingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-signalTermination:
+			return nil
+		case <-ticker.C:
+		}
+
+		// Read any new content from the current file.
+		if file != nil {
+			if partial, err = filter.processReader(bufio.NewReader(file), partial); err != nil {
+				return fmt.Errorf("unable to read log file: %w", err)
+			}
+		}
+
+		// Check whether or not the log file has been replaced.
+		current, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if file != nil {
+			if existing, err := file.Stat(); err == nil && os.SameFile(existing, current) {
+				continue
+			}
+			partial, err = filter.processReader(bufio.NewReader(file), partial)
+			file.Close()
+			if err != nil {
+				return fmt.Errorf("unable to read rotated log file: %w", err)
+			}
+		}
+		if partial != "" {
+			filter.process(partial + "\n")
+			partial = ""
+		}
+		if file, err = os.Open(path); err != nil {
+			file = nil
+		}
+	}
+}
+
+// logsCommand is the logs command.
+var logsCommand = &cobra.Command{
+	Use:          "logs",
+	Short:        "Show the Mutagen daemon log",
+	Args:         cmd.DisallowArguments,
+	RunE:         logsMain,
+	SilenceUsage: true,
+}
+
+// logsConfiguration stores configuration for the logs command.
+var logsConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// follow indicates whether or not to wait for and print new log output.
+	follow bool
+	// level is the maximum log level to display.
+	level string
+	// session is the session identifier by which to filter log output.
+	session string
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := logsCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&logsConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up logs flags.
+	flags.BoolVarP(&logsConfiguration.follow, "follow", "f", false, "Wait for and print new log output")
+	flags.StringVar(&logsConfiguration.level, "level", "", "Only show log lines at or above the specified severity (error|warn|info|debug|trace)")
+	flags.StringVar(&logsConfiguration.session, "session", "", "Only show log lines for the specified session identifier")
+}
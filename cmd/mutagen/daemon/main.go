@@ -49,6 +49,8 @@ func init() {
 		runCommand,
 		startCommand,
 		stopCommand,
+		logsCommand,
+		credentialsCommand,
 	}
 	if daemon.RegistrationSupported {
 		supportedCommands = append(supportedCommands,
@@ -1,21 +1,30 @@
 package daemon
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"google.golang.org/grpc"
+	grpccredentials "google.golang.org/grpc/credentials"
 
 	"github.com/mutagen-io/mutagen/cmd"
 
+	"github.com/mutagen-io/mutagen/pkg/client"
 	"github.com/mutagen-io/mutagen/pkg/daemon"
 	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/gateway"
 	"github.com/mutagen-io/mutagen/pkg/grpcutil"
 	"github.com/mutagen-io/mutagen/pkg/ipc"
 	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/metrics"
 	daemonsvc "github.com/mutagen-io/mutagen/pkg/service/daemon"
 	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
 	promptingsvc "github.com/mutagen-io/mutagen/pkg/service/prompting"
@@ -30,6 +39,31 @@ import (
 	_ "github.com/mutagen-io/mutagen/pkg/synchronization/protocols/ssh"
 )
 
+const (
+	// logMaximumSize is the size at which the daemon log file is rotated.
+	logMaximumSize = 10 * 1024 * 1024
+	// logMaximumCount is the maximum number of rotated daemon log files to
+	// retain.
+	logMaximumCount = 5
+	// logMaximumAge is the maximum age of rotated daemon log files to retain.
+	logMaximumAge = 7 * 24 * time.Hour
+)
+
+// errorIgnoringWriter is an io.Writer that forwards writes to an underlying
+// writer but ignores any errors (including short writes) that it encounters.
+// It's used to ensure that a failure in one log destination doesn't prevent
+// output from reaching other destinations when used with io.MultiWriter.
+type errorIgnoringWriter struct {
+	// writer is the underlying writer.
+	writer io.Writer
+}
+
+// Write implements io.Writer.Write.
+func (w *errorIgnoringWriter) Write(data []byte) (int, error) {
+	w.writer.Write(data)
+	return len(data), nil
+}
+
 // runMain is the entry point for the run command.
 func runMain(_ *cobra.Command, _ []string) error {
 	// Attempt to acquire the daemon lock and defer its release.
@@ -54,7 +88,32 @@ func runMain(_ *cobra.Command, _ []string) error {
 			logLevel = l
 		}
 	}
-	logger := logging.NewLogger(logLevel, os.Stderr)
+	logFormat := logging.FormatText
+	logFormatName := runConfiguration.logFormat
+	if logFormatName == "" {
+		logFormatName = os.Getenv("MUTAGEN_LOG_FORMAT")
+	}
+	if logFormatName != "" {
+		if f, ok := logging.NameToFormat(logFormatName); !ok {
+			return fmt.Errorf("invalid log format specified: %s", logFormatName)
+		} else {
+			logFormat = f
+		}
+	}
+	var logWriter io.Writer = os.Stderr
+	var logFileErr error
+	if logPath, err := daemon.LogPath(); err != nil {
+		logFileErr = fmt.Errorf("unable to compute log file path: %w", err)
+	} else if logFile, err := logging.NewRotatingFile(logPath, logMaximumSize, logMaximumCount, logMaximumAge); err != nil {
+		logFileErr = err
+	} else {
+		defer logFile.Close()
+		logWriter = io.MultiWriter(&errorIgnoringWriter{logFile}, os.Stderr)
+	}
+	logger := logging.NewFormattedLogger(logLevel, logFormat, logWriter)
+	if logFileErr != nil {
+		logger.Warn("Unable to create daemon log file:", logFileErr)
+	}
 
 	// Initialize the licensing manager and defer its shutdown. This must be
 	// done before creating forwarding and synchronization session managers
@@ -79,32 +138,35 @@ func runMain(_ *cobra.Command, _ []string) error {
 	}
 	defer synchronizationManager.Shutdown()
 
-	// Create the gRPC server and defer its termination. We use a hard stop
-	// rather than a graceful stop so that it doesn't hang on open requests.
-	server := grpc.NewServer(
-		grpc.MaxSendMsgSize(grpcutil.MaximumMessageSize),
-		grpc.MaxRecvMsgSize(grpcutil.MaximumMessageSize),
-	)
-	defer server.Stop()
-
-	// Create the daemon server, defer its shutdown, and register it.
+	// Create the daemon server and defer its shutdown.
 	daemonServer := daemonsvc.NewServer()
 	defer daemonServer.Shutdown()
-	daemonsvc.RegisterDaemonServer(server, daemonServer)
-
-	// Register the licensing service. This is a no-op in non-SSPL builds.
-	registerLicensingService(server)
-
-	// Create and register the prompt server.
-	promptingsvc.RegisterPromptingServer(server, promptingsvc.NewServer())
 
-	// Create and register the forwarding server.
+	// Create the remaining service servers.
+	promptingServer := promptingsvc.NewServer()
 	forwardingServer := forwardingsvc.NewServer(forwardingManager)
-	forwardingsvc.RegisterForwardingServer(server, forwardingServer)
-
-	// Create and register the synchronization server.
 	synchronizationServer := synchronizationsvc.NewServer(synchronizationManager)
-	synchronizationsvc.RegisterSynchronizationServer(server, synchronizationServer)
+
+	// Create a function to create gRPC servers with all services registered.
+	// The caller is responsible for stopping the server. We use a hard stop
+	// rather than a graceful stop so that it doesn't hang on open requests.
+	newServer := func(options ...grpc.ServerOption) *grpc.Server {
+		options = append(options,
+			grpc.MaxSendMsgSize(grpcutil.MaximumMessageSize),
+			grpc.MaxRecvMsgSize(grpcutil.MaximumMessageSize),
+		)
+		server := grpc.NewServer(options...)
+		daemonsvc.RegisterDaemonServer(server, daemonServer)
+		registerLicensingService(server)
+		promptingsvc.RegisterPromptingServer(server, promptingServer)
+		forwardingsvc.RegisterForwardingServer(server, forwardingServer)
+		synchronizationsvc.RegisterSynchronizationServer(server, synchronizationServer)
+		return server
+	}
+
+	// Create the local gRPC server and defer its termination.
+	server := newServer()
+	defer server.Stop()
 
 	// Compute the path to the daemon IPC endpoint.
 	endpoint, err := daemon.EndpointPath()
@@ -123,11 +185,101 @@ func runMain(_ *cobra.Command, _ []string) error {
 	defer listener.Close()
 
 	// Serve incoming requests and watch for server failure.
-	serverErrors := make(chan error, 1)
+	serverErrors := make(chan error, 2)
 	go func() {
 		serverErrors <- server.Serve(listener)
 	}()
 
+	// If a remote access address has been specified, then start the remote
+	// access server and defer its termination. Remote access requires TLS and
+	// bearer token authentication.
+	listenAddress := runConfiguration.listenAddress
+	if listenAddress == "" {
+		listenAddress = os.Getenv("MUTAGEN_DAEMON_LISTEN_ADDRESS")
+	}
+	if listenAddress != "" {
+		credentials, err := daemon.LoadOrCreateRemoteServerCredentials()
+		if err != nil {
+			return fmt.Errorf("unable to load remote access credentials: %w", err)
+		}
+		tlsConfiguration, err := credentials.ServerTLSConfiguration()
+		if err != nil {
+			return fmt.Errorf("unable to create remote access TLS configuration: %w", err)
+		}
+		remoteListener, err := net.Listen("tcp", listenAddress)
+		if err != nil {
+			return fmt.Errorf("unable to create remote access listener: %w", err)
+		}
+		defer remoteListener.Close()
+		options := append(
+			grpcutil.BearerTokenServerOptions(credentials.Token),
+			grpc.Creds(grpccredentials.NewTLS(tlsConfiguration)),
+		)
+		remoteServer := newServer(options...)
+		defer remoteServer.Stop()
+		go func() {
+			serverErrors <- remoteServer.Serve(remoteListener)
+		}()
+		logger.Info("Serving remote access on", remoteListener.Addr())
+	}
+
+	// If a metrics address has been specified, then start the metrics server
+	// and defer its closure.
+	metricsAddress := runConfiguration.metricsAddress
+	if metricsAddress == "" {
+		metricsAddress = os.Getenv("MUTAGEN_DAEMON_METRICS_ADDRESS")
+	}
+	metricsErrors := make(chan error, 1)
+	if metricsAddress != "" {
+		metricsListener, err := metrics.Listen(metricsAddress)
+		if err != nil {
+			return fmt.Errorf("unable to create metrics listener: %w", err)
+		}
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics.NewHandler(synchronizationManager, forwardingManager))
+		metricsServer := &http.Server{Handler: mux}
+		defer metricsServer.Close()
+		go func() {
+			if err := metricsServer.Serve(metricsListener); !errors.Is(err, http.ErrServerClosed) {
+				metricsErrors <- err
+			}
+		}()
+		logger.Info("Serving metrics on", metricsListener.Addr())
+	}
+
+	// If a gateway address has been specified, then start the HTTP gateway and
+	// defer its closure. The gateway accesses the daemon API via the local
+	// endpoint and requires the same bearer token as remote access.
+	gatewayAddress := runConfiguration.gatewayAddress
+	if gatewayAddress == "" {
+		gatewayAddress = os.Getenv("MUTAGEN_DAEMON_GATEWAY_ADDRESS")
+	}
+	gatewayErrors := make(chan error, 1)
+	if gatewayAddress != "" {
+		credentials, err := daemon.LoadOrCreateRemoteServerCredentials()
+		if err != nil {
+			return fmt.Errorf("unable to load remote access credentials: %w", err)
+		}
+		gatewayListener, err := gateway.Listen(gatewayAddress)
+		if err != nil {
+			return fmt.Errorf("unable to create gateway listener: %w", err)
+		}
+		defer gatewayListener.Close()
+		gatewayClient, err := client.New(&client.Configuration{})
+		if err != nil {
+			return fmt.Errorf("unable to connect gateway to daemon: %w", err)
+		}
+		defer gatewayClient.Close()
+		gatewayServer := &http.Server{Handler: gateway.NewHandler(gatewayClient, credentials.Token)}
+		defer gatewayServer.Close()
+		go func() {
+			if err := gatewayServer.Serve(gatewayListener); !errors.Is(err, http.ErrServerClosed) {
+				gatewayErrors <- err
+			}
+		}()
+		logger.Info("Serving HTTP gateway on", gatewayListener.Addr())
+	}
+
 	// Wait for termination from a signal, the daemon service, or the gRPC
 	// server. We treat termination via the daemon service as a non-error.
 	select {
@@ -140,6 +292,12 @@ func runMain(_ *cobra.Command, _ []string) error {
 	case err = <-serverErrors:
 		logger.Error("Daemon server failure:", err)
 		return fmt.Errorf("daemon server termination: %w", err)
+	case err = <-metricsErrors:
+		logger.Error("Metrics server failure:", err)
+		return fmt.Errorf("metrics server termination: %w", err)
+	case err = <-gatewayErrors:
+		logger.Error("Gateway server failure:", err)
+		return fmt.Errorf("gateway server termination: %w", err)
 	}
 }
 
@@ -157,6 +315,16 @@ var runCommand = &cobra.Command{
 var runConfiguration struct {
 	// help indicates whether or not to show help information and exit.
 	help bool
+	// logFormat is the log output format.
+	logFormat string
+	// metricsAddress is the address on which to serve metrics, if any.
+	metricsAddress string
+	// gatewayAddress is the loopback TCP address on which to serve the HTTP
+	// gateway, if any.
+	gatewayAddress string
+	// listenAddress is the TCP address on which to serve remote access, if
+	// any.
+	listenAddress string
 }
 
 func init() {
@@ -169,4 +337,16 @@ func init() {
 	// Manually add a help flag to override the default message. Cobra will
 	// still implement its logic automatically.
 	flags.BoolVarP(&runConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up logging flags.
+	flags.StringVar(&runConfiguration.logFormat, "log-format", "", "Set the log output format (text|json|logfmt)")
+
+	// Wire up remote access flags.
+	flags.StringVar(&runConfiguration.listenAddress, "listen-address", "", "Serve authenticated remote access on the specified TCP address (<host>:<port>)")
+
+	// Wire up metrics flags.
+	flags.StringVar(&runConfiguration.metricsAddress, "metrics-address", "", "Serve Prometheus metrics at /metrics on the specified address (<host>:<port> or unix:<path>)")
+
+	// Wire up gateway flags.
+	flags.StringVar(&runConfiguration.gatewayAddress, "gateway-address", "", "Serve the HTTP/JSON gateway on the specified loopback address (<host>:<port>)")
 }
@@ -3,52 +3,30 @@ package daemon
 import (
 	"fmt"
 	"os"
-	"os/exec"
-	"runtime"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mutagen-io/mutagen/cmd"
 	"github.com/mutagen-io/mutagen/cmd/external"
 
-	"github.com/mutagen-io/mutagen/pkg/daemon"
-	"github.com/mutagen-io/mutagen/pkg/platform"
+	"github.com/mutagen-io/mutagen/pkg/client"
 )
 
 // startMain is the entry point for the start command.
 func startMain(_ *cobra.Command, _ []string) error {
-	// If the daemon is registered with the system, it may have a different
-	// start mechanism, so see if the system should handle it.
-	if handled, err := daemon.RegisteredStart(); err != nil {
-		return fmt.Errorf("unable to start daemon using system mechanism: %w", err)
-	} else if handled {
-		return nil
-	}
-
-	// Compute the path to the Mutagen CLI executable.
+	// Compute the path to the Mutagen CLI executable. If path-based lookup is
+	// requested, then we let the client package perform it.
 	var executablePath string
-	var err error
 	if !external.UsePathBasedLookupForDaemonStart {
-		executablePath, err = os.Executable()
-	} else {
-		executablePath, err = exec.LookPath(platform.ExecutableName("mutagen", runtime.GOOS))
-	}
-	if err != nil {
-		return fmt.Errorf("unable to determine executable path: %w", err)
-	}
-
-	// Restart in the background.
-	daemonProcess := &exec.Cmd{
-		Path:        executablePath,
-		Args:        []string{"mutagen", "daemon", "run"},
-		SysProcAttr: daemonProcessAttributes,
-	}
-	if err := daemonProcess.Start(); err != nil {
-		return fmt.Errorf("unable to fork daemon: %w", err)
+		if path, err := os.Executable(); err != nil {
+			return fmt.Errorf("unable to determine executable path: %w", err)
+		} else {
+			executablePath = path
+		}
 	}
 
-	// Success.
-	return nil
+	// Start the daemon.
+	return client.StartDaemon(executablePath)
 }
 
 // startCommand is the start command.
@@ -0,0 +1,164 @@
+package forward
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/spf13/cobra"
+
+	"google.golang.org/grpc"
+
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
+)
+
+// CaptureWithSelection is an orchestration convenience method that performs a
+// capture operation using the provided daemon connection, session selection,
+// and capture parameters.
+func CaptureWithSelection(
+	daemonConnection *grpc.ClientConn,
+	selection *selection.Selection,
+	enable bool,
+	directory string,
+	format forwarding.CaptureFormat,
+	maximumFileSize uint64,
+	maximumFileCount uint32,
+) error {
+	// Perform the capture operation.
+	forwardingService := forwardingsvc.NewForwardingClient(daemonConnection)
+	request := &forwardingsvc.CaptureRequest{
+		Selection:        selection,
+		Enable:           enable,
+		Directory:        directory,
+		Format:           format,
+		MaximumFileSize:  maximumFileSize,
+		MaximumFileCount: maximumFileCount,
+	}
+	response, err := forwardingService.Capture(context.Background(), request)
+	if err != nil {
+		return grpcutil.PeelAwayRPCErrorLayer(err)
+	} else if err = response.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid capture response received: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
+// captureMain is the entry point for the capture command.
+func captureMain(_ *cobra.Command, arguments []string) error {
+	// Create session selection specification.
+	selection := &selection.Selection{
+		All:            captureConfiguration.all,
+		Specifications: arguments,
+		LabelSelector:  captureConfiguration.labelSelector,
+	}
+	if err := selection.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid session selection specification: %w", err)
+	}
+
+	// Validate and convert capture parameters if capture is being enabled.
+	var directory string
+	var format forwarding.CaptureFormat
+	var maximumFileSize uint64
+	if !captureConfiguration.disable {
+		// Resolve the capture directory to an absolute path, since it will be
+		// interpreted by the daemon.
+		if d, err := filepath.Abs(captureConfiguration.directory); err != nil {
+			return fmt.Errorf("unable to resolve capture directory: %w", err)
+		} else {
+			directory = d
+		}
+
+		// Validate and convert the capture format.
+		if captureConfiguration.format != "" {
+			if err := format.UnmarshalText([]byte(captureConfiguration.format)); err != nil {
+				return fmt.Errorf("unable to parse capture format: %w", err)
+			}
+		}
+
+		// Validate and convert the maximum file size.
+		if captureConfiguration.maximumFileSize != "" {
+			if s, err := humanize.ParseBytes(captureConfiguration.maximumFileSize); err != nil {
+				return fmt.Errorf("unable to parse maximum capture file size: %w", err)
+			} else {
+				maximumFileSize = s
+			}
+		}
+	}
+
+	// Connect to the daemon and defer closure of the connection.
+	daemonConnection, err := daemon.Connect(true, true)
+	if err != nil {
+		return fmt.Errorf("unable to connect to daemon: %w", err)
+	}
+	defer daemonConnection.Close()
+
+	// Perform the capture operation.
+	return CaptureWithSelection(
+		daemonConnection,
+		selection,
+		!captureConfiguration.disable,
+		directory,
+		format,
+		maximumFileSize,
+		captureConfiguration.maximumFileCount,
+	)
+}
+
+// captureCommand is the capture command.
+var captureCommand = &cobra.Command{
+	Use:          "capture [<session>...]",
+	Short:        "Capture traffic for a forwarding session",
+	RunE:         captureMain,
+	SilenceUsage: true,
+}
+
+// captureConfiguration stores configuration for the capture command.
+var captureConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// all indicates whether or not all sessions should be captured.
+	all bool
+	// labelSelector encodes a label selector to be used in identifying which
+	// sessions should be captured.
+	labelSelector string
+	// disable indicates that capture should be disabled.
+	disable bool
+	// directory is the directory in which capture files should be created.
+	directory string
+	// format specifies the capture file format.
+	format string
+	// maximumFileSize specifies the size at which capture files are rotated.
+	maximumFileSize string
+	// maximumFileCount specifies the number of capture files to retain.
+	maximumFileCount uint32
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := captureCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&captureConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up capture flags.
+	flags.BoolVarP(&captureConfiguration.all, "all", "a", false, "Capture traffic for all sessions")
+	flags.StringVar(&captureConfiguration.labelSelector, "label-selector", "", "Capture traffic for sessions matching the specified label selector")
+	flags.BoolVar(&captureConfiguration.disable, "disable", false, "Stop capturing traffic")
+	flags.StringVarP(&captureConfiguration.directory, "directory", "d", ".", "Specify the directory in which capture files are created")
+	flags.StringVar(&captureConfiguration.format, "format", "", "Specify the capture format (pcapng|transcript)")
+	flags.StringVar(&captureConfiguration.maximumFileSize, "max-size", "", "Specify the size at which capture files are rotated")
+	flags.Uint32Var(&captureConfiguration.maximumFileCount, "max-files", 0, "Specify the number of capture files retained per capture")
+}
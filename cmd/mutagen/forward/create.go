@@ -45,6 +45,37 @@ func loadAndValidateGlobalForwardingConfiguration(path string) (*forwarding.Conf
 	return configuration, nil
 }
 
+// loadAndValidateGlobalForwardingPreset loads a YAML-based global configuration,
+// extracts the forwarding component of the specified preset, converts it to a
+// Protocol Buffers session configuration, and validates it.
+func loadAndValidateGlobalForwardingPreset(path, name string) (*forwarding.Configuration, error) {
+	// Load the YAML configuration. If the file doesn't exist, then no presets
+	// are defined.
+	yamlConfiguration, err := global.LoadConfiguration(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("unknown preset: %s", name)
+		}
+		return nil, err
+	}
+
+	// Look up the preset.
+	preset, err := yamlConfiguration.Preset(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert the preset configuration to a Protocol Buffers representation
+	// and validate it.
+	configuration := preset.Forwarding.ToInternal()
+	if err := configuration.EnsureValid(false); err != nil {
+		return nil, fmt.Errorf("invalid preset configuration: %w", err)
+	}
+
+	// Success.
+	return configuration, nil
+}
+
 // CreateWithSpecification is an orchestration convenience method that performs
 // a create operation using the provided daemon connection and session
 // specification.
@@ -89,7 +120,7 @@ func CreateWithSpecification(
 // createMain is the entry point for the create command.
 func createMain(_ *cobra.Command, arguments []string) error {
 	// Validate, extract, and parse URLs.
-	if len(arguments) != 2 {
+	if len(arguments) < 2 {
 		return errors.New("invalid number of endpoint URLs provided")
 	}
 	source, err := url.Parse(arguments[0], url.Kind_Forwarding, true)
@@ -100,6 +131,14 @@ func createMain(_ *cobra.Command, arguments []string) error {
 	if err != nil {
 		return fmt.Errorf("unable to parse destination URL: %w", err)
 	}
+	var additionalDestinations []*url.URL
+	for _, argument := range arguments[2:] {
+		if destination, err := url.Parse(argument, url.Kind_Forwarding, false); err != nil {
+			return fmt.Errorf("unable to parse additional destination URL: %w", err)
+		} else {
+			additionalDestinations = append(additionalDestinations, destination)
+		}
+	}
 
 	// Validate the name.
 	if err := selection.EnsureNameValid(createConfiguration.name); err != nil {
@@ -131,7 +170,12 @@ func createMain(_ *cobra.Command, arguments []string) error {
 	configuration := &forwarding.Configuration{}
 
 	// Unless disabled, attempt to load configuration from the global
-	// configuration file and merge it into our cumulative configuration.
+	// configuration file and merge it into our cumulative configuration. If a
+	// preset has been specified, then it's merged on top of the global
+	// defaults.
+	if createConfiguration.noGlobalConfiguration && createConfiguration.preset != "" {
+		return errors.New("presets cannot be used when ignoring the global configuration file")
+	}
 	if !createConfiguration.noGlobalConfiguration {
 		// Compute the path to the global configuration file.
 		globalConfigurationPath, err := global.ConfigurationPath()
@@ -148,6 +192,15 @@ func createMain(_ *cobra.Command, arguments []string) error {
 		} else {
 			configuration = forwarding.MergeConfigurations(configuration, globalConfiguration)
 		}
+
+		// Load and merge the preset, if any.
+		if createConfiguration.preset != "" {
+			preset, err := loadAndValidateGlobalForwardingPreset(globalConfigurationPath, createConfiguration.preset)
+			if err != nil {
+				return fmt.Errorf("unable to load preset: %w", err)
+			}
+			configuration = forwarding.MergeConfigurations(configuration, preset)
+		}
 	}
 
 	// If additional default configuration files have been specified, then load
@@ -160,6 +213,26 @@ func createMain(_ *cobra.Command, arguments []string) error {
 		}
 	}
 
+	// Validate and convert the destination selection mode specification.
+	var destinationSelectionMode forwarding.DestinationSelectionMode
+	if createConfiguration.destinationSelectionMode != "" {
+		if err := destinationSelectionMode.UnmarshalText([]byte(createConfiguration.destinationSelectionMode)); err != nil {
+			return fmt.Errorf("unable to parse destination selection mode: %w", err)
+		}
+	}
+
+	// Validate network access control specifications.
+	for _, network := range createConfiguration.allowedNetworks {
+		if _, err := forwarding.ParseNetwork(network); err != nil {
+			return fmt.Errorf("invalid allowed network (%s): %w", network, err)
+		}
+	}
+	for _, network := range createConfiguration.deniedNetworks {
+		if _, err := forwarding.ParseNetwork(network); err != nil {
+			return fmt.Errorf("invalid denied network (%s): %w", network, err)
+		}
+	}
+
 	// Validate and convert socket overwrite mode specifications.
 	var socketOverwriteMode, socketOverwriteModeSource, socketOverwriteModeDestination forwarding.SocketOverwriteMode
 	if createConfiguration.socketOverwriteMode != "" {
@@ -224,6 +297,18 @@ func createMain(_ *cobra.Command, arguments []string) error {
 		}
 	}
 
+	// Validate socket peer access control specifications.
+	for _, user := range createConfiguration.socketAllowedUsers {
+		if kind, _ := filesystem.ParseOwnershipIdentifier(user); kind == filesystem.OwnershipIdentifierKindInvalid {
+			return fmt.Errorf("invalid allowed socket user specification: %s", user)
+		}
+	}
+	for _, group := range createConfiguration.socketAllowedGroups {
+		if kind, _ := filesystem.ParseOwnershipIdentifier(group); kind == filesystem.OwnershipIdentifierKindInvalid {
+			return fmt.Errorf("invalid allowed socket group specification: %s", group)
+		}
+	}
+
 	// Validate and convert socket permission mode specifications.
 	var socketPermissionMode, socketPermissionModeSource, socketPermissionModeDestination filesystem.Mode
 	if createConfiguration.socketPermissionMode != "" {
@@ -242,31 +327,67 @@ func createMain(_ *cobra.Command, arguments []string) error {
 		}
 	}
 
+	// Validate and convert TLS mode specifications.
+	var tlsModeSource, tlsModeDestination forwarding.TLSMode
+	if createConfiguration.tlsModeSource != "" {
+		if err := tlsModeSource.UnmarshalText([]byte(createConfiguration.tlsModeSource)); err != nil {
+			return fmt.Errorf("unable to parse TLS mode for source: %w", err)
+		}
+	}
+	if createConfiguration.tlsModeDestination != "" {
+		if err := tlsModeDestination.UnmarshalText([]byte(createConfiguration.tlsModeDestination)); err != nil {
+			return fmt.Errorf("unable to parse TLS mode for destination: %w", err)
+		}
+	}
+
+	// Validate TLS certificate and key specifications.
+	if (createConfiguration.tlsCertificateSource == "") != (createConfiguration.tlsKeySource == "") {
+		return errors.New("TLS certificate and key for source must be specified together")
+	}
+	if (createConfiguration.tlsCertificateDestination == "") != (createConfiguration.tlsKeyDestination == "") {
+		return errors.New("TLS certificate and key for destination must be specified together")
+	}
+
 	// Create the command line configuration and merge it into our cumulative
 	// configuration.
 	configuration = forwarding.MergeConfigurations(configuration, &forwarding.Configuration{
-		SocketOverwriteMode:  socketOverwriteMode,
-		SocketOwner:          createConfiguration.socketOwner,
-		SocketGroup:          createConfiguration.socketGroup,
-		SocketPermissionMode: uint32(socketPermissionMode),
+		DestinationSelectionMode: destinationSelectionMode,
+		AllowedNetworks:          createConfiguration.allowedNetworks,
+		DeniedNetworks:           createConfiguration.deniedNetworks,
+		SocketOverwriteMode:      socketOverwriteMode,
+		SocketOwner:              createConfiguration.socketOwner,
+		SocketGroup:              createConfiguration.socketGroup,
+		SocketPermissionMode:     uint32(socketPermissionMode),
+		SocketAllowedUsers:       createConfiguration.socketAllowedUsers,
+		SocketAllowedGroups:      createConfiguration.socketAllowedGroups,
 	})
 
 	// Create the creation specification.
 	specification := &forwardingsvc.CreationSpecification{
-		Source:        source,
-		Destination:   destination,
-		Configuration: configuration,
+		Source:                 source,
+		Destination:            destination,
+		AdditionalDestinations: additionalDestinations,
+		Configuration:          configuration,
 		ConfigurationSource: &forwarding.Configuration{
-			SocketOverwriteMode:  socketOverwriteModeSource,
-			SocketOwner:          createConfiguration.socketOwnerSource,
-			SocketGroup:          createConfiguration.socketGroupSource,
-			SocketPermissionMode: uint32(socketPermissionModeSource),
+			SocketOverwriteMode:     socketOverwriteModeSource,
+			SocketOwner:             createConfiguration.socketOwnerSource,
+			SocketGroup:             createConfiguration.socketGroupSource,
+			SocketPermissionMode:    uint32(socketPermissionModeSource),
+			TlsMode:                 tlsModeSource,
+			TlsCertificate:          createConfiguration.tlsCertificateSource,
+			TlsKey:                  createConfiguration.tlsKeySource,
+			TlsCertificateAuthority: createConfiguration.tlsCertificateAuthoritySource,
 		},
 		ConfigurationDestination: &forwarding.Configuration{
-			SocketOverwriteMode:  socketOverwriteModeDestination,
-			SocketOwner:          createConfiguration.socketOwnerDestination,
-			SocketGroup:          createConfiguration.socketGroupDestination,
-			SocketPermissionMode: uint32(socketPermissionModeDestination),
+			SocketOverwriteMode:     socketOverwriteModeDestination,
+			SocketOwner:             createConfiguration.socketOwnerDestination,
+			SocketGroup:             createConfiguration.socketGroupDestination,
+			SocketPermissionMode:    uint32(socketPermissionModeDestination),
+			TlsMode:                 tlsModeDestination,
+			TlsCertificate:          createConfiguration.tlsCertificateDestination,
+			TlsKey:                  createConfiguration.tlsKeyDestination,
+			TlsCertificateAuthority: createConfiguration.tlsCertificateAuthorityDestination,
+			TlsServerName:           createConfiguration.tlsServerNameDestination,
 		},
 		Name:   createConfiguration.name,
 		Labels: labels,
@@ -295,7 +416,7 @@ func createMain(_ *cobra.Command, arguments []string) error {
 
 // createCommand is the create command.
 var createCommand = &cobra.Command{
-	Use:          "create <source> <destination>",
+	Use:          "create <source> <destination> [<destination>...]",
 	Short:        "Create and start a new forwarding session",
 	RunE:         createMain,
 	SilenceUsage: true,
@@ -315,9 +436,20 @@ var createConfiguration struct {
 	// noGlobalConfiguration specifies whether or not the global configuration
 	// file should be ignored.
 	noGlobalConfiguration bool
+	// preset is the name of the global configuration preset to apply.
+	preset string
 	// configurationFiles stores paths of additional files from which to load
 	// default configuration.
 	configurationFiles []string
+	// destinationSelectionMode specifies the mode used to select between
+	// multiple destinations.
+	destinationSelectionMode string
+	// allowedNetworks specifies the networks from which TCP listeners will
+	// accept connections.
+	allowedNetworks []string
+	// deniedNetworks specifies the networks from which TCP listeners will
+	// refuse connections.
+	deniedNetworks []string
 	// socketOverwriteMode specifies the socket overwrite mode to use for the
 	// session.
 	socketOverwriteMode string
@@ -363,6 +495,39 @@ var createConfiguration struct {
 	// use for new Unix domain socket listeners on destination, taking priority
 	// over socketPermissionMode on destination if specified.
 	socketPermissionModeDestination string
+	// socketAllowedUsers specifies the users whose peer processes are allowed
+	// to connect to Unix domain socket listeners.
+	socketAllowedUsers []string
+	// socketAllowedGroups specifies the groups whose peer processes are
+	// allowed to connect to Unix domain socket listeners.
+	socketAllowedGroups []string
+	// tlsModeSource specifies the TLS mode to use for terminating TLS on the
+	// source.
+	tlsModeSource string
+	// tlsModeDestination specifies the TLS mode to use for originating TLS on
+	// the destination.
+	tlsModeDestination string
+	// tlsCertificateSource specifies the path to the TLS server certificate to
+	// use on the source.
+	tlsCertificateSource string
+	// tlsCertificateDestination specifies the path to the TLS client
+	// certificate to use on the destination.
+	tlsCertificateDestination string
+	// tlsKeySource specifies the path to the private key corresponding to
+	// tlsCertificateSource.
+	tlsKeySource string
+	// tlsKeyDestination specifies the path to the private key corresponding to
+	// tlsCertificateDestination.
+	tlsKeyDestination string
+	// tlsCertificateAuthoritySource specifies the path to the certificate
+	// authority bundle used to verify client certificates on the source.
+	tlsCertificateAuthoritySource string
+	// tlsCertificateAuthorityDestination specifies the path to the certificate
+	// authority bundle used to verify server certificates on the destination.
+	tlsCertificateAuthorityDestination string
+	// tlsServerNameDestination specifies the server name to use for server
+	// certificate verification and SNI on the destination.
+	tlsServerNameDestination string
 }
 
 func init() {
@@ -385,8 +550,16 @@ func init() {
 
 	// Wire up general configuration flags.
 	flags.BoolVar(&createConfiguration.noGlobalConfiguration, "no-global-configuration", false, "Ignore the global configuration file")
+	flags.StringVar(&createConfiguration.preset, "preset", "", "Apply the specified preset from the global configuration file")
 	flags.StringSliceVarP(&createConfiguration.configurationFiles, "configuration-file", "c", nil, "Specify additional files from which to load (and merge) default configuration parameters")
 
+	// Wire up destination flags.
+	flags.StringVar(&createConfiguration.destinationSelectionMode, "destination-selection-mode", "", "Specify destination selection mode for multiple destinations (failover|round-robin)")
+
+	// Wire up network flags.
+	flags.StringSliceVar(&createConfiguration.allowedNetworks, "allow-network", nil, "Specify networks (CIDR or IP address) allowed to connect to TCP listeners")
+	flags.StringSliceVar(&createConfiguration.deniedNetworks, "deny-network", nil, "Specify networks (CIDR or IP address) denied from connecting to TCP listeners")
+
 	// Wire up socket flags.
 	flags.StringVar(&createConfiguration.socketOverwriteMode, "socket-overwrite-mode", "", "Specify socket overwrite mode (leave|overwrite)")
 	flags.StringVar(&createConfiguration.socketOverwriteModeSource, "socket-overwrite-mode-source", "", "Specify socket overwrite mode for source (leave|overwrite)")
@@ -400,4 +573,17 @@ func init() {
 	flags.StringVar(&createConfiguration.socketPermissionMode, "socket-permission-mode", "", "Specify socket permission mode")
 	flags.StringVar(&createConfiguration.socketPermissionModeSource, "socket-permission-mode-source", "", "Specify socket permission mode for source")
 	flags.StringVar(&createConfiguration.socketPermissionModeDestination, "socket-permission-mode-destination", "", "Specify socket permission mode for destination")
+	flags.StringSliceVar(&createConfiguration.socketAllowedUsers, "socket-allowed-user", nil, "Specify users allowed to connect to socket listeners")
+	flags.StringSliceVar(&createConfiguration.socketAllowedGroups, "socket-allowed-group", nil, "Specify groups allowed to connect to socket listeners")
+
+	// Wire up TLS flags.
+	flags.StringVar(&createConfiguration.tlsModeSource, "tls-mode-source", "", "Specify TLS termination mode for source (disabled|enabled)")
+	flags.StringVar(&createConfiguration.tlsModeDestination, "tls-mode-destination", "", "Specify TLS origination mode for destination (disabled|enabled|insecure)")
+	flags.StringVar(&createConfiguration.tlsCertificateSource, "tls-certificate-source", "", "Specify TLS server certificate path for source (self-signed if unspecified)")
+	flags.StringVar(&createConfiguration.tlsKeySource, "tls-key-source", "", "Specify TLS server key path for source")
+	flags.StringVar(&createConfiguration.tlsCertificateAuthoritySource, "tls-ca-source", "", "Specify TLS certificate authority path for verifying clients on source")
+	flags.StringVar(&createConfiguration.tlsCertificateDestination, "tls-certificate-destination", "", "Specify TLS client certificate path for destination")
+	flags.StringVar(&createConfiguration.tlsKeyDestination, "tls-key-destination", "", "Specify TLS client key path for destination")
+	flags.StringVar(&createConfiguration.tlsCertificateAuthorityDestination, "tls-ca-destination", "", "Specify TLS certificate authority path for verifying servers on destination")
+	flags.StringVar(&createConfiguration.tlsServerNameDestination, "tls-server-name-destination", "", "Specify TLS server name for destination")
 }
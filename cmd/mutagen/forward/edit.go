@@ -0,0 +1,292 @@
+package forward
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"google.golang.org/grpc"
+
+	"github.com/mutagen-io/mutagen/cmd"
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
+	promptingsvc "github.com/mutagen-io/mutagen/pkg/service/prompting"
+)
+
+// EditWithConfigurations is an orchestration convenience method that performs
+// an update operation using the provided daemon connection, session
+// specification, and configurations.
+func EditWithConfigurations(
+	daemonConnection *grpc.ClientConn,
+	session string,
+	configuration, configurationSource, configurationDestination *forwarding.Configuration,
+) error {
+	// Initiate command line prompting.
+	statusLinePrinter := &cmd.StatusLinePrinter{}
+	promptingCtx, promptingCancel := context.WithCancel(context.Background())
+	prompter, promptingErrors, err := promptingsvc.Host(
+		promptingCtx, promptingsvc.NewPromptingClient(daemonConnection),
+		&cmd.StatusLinePrompter{Printer: statusLinePrinter}, true,
+	)
+	if err != nil {
+		promptingCancel()
+		return fmt.Errorf("unable to initiate prompting: %w", err)
+	}
+
+	// Perform the update operation, cancel prompting, and handle errors.
+	forwardingService := forwardingsvc.NewForwardingClient(daemonConnection)
+	request := &forwardingsvc.UpdateRequest{
+		Prompter:                 prompter,
+		Session:                  session,
+		Configuration:            configuration,
+		ConfigurationSource:      configurationSource,
+		ConfigurationDestination: configurationDestination,
+	}
+	response, err := forwardingService.Update(context.Background(), request)
+	promptingCancel()
+	<-promptingErrors
+	if err != nil {
+		statusLinePrinter.BreakIfPopulated()
+		return grpcutil.PeelAwayRPCErrorLayer(err)
+	} else if err = response.EnsureValid(); err != nil {
+		statusLinePrinter.BreakIfPopulated()
+		return fmt.Errorf("invalid update response received: %w", err)
+	}
+
+	// Success.
+	statusLinePrinter.Clear()
+	return nil
+}
+
+// editMain is the entry point for the edit command.
+func editMain(_ *cobra.Command, arguments []string) error {
+	// Validate and extract the session specification.
+	if len(arguments) != 1 {
+		return errors.New("a single session must be specified")
+	}
+	specification := arguments[0]
+
+	// Validate and convert the destination selection mode specification.
+	var destinationSelectionMode forwarding.DestinationSelectionMode
+	if editConfiguration.destinationSelectionMode != "" {
+		if err := destinationSelectionMode.UnmarshalText([]byte(editConfiguration.destinationSelectionMode)); err != nil {
+			return fmt.Errorf("unable to parse destination selection mode: %w", err)
+		}
+	}
+
+	// Validate network access control specifications.
+	for _, network := range editConfiguration.allowedNetworks {
+		if _, err := forwarding.ParseNetwork(network); err != nil {
+			return fmt.Errorf("invalid allowed network (%s): %w", network, err)
+		}
+	}
+	for _, network := range editConfiguration.deniedNetworks {
+		if _, err := forwarding.ParseNetwork(network); err != nil {
+			return fmt.Errorf("invalid denied network (%s): %w", network, err)
+		}
+	}
+
+	// Validate and convert socket overwrite mode specifications.
+	var socketOverwriteMode, socketOverwriteModeSource, socketOverwriteModeDestination forwarding.SocketOverwriteMode
+	if editConfiguration.socketOverwriteMode != "" {
+		if err := socketOverwriteMode.UnmarshalText([]byte(editConfiguration.socketOverwriteMode)); err != nil {
+			return fmt.Errorf("unable to parse socket overwrite mode: %w", err)
+		}
+	}
+	if editConfiguration.socketOverwriteModeSource != "" {
+		if err := socketOverwriteModeSource.UnmarshalText([]byte(editConfiguration.socketOverwriteModeSource)); err != nil {
+			return fmt.Errorf("unable to parse socket overwrite mode for source: %w", err)
+		}
+	}
+	if editConfiguration.socketOverwriteModeDestination != "" {
+		if err := socketOverwriteModeDestination.UnmarshalText([]byte(editConfiguration.socketOverwriteModeDestination)); err != nil {
+			return fmt.Errorf("unable to parse socket overwrite mode for destination: %w", err)
+		}
+	}
+
+	// Validate socket peer access control specifications.
+	for _, user := range editConfiguration.socketAllowedUsers {
+		if kind, _ := filesystem.ParseOwnershipIdentifier(user); kind == filesystem.OwnershipIdentifierKindInvalid {
+			return fmt.Errorf("invalid allowed socket user specification: %s", user)
+		}
+	}
+	for _, group := range editConfiguration.socketAllowedGroups {
+		if kind, _ := filesystem.ParseOwnershipIdentifier(group); kind == filesystem.OwnershipIdentifierKindInvalid {
+			return fmt.Errorf("invalid allowed socket group specification: %s", group)
+		}
+	}
+
+	// Validate and convert socket permission mode specifications.
+	var socketPermissionMode, socketPermissionModeSource, socketPermissionModeDestination filesystem.Mode
+	if editConfiguration.socketPermissionMode != "" {
+		if err := socketPermissionMode.UnmarshalText([]byte(editConfiguration.socketPermissionMode)); err != nil {
+			return fmt.Errorf("unable to parse socket permission mode: %w", err)
+		}
+	}
+	if editConfiguration.socketPermissionModeSource != "" {
+		if err := socketPermissionModeSource.UnmarshalText([]byte(editConfiguration.socketPermissionModeSource)); err != nil {
+			return fmt.Errorf("unable to parse socket permission mode for source: %w", err)
+		}
+	}
+	if editConfiguration.socketPermissionModeDestination != "" {
+		if err := socketPermissionModeDestination.UnmarshalText([]byte(editConfiguration.socketPermissionModeDestination)); err != nil {
+			return fmt.Errorf("unable to parse socket permission mode for destination: %w", err)
+		}
+	}
+
+	// Connect to the daemon and defer closure of the connection.
+	daemonConnection, err := daemon.Connect(true, true)
+	if err != nil {
+		return fmt.Errorf("unable to connect to daemon: %w", err)
+	}
+	defer daemonConnection.Close()
+
+	// Look up the session's existing configuration.
+	forwardingService := forwardingsvc.NewForwardingClient(daemonConnection)
+	listRequest := &forwardingsvc.ListRequest{
+		Selection: &selection.Selection{Specifications: []string{specification}},
+	}
+	listResponse, err := forwardingService.List(context.Background(), listRequest)
+	if err != nil {
+		return grpcutil.PeelAwayRPCErrorLayer(err)
+	} else if err = listResponse.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid list response received: %w", err)
+	} else if len(listResponse.SessionStates) != 1 {
+		return fmt.Errorf("specification \"%s\" matched multiple sessions", specification)
+	}
+	session := listResponse.SessionStates[0].Session
+
+	// Merge any specified configuration files into the existing configuration.
+	configuration := session.Configuration
+	for _, configurationFile := range editConfiguration.configurationFiles {
+		if c, err := loadAndValidateGlobalForwardingConfiguration(configurationFile); err != nil {
+			return fmt.Errorf("unable to load configuration file (%s): %w", configurationFile, err)
+		} else {
+			configuration = forwarding.MergeConfigurations(configuration, c)
+		}
+	}
+
+	// Merge the command line configuration into the configuration.
+	configuration = forwarding.MergeConfigurations(configuration, &forwarding.Configuration{
+		DestinationSelectionMode: destinationSelectionMode,
+		AllowedNetworks:          editConfiguration.allowedNetworks,
+		DeniedNetworks:           editConfiguration.deniedNetworks,
+		SocketOverwriteMode:      socketOverwriteMode,
+		SocketPermissionMode:     uint32(socketPermissionMode),
+		SocketAllowedUsers:       editConfiguration.socketAllowedUsers,
+		SocketAllowedGroups:      editConfiguration.socketAllowedGroups,
+	})
+	configurationSource := forwarding.MergeConfigurations(session.ConfigurationSource, &forwarding.Configuration{
+		SocketOverwriteMode:  socketOverwriteModeSource,
+		SocketPermissionMode: uint32(socketPermissionModeSource),
+	})
+	configurationDestination := forwarding.MergeConfigurations(session.ConfigurationDestination, &forwarding.Configuration{
+		SocketOverwriteMode:  socketOverwriteModeDestination,
+		SocketPermissionMode: uint32(socketPermissionModeDestination),
+	})
+
+	// If nothing has changed, then there's no need to perform an update.
+	if configuration.Equal(session.Configuration) &&
+		configurationSource.Equal(session.ConfigurationSource) &&
+		configurationDestination.Equal(session.ConfigurationDestination) {
+		fmt.Println("Session configuration unchanged")
+		return nil
+	}
+
+	// Perform the update operation.
+	return EditWithConfigurations(daemonConnection, session.Identifier, configuration, configurationSource, configurationDestination)
+}
+
+// editCommand is the edit command.
+var editCommand = &cobra.Command{
+	Use:          "edit <session>",
+	Short:        "Change the configuration of an existing forwarding session",
+	RunE:         editMain,
+	SilenceUsage: true,
+}
+
+// editConfiguration stores configuration for the edit command.
+var editConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// configurationFiles stores paths of additional files from which to load
+	// configuration parameters to merge into the session configuration.
+	configurationFiles []string
+	// destinationSelectionMode specifies the mode used to select between
+	// multiple destinations.
+	destinationSelectionMode string
+	// allowedNetworks specifies additional networks from which TCP listeners
+	// will accept connections.
+	allowedNetworks []string
+	// deniedNetworks specifies additional networks from which TCP listeners
+	// will refuse connections.
+	deniedNetworks []string
+	// socketOverwriteMode specifies the socket overwrite mode to use for the
+	// session.
+	socketOverwriteMode string
+	// socketOverwriteModeSource specifies the socket overwrite mode to use for
+	// the session, taking priority over socketOverwriteMode on source if
+	// specified.
+	socketOverwriteModeSource string
+	// socketOverwriteModeDestination specifies the socket overwrite mode to use
+	// for the session, taking priority over socketOverwriteMode on destination
+	// if specified.
+	socketOverwriteModeDestination string
+	// socketPermissionMode specifies the socket permission mode to use for new
+	// Unix domain socket listeners, with endpoint-specific specifications
+	// taking priority.
+	socketPermissionMode string
+	// socketPermissionModeSource specifies the socket permission mode to use
+	// for new Unix domain socket listeners on source, taking priority over
+	// socketPermissionMode on source if specified.
+	socketPermissionModeSource string
+	// socketPermissionModeDestination specifies the socket permission mode to
+	// use for new Unix domain socket listeners on destination, taking priority
+	// over socketPermissionMode on destination if specified.
+	socketPermissionModeDestination string
+	// socketAllowedUsers specifies additional users whose peer processes are
+	// allowed to connect to Unix domain socket listeners.
+	socketAllowedUsers []string
+	// socketAllowedGroups specifies additional groups whose peer processes are
+	// allowed to connect to Unix domain socket listeners.
+	socketAllowedGroups []string
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := editCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&editConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up general configuration flags.
+	flags.StringSliceVarP(&editConfiguration.configurationFiles, "configuration-file", "c", nil, "Specify files from which to load (and merge) configuration parameters")
+
+	// Wire up destination flags.
+	flags.StringVar(&editConfiguration.destinationSelectionMode, "destination-selection-mode", "", "Specify destination selection mode for multiple destinations (failover|round-robin)")
+
+	// Wire up network flags.
+	flags.StringSliceVar(&editConfiguration.allowedNetworks, "allow-network", nil, "Add networks (CIDR or IP address) allowed to connect to TCP listeners")
+	flags.StringSliceVar(&editConfiguration.deniedNetworks, "deny-network", nil, "Add networks (CIDR or IP address) denied from connecting to TCP listeners")
+
+	// Wire up socket flags.
+	flags.StringVar(&editConfiguration.socketOverwriteMode, "socket-overwrite-mode", "", "Specify socket overwrite mode (leave|overwrite)")
+	flags.StringVar(&editConfiguration.socketOverwriteModeSource, "socket-overwrite-mode-source", "", "Specify socket overwrite mode for source (leave|overwrite)")
+	flags.StringVar(&editConfiguration.socketOverwriteModeDestination, "socket-overwrite-mode-destination", "", "Specify socket overwrite mode for destination (leave|overwrite)")
+	flags.StringVar(&editConfiguration.socketPermissionMode, "socket-permission-mode", "", "Specify socket permission mode")
+	flags.StringVar(&editConfiguration.socketPermissionModeSource, "socket-permission-mode-source", "", "Specify socket permission mode for source")
+	flags.StringVar(&editConfiguration.socketPermissionModeDestination, "socket-permission-mode-destination", "", "Specify socket permission mode for destination")
+	flags.StringSliceVar(&editConfiguration.socketAllowedUsers, "socket-allowed-user", nil, "Add users allowed to connect to socket listeners")
+	flags.StringSliceVar(&editConfiguration.socketAllowedGroups, "socket-allowed-group", nil, "Add groups allowed to connect to socket listeners")
+}
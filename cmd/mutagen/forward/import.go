@@ -0,0 +1,205 @@
+package forward
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/project"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// importMain is the entry point for the import command.
+func importMain(_ *cobra.Command, arguments []string) error {
+	// Validate and extract the specification path.
+	if len(arguments) != 1 {
+		return errors.New("a single specification file must be specified")
+	}
+	path := arguments[0]
+
+	// Load the specification file.
+	configuration, err := project.LoadConfiguration(path)
+	if err != nil {
+		return fmt.Errorf("unable to load specification file: %w", err)
+	}
+
+	// Extract and sort session names, ignoring defaults.
+	var names []string
+	for name := range configuration.Forwarding {
+		if name != "defaults" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return errors.New("no forwarding sessions found in specification file")
+	}
+
+	// Exported specifications contain effective session configurations, which
+	// already incorporate any global configuration that was applied when the
+	// sessions were originally created, so we don't merge in the global
+	// configuration file here (doing so would duplicate list-based settings on
+	// each export and import round trip).
+	defaultConfiguration := &forwarding.Configuration{}
+
+	// Extract and validate defaults.
+	var defaultSource, defaultDestination string
+	defaultConfigurationSource := &forwarding.Configuration{}
+	defaultConfigurationDestination := &forwarding.Configuration{}
+	if defaults, ok := configuration.Forwarding["defaults"]; ok {
+		defaultSource = defaults.Source
+		defaultDestination = defaults.Destination
+		configuration := defaults.Configuration.ToInternal()
+		if err := configuration.EnsureValid(false); err != nil {
+			return fmt.Errorf("invalid default forwarding configuration: %w", err)
+		}
+		defaultConfiguration = forwarding.MergeConfigurations(defaultConfiguration, configuration)
+		defaultConfigurationSource = defaults.ConfigurationSource.ToInternal()
+		if err := defaultConfigurationSource.EnsureValid(true); err != nil {
+			return fmt.Errorf("invalid default forwarding source configuration: %w", err)
+		}
+		defaultConfigurationDestination = defaults.ConfigurationDestination.ToInternal()
+		if err := defaultConfigurationDestination.EnsureValid(true); err != nil {
+			return fmt.Errorf("invalid default forwarding destination configuration: %w", err)
+		}
+	}
+
+	// Generate session creation specifications.
+	specifications := make([]*forwardingsvc.CreationSpecification, 0, len(names))
+	for _, name := range names {
+		session := configuration.Forwarding[name]
+
+		// Verify that the name is valid.
+		if err := selection.EnsureNameValid(name); err != nil {
+			return fmt.Errorf("invalid session name (%s): %w", name, err)
+		}
+
+		// Validate labels. We don't allow the project label to be specified,
+		// since that would cause the session to appear as if it were managed
+		// by a project.
+		for key, value := range session.Labels {
+			if err := selection.EnsureLabelKeyValid(key); err != nil {
+				return fmt.Errorf("invalid label key for %s: %w", name, err)
+			} else if key == project.LabelKey {
+				return fmt.Errorf("reserved label key for %s: %s", name, key)
+			} else if err = selection.EnsureLabelValueValid(value); err != nil {
+				return fmt.Errorf("invalid label value for %s: %w", name, err)
+			}
+		}
+
+		// Compute URLs.
+		source := session.Source
+		if source == "" {
+			source = defaultSource
+		}
+		destination := session.Destination
+		if destination == "" {
+			destination = defaultDestination
+		}
+
+		// Parse URLs.
+		sourceURL, err := url.Parse(source, url.Kind_Forwarding, true)
+		if err != nil {
+			return fmt.Errorf("unable to parse source URL for %s (%s): %w", name, source, err)
+		}
+		destinationURL, err := url.Parse(destination, url.Kind_Forwarding, false)
+		if err != nil {
+			return fmt.Errorf("unable to parse destination URL for %s (%s): %w", name, destination, err)
+		}
+		var additionalDestinationURLs []*url.URL
+		for _, additionalDestination := range session.AdditionalDestinations {
+			if u, err := url.Parse(additionalDestination, url.Kind_Forwarding, false); err != nil {
+				return fmt.Errorf("unable to parse additional destination URL for %s (%s): %w", name, additionalDestination, err)
+			} else {
+				additionalDestinationURLs = append(additionalDestinationURLs, u)
+			}
+		}
+
+		// Compute configurations.
+		configuration := session.Configuration.ToInternal()
+		if err := configuration.EnsureValid(false); err != nil {
+			return fmt.Errorf("invalid configuration for %s: %w", name, err)
+		}
+		configuration = forwarding.MergeConfigurations(defaultConfiguration, configuration)
+		sourceConfiguration := session.ConfigurationSource.ToInternal()
+		if err := sourceConfiguration.EnsureValid(true); err != nil {
+			return fmt.Errorf("invalid source configuration for %s: %w", name, err)
+		}
+		sourceConfiguration = forwarding.MergeConfigurations(defaultConfigurationSource, sourceConfiguration)
+		destinationConfiguration := session.ConfigurationDestination.ToInternal()
+		if err := destinationConfiguration.EnsureValid(true); err != nil {
+			return fmt.Errorf("invalid destination configuration for %s: %w", name, err)
+		}
+		destinationConfiguration = forwarding.MergeConfigurations(defaultConfigurationDestination, destinationConfiguration)
+
+		// Record the specification.
+		specifications = append(specifications, &forwardingsvc.CreationSpecification{
+			Source:                   sourceURL,
+			Destination:              destinationURL,
+			AdditionalDestinations:   additionalDestinationURLs,
+			Configuration:            configuration,
+			ConfigurationSource:      sourceConfiguration,
+			ConfigurationDestination: destinationConfiguration,
+			Name:                     name,
+			Labels:                   session.Labels,
+			Paused:                   importConfiguration.paused,
+		})
+	}
+
+	// Connect to the daemon and defer closure of the connection.
+	daemonConnection, err := daemon.Connect(true, true)
+	if err != nil {
+		return fmt.Errorf("unable to connect to daemon: %w", err)
+	}
+	defer daemonConnection.Close()
+
+	// Create sessions.
+	for _, specification := range specifications {
+		identifier, err := CreateWithSpecification(daemonConnection, specification)
+		if err != nil {
+			return fmt.Errorf("unable to create session %s: %w", specification.Name, err)
+		}
+		fmt.Printf("Created session %s (%s)\n", identifier, specification.Name)
+	}
+
+	// Success.
+	return nil
+}
+
+// importCommand is the import command.
+var importCommand = &cobra.Command{
+	Use:          "import <specification-file>",
+	Short:        "Create forwarding sessions from an exported YAML specification",
+	RunE:         importMain,
+	SilenceUsage: true,
+}
+
+// importConfiguration stores configuration for the import command.
+var importConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// paused indicates whether or not to create sessions pre-paused.
+	paused bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := importCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&importConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up import flags.
+	flags.BoolVarP(&importConfiguration.paused, "paused", "p", false, "Create the sessions pre-paused")
+}
@@ -2,6 +2,7 @@ package forward
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/dustin/go-humanize"
 
@@ -34,6 +35,16 @@ func printEndpoint(name string, url *url.URL, configuration *forwarding.Configur
 		// Print configuration header.
 		fmt.Println("\tConfiguration:")
 
+		// Print network access control restrictions.
+		allowedNetworksDescription := "Any"
+		if len(configuration.AllowedNetworks) > 0 {
+			allowedNetworksDescription = strings.Join(configuration.AllowedNetworks, ", ")
+		}
+		fmt.Println("\t\tAllowed networks:", allowedNetworksDescription)
+		if len(configuration.DeniedNetworks) > 0 {
+			fmt.Println("\t\tDenied networks:", strings.Join(configuration.DeniedNetworks, ", "))
+		}
+
 		// Compute and print the socket overwrite mode.
 		socketOverwriteModeDescription := configuration.SocketOverwriteMode.Description()
 		if configuration.SocketOverwriteMode.IsDefault() {
@@ -63,6 +74,33 @@ func printEndpoint(name string, url *url.URL, configuration *forwarding.Configur
 			socketPermissionModeDescription = fmt.Sprintf("%#o", configuration.SocketPermissionMode)
 		}
 		fmt.Println("\t\tSocket permission mode:", socketPermissionModeDescription)
+
+		// Print socket peer access control restrictions.
+		if len(configuration.SocketAllowedUsers) > 0 {
+			fmt.Println("\t\tSocket allowed users:", strings.Join(configuration.SocketAllowedUsers, ", "))
+		}
+		if len(configuration.SocketAllowedGroups) > 0 {
+			fmt.Println("\t\tSocket allowed groups:", strings.Join(configuration.SocketAllowedGroups, ", "))
+		}
+
+		// Compute and print the TLS mode.
+		tlsModeDescription := configuration.TlsMode.Description()
+		if configuration.TlsMode.IsDefault() {
+			tlsModeDescription += fmt.Sprintf(" (%s)", version.DefaultTLSMode().Description())
+		}
+		fmt.Println("\t\tTLS mode:", tlsModeDescription)
+
+		// Print TLS parameters, if any.
+		if configuration.TlsCertificate != "" {
+			fmt.Println("\t\tTLS certificate:", configuration.TlsCertificate)
+			fmt.Println("\t\tTLS key:", configuration.TlsKey)
+		}
+		if configuration.TlsCertificateAuthority != "" {
+			fmt.Println("\t\tTLS certificate authority:", configuration.TlsCertificateAuthority)
+		}
+		if configuration.TlsServerName != "" {
+			fmt.Println("\t\tTLS server name:", configuration.TlsServerName)
+		}
 	}
 
 	// At this point, there's no other status information that will be displayed
@@ -74,6 +112,16 @@ func printEndpoint(name string, url *url.URL, configuration *forwarding.Configur
 
 	// Print connection status.
 	fmt.Println("\tConnected:", common.FormatConnectionStatus(state.Connected))
+
+	// Print listening addresses, if any.
+	if state.Connected && len(state.ListenerAddresses) > 0 {
+		fmt.Println("\tListening on:", strings.Join(state.ListenerAddresses, ", "))
+	}
+
+	// Print health warnings, if any.
+	if state.Connected && state.Unhealthy {
+		color.Yellow("\tUnhealthy: recent connection attempts have failed\n")
+	}
 }
 
 // printSession prints the configuration and status of a forwarding session and
@@ -102,11 +150,17 @@ func printSession(state *forwarding.State, mode common.SessionDisplayMode) {
 			}
 		}
 
-		// Print the configuration header and configuration.
-		// TODO: Implement this in the future if we have any session-level
-		// configuration behaviors. We currently only have endpoint-level
-		// configuration behaviors and thus we don't print any session-level
-		// configuration information like we do for synchronization.
+		// Print session-level configuration. The only session-level
+		// configuration behavior is destination selection, which is only
+		// relevant if there are multiple destinations.
+		if len(state.Session.AdditionalDestinations) > 0 {
+			fmt.Println("Configuration:")
+			selectionModeDescription := state.Session.Configuration.DestinationSelectionMode.Description()
+			if state.Session.Configuration.DestinationSelectionMode.IsDefault() {
+				selectionModeDescription += fmt.Sprintf(" (%s)", state.Session.Version.DefaultDestinationSelectionMode().Description())
+			}
+			fmt.Println("\tDestination selection mode:", selectionModeDescription)
+		}
 	}
 
 	// Compute and print source-specific configuration.
@@ -133,6 +187,17 @@ func printSession(state *forwarding.State, mode common.SessionDisplayMode) {
 		mode,
 	)
 
+	// Print additional destinations, if any. These share the destination
+	// configuration.
+	for i, destination := range state.Session.AdditionalDestinations {
+		printEndpoint(
+			fmt.Sprintf("Destination %d", i+2), destination,
+			destinationConfigurationMerged, state.AdditionalDestinationStates[i],
+			state.Session.Version,
+			mode,
+		)
+	}
+
 	// At this point, there's no other status information that will be displayed
 	// for non-list modes, so we can save ourselves some checks and return if
 	// we're in a monitor mode.
@@ -160,5 +225,20 @@ func printSession(state *forwarding.State, mode common.SessionDisplayMode) {
 			humanize.Bytes(state.TotalOutboundData),
 			humanize.Bytes(state.TotalInboundData),
 		)
+		if state.Session.Source.Protocol != url.Protocol_Local {
+			if len(sourceConfigurationMerged.AllowedNetworks) > 0 ||
+				len(sourceConfigurationMerged.DeniedNetworks) > 0 ||
+				len(sourceConfigurationMerged.SocketAllowedUsers) > 0 ||
+				len(sourceConfigurationMerged.SocketAllowedGroups) > 0 {
+				fmt.Println("Refused connections: Not tracked for remote sources")
+			}
+		} else if state.RefusedConnections > 0 {
+			color.Yellow("Refused connections: %d\n", state.RefusedConnections)
+		}
+	}
+
+	// Print capture information, if any.
+	if state.CapturePath != "" {
+		fmt.Println("Capturing to:", state.CapturePath)
 	}
 }
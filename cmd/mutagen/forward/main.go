@@ -43,7 +43,11 @@ func init() {
 
 	// Register commands.
 	ForwardCommand.AddCommand(
+		captureCommand,
 		createCommand,
+		editCommand,
+		exportCommand,
+		importCommand,
 		listCommand,
 		monitorCommand,
 		pauseCommand,
@@ -67,6 +67,10 @@ func init() {
 	// still implement its logic automatically.
 	flags.BoolVarP(&rootConfiguration.help, "help", "h", false, "Show help information")
 
+	// Register flags for connecting to a remote daemon. These are persistent
+	// so that they're available to every command that connects to the daemon.
+	daemon.RegisterRemoteFlags(rootCommand.PersistentFlags())
+
 	// Hide Cobra's completion command.
 	rootCommand.CompletionOptions.HiddenDefaultCmd = true
 
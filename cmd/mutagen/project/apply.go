@@ -0,0 +1,359 @@
+package project
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"google.golang.org/grpc"
+
+	"github.com/mutagen-io/mutagen/cmd"
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+	"github.com/mutagen-io/mutagen/cmd/mutagen/forward"
+	"github.com/mutagen-io/mutagen/cmd/mutagen/sync"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem/locking"
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/project"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+)
+
+// planAction represents an action in a reconciliation plan.
+type planAction struct {
+	// symbol is the symbol used to prefix the action when printing the plan.
+	symbol string
+	// description is the human-readable description of the action.
+	description string
+}
+
+// listForwardingStates lists the states of the forwarding sessions matching a
+// selection.
+func listForwardingStates(daemonConnection *grpc.ClientConn, selection *selection.Selection) ([]*forwarding.State, error) {
+	forwardingService := forwardingsvc.NewForwardingClient(daemonConnection)
+	response, err := forwardingService.List(context.Background(), &forwardingsvc.ListRequest{Selection: selection})
+	if err != nil {
+		return nil, grpcutil.PeelAwayRPCErrorLayer(err)
+	} else if err = response.EnsureValid(); err != nil {
+		return nil, fmt.Errorf("invalid list response received: %w", err)
+	}
+	return response.SessionStates, nil
+}
+
+// listForwardingSessions lists the forwarding sessions matching a selection.
+func listForwardingSessions(daemonConnection *grpc.ClientConn, selection *selection.Selection) ([]*forwarding.Session, error) {
+	// Perform the list operation.
+	states, err := listForwardingStates(daemonConnection, selection)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract sessions.
+	sessions := make([]*forwarding.Session, len(states))
+	for s, state := range states {
+		sessions[s] = state.Session
+	}
+	return sessions, nil
+}
+
+// listSynchronizationStates lists the states of the synchronization sessions
+// matching a selection.
+func listSynchronizationStates(daemonConnection *grpc.ClientConn, selection *selection.Selection) ([]*synchronization.State, error) {
+	synchronizationService := synchronizationsvc.NewSynchronizationClient(daemonConnection)
+	response, err := synchronizationService.List(context.Background(), &synchronizationsvc.ListRequest{Selection: selection})
+	if err != nil {
+		return nil, grpcutil.PeelAwayRPCErrorLayer(err)
+	} else if err = response.EnsureValid(); err != nil {
+		return nil, fmt.Errorf("invalid list response received: %w", err)
+	}
+	return response.SessionStates, nil
+}
+
+// listSynchronizationSessions lists the synchronization sessions matching a
+// selection.
+func listSynchronizationSessions(daemonConnection *grpc.ClientConn, selection *selection.Selection) ([]*synchronization.Session, error) {
+	// Perform the list operation.
+	states, err := listSynchronizationStates(daemonConnection, selection)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract sessions.
+	sessions := make([]*synchronization.Session, len(states))
+	for s, state := range states {
+		sessions[s] = state.Session
+	}
+	return sessions, nil
+}
+
+// applyMain is the entry point for the apply command.
+func applyMain(_ *cobra.Command, _ []string) error {
+	// Resolve the project configuration files and ensure that our working
+	// directory is the project directory.
+	files, err := resolveProjectFiles(applyConfiguration.projectFiles)
+	if err != nil {
+		return err
+	}
+
+	// Compute the lock path.
+	lockPath := files.stateFileBase + project.LockFileExtension
+
+	// Track whether or not we should remove the lock file on return.
+	var removeLockFileOnReturn bool
+
+	// Create a locker and defer its closure and potential removal. On Windows
+	// systems, we have to handle this removal after the file is closed.
+	locker, err := locking.NewLocker(lockPath, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to create project locker: %w", err)
+	}
+	defer func() {
+		locker.Close()
+		if removeLockFileOnReturn && runtime.GOOS == "windows" {
+			os.Remove(lockPath)
+		}
+	}()
+
+	// Acquire the project lock and defer its release and potential removal. On
+	// Windows systems, we can't remove the lock file if it's locked or even
+	// just opened, so we handle removal for Windows systems after we close the
+	// lock file (see above). In this case, we truncate the lock file before
+	// releasing it to ensure that any other process that opens or acquires the
+	// lock file before we manage to remove it will simply see an empty lock
+	// file, which it will ignore or attempt to remove.
+	if err := locker.Lock(true); err != nil {
+		return fmt.Errorf("unable to acquire project lock: %w", err)
+	}
+	defer func() {
+		if removeLockFileOnReturn {
+			if runtime.GOOS == "windows" {
+				locker.Truncate(0)
+			} else {
+				os.Remove(lockPath)
+			}
+		}
+		locker.Unlock()
+	}()
+
+	// Read the project lock from the lock file. If the lock file is empty, then
+	// we can assume that we created it when we created the lock and just
+	// remove it.
+	buffer := &bytes.Buffer{}
+	if length, err := buffer.ReadFrom(locker); err != nil {
+		return fmt.Errorf("unable to read project lock: %w", err)
+	} else if length == 0 {
+		removeLockFileOnReturn = true
+		return errors.New("project not running")
+	}
+
+	// Parse and validate the project lock.
+	lock := &project.Lock{}
+	if err := lock.UnmarshalText(buffer.Bytes()); err != nil {
+		return err
+	}
+	projectIdentifier := lock.Identifier
+
+	// Load the configuration file.
+	configuration, err := project.LoadComposedConfiguration(files.paths)
+	if err != nil {
+		return fmt.Errorf("unable to load configuration file: %w", err)
+	}
+
+	// Compute session specifications using the profiles that were active when
+	// the project was started.
+	specifications, err := computeSpecifications(
+		configuration, projectIdentifier, lock.Profiles,
+		false, applyConfiguration.noGlobalConfiguration,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Connect to the daemon and defer closure of the connection.
+	daemonConnection, err := daemon.Connect(true, true)
+	if err != nil {
+		return fmt.Errorf("unable to connect to daemon: %w", err)
+	}
+	defer daemonConnection.Close()
+
+	// Compute the selection that we're going to use to find running sessions.
+	projectSelection := &selection.Selection{
+		LabelSelector: fmt.Sprintf("%s=%s", project.LabelKey, projectIdentifier),
+	}
+
+	// List the project's running sessions.
+	forwardingSessions, err := listForwardingSessions(daemonConnection, projectSelection)
+	if err != nil {
+		return fmt.Errorf("unable to list forwarding session(s): %w", err)
+	}
+	synchronizationSessions, err := listSynchronizationSessions(daemonConnection, projectSelection)
+	if err != nil {
+		return fmt.Errorf("unable to list synchronization session(s): %w", err)
+	}
+
+	// Track the plan.
+	var plan []planAction
+	var forwardingToTerminate, synchronizationToTerminate []string
+	toCreate := make(map[project.SessionReference]bool)
+
+	// Reconcile forwarding sessions. Any running session without a
+	// corresponding specification (or that duplicates the name of an earlier
+	// session) is terminated. Sessions that don't match their specification
+	// are recreated, preserving their paused state.
+	forwardingByName := make(map[string]*forwarding.Session, len(forwardingSessions))
+	for _, session := range forwardingSessions {
+		if _, ok := forwardingByName[session.Name]; ok || session.Name == "" {
+			forwardingToTerminate = append(forwardingToTerminate, session.Identifier)
+			plan = append(plan, planAction{"-", fmt.Sprintf("terminate forwarding session %s", session.Identifier)})
+			continue
+		}
+		forwardingByName[session.Name] = session
+	}
+	forwardingNames := make(map[string]bool, len(specifications.forwarding))
+	for _, specification := range specifications.forwarding {
+		forwardingNames[specification.Name] = true
+		reference := project.SessionReference{Kind: project.SessionKindForwarding, Name: specification.Name}
+		if session, ok := forwardingByName[specification.Name]; !ok {
+			toCreate[reference] = true
+			plan = append(plan, planAction{"+", fmt.Sprintf("create forwarding session %s", specification.Name)})
+		} else if !project.ForwardingSessionMatches(session, specification) {
+			specification.Paused = session.Paused
+			forwardingToTerminate = append(forwardingToTerminate, session.Identifier)
+			toCreate[reference] = true
+			plan = append(plan, planAction{"~", fmt.Sprintf("recreate forwarding session %s", specification.Name)})
+		}
+	}
+	for name, session := range forwardingByName {
+		if !forwardingNames[name] {
+			forwardingToTerminate = append(forwardingToTerminate, session.Identifier)
+			plan = append(plan, planAction{"-", fmt.Sprintf("terminate forwarding session %s", name)})
+		}
+	}
+
+	// Reconcile synchronization sessions using the same logic.
+	synchronizationByName := make(map[string]*synchronization.Session, len(synchronizationSessions))
+	for _, session := range synchronizationSessions {
+		if _, ok := synchronizationByName[session.Name]; ok || session.Name == "" {
+			synchronizationToTerminate = append(synchronizationToTerminate, session.Identifier)
+			plan = append(plan, planAction{"-", fmt.Sprintf("terminate synchronization session %s", session.Identifier)})
+			continue
+		}
+		synchronizationByName[session.Name] = session
+	}
+	synchronizationNames := make(map[string]bool, len(specifications.synchronization))
+	for _, specification := range specifications.synchronization {
+		synchronizationNames[specification.Name] = true
+		reference := project.SessionReference{Kind: project.SessionKindSynchronization, Name: specification.Name}
+		if session, ok := synchronizationByName[specification.Name]; !ok {
+			toCreate[reference] = true
+			plan = append(plan, planAction{"+", fmt.Sprintf("create synchronization session %s", specification.Name)})
+		} else if !project.SynchronizationSessionMatches(session, specification) {
+			specification.Paused = session.Paused
+			synchronizationToTerminate = append(synchronizationToTerminate, session.Identifier)
+			toCreate[reference] = true
+			plan = append(plan, planAction{"~", fmt.Sprintf("recreate synchronization session %s", specification.Name)})
+		}
+	}
+	for name, session := range synchronizationByName {
+		if !synchronizationNames[name] {
+			synchronizationToTerminate = append(synchronizationToTerminate, session.Identifier)
+			plan = append(plan, planAction{"-", fmt.Sprintf("terminate synchronization session %s", name)})
+		}
+	}
+
+	// Print the plan.
+	if len(plan) == 0 {
+		fmt.Println("Project sessions are up-to-date")
+		return nil
+	}
+	sort.SliceStable(plan, func(i, j int) bool {
+		return plan[i].description < plan[j].description
+	})
+	fmt.Println("Planned changes:")
+	for _, action := range plan {
+		fmt.Printf("  %s %s\n", action.symbol, action.description)
+	}
+
+	// If this is a dry run, then we're done.
+	if applyConfiguration.dryRun {
+		return nil
+	}
+
+	// Terminate removed and changed forwarding sessions.
+	if len(forwardingToTerminate) > 0 {
+		terminateSelection := &selection.Selection{Specifications: forwardingToTerminate}
+		if err := forward.TerminateWithSelection(daemonConnection, terminateSelection); err != nil {
+			return fmt.Errorf("unable to terminate forwarding session(s): %w", err)
+		}
+	}
+
+	// Terminate removed and changed synchronization sessions.
+	if len(synchronizationToTerminate) > 0 {
+		terminateSelection := &selection.Selection{Specifications: synchronizationToTerminate}
+		if err := sync.TerminateWithSelection(daemonConnection, terminateSelection); err != nil {
+			return fmt.Errorf("unable to terminate synchronization session(s): %w", err)
+		}
+	}
+
+	// Create new and changed sessions.
+	if _, err := createSessions(daemonConnection, specifications, func(reference project.SessionReference) bool {
+		return toCreate[reference]
+	}); err != nil {
+		return err
+	}
+
+	// Success.
+	return nil
+}
+
+// applyCommand is the apply command.
+var applyCommand = &cobra.Command{
+	Use:          "apply",
+	Short:        "Reconcile project sessions with the project file",
+	Args:         cmd.DisallowArguments,
+	RunE:         applyMain,
+	SilenceUsage: true,
+}
+
+// applyConfiguration stores configuration for the apply command.
+var applyConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// projectFiles are the paths to the project files, if non-default.
+	projectFiles []string
+	// dryRun indicates whether or not to print the plan without applying it.
+	dryRun bool
+	// noGlobalConfiguration specifies whether or not the global configuration
+	// file should be ignored.
+	noGlobalConfiguration bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := applyCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&applyConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up project file flags.
+	flags.StringArrayVarP(&applyConfiguration.projectFiles, "project-file", "f", nil, "Specify project file(s) (repeatable, merged in order)")
+
+	// Wire up apply flags.
+	flags.BoolVar(&applyConfiguration.dryRun, "dry-run", false, "Print planned changes without applying them")
+
+	// Wire up general configuration flags.
+	flags.BoolVar(&applyConfiguration.noGlobalConfiguration, "no-global-configuration", false, "Ignore the global configuration file")
+}
@@ -3,8 +3,10 @@
 package project
 
 import (
+	"errors"
 	"os"
 	"os/exec"
+	"syscall"
 )
 
 // runInShell runs the specified command using the system shell. On POSIX
@@ -19,3 +21,20 @@ func runInShell(command string) error {
 	// Run the process and wait for its completion.
 	return process.Run()
 }
+
+// supervisorProcessAttributes returns the process attributes to use for the
+// service supervisor process. The supervisor is started in its own session so
+// that it's detached from the invoking terminal.
+func supervisorProcessAttributes() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
+
+// signalSupervisor requests that the service supervisor process with the
+// specified process identifier terminate. It succeeds if the process no longer
+// exists.
+func signalSupervisor(pid int) error {
+	if err := syscall.Kill(pid, syscall.SIGTERM); err != nil && !errors.Is(err, syscall.ESRCH) {
+		return err
+	}
+	return nil
+}
@@ -1,8 +1,12 @@
 package project
 
 import (
+	"errors"
 	"os"
 	"os/exec"
+	"syscall"
+
+	"golang.org/x/sys/windows"
 )
 
 // runInShell runs the specified command using the system shell. On Windows
@@ -23,3 +27,29 @@ func runInShell(command string) error {
 	// Run the process and wait for its completion.
 	return process.Run()
 }
+
+// supervisorProcessAttributes returns the process attributes to use for the
+// service supervisor process. The supervisor is started without a console so
+// that it's detached from the invoking terminal.
+func supervisorProcessAttributes() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{
+		CreationFlags: windows.DETACHED_PROCESS | windows.CREATE_NEW_PROCESS_GROUP,
+	}
+}
+
+// signalSupervisor terminates the service supervisor process with the
+// specified process identifier. Windows doesn't support termination requests
+// for console processes, so the process is killed, and any service processes
+// that it was running are left to exit on their own. It succeeds if the
+// process no longer exists.
+func signalSupervisor(pid int) error {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return nil
+	}
+	defer process.Release()
+	if err := process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return err
+	}
+	return nil
+}
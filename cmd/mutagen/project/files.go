@@ -0,0 +1,71 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mutagen-io/mutagen/pkg/project"
+)
+
+// projectFiles represents the configuration files that compose a project.
+type projectFiles struct {
+	// paths are the configuration file paths, in merge order. They are
+	// relative to the project directory where possible.
+	paths []string
+	// stateFileBase is the base path for project state files.
+	stateFileBase string
+}
+
+// resolveProjectFiles resolves the configuration files that compose a project
+// and switches the working directory to the project directory (i.e. the
+// directory containing the first file). This is required for relative paths
+// (including relative synchronization paths, relative Unix Domain Socket
+// paths, and service commands) to be resolved relative to the project
+// configuration file. If no files are specified, then the default
+// configuration file in the current directory is used.
+func resolveProjectFiles(specified []string) (*projectFiles, error) {
+	// Handle the default case.
+	if len(specified) == 0 {
+		return &projectFiles{
+			paths:         []string{project.DefaultConfigurationFileName},
+			stateFileBase: project.DefaultConfigurationFileName,
+		}, nil
+	}
+
+	// Convert paths to absolute paths before we change directories.
+	absolute := make([]string, len(specified))
+	for p, path := range specified {
+		if path == "" {
+			return nil, errors.New("empty project file path")
+		}
+		var err error
+		if absolute[p], err = filepath.Abs(path); err != nil {
+			return nil, fmt.Errorf("unable to compute absolute path for project file (%s): %w", path, err)
+		}
+	}
+
+	// Switch to the project directory.
+	directory := filepath.Dir(absolute[0])
+	if err := os.Chdir(directory); err != nil {
+		return nil, fmt.Errorf("unable to switch to target directory: %w", err)
+	}
+
+	// Compute paths relative to the project directory where possible, which
+	// keeps the state file base independent of the invocation directory.
+	paths := make([]string, len(absolute))
+	for p, path := range absolute {
+		if relative, err := filepath.Rel(directory, path); err == nil {
+			paths[p] = relative
+		} else {
+			paths[p] = path
+		}
+	}
+
+	// Success.
+	return &projectFiles{
+		paths:         paths,
+		stateFileBase: project.StateFileBase(paths),
+	}, nil
+}
@@ -5,8 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -16,31 +16,21 @@ import (
 	"github.com/mutagen-io/mutagen/cmd/mutagen/sync"
 
 	"github.com/mutagen-io/mutagen/pkg/filesystem/locking"
-	"github.com/mutagen-io/mutagen/pkg/identifier"
 	"github.com/mutagen-io/mutagen/pkg/project"
 	"github.com/mutagen-io/mutagen/pkg/selection"
 )
 
 // listMain is the entry point for the list command.
 func listMain(_ *cobra.Command, _ []string) error {
-	// Compute the name of the configuration file and ensure that our working
-	// directory is that in which the file resides. This is required for
-	// relative paths (including relative synchronization paths and relative
-	// Unix Domain Socket paths) to be resolved relative to the project
-	// configuration file.
-	configurationFileName := project.DefaultConfigurationFileName
-	if listConfiguration.projectFile != "" {
-		var directory string
-		directory, configurationFileName = filepath.Split(listConfiguration.projectFile)
-		if directory != "" {
-			if err := os.Chdir(directory); err != nil {
-				return fmt.Errorf("unable to switch to target directory: %w", err)
-			}
-		}
+	// Resolve the project configuration files and ensure that our working
+	// directory is the project directory.
+	files, err := resolveProjectFiles(listConfiguration.projectFiles)
+	if err != nil {
+		return err
 	}
 
 	// Compute the lock path.
-	lockPath := configurationFileName + project.LockFileExtension
+	lockPath := files.stateFileBase + project.LockFileExtension
 
 	// Track whether or not we should remove the lock file on return.
 	var removeLockFileOnReturn bool
@@ -79,7 +69,7 @@ func listMain(_ *cobra.Command, _ []string) error {
 		locker.Unlock()
 	}()
 
-	// Read the project identifier from the lock file. If the lock file is
+	// Read the project lock from the lock file. If the lock file is
 	// empty, then we can assume that we created it when we created the lock and
 	// just remove it.
 	buffer := &bytes.Buffer{}
@@ -89,12 +79,13 @@ func listMain(_ *cobra.Command, _ []string) error {
 		removeLockFileOnReturn = true
 		return errors.New("project not running")
 	}
-	projectIdentifier := buffer.String()
 
-	// Ensure that the project identifier is valid.
-	if !identifier.IsValid(projectIdentifier) {
-		return errors.New("invalid project identifier found in project lock")
+	// Parse and validate the project lock.
+	lock := &project.Lock{}
+	if err := lock.UnmarshalText(buffer.Bytes()); err != nil {
+		return err
 	}
+	projectIdentifier := lock.Identifier
 
 	// Connect to the daemon and defer closure of the connection.
 	daemonConnection, err := daemon.Connect(true, true)
@@ -108,6 +99,12 @@ func listMain(_ *cobra.Command, _ []string) error {
 		LabelSelector: fmt.Sprintf("%s=%s", project.LabelKey, projectIdentifier),
 	}
 
+	// Print active profiles.
+	if len(lock.Profiles) > 0 {
+		fmt.Println("Active profiles:", strings.Join(lock.Profiles, ", "))
+		fmt.Println()
+	}
+
 	// List forwarding sessions.
 	fmt.Println("Forwarding sessions:")
 	if err := forward.ListWithSelection(daemonConnection, selection, listConfiguration.long); err != nil {
@@ -140,8 +137,8 @@ var listCommand = &cobra.Command{
 var listConfiguration struct {
 	// help indicates whether or not to show help information and exit.
 	help bool
-	// projectFile is the path to the project file, if non-default.
-	projectFile string
+	// projectFiles are the paths to the project files, if non-default.
+	projectFiles []string
 	// long indicates whether or not to use long-format listing.
 	long bool
 }
@@ -158,7 +155,7 @@ func init() {
 	flags.BoolVarP(&listConfiguration.help, "help", "h", false, "Show help information")
 
 	// Wire up project file flags.
-	flags.StringVarP(&listConfiguration.projectFile, "project-file", "f", "", "Specify project file")
+	flags.StringArrayVarP(&listConfiguration.projectFiles, "project-file", "f", nil, "Specify project file(s) (repeatable, merged in order)")
 
 	// Wire up list flags.
 	flags.BoolVarP(&listConfiguration.long, "long", "l", false, "Show detailed session information")
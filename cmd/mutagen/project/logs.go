@@ -0,0 +1,107 @@
+package project
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/cmd"
+
+	"github.com/mutagen-io/mutagen/pkg/project"
+)
+
+const (
+	// logsFollowPollingInterval is the interval at which the service log file
+	// is polled for new content when following.
+	logsFollowPollingInterval = 250 * time.Millisecond
+)
+
+// logsMain is the entry point for the logs command.
+func logsMain(_ *cobra.Command, _ []string) error {
+	// Resolve the project configuration files and ensure that our working
+	// directory is the project directory.
+	files, err := resolveProjectFiles(logsConfiguration.projectFiles)
+	if err != nil {
+		return err
+	}
+
+	// Open the service log file. If it doesn't exist, then no services have
+	// been run.
+	file, err := os.Open(files.stateFileBase + project.ServicesLogFileExtension)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("unable to open service log file: %w", err)
+	}
+	defer file.Close()
+
+	// Print existing content.
+	if _, err := io.Copy(os.Stdout, file); err != nil {
+		return fmt.Errorf("unable to read service log file: %w", err)
+	}
+
+	// If we're not following, then we're done.
+	if !logsConfiguration.follow {
+		return nil
+	}
+
+	// Set up termination signal handling.
+	signalTermination := make(chan os.Signal, 1)
+	signal.Notify(signalTermination, cmd.TerminationSignals...)
+
+	// Poll for new content.
+	ticker := time.NewTicker(logsFollowPollingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-signalTermination:
+			return nil
+		case <-ticker.C:
+		}
+		if _, err := io.Copy(os.Stdout, file); err != nil {
+			return fmt.Errorf("unable to read service log file: %w", err)
+		}
+	}
+}
+
+// logsCommand is the logs command.
+var logsCommand = &cobra.Command{
+	Use:          "logs",
+	Short:        "Show project service output",
+	Args:         cmd.DisallowArguments,
+	RunE:         logsMain,
+	SilenceUsage: true,
+}
+
+// logsConfiguration stores configuration for the logs command.
+var logsConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// projectFiles are the paths to the project files, if non-default.
+	projectFiles []string
+	// follow indicates whether or not to wait for and print new output.
+	follow bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := logsCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&logsConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up project file flags.
+	flags.StringArrayVarP(&logsConfiguration.projectFiles, "project-file", "f", nil, "Specify project file(s) (repeatable, merged in order)")
+
+	// Wire up logs flags.
+	flags.BoolVar(&logsConfiguration.follow, "follow", false, "Wait for and print new output")
+}
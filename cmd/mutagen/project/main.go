@@ -49,12 +49,18 @@ func init() {
 	// Register commands.
 	ProjectCommand.AddCommand(
 		startCommand,
+		validateCommand,
+		applyCommand,
 		runCommand,
+		logsCommand,
 		listCommand,
+		statusCommand,
 		flushCommand,
 		pauseCommand,
 		resumeCommand,
 		resetCommand,
 		terminateCommand,
+		schemaCommand,
+		superviseCommand,
 	)
 }
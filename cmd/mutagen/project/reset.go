@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 	"runtime"
 
 	"github.com/spf13/cobra"
@@ -15,31 +14,21 @@ import (
 	"github.com/mutagen-io/mutagen/cmd/mutagen/sync"
 
 	"github.com/mutagen-io/mutagen/pkg/filesystem/locking"
-	"github.com/mutagen-io/mutagen/pkg/identifier"
 	"github.com/mutagen-io/mutagen/pkg/project"
 	"github.com/mutagen-io/mutagen/pkg/selection"
 )
 
 // resetMain is the entry point for the reset command.
 func resetMain(_ *cobra.Command, _ []string) error {
-	// Compute the name of the configuration file and ensure that our working
-	// directory is that in which the file resides. This is required for
-	// relative paths (including relative synchronization paths and relative
-	// Unix Domain Socket paths) to be resolved relative to the project
-	// configuration file.
-	configurationFileName := project.DefaultConfigurationFileName
-	if resetConfiguration.projectFile != "" {
-		var directory string
-		directory, configurationFileName = filepath.Split(resetConfiguration.projectFile)
-		if directory != "" {
-			if err := os.Chdir(directory); err != nil {
-				return fmt.Errorf("unable to switch to target directory: %w", err)
-			}
-		}
+	// Resolve the project configuration files and ensure that our working
+	// directory is the project directory.
+	files, err := resolveProjectFiles(resetConfiguration.projectFiles)
+	if err != nil {
+		return err
 	}
 
 	// Compute the lock path.
-	lockPath := configurationFileName + project.LockFileExtension
+	lockPath := files.stateFileBase + project.LockFileExtension
 
 	// Track whether or not we should remove the lock file on return.
 	var removeLockFileOnReturn bool
@@ -78,7 +67,7 @@ func resetMain(_ *cobra.Command, _ []string) error {
 		locker.Unlock()
 	}()
 
-	// Read the project identifier from the lock file. If the lock file is
+	// Read the project lock from the lock file. If the lock file is
 	// empty, then we can assume that we created it when we created the lock and
 	// just remove it.
 	buffer := &bytes.Buffer{}
@@ -88,12 +77,13 @@ func resetMain(_ *cobra.Command, _ []string) error {
 		removeLockFileOnReturn = true
 		return errors.New("project not running")
 	}
-	projectIdentifier := buffer.String()
 
-	// Ensure that the project identifier is valid.
-	if !identifier.IsValid(projectIdentifier) {
-		return errors.New("invalid project identifier found in project lock")
+	// Parse and validate the project lock.
+	lock := &project.Lock{}
+	if err := lock.UnmarshalText(buffer.Bytes()); err != nil {
+		return err
 	}
+	projectIdentifier := lock.Identifier
 
 	// Connect to the daemon and defer closure of the connection.
 	daemonConnection, err := daemon.Connect(true, true)
@@ -129,8 +119,8 @@ var resetCommand = &cobra.Command{
 var resetConfiguration struct {
 	// help indicates whether or not to show help information and exit.
 	help bool
-	// projectFile is the path to the project file, if non-default.
-	projectFile string
+	// projectFiles are the paths to the project files, if non-default.
+	projectFiles []string
 }
 
 func init() {
@@ -145,5 +135,5 @@ func init() {
 	flags.BoolVarP(&resetConfiguration.help, "help", "h", false, "Show help information")
 
 	// Wire up project file flags.
-	flags.StringVarP(&resetConfiguration.projectFile, "project-file", "f", "", "Specify project file")
+	flags.StringArrayVarP(&resetConfiguration.projectFiles, "project-file", "f", nil, "Specify project file(s) (repeatable, merged in order)")
 }
@@ -0,0 +1,66 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/cmd"
+
+	"github.com/mutagen-io/mutagen/pkg/configuration/schema"
+)
+
+// schemaMain is the entry point for the schema command.
+func schemaMain(_ *cobra.Command, _ []string) error {
+	// Generate the requested schema.
+	var target *schema.Schema
+	if schemaConfiguration.global {
+		target = schema.Global()
+	} else {
+		target = schema.Project()
+	}
+
+	// Encode and print the schema.
+	encoded, err := json.MarshalIndent(target, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to encode schema: %w", err)
+	}
+	fmt.Println(string(encoded))
+
+	// Success.
+	return nil
+}
+
+// schemaCommand is the schema command.
+var schemaCommand = &cobra.Command{
+	Use:          "schema",
+	Short:        "Print the JSON Schema for project files",
+	Args:         cmd.DisallowArguments,
+	RunE:         schemaMain,
+	SilenceUsage: true,
+}
+
+// schemaConfiguration stores configuration for the schema command.
+var schemaConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// global indicates whether or not to print the schema for the global
+	// configuration file instead.
+	global bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := schemaCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&schemaConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up schema flags.
+	flags.BoolVar(&schemaConfiguration.global, "global", false, "Print the schema for the global configuration file")
+}
@@ -0,0 +1,532 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc"
+
+	"github.com/mutagen-io/mutagen/cmd/mutagen/forward"
+	"github.com/mutagen-io/mutagen/cmd/mutagen/sync"
+
+	"github.com/mutagen-io/mutagen/pkg/configuration/global"
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/project"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// specifications stores the session creation specifications computed for a
+// project.
+type specifications struct {
+	// forwarding are the forwarding session creation specifications.
+	forwarding []*forwardingsvc.CreationSpecification
+	// synchronization are the synchronization session creation
+	// specifications.
+	synchronization []*synchronizationsvc.CreationSpecification
+	// flushOnCreate indicates whether or not each synchronization session
+	// should be flushed after creation. It is indexed in parallel with
+	// synchronization.
+	flushOnCreate []bool
+	// order is the order in which sessions should be created in order to
+	// respect their dependencies.
+	order []project.SessionReference
+	// hasDependents indicates which sessions have other sessions that depend
+	// on them.
+	hasDependents map[project.SessionReference]bool
+}
+
+// lookupPreset looks up a preset from the global configuration file. The
+// global configuration may be nil if the file doesn't exist.
+func lookupPreset(globalConfiguration *global.Configuration, noGlobalConfiguration bool, name string) (*global.Preset, error) {
+	if noGlobalConfiguration {
+		return nil, errors.New("presets cannot be used when ignoring the global configuration file")
+	} else if globalConfiguration == nil {
+		return nil, fmt.Errorf("unknown preset: %s", name)
+	}
+	return globalConfiguration.Preset(name)
+}
+
+// computeSpecifications computes session creation specifications for the
+// sessions defined in a project configuration. Sessions are labeled with the
+// specified project identifier, and only those sessions that are active for
+// the specified profiles are included. Relative URLs are resolved against the
+// current working directory, so the caller should switch to the project
+// directory before invoking this function.
+func computeSpecifications(
+	configuration *project.Configuration,
+	projectIdentifier string,
+	profiles []string,
+	paused, noGlobalConfiguration bool,
+) (*specifications, error) {
+	// Create the result.
+	result := &specifications{
+		hasDependents: make(map[project.SessionReference]bool),
+	}
+
+	// Unless disabled, attempt to load configuration from the global
+	// configuration file and use it as the base for our core session
+	// configurations.
+	globalConfigurationForwarding := &forwarding.Configuration{}
+	globalConfigurationSynchronization := &synchronization.Configuration{}
+	var globalConfiguration *global.Configuration
+	if !noGlobalConfiguration {
+		// Compute the path to the global configuration file.
+		globalConfigurationPath, err := global.ConfigurationPath()
+		if err != nil {
+			return nil, fmt.Errorf("unable to compute path to global configuration file: %w", err)
+		}
+
+		// Attempt to load and validate the file. We allow it to not exist.
+		globalConfiguration, err = global.LoadConfiguration(globalConfigurationPath)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				return nil, fmt.Errorf("unable to load global configuration: %w", err)
+			}
+		} else {
+			globalConfigurationForwarding = globalConfiguration.Forwarding.Defaults.ToInternal()
+			if err := globalConfigurationForwarding.EnsureValid(false); err != nil {
+				return nil, fmt.Errorf("invalid global forwarding configuration: %w", err)
+			}
+			globalConfigurationSynchronization = globalConfiguration.Synchronization.Defaults.ToInternal()
+			if err := globalConfigurationSynchronization.EnsureValid(false); err != nil {
+				return nil, fmt.Errorf("invalid global synchronization configuration: %w", err)
+			}
+		}
+	}
+
+	// Extract and validate forwarding defaults.
+	var defaultSource, defaultDestination string
+	defaultConfigurationForwarding := &forwarding.Configuration{}
+	defaultConfigurationSource := &forwarding.Configuration{}
+	defaultConfigurationDestination := &forwarding.Configuration{}
+	if defaults, ok := configuration.Forwarding["defaults"]; ok {
+		if len(defaults.Profiles) > 0 {
+			return nil, errors.New("profiles cannot be specified for default forwarding configuration")
+		}
+		if defaults.Preset != "" {
+			return nil, errors.New("presets cannot be specified for default forwarding configuration")
+		}
+		defaultSource = defaults.Source
+		defaultDestination = defaults.Destination
+		defaultConfigurationForwarding = defaults.Configuration.ToInternal()
+		if err := defaultConfigurationForwarding.EnsureValid(false); err != nil {
+			return nil, fmt.Errorf("invalid default forwarding configuration: %w", err)
+		}
+		defaultConfigurationSource = defaults.ConfigurationSource.ToInternal()
+		if err := defaultConfigurationSource.EnsureValid(true); err != nil {
+			return nil, fmt.Errorf("invalid default forwarding source configuration: %w", err)
+		}
+		defaultConfigurationDestination = defaults.ConfigurationDestination.ToInternal()
+		if err := defaultConfigurationDestination.EnsureValid(true); err != nil {
+			return nil, fmt.Errorf("invalid default forwarding destination configuration: %w", err)
+		}
+	}
+
+	// Extract and validate synchronization defaults.
+	var defaultAlpha, defaultBeta string
+	var defaultFlushOnCreate project.FlushOnCreateBehavior
+	defaultConfigurationSynchronization := &synchronization.Configuration{}
+	defaultConfigurationAlpha := &synchronization.Configuration{}
+	defaultConfigurationBeta := &synchronization.Configuration{}
+	if defaults, ok := configuration.Synchronization["defaults"]; ok {
+		if len(defaults.Profiles) > 0 {
+			return nil, errors.New("profiles cannot be specified for default synchronization configuration")
+		}
+		if defaults.Preset != "" {
+			return nil, errors.New("presets cannot be specified for default synchronization configuration")
+		}
+		defaultAlpha = defaults.Alpha
+		defaultBeta = defaults.Beta
+		defaultFlushOnCreate = defaults.FlushOnCreate
+		defaultConfigurationSynchronization = defaults.Configuration.ToInternal()
+		if err := defaultConfigurationSynchronization.EnsureValid(false); err != nil {
+			return nil, fmt.Errorf("invalid default synchronization configuration: %w", err)
+		}
+		defaultConfigurationAlpha = defaults.ConfigurationAlpha.ToInternal()
+		if err := defaultConfigurationAlpha.EnsureValid(true); err != nil {
+			return nil, fmt.Errorf("invalid default synchronization alpha configuration: %w", err)
+		}
+		defaultConfigurationBeta = defaults.ConfigurationBeta.ToInternal()
+		if err := defaultConfigurationBeta.EnsureValid(true); err != nil {
+			return nil, fmt.Errorf("invalid default synchronization beta configuration: %w", err)
+		}
+	}
+
+	// Merge global and default configurations, with defaults taking priority.
+	defaultConfigurationForwarding = forwarding.MergeConfigurations(
+		globalConfigurationForwarding,
+		defaultConfigurationForwarding,
+	)
+	defaultConfigurationSynchronization = synchronization.MergeConfigurations(
+		globalConfigurationSynchronization,
+		defaultConfigurationSynchronization,
+	)
+
+	// Generate forward session creation specifications.
+	for name, session := range configuration.Forwarding {
+		// Ignore defaults.
+		if name == "defaults" {
+			continue
+		}
+
+		// Verify that the name is valid.
+		if err := selection.EnsureNameValid(name); err != nil {
+			return nil, fmt.Errorf("invalid forwarding session name (%s): %v", name, err)
+		}
+
+		// Verify that the profiles are valid and skip the session if none of
+		// them are active.
+		for _, profile := range session.Profiles {
+			if err := project.EnsureProfileNameValid(profile); err != nil {
+				return nil, fmt.Errorf("invalid forwarding session profile for %s: %v", name, err)
+			}
+		}
+		if !project.ProfilesActive(session.Profiles, profiles) {
+			continue
+		}
+
+		// Compute and validate labels.
+		labels := make(map[string]string, len(session.Labels)+1)
+		for key, value := range session.Labels {
+			if err := selection.EnsureLabelKeyValid(key); err != nil {
+				return nil, fmt.Errorf("invalid forwarding session label key for %s: %v", name, err)
+			} else if key == project.LabelKey {
+				return nil, fmt.Errorf("reserved forwarding session label key for %s: %s", name, key)
+			} else if err = selection.EnsureLabelValueValid(value); err != nil {
+				return nil, fmt.Errorf("invalid forwarding session label value for %s: %v", name, err)
+			}
+			labels[key] = value
+		}
+		labels[project.LabelKey] = projectIdentifier
+
+		// Compute URLs.
+		source := session.Source
+		if source == "" {
+			source = defaultSource
+		}
+		destination := session.Destination
+		if destination == "" {
+			destination = defaultDestination
+		}
+
+		// Parse URLs.
+		sourceURL, err := url.Parse(source, url.Kind_Forwarding, true)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse forwarding source URL (%s): %v", source, err)
+		}
+		destinationURL, err := url.Parse(destination, url.Kind_Forwarding, false)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse forwarding destination URL (%s): %v", destination, err)
+		}
+		var additionalDestinationURLs []*url.URL
+		for _, additionalDestination := range session.AdditionalDestinations {
+			if u, err := url.Parse(additionalDestination, url.Kind_Forwarding, false); err != nil {
+				return nil, fmt.Errorf("unable to parse forwarding destination URL (%s): %v", additionalDestination, err)
+			} else {
+				additionalDestinationURLs = append(additionalDestinationURLs, u)
+			}
+		}
+
+		// Compute configuration.
+		configuration := session.Configuration.ToInternal()
+		if err := configuration.EnsureValid(false); err != nil {
+			return nil, fmt.Errorf("invalid forwarding session configuration for %s: %v", name, err)
+		}
+		if session.Preset != "" {
+			preset, err := lookupPreset(globalConfiguration, noGlobalConfiguration, session.Preset)
+			if err != nil {
+				return nil, fmt.Errorf("unable to apply preset for forwarding session %s: %w", name, err)
+			}
+			presetConfiguration := preset.Forwarding.ToInternal()
+			if err := presetConfiguration.EnsureValid(false); err != nil {
+				return nil, fmt.Errorf("invalid forwarding configuration for preset %s: %w", session.Preset, err)
+			}
+			configuration = forwarding.MergeConfigurations(presetConfiguration, configuration)
+		}
+		configuration = forwarding.MergeConfigurations(defaultConfigurationForwarding, configuration)
+
+		// Compute source-specific configuration.
+		sourceConfiguration := session.ConfigurationSource.ToInternal()
+		if err := sourceConfiguration.EnsureValid(true); err != nil {
+			return nil, fmt.Errorf("invalid forwarding session source configuration for %s: %v", name, err)
+		}
+		sourceConfiguration = forwarding.MergeConfigurations(defaultConfigurationSource, sourceConfiguration)
+
+		// Compute destination-specific configuration.
+		destinationConfiguration := session.ConfigurationDestination.ToInternal()
+		if err := destinationConfiguration.EnsureValid(true); err != nil {
+			return nil, fmt.Errorf("invalid forwarding session destination configuration for %s: %v", name, err)
+		}
+		destinationConfiguration = forwarding.MergeConfigurations(defaultConfigurationDestination, destinationConfiguration)
+
+		// Record the specification.
+		result.forwarding = append(result.forwarding, &forwardingsvc.CreationSpecification{
+			Source:                   sourceURL,
+			Destination:              destinationURL,
+			AdditionalDestinations:   additionalDestinationURLs,
+			Configuration:            configuration,
+			ConfigurationSource:      sourceConfiguration,
+			ConfigurationDestination: destinationConfiguration,
+			Name:                     name,
+			Labels:                   labels,
+			Paused:                   paused,
+		})
+	}
+
+	// Generate synchronization session creation specifications and keep track
+	// of those that we should flush on creation.
+	for name, session := range configuration.Synchronization {
+		// Ignore defaults.
+		if name == "defaults" {
+			continue
+		}
+
+		// Verify that the name is valid.
+		if err := selection.EnsureNameValid(name); err != nil {
+			return nil, fmt.Errorf("invalid synchronization session name (%s): %v", name, err)
+		}
+
+		// Verify that the profiles are valid and skip the session if none of
+		// them are active.
+		for _, profile := range session.Profiles {
+			if err := project.EnsureProfileNameValid(profile); err != nil {
+				return nil, fmt.Errorf("invalid synchronization session profile for %s: %v", name, err)
+			}
+		}
+		if !project.ProfilesActive(session.Profiles, profiles) {
+			continue
+		}
+
+		// Compute and validate labels.
+		labels := make(map[string]string, len(session.Labels)+1)
+		for key, value := range session.Labels {
+			if err := selection.EnsureLabelKeyValid(key); err != nil {
+				return nil, fmt.Errorf("invalid synchronization session label key for %s: %v", name, err)
+			} else if key == project.LabelKey {
+				return nil, fmt.Errorf("reserved synchronization session label key for %s: %s", name, key)
+			} else if err = selection.EnsureLabelValueValid(value); err != nil {
+				return nil, fmt.Errorf("invalid synchronization session label value for %s: %v", name, err)
+			}
+			labels[key] = value
+		}
+		labels[project.LabelKey] = projectIdentifier
+
+		// Compute URLs.
+		alpha := session.Alpha
+		if alpha == "" {
+			alpha = defaultAlpha
+		}
+		beta := session.Beta
+		if beta == "" {
+			beta = defaultBeta
+		}
+
+		// Parse URLs.
+		alphaURL, err := url.Parse(alpha, url.Kind_Synchronization, true)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse synchronization alpha URL (%s): %v", alpha, err)
+		}
+		betaURL, err := url.Parse(beta, url.Kind_Synchronization, false)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse synchronization beta URL (%s): %v", beta, err)
+		}
+
+		// Compute configuration.
+		configuration := session.Configuration.ToInternal()
+		if err := configuration.EnsureValid(false); err != nil {
+			return nil, fmt.Errorf("invalid synchronization session configuration for %s: %v", name, err)
+		}
+		if session.Preset != "" {
+			preset, err := lookupPreset(globalConfiguration, noGlobalConfiguration, session.Preset)
+			if err != nil {
+				return nil, fmt.Errorf("unable to apply preset for synchronization session %s: %w", name, err)
+			}
+			presetConfiguration := preset.Synchronization.ToInternal()
+			if err := presetConfiguration.EnsureValid(false); err != nil {
+				return nil, fmt.Errorf("invalid synchronization configuration for preset %s: %w", session.Preset, err)
+			}
+			configuration = synchronization.MergeConfigurations(presetConfiguration, configuration)
+		}
+		configuration = synchronization.MergeConfigurations(defaultConfigurationSynchronization, configuration)
+
+		// Compute alpha-specific configuration.
+		alphaConfiguration := session.ConfigurationAlpha.ToInternal()
+		if err := alphaConfiguration.EnsureValid(true); err != nil {
+			return nil, fmt.Errorf("invalid synchronization session alpha configuration for %s: %v", name, err)
+		}
+		alphaConfiguration = synchronization.MergeConfigurations(defaultConfigurationAlpha, alphaConfiguration)
+
+		// Compute beta-specific configuration.
+		betaConfiguration := session.ConfigurationBeta.ToInternal()
+		if err := betaConfiguration.EnsureValid(true); err != nil {
+			return nil, fmt.Errorf("invalid synchronization session beta configuration for %s: %v", name, err)
+		}
+		betaConfiguration = synchronization.MergeConfigurations(defaultConfigurationBeta, betaConfiguration)
+
+		// Record the specification.
+		result.synchronization = append(result.synchronization, &synchronizationsvc.CreationSpecification{
+			Alpha:              alphaURL,
+			Beta:               betaURL,
+			Configuration:      configuration,
+			ConfigurationAlpha: alphaConfiguration,
+			ConfigurationBeta:  betaConfiguration,
+			Name:               name,
+			Labels:             labels,
+			Paused:             paused,
+		})
+
+		// Compute and store flush-on-creation behavior.
+		if session.FlushOnCreate.IsDefault() {
+			result.flushOnCreate = append(result.flushOnCreate, defaultFlushOnCreate.FlushOnCreate())
+		} else {
+			result.flushOnCreate = append(result.flushOnCreate, session.FlushOnCreate.FlushOnCreate())
+		}
+	}
+
+	// Compute the session dependency graph and creation order.
+	dependencies, err := configuration.Dependencies()
+	if err != nil {
+		return nil, err
+	}
+	order, err := configuration.SessionOrder()
+	if err != nil {
+		return nil, err
+	}
+
+	// Restrict the creation order to active sessions and ensure that active
+	// sessions don't depend on inactive sessions.
+	active := make(map[project.SessionReference]bool, len(result.forwarding)+len(result.synchronization))
+	for _, specification := range result.forwarding {
+		active[project.SessionReference{Kind: project.SessionKindForwarding, Name: specification.Name}] = true
+	}
+	for _, specification := range result.synchronization {
+		active[project.SessionReference{Kind: project.SessionKindSynchronization, Name: specification.Name}] = true
+	}
+	for _, session := range order {
+		if !active[session] {
+			continue
+		}
+		for _, dependency := range dependencies[session] {
+			if !active[dependency] {
+				return nil, fmt.Errorf("%s depends on inactive %s", session, dependency)
+			}
+			result.hasDependents[dependency] = true
+		}
+		result.order = append(result.order, session)
+	}
+
+	// Success.
+	return result, nil
+}
+
+// validateProjectConfiguration validates the portions of a project
+// configuration that aren't validated by computeSpecifications, namely
+// readiness conditions and services. Readiness conditions that reference
+// synchronization sessions must reference sessions in the computed
+// specifications.
+func validateProjectConfiguration(configuration *project.Configuration, specifications *specifications) error {
+	// Validate readiness conditions and ensure that any referenced
+	// synchronization sessions will be created.
+	synchronizationNames := make(map[string]bool, len(specifications.synchronization))
+	for _, specification := range specifications.synchronization {
+		synchronizationNames[specification.Name] = true
+	}
+	for c, condition := range configuration.WaitFor {
+		if err := condition.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid wait condition at index %d: %w", c, err)
+		} else if condition.Session != "" && !synchronizationNames[condition.Session] {
+			return fmt.Errorf("wait condition at index %d references unknown or inactive synchronization session: %s", c, condition.Session)
+		}
+	}
+
+	// Validate services.
+	for name, service := range configuration.Services {
+		if err := service.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid service (%s): %w", name, err)
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+// createSessions creates sessions from the specifications in dependency order.
+// If include is non-nil, then only those sessions for which it returns true are
+// created. Unpaused synchronization sessions with flush-on-create behavior
+// enabled are flushed after creation: those with dependents are flushed
+// immediately (before their dependents are created) and the remainder are
+// flushed together once all sessions have been created. The function returns
+// a map from the names of the created synchronization sessions to their
+// identifiers.
+func createSessions(
+	daemonConnection *grpc.ClientConn,
+	specifications *specifications,
+	include func(project.SessionReference) bool,
+) (map[string]string, error) {
+	// Index specifications by name.
+	forwardingByName := make(map[string]*forwardingsvc.CreationSpecification, len(specifications.forwarding))
+	for _, specification := range specifications.forwarding {
+		forwardingByName[specification.Name] = specification
+	}
+	synchronizationIndicesByName := make(map[string]int, len(specifications.synchronization))
+	for s, specification := range specifications.synchronization {
+		synchronizationIndicesByName[specification.Name] = s
+	}
+
+	// Create sessions and track those that we should flush later.
+	sessions := make(map[string]string, len(specifications.synchronization))
+	var sessionsToFlush []string
+	for _, reference := range specifications.order {
+		// Skip sessions that aren't included.
+		if include != nil && !include(reference) {
+			continue
+		}
+
+		// Handle forwarding sessions.
+		if reference.Kind == project.SessionKindForwarding {
+			specification := forwardingByName[reference.Name]
+			if _, err := forward.CreateWithSpecification(daemonConnection, specification); err != nil {
+				return nil, fmt.Errorf("unable to create forwarding session (%s): %v", specification.Name, err)
+			}
+			continue
+		}
+
+		// Perform synchronization session creation.
+		s := synchronizationIndicesByName[reference.Name]
+		specification := specifications.synchronization[s]
+		session, err := sync.CreateWithSpecification(daemonConnection, specification)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create synchronization session (%s): %v", specification.Name, err)
+		}
+		sessions[specification.Name] = session
+
+		// Determine whether or not and when to flush this session.
+		if specification.Paused || !specifications.flushOnCreate[s] {
+			continue
+		} else if specifications.hasDependents[reference] {
+			flushSelection := &selection.Selection{Specifications: []string{session}}
+			if err := sync.FlushWithSelection(daemonConnection, flushSelection, false); err != nil {
+				return nil, fmt.Errorf("unable to flush synchronization session (%s): %w", specification.Name, err)
+			}
+		} else {
+			sessionsToFlush = append(sessionsToFlush, session)
+		}
+	}
+
+	// Flush remaining synchronization sessions for which flushing has been
+	// requested.
+	if len(sessionsToFlush) > 0 {
+		flushSelection := &selection.Selection{Specifications: sessionsToFlush}
+		if err := sync.FlushWithSelection(daemonConnection, flushSelection, false); err != nil {
+			return nil, fmt.Errorf("unable to flush synchronization session(s): %w", err)
+		}
+	}
+
+	// Success.
+	return sessions, nil
+}
@@ -5,48 +5,29 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 	"runtime"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mutagen-io/mutagen/cmd"
 	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
-	"github.com/mutagen-io/mutagen/cmd/mutagen/forward"
-	"github.com/mutagen-io/mutagen/cmd/mutagen/sync"
 
-	"github.com/mutagen-io/mutagen/pkg/configuration/global"
 	"github.com/mutagen-io/mutagen/pkg/filesystem/locking"
-	"github.com/mutagen-io/mutagen/pkg/forwarding"
 	"github.com/mutagen-io/mutagen/pkg/identifier"
 	"github.com/mutagen-io/mutagen/pkg/project"
-	"github.com/mutagen-io/mutagen/pkg/selection"
-	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
-	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
-	"github.com/mutagen-io/mutagen/pkg/synchronization"
-	"github.com/mutagen-io/mutagen/pkg/url"
 )
 
 // startMain is the entry point for the start command.
 func startMain(_ *cobra.Command, _ []string) error {
-	// Compute the name of the configuration file and ensure that our working
-	// directory is that in which the file resides. This is required for
-	// relative paths (including relative synchronization paths and relative
-	// Unix Domain Socket paths) to be resolved relative to the project
-	// configuration file.
-	configurationFileName := project.DefaultConfigurationFileName
-	if startConfiguration.projectFile != "" {
-		var directory string
-		directory, configurationFileName = filepath.Split(startConfiguration.projectFile)
-		if directory != "" {
-			if err := os.Chdir(directory); err != nil {
-				return fmt.Errorf("unable to switch to target directory: %w", err)
-			}
-		}
+	// Resolve the project configuration files and ensure that our working
+	// directory is the project directory.
+	files, err := resolveProjectFiles(startConfiguration.projectFiles)
+	if err != nil {
+		return err
 	}
 
 	// Compute the lock path.
-	lockPath := configurationFileName + project.LockFileExtension
+	lockPath := files.stateFileBase + project.LockFileExtension
 
 	// Track whether or not we should remove the lock file on return.
 	var removeLockFileOnReturn bool
@@ -105,248 +86,40 @@ func startMain(_ *cobra.Command, _ []string) error {
 		return fmt.Errorf("unable to generate project identifier: %w", err)
 	}
 
-	// Write the project identifier to the lock file.
-	if _, err := locker.Write([]byte(identifier)); err != nil {
-		return fmt.Errorf("unable to write project identifier: %w", err)
+	// Write the project identifier and active profiles to the lock file.
+	lock := &project.Lock{
+		Identifier: identifier,
+		Profiles:   startConfiguration.profiles,
+	}
+	if lockData, err := lock.MarshalText(); err != nil {
+		return fmt.Errorf("unable to encode project lock: %w", err)
+	} else if _, err := locker.Write(lockData); err != nil {
+		return fmt.Errorf("unable to write project lock: %w", err)
 	}
 
 	// Load the configuration file.
-	configuration, err := project.LoadConfiguration(configurationFileName)
+	configuration, err := project.LoadComposedConfiguration(files.paths)
 	if err != nil {
 		return fmt.Errorf("unable to load configuration file: %w", err)
 	}
 
-	// Unless disabled, attempt to load configuration from the global
-	// configuration file and use it as the base for our core session
-	// configurations.
-	globalConfigurationForwarding := &forwarding.Configuration{}
-	globalConfigurationSynchronization := &synchronization.Configuration{}
-	if !startConfiguration.noGlobalConfiguration {
-		// Compute the path to the global configuration file.
-		globalConfigurationPath, err := global.ConfigurationPath()
-		if err != nil {
-			return fmt.Errorf("unable to compute path to global configuration file: %w", err)
-		}
-
-		// Attempt to load and validate the file. We allow it to not exist.
-		globalConfiguration, err := global.LoadConfiguration(globalConfigurationPath)
-		if err != nil {
-			if !os.IsNotExist(err) {
-				return fmt.Errorf("unable to load global configuration: %w", err)
-			}
-		} else {
-			globalConfigurationForwarding = globalConfiguration.Forwarding.Defaults.ToInternal()
-			if err := globalConfigurationForwarding.EnsureValid(false); err != nil {
-				return fmt.Errorf("invalid global forwarding configuration: %w", err)
-			}
-			globalConfigurationSynchronization = globalConfiguration.Synchronization.Defaults.ToInternal()
-			if err := globalConfigurationSynchronization.EnsureValid(false); err != nil {
-				return fmt.Errorf("invalid global synchronization configuration: %w", err)
-			}
-		}
-	}
-
-	// Extract and validate forwarding defaults.
-	var defaultSource, defaultDestination string
-	defaultConfigurationForwarding := &forwarding.Configuration{}
-	defaultConfigurationSource := &forwarding.Configuration{}
-	defaultConfigurationDestination := &forwarding.Configuration{}
-	if defaults, ok := configuration.Forwarding["defaults"]; ok {
-		defaultSource = defaults.Source
-		defaultDestination = defaults.Destination
-		defaultConfigurationForwarding = defaults.Configuration.ToInternal()
-		if err := defaultConfigurationForwarding.EnsureValid(false); err != nil {
-			return fmt.Errorf("invalid default forwarding configuration: %w", err)
-		}
-		defaultConfigurationSource = defaults.ConfigurationSource.ToInternal()
-		if err := defaultConfigurationSource.EnsureValid(true); err != nil {
-			return fmt.Errorf("invalid default forwarding source configuration: %w", err)
-		}
-		defaultConfigurationDestination = defaults.ConfigurationDestination.ToInternal()
-		if err := defaultConfigurationDestination.EnsureValid(true); err != nil {
-			return fmt.Errorf("invalid default forwarding destination configuration: %w", err)
-		}
-	}
-
-	// Extract and validate synchronization defaults.
-	var defaultAlpha, defaultBeta string
-	var defaultFlushOnCreate project.FlushOnCreateBehavior
-	defaultConfigurationSynchronization := &synchronization.Configuration{}
-	defaultConfigurationAlpha := &synchronization.Configuration{}
-	defaultConfigurationBeta := &synchronization.Configuration{}
-	if defaults, ok := configuration.Synchronization["defaults"]; ok {
-		defaultAlpha = defaults.Alpha
-		defaultBeta = defaults.Beta
-		defaultFlushOnCreate = defaults.FlushOnCreate
-		defaultConfigurationSynchronization = defaults.Configuration.ToInternal()
-		if err := defaultConfigurationSynchronization.EnsureValid(false); err != nil {
-			return fmt.Errorf("invalid default synchronization configuration: %w", err)
-		}
-		defaultConfigurationAlpha = defaults.ConfigurationAlpha.ToInternal()
-		if err := defaultConfigurationAlpha.EnsureValid(true); err != nil {
-			return fmt.Errorf("invalid default synchronization alpha configuration: %w", err)
-		}
-		defaultConfigurationBeta = defaults.ConfigurationBeta.ToInternal()
-		if err := defaultConfigurationBeta.EnsureValid(true); err != nil {
-			return fmt.Errorf("invalid default synchronization beta configuration: %w", err)
-		}
+	// Validate the requested profiles.
+	if err := configuration.EnsureProfilesValid(startConfiguration.profiles); err != nil {
+		return err
 	}
 
-	// Merge global and default configurations, with defaults taking priority.
-	defaultConfigurationForwarding = forwarding.MergeConfigurations(
-		globalConfigurationForwarding,
-		defaultConfigurationForwarding,
-	)
-	defaultConfigurationSynchronization = synchronization.MergeConfigurations(
-		globalConfigurationSynchronization,
-		defaultConfigurationSynchronization,
+	// Compute session specifications.
+	specifications, err := computeSpecifications(
+		configuration, identifier, startConfiguration.profiles,
+		startConfiguration.paused, startConfiguration.noGlobalConfiguration,
 	)
-
-	// Generate forward session creation specifications.
-	var forwardingSpecifications []*forwardingsvc.CreationSpecification
-	for name, session := range configuration.Forwarding {
-		// Ignore defaults.
-		if name == "defaults" {
-			continue
-		}
-
-		// Verify that the name is valid.
-		if err := selection.EnsureNameValid(name); err != nil {
-			return fmt.Errorf("invalid forwarding session name (%s): %v", name, err)
-		}
-
-		// Compute URLs.
-		source := session.Source
-		if source == "" {
-			source = defaultSource
-		}
-		destination := session.Destination
-		if destination == "" {
-			destination = defaultDestination
-		}
-
-		// Parse URLs.
-		sourceURL, err := url.Parse(source, url.Kind_Forwarding, true)
-		if err != nil {
-			return fmt.Errorf("unable to parse forwarding source URL (%s): %v", source, err)
-		}
-		destinationURL, err := url.Parse(destination, url.Kind_Forwarding, false)
-		if err != nil {
-			return fmt.Errorf("unable to parse forwarding destination URL (%s): %v", destination, err)
-		}
-
-		// Compute configuration.
-		configuration := session.Configuration.ToInternal()
-		if err := configuration.EnsureValid(false); err != nil {
-			return fmt.Errorf("invalid forwarding session configuration for %s: %v", name, err)
-		}
-		configuration = forwarding.MergeConfigurations(defaultConfigurationForwarding, configuration)
-
-		// Compute source-specific configuration.
-		sourceConfiguration := session.ConfigurationSource.ToInternal()
-		if err := sourceConfiguration.EnsureValid(true); err != nil {
-			return fmt.Errorf("invalid forwarding session source configuration for %s: %v", name, err)
-		}
-		sourceConfiguration = forwarding.MergeConfigurations(defaultConfigurationSource, sourceConfiguration)
-
-		// Compute destination-specific configuration.
-		destinationConfiguration := session.ConfigurationDestination.ToInternal()
-		if err := destinationConfiguration.EnsureValid(true); err != nil {
-			return fmt.Errorf("invalid forwarding session destination configuration for %s: %v", name, err)
-		}
-		destinationConfiguration = forwarding.MergeConfigurations(defaultConfigurationDestination, destinationConfiguration)
-
-		// Record the specification.
-		forwardingSpecifications = append(forwardingSpecifications, &forwardingsvc.CreationSpecification{
-			Source:                   sourceURL,
-			Destination:              destinationURL,
-			Configuration:            configuration,
-			ConfigurationSource:      sourceConfiguration,
-			ConfigurationDestination: destinationConfiguration,
-			Name:                     name,
-			Labels: map[string]string{
-				project.LabelKey: identifier,
-			},
-			Paused: startConfiguration.paused,
-		})
+	if err != nil {
+		return err
 	}
 
-	// Generate synchronization session creation specifications and keep track
-	// of those that we should flush on creation.
-	var synchronizationSpecifications []*synchronizationsvc.CreationSpecification
-	var flushOnCreateByIndex []bool
-	for name, session := range configuration.Synchronization {
-		// Ignore defaults.
-		if name == "defaults" {
-			continue
-		}
-
-		// Verify that the name is valid.
-		if err := selection.EnsureNameValid(name); err != nil {
-			return fmt.Errorf("invalid synchronization session name (%s): %v", name, err)
-		}
-
-		// Compute URLs.
-		alpha := session.Alpha
-		if alpha == "" {
-			alpha = defaultAlpha
-		}
-		beta := session.Beta
-		if beta == "" {
-			beta = defaultBeta
-		}
-
-		// Parse URLs.
-		alphaURL, err := url.Parse(alpha, url.Kind_Synchronization, true)
-		if err != nil {
-			return fmt.Errorf("unable to parse synchronization alpha URL (%s): %v", alpha, err)
-		}
-		betaURL, err := url.Parse(beta, url.Kind_Synchronization, false)
-		if err != nil {
-			return fmt.Errorf("unable to parse synchronization beta URL (%s): %v", beta, err)
-		}
-
-		// Compute configuration.
-		configuration := session.Configuration.ToInternal()
-		if err := configuration.EnsureValid(false); err != nil {
-			return fmt.Errorf("invalid synchronization session configuration for %s: %v", name, err)
-		}
-		configuration = synchronization.MergeConfigurations(defaultConfigurationSynchronization, configuration)
-
-		// Compute alpha-specific configuration.
-		alphaConfiguration := session.ConfigurationAlpha.ToInternal()
-		if err := alphaConfiguration.EnsureValid(true); err != nil {
-			return fmt.Errorf("invalid synchronization session alpha configuration for %s: %v", name, err)
-		}
-		alphaConfiguration = synchronization.MergeConfigurations(defaultConfigurationAlpha, alphaConfiguration)
-
-		// Compute beta-specific configuration.
-		betaConfiguration := session.ConfigurationBeta.ToInternal()
-		if err := betaConfiguration.EnsureValid(true); err != nil {
-			return fmt.Errorf("invalid synchronization session beta configuration for %s: %v", name, err)
-		}
-		betaConfiguration = synchronization.MergeConfigurations(defaultConfigurationBeta, betaConfiguration)
-
-		// Record the specification.
-		synchronizationSpecifications = append(synchronizationSpecifications, &synchronizationsvc.CreationSpecification{
-			Alpha:              alphaURL,
-			Beta:               betaURL,
-			Configuration:      configuration,
-			ConfigurationAlpha: alphaConfiguration,
-			ConfigurationBeta:  betaConfiguration,
-			Name:               name,
-			Labels: map[string]string{
-				project.LabelKey: identifier,
-			},
-			Paused: startConfiguration.paused,
-		})
-
-		// Compute and store flush-on-creation behavior.
-		if session.FlushOnCreate.IsDefault() {
-			flushOnCreateByIndex = append(flushOnCreateByIndex, defaultFlushOnCreate.FlushOnCreate())
-		} else {
-			flushOnCreateByIndex = append(flushOnCreateByIndex, session.FlushOnCreate.FlushOnCreate())
-		}
+	// Validate readiness conditions and services.
+	if err := validateProjectConfiguration(configuration, specifications); err != nil {
+		return err
 	}
 
 	// Connect to the daemon and defer closure of the connection.
@@ -368,33 +141,21 @@ func startMain(_ *cobra.Command, _ []string) error {
 		}
 	}
 
-	// Create forwarding sessions.
-	for _, specification := range forwardingSpecifications {
-		if _, err := forward.CreateWithSpecification(daemonConnection, specification); err != nil {
-			return fmt.Errorf("unable to create forwarding session (%s): %v", specification.Name, err)
-		}
+	// Create sessions.
+	sessions, err := createSessions(daemonConnection, specifications, nil)
+	if err != nil {
+		return err
 	}
 
-	// Create synchronization sessions and track those that we should flush.
-	var sessionsToFlush []string
-	for s, specification := range synchronizationSpecifications {
-		// Perform session creation.
-		session, err := sync.CreateWithSpecification(daemonConnection, specification)
-		if err != nil {
-			return fmt.Errorf("unable to create synchronization session (%s): %v", specification.Name, err)
-		}
-
-		// Determine whether or not to flush this session.
-		if !startConfiguration.paused && flushOnCreateByIndex[s] {
-			sessionsToFlush = append(sessionsToFlush, session)
-		}
+	// Wait for readiness conditions.
+	if err := waitForConditions(daemonConnection, configuration.WaitFor, sessions, startConfiguration.paused); err != nil {
+		return err
 	}
 
-	// Flush synchronization sessions for which flushing has been requested.
-	if len(sessionsToFlush) > 0 {
-		flushSelection := &selection.Selection{Specifications: sessionsToFlush}
-		if err := sync.FlushWithSelection(daemonConnection, flushSelection, false); err != nil {
-			return fmt.Errorf("unable to flush synchronization session(s): %w", err)
+	// Start services.
+	if len(configuration.Services) > 0 {
+		if err := startSupervisor(files); err != nil {
+			return fmt.Errorf("unable to start services: %w", err)
 		}
 	}
 
@@ -423,10 +184,12 @@ var startCommand = &cobra.Command{
 var startConfiguration struct {
 	// help indicates whether or not to show help information and exit.
 	help bool
-	// projectFile is the path to the project file, if non-default.
-	projectFile string
+	// projectFiles are the paths to the project files, if non-default.
+	projectFiles []string
 	// paused indicates whether or not to create sessions in a pre-paused state.
 	paused bool
+	// profiles are the profiles to activate.
+	profiles []string
 	// noGlobalConfiguration specifies whether or not the global configuration
 	// file should be ignored.
 	noGlobalConfiguration bool
@@ -444,11 +207,14 @@ func init() {
 	flags.BoolVarP(&startConfiguration.help, "help", "h", false, "Show help information")
 
 	// Wire up project file flags.
-	flags.StringVarP(&startConfiguration.projectFile, "project-file", "f", "", "Specify project file")
+	flags.StringArrayVarP(&startConfiguration.projectFiles, "project-file", "f", nil, "Specify project file(s) (repeatable, merged in order)")
 
 	// Wire up paused flags.
 	flags.BoolVarP(&startConfiguration.paused, "paused", "p", false, "Create the session pre-paused")
 
+	// Wire up profile flags.
+	flags.StringSliceVar(&startConfiguration.profiles, "profile", nil, "Activate the specified profile(s) (comma-separated or repeated)")
+
 	// Wire up general configuration flags.
 	flags.BoolVar(&startConfiguration.noGlobalConfiguration, "no-global-configuration", false, "Ignore the global configuration file")
 }
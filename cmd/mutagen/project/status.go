@@ -0,0 +1,259 @@
+package project
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/cmd"
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem/locking"
+	"github.com/mutagen-io/mutagen/pkg/project"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+)
+
+// printHealthTable prints a table summarizing session health.
+func printHealthTable(sessions []*project.SessionHealth) {
+	// Compute the table rows.
+	rows := [][]string{{"KIND", "NAME", "STATUS", "HEALTH"}}
+	for _, session := range sessions {
+		name := session.Name
+		if name == "" {
+			name = session.Identifier
+		}
+		health := "healthy"
+		if !session.Healthy() {
+			health = strings.Join(session.Issues, ", ")
+		}
+		rows = append(rows, []string{session.Kind.String(), name, session.Status, health})
+	}
+
+	// Compute column widths. The final column isn't padded.
+	var widths [3]int
+	for _, row := range rows {
+		for c := range widths {
+			if len(row[c]) > widths[c] {
+				widths[c] = len(row[c])
+			}
+		}
+	}
+
+	// Print the rows.
+	for _, row := range rows {
+		fmt.Printf("%-*s  %-*s  %-*s  %s\n",
+			widths[0], row[0], widths[1], row[1], widths[2], row[2], row[3],
+		)
+	}
+}
+
+// statusMain is the entry point for the status command.
+func statusMain(_ *cobra.Command, _ []string) error {
+	// Resolve the project configuration files and ensure that our working
+	// directory is the project directory.
+	files, err := resolveProjectFiles(statusConfiguration.projectFiles)
+	if err != nil {
+		return err
+	}
+
+	// Compute the lock path.
+	lockPath := files.stateFileBase + project.LockFileExtension
+
+	// Track whether or not we should remove the lock file on return.
+	var removeLockFileOnReturn bool
+
+	// Create a locker and defer its closure and potential removal. On Windows
+	// systems, we have to handle this removal after the file is closed.
+	locker, err := locking.NewLocker(lockPath, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to create project locker: %w", err)
+	}
+	defer func() {
+		locker.Close()
+		if removeLockFileOnReturn && runtime.GOOS == "windows" {
+			os.Remove(lockPath)
+		}
+	}()
+
+	// Acquire the project lock and defer its release and potential removal. On
+	// Windows systems, we can't remove the lock file if it's locked or even
+	// just opened, so we handle removal for Windows systems after we close the
+	// lock file (see above). In this case, we truncate the lock file before
+	// releasing it to ensure that any other process that opens or acquires the
+	// lock file before we manage to remove it will simply see an empty lock
+	// file, which it will ignore or attempt to remove.
+	if err := locker.Lock(true); err != nil {
+		return fmt.Errorf("unable to acquire project lock: %w", err)
+	}
+	defer func() {
+		if removeLockFileOnReturn {
+			if runtime.GOOS == "windows" {
+				locker.Truncate(0)
+			} else {
+				os.Remove(lockPath)
+			}
+		}
+		locker.Unlock()
+	}()
+
+	// Read the project lock from the lock file. If the lock file is
+	// empty, then we can assume that we created it when we created the lock and
+	// just remove it.
+	buffer := &bytes.Buffer{}
+	if length, err := buffer.ReadFrom(locker); err != nil {
+		return fmt.Errorf("unable to read project lock: %w", err)
+	} else if length == 0 {
+		removeLockFileOnReturn = true
+		return errors.New("project not running")
+	}
+
+	// Parse and validate the project lock.
+	lock := &project.Lock{}
+	if err := lock.UnmarshalText(buffer.Bytes()); err != nil {
+		return err
+	}
+	projectIdentifier := lock.Identifier
+
+	// Load the configuration file.
+	configuration, err := project.LoadComposedConfiguration(files.paths)
+	if err != nil {
+		return fmt.Errorf("unable to load configuration file: %w", err)
+	}
+
+	// Compute session specifications using the profiles that were active when
+	// the project was started so that we can identify missing sessions.
+	specifications, err := computeSpecifications(
+		configuration, projectIdentifier, lock.Profiles,
+		false, statusConfiguration.noGlobalConfiguration,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Connect to the daemon and defer closure of the connection.
+	daemonConnection, err := daemon.Connect(true, true)
+	if err != nil {
+		return fmt.Errorf("unable to connect to daemon: %w", err)
+	}
+	defer daemonConnection.Close()
+
+	// Compute the selection that we're going to use to query sessions.
+	selection := &selection.Selection{
+		LabelSelector: fmt.Sprintf("%s=%s", project.LabelKey, projectIdentifier),
+	}
+
+	// Query session states.
+	forwardingStates, err := listForwardingStates(daemonConnection, selection)
+	if err != nil {
+		return fmt.Errorf("unable to list forwarding session(s): %w", err)
+	}
+	synchronizationStates, err := listSynchronizationStates(daemonConnection, selection)
+	if err != nil {
+		return fmt.Errorf("unable to list synchronization session(s): %w", err)
+	}
+
+	// Compute session health, including entries for any sessions defined by
+	// the project configuration that don't exist.
+	sessions := make([]*project.SessionHealth, 0, len(forwardingStates)+len(synchronizationStates))
+	forwardingNames := make(map[string]bool, len(forwardingStates))
+	for _, state := range forwardingStates {
+		forwardingNames[state.Session.Name] = true
+		sessions = append(sessions, project.ForwardingSessionHealth(state))
+	}
+	for _, specification := range specifications.forwarding {
+		if !forwardingNames[specification.Name] {
+			sessions = append(sessions, project.MissingSessionHealth(project.SessionReference{
+				Kind: project.SessionKindForwarding,
+				Name: specification.Name,
+			}))
+		}
+	}
+	synchronizationNames := make(map[string]bool, len(synchronizationStates))
+	for _, state := range synchronizationStates {
+		synchronizationNames[state.Session.Name] = true
+		sessions = append(sessions, project.SynchronizationSessionHealth(state))
+	}
+	for _, specification := range specifications.synchronization {
+		if !synchronizationNames[specification.Name] {
+			sessions = append(sessions, project.MissingSessionHealth(project.SessionReference{
+				Kind: project.SessionKindSynchronization,
+				Name: specification.Name,
+			}))
+		}
+	}
+	var unhealthy int
+	for _, session := range sessions {
+		if !session.Healthy() {
+			unhealthy++
+		}
+	}
+
+	// Print the health summary.
+	if !statusConfiguration.quiet {
+		if len(lock.Profiles) > 0 {
+			fmt.Println("Active profiles:", strings.Join(lock.Profiles, ", "))
+			fmt.Println()
+		}
+		if len(sessions) > 0 {
+			printHealthTable(sessions)
+		} else {
+			fmt.Println("No project sessions found")
+		}
+	}
+
+	// Report unhealthy sessions.
+	if unhealthy > 0 {
+		return fmt.Errorf("project unhealthy (%d of %d session(s) have issues)", unhealthy, len(sessions))
+	}
+
+	// Success.
+	return nil
+}
+
+// statusCommand is the status command.
+var statusCommand = &cobra.Command{
+	Use:          "status",
+	Short:        "Summarize project session health",
+	Args:         cmd.DisallowArguments,
+	RunE:         statusMain,
+	SilenceUsage: true,
+}
+
+// statusConfiguration stores configuration for the status command.
+var statusConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// projectFiles are the paths to the project files, if non-default.
+	projectFiles []string
+	// quiet indicates whether or not to suppress the health summary.
+	quiet bool
+	// noGlobalConfiguration specifies whether or not the global configuration
+	// file should be ignored.
+	noGlobalConfiguration bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := statusCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&statusConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up project file flags.
+	flags.StringArrayVarP(&statusConfiguration.projectFiles, "project-file", "f", nil, "Specify project file(s) (repeatable, merged in order)")
+
+	// Wire up status flags.
+	flags.BoolVarP(&statusConfiguration.quiet, "quiet", "q", false, "Suppress the health summary")
+
+	// Wire up general configuration flags.
+	flags.BoolVar(&statusConfiguration.noGlobalConfiguration, "no-global-configuration", false, "Ignore the global configuration file")
+}
@@ -0,0 +1,289 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/cmd"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem/locking"
+	"github.com/mutagen-io/mutagen/pkg/project"
+)
+
+const (
+	// supervisorRegistrationTimeout is the maximum amount of time that
+	// startSupervisor will wait for the supervisor to record its process
+	// identifier.
+	supervisorRegistrationTimeout = 10 * time.Second
+	// supervisorExitTimeout is the maximum amount of time that stopSupervisor
+	// will wait for the supervisor to exit after signaling it. It exceeds the
+	// time that the supervisor gives its services to exit.
+	supervisorExitTimeout = 30 * time.Second
+	// supervisorPollInterval is the interval at which supervisor registration
+	// and exit are polled.
+	supervisorPollInterval = 50 * time.Millisecond
+)
+
+// readSupervisorPID reads the process identifier recorded at the specified
+// path.
+func readSupervisorPID(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, fmt.Errorf("invalid service supervisor process identifier: %w", err)
+	}
+	return pid, nil
+}
+
+// startSupervisor starts a background process that supervises the project's
+// services. The supervisor's output is written to the project's service log
+// file. The supervisor holds the project's service lock for the duration of its
+// execution and records its process identifier so that it can be stopped by
+// stopSupervisor, and this function waits for that registration to complete.
+// The current working directory must be the project directory.
+func startSupervisor(files *projectFiles) error {
+	// Compute the path to the current executable.
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("unable to determine executable path: %w", err)
+	}
+
+	// Open the service log file.
+	logPath := files.stateFileBase + project.ServicesLogFileExtension
+	log, err := os.OpenFile(logPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to open service log file: %w", err)
+	}
+	defer log.Close()
+
+	// Remove any stale process identifier record so that it isn't mistaken for
+	// the new supervisor's registration.
+	pidPath := files.stateFileBase + project.ServicesPIDFileExtension
+	if err := os.Remove(pidPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("unable to remove stale service supervisor process identifier: %w", err)
+	}
+
+	// Start the supervisor.
+	arguments := []string{"project", "supervise"}
+	for _, path := range files.paths {
+		arguments = append(arguments, "--project-file", path)
+	}
+	process := exec.Command(executable, arguments...)
+	process.Stdout = log
+	process.Stderr = log
+	process.SysProcAttr = supervisorProcessAttributes()
+	if err := process.Start(); err != nil {
+		return fmt.Errorf("unable to start service supervisor: %w", err)
+	}
+
+	// Monitor the supervisor for premature exit. If the supervisor exits
+	// successfully before we see its registration, then its services exited on
+	// their own. We don't need to wait for this monitoring to complete since
+	// the supervisor is designed to outlive us.
+	exited := make(chan struct{})
+	go func() {
+		process.Wait()
+		close(exited)
+	}()
+
+	// Wait for the supervisor to record its process identifier.
+	timeout := time.NewTimer(supervisorRegistrationTimeout)
+	defer timeout.Stop()
+	ticker := time.NewTicker(supervisorPollInterval)
+	defer ticker.Stop()
+	for {
+		if pid, err := readSupervisorPID(pidPath); err == nil && pid == process.Process.Pid {
+			return nil
+		}
+		select {
+		case <-exited:
+			if process.ProcessState.Success() {
+				return nil
+			}
+			return fmt.Errorf("service supervisor exited prematurely (see %s)", logPath)
+		case <-timeout.C:
+			process.Process.Kill()
+			return errors.New("timed out waiting for service supervisor to start")
+		case <-ticker.C:
+		}
+	}
+}
+
+// stopSupervisor stops the project's service supervisor, if any, and waits for
+// it to exit. The supervisor is only signaled if it's still holding the
+// project's service lock, which ensures that the recorded process identifier
+// hasn't become stale (and potentially been reused by an unrelated process).
+// The current working directory must be the project directory.
+func stopSupervisor(files *projectFiles) error {
+	// Compute paths.
+	pidPath := files.stateFileBase + project.ServicesPIDFileExtension
+	lockPath := files.stateFileBase + project.ServicesLockFileExtension
+
+	// Create a locker for the service lock and defer its closure and the
+	// removal of the lock file (which we'll only reach once we know that no
+	// supervisor is running). On Windows systems, the lock file can only be
+	// removed once it's closed. It's safe to remove the lock file because
+	// supervisors are only started while holding the project lock, which our
+	// caller also holds.
+	locker, err := locking.NewLocker(lockPath, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to create service locker: %w", err)
+	}
+	var removeLockFile bool
+	defer func() {
+		if locker.Held() {
+			locker.Unlock()
+		}
+		locker.Close()
+		if removeLockFile {
+			os.Remove(lockPath)
+		}
+	}()
+
+	// If we're able to acquire the service lock, then no supervisor is running
+	// and any process identifier record is stale.
+	if locker.Lock(false) != nil {
+		// Read the supervisor's process identifier. The supervisor records this
+		// after acquiring the service lock, so it should be present.
+		pid, err := readSupervisorPID(pidPath)
+		if err != nil {
+			return fmt.Errorf("unable to read service supervisor process identifier: %w", err)
+		}
+
+		// Signal the supervisor.
+		if err := signalSupervisor(pid); err != nil {
+			return fmt.Errorf("unable to signal service supervisor: %w", err)
+		}
+
+		// Wait for the supervisor to exit, which we detect by its release of
+		// the service lock.
+		timeout := time.NewTimer(supervisorExitTimeout)
+		defer timeout.Stop()
+		ticker := time.NewTicker(supervisorPollInterval)
+		defer ticker.Stop()
+		for locker.Lock(false) != nil {
+			select {
+			case <-timeout.C:
+				return errors.New("timed out waiting for service supervisor to exit")
+			case <-ticker.C:
+			}
+		}
+	}
+
+	// Remove the process identifier record, if any.
+	if err := os.Remove(pidPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("unable to remove service supervisor process identifier: %w", err)
+	}
+
+	// Schedule removal of the lock file.
+	removeLockFile = true
+
+	// Success.
+	return nil
+}
+
+// superviseMain is the entry point for the supervise command.
+func superviseMain(_ *cobra.Command, _ []string) error {
+	// Resolve the project configuration files and ensure that our working
+	// directory is the project directory.
+	files, err := resolveProjectFiles(superviseConfiguration.projectFiles)
+	if err != nil {
+		return err
+	}
+
+	// Load the configuration file.
+	configuration, err := project.LoadComposedConfiguration(files.paths)
+	if err != nil {
+		return fmt.Errorf("unable to load configuration file: %w", err)
+	}
+
+	// Validate services.
+	for name, service := range configuration.Services {
+		if err := service.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid service (%s): %w", name, err)
+		}
+	}
+
+	// Set up termination signal handling. We do this before registering so
+	// that any termination request received after registration is handled.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signalTermination := make(chan os.Signal, 1)
+	signal.Notify(signalTermination, cmd.TerminationSignals...)
+	go func() {
+		<-signalTermination
+		cancel()
+	}()
+
+	// Acquire the service lock, which we'll hold for the duration of our
+	// execution to indicate that we're running. We release the lock implicitly
+	// on exit (including abnormal exit), so we don't unlock it explicitly.
+	lockPath := files.stateFileBase + project.ServicesLockFileExtension
+	locker, err := locking.NewLocker(lockPath, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to create service locker: %w", err)
+	}
+	defer locker.Close()
+	if err := locker.Lock(false); err != nil {
+		return fmt.Errorf("unable to acquire service lock (is another supervisor running?): %w", err)
+	}
+
+	// Record our process identifier and defer its removal. We hold the service
+	// lock, so the record is ours to remove.
+	pidPath := files.stateFileBase + project.ServicesPIDFileExtension
+	if err := os.WriteFile(pidPath, []byte(strconv.Itoa(os.Getpid())), 0600); err != nil {
+		return fmt.Errorf("unable to record process identifier: %w", err)
+	}
+	defer os.Remove(pidPath)
+
+	// Supervise services.
+	project.Supervise(ctx, configuration.Services, os.Stdout)
+
+	// Success.
+	return nil
+}
+
+// superviseCommand is the supervise command.
+var superviseCommand = &cobra.Command{
+	Use:          "supervise",
+	Short:        "Supervise project services",
+	Args:         cmd.DisallowArguments,
+	Hidden:       true,
+	RunE:         superviseMain,
+	SilenceUsage: true,
+}
+
+// superviseConfiguration stores configuration for the supervise command.
+var superviseConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// projectFiles are the paths to the project files, if non-default.
+	projectFiles []string
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := superviseCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&superviseConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up project file flags.
+	flags.StringArrayVarP(&superviseConfiguration.projectFiles, "project-file", "f", nil, "Specify project file(s) (repeatable, merged in order)")
+}
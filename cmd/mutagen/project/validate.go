@@ -0,0 +1,109 @@
+package project
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/cmd"
+
+	"github.com/mutagen-io/mutagen/pkg/identifier"
+	"github.com/mutagen-io/mutagen/pkg/project"
+)
+
+// validateMain is the entry point for the validate command.
+func validateMain(_ *cobra.Command, _ []string) error {
+	// Resolve the project configuration files and ensure that our working
+	// directory is the project directory.
+	files, err := resolveProjectFiles(validateConfiguration.projectFiles)
+	if err != nil {
+		return err
+	}
+
+	// Load the configuration file. Unknown keys are rejected.
+	configuration, err := project.LoadComposedConfiguration(files.paths)
+	if err != nil {
+		return fmt.Errorf("unable to load configuration file: %w", err)
+	}
+
+	// Determine the profiles to validate against. If none have been specified,
+	// then all profiles are activated so that every session is validated.
+	profiles := validateConfiguration.profiles
+	if len(profiles) == 0 {
+		profiles = configuration.Profiles()
+	} else if err := configuration.EnsureProfilesValid(profiles); err != nil {
+		return err
+	}
+
+	// Create a placeholder project identifier for computing specifications.
+	identifier, err := identifier.New(identifier.PrefixProject)
+	if err != nil {
+		return fmt.Errorf("unable to generate project identifier: %w", err)
+	}
+
+	// Compute session specifications, which validates sessions and their
+	// dependencies.
+	specifications, err := computeSpecifications(
+		configuration, identifier, profiles,
+		false, validateConfiguration.noGlobalConfiguration,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Validate readiness conditions and services.
+	if err := validateProjectConfiguration(configuration, specifications); err != nil {
+		return err
+	}
+
+	// Print a summary.
+	fmt.Printf("Project configuration is valid (%d forwarding session(s), %d synchronization session(s), %d service(s))\n",
+		len(specifications.forwarding), len(specifications.synchronization), len(configuration.Services),
+	)
+
+	// Success.
+	return nil
+}
+
+// validateCommand is the validate command.
+var validateCommand = &cobra.Command{
+	Use:          "validate",
+	Short:        "Validate the project file without starting the project",
+	Args:         cmd.DisallowArguments,
+	RunE:         validateMain,
+	SilenceUsage: true,
+}
+
+// validateConfiguration stores configuration for the validate command.
+var validateConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// projectFiles are the paths to the project files, if non-default.
+	projectFiles []string
+	// profiles are the profiles to activate.
+	profiles []string
+	// noGlobalConfiguration specifies whether or not the global configuration
+	// file should be ignored.
+	noGlobalConfiguration bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := validateCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&validateConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up project file flags.
+	flags.StringArrayVarP(&validateConfiguration.projectFiles, "project-file", "f", nil, "Specify project file(s) (repeatable, merged in order)")
+
+	// Wire up profile flags.
+	flags.StringSliceVar(&validateConfiguration.profiles, "profile", nil, "Validate with only the specified profile(s) active (defaults to all profiles)")
+
+	// Wire up general configuration flags.
+	flags.BoolVar(&validateConfiguration.noGlobalConfiguration, "no-global-configuration", false, "Ignore the global configuration file")
+}
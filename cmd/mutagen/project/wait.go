@@ -0,0 +1,104 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/project"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+)
+
+// waitForSynchronizationCycle waits for a synchronization session to complete
+// a successful synchronization cycle. On timeout, the most recently observed
+// session status and error are reported.
+func waitForSynchronizationCycle(daemonConnection *grpc.ClientConn, session string, timeout time.Duration) error {
+	// Create a timeout context.
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// Poll session states until a successful cycle is observed.
+	synchronizationService := synchronizationsvc.NewSynchronizationClient(daemonConnection)
+	request := &synchronizationsvc.ListRequest{
+		Selection: &selection.Selection{Specifications: []string{session}},
+	}
+	var state *synchronization.State
+	for {
+		// Perform a list operation, which will block until the session state
+		// changes (after the first iteration).
+		response, err := synchronizationService.List(ctx, request)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			return grpcutil.PeelAwayRPCErrorLayer(err)
+		} else if err = response.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid list response received: %w", err)
+		} else if len(response.SessionStates) != 1 {
+			return errors.New("session not found")
+		}
+
+		// Check whether or not the session has completed a cycle.
+		state = response.SessionStates[0]
+		if state.SuccessfulCycles > 0 {
+			return nil
+		}
+
+		// Update the state index.
+		request.PreviousStateIndex = response.StateIndex
+	}
+
+	// Report the timeout with the last known status.
+	if state == nil {
+		return fmt.Errorf("timed out after %s", timeout)
+	} else if state.LastError != "" {
+		return fmt.Errorf("timed out after %s (status: %s, last error: %s)",
+			timeout, state.Status.Description(), state.LastError,
+		)
+	}
+	return fmt.Errorf("timed out after %s (status: %s)", timeout, state.Status.Description())
+}
+
+// waitForConditions waits for a project's readiness conditions to be
+// satisfied, in the order specified. The sessions argument maps
+// synchronization session names to the identifiers of the sessions that were
+// created. If paused is true, then session conditions are skipped, since they
+// can't be satisfied by paused sessions.
+func waitForConditions(
+	daemonConnection *grpc.ClientConn,
+	conditions []project.WaitCondition,
+	sessions map[string]string,
+	paused bool,
+) error {
+	for _, condition := range conditions {
+		// Handle session conditions.
+		description := condition.Description()
+		if condition.Session != "" {
+			if paused {
+				fmt.Printf("Skipping wait for %s (sessions are paused)\n", description)
+				continue
+			}
+			fmt.Printf("Waiting for %s...\n", description)
+			err := waitForSynchronizationCycle(daemonConnection, sessions[condition.Session], condition.EffectiveTimeout())
+			if err != nil {
+				return fmt.Errorf("wait for %s failed: %w", description, err)
+			}
+			continue
+		}
+
+		// Handle other conditions.
+		fmt.Printf("Waiting for %s...\n", description)
+		if err := project.Wait(context.Background(), condition.EffectiveTimeout(), condition.Probe); err != nil {
+			return fmt.Errorf("wait for %s failed: %w", description, err)
+		}
+	}
+
+	// Success.
+	return nil
+}
@@ -52,6 +52,37 @@ func loadAndValidateGlobalSynchronizationConfiguration(path string) (*synchroniz
 	return configuration, nil
 }
 
+// loadAndValidateGlobalSynchronizationPreset loads a YAML-based global configuration,
+// extracts the synchronization component of the specified preset, converts it to a
+// Protocol Buffers session configuration, and validates it.
+func loadAndValidateGlobalSynchronizationPreset(path, name string) (*synchronization.Configuration, error) {
+	// Load the YAML configuration. If the file doesn't exist, then no presets
+	// are defined.
+	yamlConfiguration, err := global.LoadConfiguration(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("unknown preset: %s", name)
+		}
+		return nil, err
+	}
+
+	// Look up the preset.
+	preset, err := yamlConfiguration.Preset(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert the preset configuration to a Protocol Buffers representation
+	// and validate it.
+	configuration := preset.Synchronization.ToInternal()
+	if err := configuration.EnsureValid(false); err != nil {
+		return nil, fmt.Errorf("invalid preset configuration: %w", err)
+	}
+
+	// Success.
+	return configuration, nil
+}
+
 // CreateWithSpecification is an orchestration convenience method that performs
 // a create operation using the provided daemon connection and session
 // specification.
@@ -138,7 +169,12 @@ func createMain(_ *cobra.Command, arguments []string) error {
 	configuration := &synchronization.Configuration{}
 
 	// Unless disabled, attempt to load configuration from the global
-	// configuration file and merge it into our cumulative configuration.
+	// configuration file and merge it into our cumulative configuration. If a
+	// preset has been specified, then it's merged on top of the global
+	// defaults.
+	if createConfiguration.noGlobalConfiguration && createConfiguration.preset != "" {
+		return errors.New("presets cannot be used when ignoring the global configuration file")
+	}
 	if !createConfiguration.noGlobalConfiguration {
 		// Compute the path to the global configuration file.
 		globalConfigurationPath, err := global.ConfigurationPath()
@@ -155,6 +191,15 @@ func createMain(_ *cobra.Command, arguments []string) error {
 		} else {
 			configuration = synchronization.MergeConfigurations(configuration, globalConfiguration)
 		}
+
+		// Load and merge the preset, if any.
+		if createConfiguration.preset != "" {
+			preset, err := loadAndValidateGlobalSynchronizationPreset(globalConfigurationPath, createConfiguration.preset)
+			if err != nil {
+				return fmt.Errorf("unable to load preset: %w", err)
+			}
+			configuration = synchronization.MergeConfigurations(configuration, preset)
+		}
 	}
 
 	// If additional default configuration files have been specified, then load
@@ -528,6 +573,8 @@ var createConfiguration struct {
 	// noGlobalConfiguration specifies whether or not the global configuration
 	// file should be ignored.
 	noGlobalConfiguration bool
+	// preset is the name of the global configuration preset to apply.
+	preset string
 	// configurationFiles stores paths of additional files from which to load
 	// default configuration.
 	configurationFiles []string
@@ -679,6 +726,7 @@ func init() {
 
 	// Wire up general configuration flags.
 	flags.BoolVar(&createConfiguration.noGlobalConfiguration, "no-global-configuration", false, "Ignore the global configuration file")
+	flags.StringVar(&createConfiguration.preset, "preset", "", "Apply the specified preset from the global configuration file")
 	flags.StringSliceVarP(&createConfiguration.configurationFiles, "configuration-file", "c", nil, "Specify additional files from which to load (and merge) default configuration parameters")
 
 	// Wire up synchronization flags.
@@ -0,0 +1,377 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"google.golang.org/grpc"
+
+	"github.com/mutagen-io/mutagen/cmd"
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	promptingsvc "github.com/mutagen-io/mutagen/pkg/service/prompting"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+// removeIgnores removes all occurrences of the specified ignore patterns from a
+// list of patterns. It returns an error if any pattern to be removed isn't
+// present.
+func removeIgnores(ignores, removals []string) ([]string, error) {
+	// If there's nothing to remove, then the list is unmodified.
+	if len(removals) == 0 {
+		return ignores, nil
+	}
+
+	// Create a set of patterns to remove.
+	remove := make(map[string]bool, len(removals))
+	for _, removal := range removals {
+		remove[removal] = true
+	}
+
+	// Filter the list, tracking which removals were used.
+	var result []string
+	used := make(map[string]bool, len(removals))
+	for _, ignore := range ignores {
+		if remove[ignore] {
+			used[ignore] = true
+			continue
+		}
+		result = append(result, ignore)
+	}
+
+	// Ensure that all removals were used. We iterate over the original list to
+	// report errors deterministically.
+	for _, removal := range removals {
+		if !used[removal] {
+			return nil, fmt.Errorf("ignore pattern not present in session configuration: %s", removal)
+		}
+	}
+
+	// Success.
+	return result, nil
+}
+
+// EditWithConfigurations is an orchestration convenience method that performs
+// an update operation using the provided daemon connection, session
+// specification, and configurations.
+func EditWithConfigurations(
+	daemonConnection *grpc.ClientConn,
+	session string,
+	configuration, configurationAlpha, configurationBeta *synchronization.Configuration,
+) error {
+	// Initiate command line prompting.
+	statusLinePrinter := &cmd.StatusLinePrinter{}
+	promptingCtx, promptingCancel := context.WithCancel(context.Background())
+	prompter, promptingErrors, err := promptingsvc.Host(
+		promptingCtx, promptingsvc.NewPromptingClient(daemonConnection),
+		&cmd.StatusLinePrompter{Printer: statusLinePrinter}, true,
+	)
+	if err != nil {
+		promptingCancel()
+		return fmt.Errorf("unable to initiate prompting: %w", err)
+	}
+
+	// Perform the update operation, cancel prompting, and handle errors.
+	synchronizationService := synchronizationsvc.NewSynchronizationClient(daemonConnection)
+	request := &synchronizationsvc.UpdateRequest{
+		Prompter:           prompter,
+		Session:            session,
+		Configuration:      configuration,
+		ConfigurationAlpha: configurationAlpha,
+		ConfigurationBeta:  configurationBeta,
+	}
+	response, err := synchronizationService.Update(context.Background(), request)
+	promptingCancel()
+	<-promptingErrors
+	if err != nil {
+		statusLinePrinter.BreakIfPopulated()
+		return grpcutil.PeelAwayRPCErrorLayer(err)
+	} else if err = response.EnsureValid(); err != nil {
+		statusLinePrinter.BreakIfPopulated()
+		return fmt.Errorf("invalid update response received: %w", err)
+	}
+
+	// Success.
+	statusLinePrinter.Clear()
+	return nil
+}
+
+// editMain is the entry point for the edit command.
+func editMain(_ *cobra.Command, arguments []string) error {
+	// Validate and extract the session specification.
+	if len(arguments) != 1 {
+		return errors.New("a single session must be specified")
+	}
+	specification := arguments[0]
+
+	// Validate and convert the synchronization mode specification.
+	var synchronizationMode core.SynchronizationMode
+	if editConfiguration.synchronizationMode != "" {
+		if err := synchronizationMode.UnmarshalText([]byte(editConfiguration.synchronizationMode)); err != nil {
+			return fmt.Errorf("unable to parse synchronization mode: %w", err)
+		}
+	}
+
+	// Validate and convert scan mode specifications.
+	var scanMode, scanModeAlpha, scanModeBeta synchronization.ScanMode
+	if editConfiguration.scanMode != "" {
+		if err := scanMode.UnmarshalText([]byte(editConfiguration.scanMode)); err != nil {
+			return fmt.Errorf("unable to parse scan mode: %w", err)
+		}
+	}
+	if editConfiguration.scanModeAlpha != "" {
+		if err := scanModeAlpha.UnmarshalText([]byte(editConfiguration.scanModeAlpha)); err != nil {
+			return fmt.Errorf("unable to parse scan mode for alpha: %w", err)
+		}
+	}
+	if editConfiguration.scanModeBeta != "" {
+		if err := scanModeBeta.UnmarshalText([]byte(editConfiguration.scanModeBeta)); err != nil {
+			return fmt.Errorf("unable to parse scan mode for beta: %w", err)
+		}
+	}
+
+	// Validate and convert staging mode specifications.
+	var stageMode, stageModeAlpha, stageModeBeta synchronization.StageMode
+	if editConfiguration.stageMode != "" {
+		if err := stageMode.UnmarshalText([]byte(editConfiguration.stageMode)); err != nil {
+			return fmt.Errorf("unable to parse staging mode: %w", err)
+		}
+	}
+	if editConfiguration.stageModeAlpha != "" {
+		if err := stageModeAlpha.UnmarshalText([]byte(editConfiguration.stageModeAlpha)); err != nil {
+			return fmt.Errorf("unable to parse staging mode for alpha: %w", err)
+		}
+	}
+	if editConfiguration.stageModeBeta != "" {
+		if err := stageModeBeta.UnmarshalText([]byte(editConfiguration.stageModeBeta)); err != nil {
+			return fmt.Errorf("unable to parse staging mode for beta: %w", err)
+		}
+	}
+
+	// Validate and convert watch mode specifications.
+	var watchMode, watchModeAlpha, watchModeBeta synchronization.WatchMode
+	if editConfiguration.watchMode != "" {
+		if err := watchMode.UnmarshalText([]byte(editConfiguration.watchMode)); err != nil {
+			return fmt.Errorf("unable to parse watch mode: %w", err)
+		}
+	}
+	if editConfiguration.watchModeAlpha != "" {
+		if err := watchModeAlpha.UnmarshalText([]byte(editConfiguration.watchModeAlpha)); err != nil {
+			return fmt.Errorf("unable to parse watch mode for alpha: %w", err)
+		}
+	}
+	if editConfiguration.watchModeBeta != "" {
+		if err := watchModeBeta.UnmarshalText([]byte(editConfiguration.watchModeBeta)); err != nil {
+			return fmt.Errorf("unable to parse watch mode for beta: %w", err)
+		}
+	}
+
+	// There's no need to validate the watch polling intervals - any uint32
+	// values are valid.
+
+	// Validate ignore specifications.
+	for _, ignore := range editConfiguration.ignores {
+		if !core.ValidIgnorePattern(ignore) {
+			return fmt.Errorf("invalid ignore pattern: %s", ignore)
+		}
+	}
+
+	// Validate and convert the VCS ignore mode specification.
+	var ignoreVCSMode core.IgnoreVCSMode
+	if editConfiguration.ignoreVCS && editConfiguration.noIgnoreVCS {
+		return errors.New("conflicting VCS ignore behavior specified")
+	} else if editConfiguration.ignoreVCS {
+		ignoreVCSMode = core.IgnoreVCSMode_IgnoreVCSModeIgnore
+	} else if editConfiguration.noIgnoreVCS {
+		ignoreVCSMode = core.IgnoreVCSMode_IgnoreVCSModePropagate
+	}
+
+	// Connect to the daemon and defer closure of the connection.
+	daemonConnection, err := daemon.Connect(true, true)
+	if err != nil {
+		return fmt.Errorf("unable to connect to daemon: %w", err)
+	}
+	defer daemonConnection.Close()
+
+	// Look up the session's existing configuration.
+	synchronizationService := synchronizationsvc.NewSynchronizationClient(daemonConnection)
+	listRequest := &synchronizationsvc.ListRequest{
+		Selection: &selection.Selection{Specifications: []string{specification}},
+	}
+	listResponse, err := synchronizationService.List(context.Background(), listRequest)
+	if err != nil {
+		return grpcutil.PeelAwayRPCErrorLayer(err)
+	} else if err = listResponse.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid list response received: %w", err)
+	} else if len(listResponse.SessionStates) != 1 {
+		return fmt.Errorf("specification \"%s\" matched multiple sessions", specification)
+	}
+	session := listResponse.SessionStates[0].Session
+
+	// Merge any specified configuration files into the existing configuration.
+	configuration := session.Configuration
+	for _, configurationFile := range editConfiguration.configurationFiles {
+		if c, err := loadAndValidateGlobalSynchronizationConfiguration(configurationFile); err != nil {
+			return fmt.Errorf("unable to load configuration file (%s): %w", configurationFile, err)
+		} else {
+			configuration = synchronization.MergeConfigurations(configuration, c)
+		}
+	}
+
+	// Merge the command line configuration into the configuration.
+	configuration = synchronization.MergeConfigurations(configuration, &synchronization.Configuration{
+		SynchronizationMode:  synchronizationMode,
+		ScanMode:             scanMode,
+		StageMode:            stageMode,
+		WatchMode:            watchMode,
+		WatchPollingInterval: editConfiguration.watchPollingInterval,
+		Ignores:              editConfiguration.ignores,
+		IgnoreVCSMode:        ignoreVCSMode,
+	})
+	configurationAlpha := synchronization.MergeConfigurations(session.ConfigurationAlpha, &synchronization.Configuration{
+		ScanMode:             scanModeAlpha,
+		StageMode:            stageModeAlpha,
+		WatchMode:            watchModeAlpha,
+		WatchPollingInterval: editConfiguration.watchPollingIntervalAlpha,
+	})
+	configurationBeta := synchronization.MergeConfigurations(session.ConfigurationBeta, &synchronization.Configuration{
+		ScanMode:             scanModeBeta,
+		StageMode:            stageModeBeta,
+		WatchMode:            watchModeBeta,
+		WatchPollingInterval: editConfiguration.watchPollingIntervalBeta,
+	})
+
+	// Remove any ignores that have been requested for removal.
+	if configuration.Ignores, err = removeIgnores(configuration.Ignores, editConfiguration.removeIgnores); err != nil {
+		return err
+	}
+
+	// If nothing has changed, then there's no need to perform an update.
+	if configuration.Equal(session.Configuration) &&
+		configurationAlpha.Equal(session.ConfigurationAlpha) &&
+		configurationBeta.Equal(session.ConfigurationBeta) {
+		fmt.Println("Session configuration unchanged")
+		return nil
+	}
+
+	// Perform the update operation.
+	return EditWithConfigurations(daemonConnection, session.Identifier, configuration, configurationAlpha, configurationBeta)
+}
+
+// editCommand is the edit command.
+var editCommand = &cobra.Command{
+	Use:          "edit <session>",
+	Short:        "Change the configuration of an existing synchronization session",
+	RunE:         editMain,
+	SilenceUsage: true,
+}
+
+// editConfiguration stores configuration for the edit command.
+var editConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// configurationFiles stores paths of additional files from which to load
+	// configuration parameters to merge into the session configuration.
+	configurationFiles []string
+	// synchronizationMode specifies the synchronization mode for the session.
+	synchronizationMode string
+	// scanMode specifies the scan mode for the session.
+	scanMode string
+	// scanModeAlpha specifies the scan mode for the session, taking priority
+	// over scanMode on alpha if specified.
+	scanModeAlpha string
+	// scanModeBeta specifies the scan mode for the session, taking priority
+	// over scanMode on beta if specified.
+	scanModeBeta string
+	// stageMode specifies the file staging mode for the session.
+	stageMode string
+	// stageModeAlpha specifies the file staging mode for the session, taking
+	// priority over stageMode on alpha if specified.
+	stageModeAlpha string
+	// stageModeBeta specifies the file staging mode for the session, taking
+	// priority over stageMode on beta if specified.
+	stageModeBeta string
+	// watchMode specifies the filesystem watching mode for the session.
+	watchMode string
+	// watchModeAlpha specifies the filesystem watching mode for the session,
+	// taking priority over watchMode on alpha if specified.
+	watchModeAlpha string
+	// watchModeBeta specifies the filesystem watching mode for the session,
+	// taking priority over watchMode on beta if specified.
+	watchModeBeta string
+	// watchPollingInterval specifies the polling interval to use if using
+	// poll-based or hybrid watching.
+	watchPollingInterval uint32
+	// watchPollingIntervalAlpha specifies the polling interval to use if using
+	// poll-based or hybrid watching, taking priority over watchPollingInterval
+	// on alpha if specified.
+	watchPollingIntervalAlpha uint32
+	// watchPollingIntervalBeta specifies the polling interval to use if using
+	// poll-based or hybrid watching, taking priority over watchPollingInterval
+	// on beta if specified.
+	watchPollingIntervalBeta uint32
+	// ignores is the list of ignore specifications to add to the session.
+	ignores []string
+	// removeIgnores is the list of ignore specifications to remove from the
+	// session.
+	removeIgnores []string
+	// ignoreVCS specifies whether or not to enable VCS ignores for the session.
+	ignoreVCS bool
+	// noIgnoreVCS specifies whether or not to disable VCS ignores for the
+	// session.
+	noIgnoreVCS bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := editCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&editConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up general configuration flags.
+	flags.StringSliceVarP(&editConfiguration.configurationFiles, "configuration-file", "c", nil, "Specify files from which to load (and merge) configuration parameters")
+
+	// Wire up synchronization flags.
+	flags.StringVarP(&editConfiguration.synchronizationMode, "mode", "m", "", "Specify synchronization mode (two-way-safe|two-way-resolved|one-way-safe|one-way-replica)")
+	flags.StringVar(&editConfiguration.scanMode, "scan-mode", "", "Specify scan mode (full|accelerated)")
+	flags.StringVar(&editConfiguration.scanModeAlpha, "scan-mode-alpha", "", "Specify scan mode for alpha (full|accelerated)")
+	flags.StringVar(&editConfiguration.scanModeBeta, "scan-mode-beta", "", "Specify scan mode for beta (full|accelerated)")
+	flags.StringVar(&editConfiguration.stageMode, "stage-mode", "", "Specify staging mode (mutagen|neighboring)")
+	flags.StringVar(&editConfiguration.stageModeAlpha, "stage-mode-alpha", "", "Specify staging mode for alpha (mutagen|neighboring)")
+	flags.StringVar(&editConfiguration.stageModeBeta, "stage-mode-beta", "", "Specify staging mode for beta (mutagen|neighboring)")
+
+	// Wire up watch flags.
+	flags.StringVar(&editConfiguration.watchMode, "watch-mode", "", "Specify watch mode (portable|force-poll|no-watch)")
+	flags.StringVar(&editConfiguration.watchModeAlpha, "watch-mode-alpha", "", "Specify watch mode for alpha (portable|force-poll|no-watch)")
+	flags.StringVar(&editConfiguration.watchModeBeta, "watch-mode-beta", "", "Specify watch mode for beta (portable|force-poll|no-watch)")
+	flags.Uint32Var(&editConfiguration.watchPollingInterval, "watch-polling-interval", 0, "Specify watch polling interval in seconds")
+	flags.Uint32Var(&editConfiguration.watchPollingIntervalAlpha, "watch-polling-interval-alpha", 0, "Specify watch polling interval in seconds for alpha")
+	flags.Uint32Var(&editConfiguration.watchPollingIntervalBeta, "watch-polling-interval-beta", 0, "Specify watch polling interval in seconds for beta")
+
+	// Wire up ignore flags.
+	flags.StringSliceVarP(&editConfiguration.ignores, "ignore", "i", nil, "Add ignore paths")
+	flags.StringSliceVar(&editConfiguration.removeIgnores, "remove-ignore", nil, "Remove ignore paths")
+	flags.BoolVar(&editConfiguration.ignoreVCS, "ignore-vcs", false, "Ignore VCS directories")
+	flags.BoolVar(&editConfiguration.noIgnoreVCS, "no-ignore-vcs", false, "Propagate VCS directories")
+
+	// Set up flag normalization. This is only required to handle aliases.
+	flags.SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "sync-mode" {
+			name = "mode"
+		}
+		return pflag.NormalizedName(name)
+	})
+}
@@ -0,0 +1,45 @@
+package sync
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestRemoveIgnores tests removeIgnores.
+func TestRemoveIgnores(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		ignores     []string
+		removals    []string
+		expected    []string
+		expectError bool
+	}{
+		{nil, nil, nil, false},
+		{[]string{"a", "b"}, nil, []string{"a", "b"}, false},
+		{[]string{"a", "b", "c"}, []string{"b"}, []string{"a", "c"}, false},
+		{[]string{"a", "b", "c"}, []string{"c", "a"}, []string{"b"}, false},
+		{[]string{"a", "b"}, []string{"a", "b"}, nil, false},
+		{[]string{"a", "b", "a"}, []string{"a"}, []string{"b"}, false},
+		{[]string{"a", "b"}, []string{"b", "b"}, []string{"a"}, false},
+		{[]string{"a", "b"}, []string{"c"}, nil, true},
+		{[]string{"a", "b"}, []string{"a", "c"}, nil, true},
+		{nil, []string{"a"}, nil, true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		result, err := removeIgnores(testCase.ignores, testCase.removals)
+		if testCase.expectError {
+			if err == nil {
+				t.Errorf("test case %d: removal succeeded unexpectedly", i)
+			}
+			continue
+		} else if err != nil {
+			t.Errorf("test case %d: removal failed: %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(result, testCase.expected) {
+			t.Errorf("test case %d: result does not match expected: %v != %v", i, result, testCase.expected)
+		}
+	}
+}
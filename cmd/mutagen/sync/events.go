@@ -0,0 +1,168 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"google.golang.org/grpc"
+
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+
+	synchronizationmodels "github.com/mutagen-io/mutagen/pkg/api/models/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+)
+
+// formatEvent formats a synchronization session event for human-readable
+// output.
+func formatEvent(event *synchronization.Event) string {
+	// Compute the session descriptor.
+	session := event.Session
+	if event.SessionName != "" {
+		session = event.SessionName
+	}
+
+	// Compute event details.
+	var details string
+	switch event.Kind {
+	case synchronization.EventKind_EventKindStatusChanged:
+		details = fmt.Sprintf("%s -> %s", event.PreviousStatus.Description(), event.Status.Description())
+	case synchronization.EventKind_EventKindCycleCompleted:
+		details = fmt.Sprintf("%d alpha change(s), %d beta change(s), %d conflict(s)",
+			event.AlphaChanges, event.BetaChanges, event.Conflicts,
+		)
+	case synchronization.EventKind_EventKindConflictsAppeared:
+		details = fmt.Sprintf("%d conflict(s)", event.Conflicts)
+	case synchronization.EventKind_EventKindProblemsChanged:
+		details = fmt.Sprintf("%d alpha problem(s), %d beta problem(s)", event.AlphaProblems, event.BetaProblems)
+	case synchronization.EventKind_EventKindError:
+		details = event.Error
+	}
+
+	// Format the event.
+	result := fmt.Sprintf("%s %s %s",
+		event.Time.AsTime().Local().Format(time.RFC3339),
+		session,
+		event.Kind.Description(),
+	)
+	if details != "" {
+		result += ": " + details
+	}
+	return result
+}
+
+// EventsWithSelection is an orchestration convenience method that streams
+// events using the provided daemon connection and session selection, printing
+// them as they arrive. If jsonOutput is true, then events are printed as JSON
+// objects, one per line.
+func EventsWithSelection(
+	daemonConnection *grpc.ClientConn,
+	selection *selection.Selection,
+	jsonOutput bool,
+) error {
+	// Create a JSON encoder, if needed.
+	var encoder *json.Encoder
+	if jsonOutput {
+		encoder = json.NewEncoder(os.Stdout)
+		encoder.SetEscapeHTML(false)
+	}
+
+	// Start the event stream.
+	synchronizationService := synchronizationsvc.NewSynchronizationClient(daemonConnection)
+	request := &synchronizationsvc.WatchRequest{
+		Selection: selection,
+	}
+	stream, err := synchronizationService.Watch(context.Background(), request)
+	if err != nil {
+		return grpcutil.PeelAwayRPCErrorLayer(err)
+	}
+
+	// Receive and print events.
+	for {
+		// Receive and validate the next event.
+		response, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return grpcutil.PeelAwayRPCErrorLayer(err)
+		} else if err = response.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid watch response received: %w", err)
+		}
+
+		// Print the event.
+		if encoder != nil {
+			if err := encoder.Encode(synchronizationmodels.ExportEvent(response.Event)); err != nil {
+				return fmt.Errorf("unable to encode event: %w", err)
+			}
+		} else {
+			fmt.Println(formatEvent(response.Event))
+		}
+	}
+}
+
+// eventsMain is the entry point for the events command.
+func eventsMain(_ *cobra.Command, arguments []string) error {
+	// Create session selection specification.
+	selection := &selection.Selection{
+		All:            len(arguments) == 0 && eventsConfiguration.labelSelector == "",
+		Specifications: arguments,
+		LabelSelector:  eventsConfiguration.labelSelector,
+	}
+	if err := selection.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid session selection specification: %w", err)
+	}
+
+	// Connect to the daemon and defer closure of the connection.
+	daemonConnection, err := daemon.Connect(true, true)
+	if err != nil {
+		return fmt.Errorf("unable to connect to daemon: %w", err)
+	}
+	defer daemonConnection.Close()
+
+	// Stream events.
+	return EventsWithSelection(daemonConnection, selection, eventsConfiguration.json)
+}
+
+// eventsCommand is the events command.
+var eventsCommand = &cobra.Command{
+	Use:          "events [<session>...]",
+	Short:        "Stream events for synchronization sessions",
+	RunE:         eventsMain,
+	SilenceUsage: true,
+}
+
+// eventsConfiguration stores configuration for the events command.
+var eventsConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// labelSelector encodes a label selector to be used in identifying which
+	// sessions should be watched.
+	labelSelector string
+	// json indicates whether or not to print events as JSON.
+	json bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := eventsCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&eventsConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up events flags.
+	flags.StringVar(&eventsConfiguration.labelSelector, "label-selector", "", "Stream events for sessions matching the specified label selector")
+	flags.BoolVar(&eventsConfiguration.json, "json", false, "Print events as JSON objects (one per line)")
+}
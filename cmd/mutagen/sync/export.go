@@ -0,0 +1,160 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"google.golang.org/grpc"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+
+	"github.com/mutagen-io/mutagen/pkg/encoding"
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/project"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+)
+
+// ExportWithSpecification is an orchestration convenience method that exports
+// a session as a YAML-based session specification using the provided daemon
+// connection and session specification. The specification is keyed by the
+// specified name, or by the session name if none is specified. It is written
+// to the specified output path, or to standard output if no path is specified.
+// If an archive path is specified, then the session's ancestor archive is also
+// exported to that path.
+func ExportWithSpecification(
+	daemonConnection *grpc.ClientConn,
+	specification, name, outputPath, archivePath string,
+) error {
+	// Look up the session.
+	synchronizationService := synchronizationsvc.NewSynchronizationClient(daemonConnection)
+	listRequest := &synchronizationsvc.ListRequest{
+		Selection: &selection.Selection{Specifications: []string{specification}},
+	}
+	listResponse, err := synchronizationService.List(context.Background(), listRequest)
+	if err != nil {
+		return grpcutil.PeelAwayRPCErrorLayer(err)
+	} else if err = listResponse.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid list response received: %w", err)
+	} else if len(listResponse.SessionStates) != 1 {
+		return fmt.Errorf("specification \"%s\" matched multiple sessions", specification)
+	}
+	session := listResponse.SessionStates[0].Session
+
+	// Determine and validate the specification name.
+	if name == "" {
+		name = session.Name
+	}
+	if name == "" {
+		return errors.New("session is unnamed and no export name was specified")
+	} else if err := selection.EnsureNameValid(name); err != nil {
+		return fmt.Errorf("invalid export name: %w", err)
+	}
+
+	// Create the exported configuration.
+	exported := &project.Configuration{
+		Synchronization: map[string]project.SynchronizationConfiguration{
+			name: project.ExportSynchronizationSession(session),
+		},
+	}
+
+	// Export the ancestor archive, if requested. We do this before writing the
+	// specification so that a partial export doesn't leave behind a
+	// specification without its corresponding archive.
+	if archivePath != "" {
+		archiveRequest := &synchronizationsvc.ArchiveRequest{Session: session.Identifier}
+		archiveResponse, err := synchronizationService.Archive(context.Background(), archiveRequest)
+		if err != nil {
+			return grpcutil.PeelAwayRPCErrorLayer(err)
+		} else if err = archiveResponse.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid archive response received: %w", err)
+		}
+		if err := encoding.MarshalAndSaveProtobuf(archivePath, archiveResponse.Archive); err != nil {
+			return fmt.Errorf("unable to save archive: %w", err)
+		}
+	}
+
+	// Write the specification.
+	if outputPath != "" {
+		if err := encoding.MarshalAndSaveYAML(outputPath, exported); err != nil {
+			return fmt.Errorf("unable to save specification: %w", err)
+		}
+	} else {
+		data, err := yaml.Marshal(exported)
+		if err != nil {
+			return fmt.Errorf("unable to marshal specification: %w", err)
+		}
+		os.Stdout.Write(data)
+	}
+
+	// Success.
+	return nil
+}
+
+// exportMain is the entry point for the export command.
+func exportMain(_ *cobra.Command, arguments []string) error {
+	// Validate and extract the session specification.
+	if len(arguments) != 1 {
+		return errors.New("a single session must be specified")
+	}
+	specification := arguments[0]
+
+	// Connect to the daemon and defer closure of the connection.
+	daemonConnection, err := daemon.Connect(true, true)
+	if err != nil {
+		return fmt.Errorf("unable to connect to daemon: %w", err)
+	}
+	defer daemonConnection.Close()
+
+	// Perform the export.
+	return ExportWithSpecification(
+		daemonConnection,
+		specification,
+		exportConfiguration.name,
+		exportConfiguration.output,
+		exportConfiguration.archive,
+	)
+}
+
+// exportCommand is the export command.
+var exportCommand = &cobra.Command{
+	Use:          "export <session>",
+	Short:        "Export a synchronization session as a YAML specification",
+	RunE:         exportMain,
+	SilenceUsage: true,
+}
+
+// exportConfiguration stores configuration for the export command.
+var exportConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// name is the name under which to export the session.
+	name string
+	// output is the path to which the specification should be written.
+	output string
+	// archive is the path to which the ancestor archive should be written.
+	archive string
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := exportCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&exportConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up export flags.
+	flags.StringVarP(&exportConfiguration.name, "name", "n", "", "Specify the name for the exported session (defaults to the session name)")
+	flags.StringVarP(&exportConfiguration.output, "output", "o", "", "Write the specification to the specified path instead of standard output")
+	flags.StringVar(&exportConfiguration.archive, "archive", "", "Also export the session's ancestor archive to the specified path (archives are limited to 25 MB)")
+}
@@ -0,0 +1,189 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/spf13/cobra"
+
+	"google.golang.org/grpc"
+
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+)
+
+// filterHistoryChanges filters history changes using a path glob pattern. If
+// the pattern is empty, then the changes are returned unmodified.
+func filterHistoryChanges(changes []*synchronization.HistoryChange, pattern string) []*synchronization.HistoryChange {
+	if pattern == "" {
+		return changes
+	}
+	var result []*synchronization.HistoryChange
+	for _, change := range changes {
+		if match, _ := doublestar.Match(pattern, change.Path); match {
+			result = append(result, change)
+		}
+	}
+	return result
+}
+
+// printHistoryChanges prints the changes applied to an endpoint.
+func printHistoryChanges(name string, changes []*synchronization.HistoryChange, excluded uint64) {
+	if len(changes) == 0 && excluded == 0 {
+		return
+	}
+	fmt.Printf("\t%s changes:\n", name)
+	for _, change := range changes {
+		fmt.Printf("\t\t%s %s\n", change.Kind.Description(), formatPath(change.Path))
+	}
+	if excluded > 0 {
+		fmt.Printf("\t\t...+%d more...\n", excluded)
+	}
+}
+
+// printHistory prints a session cycle history, filtering changes based on the
+// specified path glob pattern. If a pattern is specified, then entries without
+// matching changes are omitted.
+func printHistory(history *synchronization.History, pattern string) {
+	// Print the session header.
+	fmt.Println("Session:", history.Session)
+
+	// Print entries.
+	var printed int
+	for _, entry := range history.Entries {
+		// Filter changes. If a pattern has been specified, then we don't report
+		// excluded changes since we can't know whether or not they match.
+		alphaChanges := filterHistoryChanges(entry.AlphaChanges, pattern)
+		betaChanges := filterHistoryChanges(entry.BetaChanges, pattern)
+		excludedAlphaChanges, excludedBetaChanges := entry.ExcludedAlphaChanges, entry.ExcludedBetaChanges
+		if pattern != "" {
+			if len(alphaChanges) == 0 && len(betaChanges) == 0 {
+				continue
+			}
+			excludedAlphaChanges, excludedBetaChanges = 0, 0
+		}
+
+		// Print the entry.
+		fmt.Printf("%s (%s)\n",
+			entry.Time.AsTime().Local().Format(time.RFC3339),
+			entry.Trigger.Description(),
+		)
+		fmt.Printf("\tPhase durations: scan %s, reconcile %s, stage %s, transition %s\n",
+			entry.ScanDuration.AsDuration().Round(time.Millisecond),
+			entry.ReconcileDuration.AsDuration().Round(time.Millisecond),
+			entry.StageDuration.AsDuration().Round(time.Millisecond),
+			entry.TransitionDuration.AsDuration().Round(time.Millisecond),
+		)
+		printHistoryChanges("Alpha", alphaChanges, excludedAlphaChanges)
+		printHistoryChanges("Beta", betaChanges, excludedBetaChanges)
+		printed++
+	}
+
+	// If nothing was printed, then say so.
+	if printed == 0 {
+		fmt.Println("No recorded synchronization cycles")
+	}
+}
+
+// HistoryWithSelection is an orchestration convenience method that queries and
+// prints session cycle histories using the provided daemon connection and
+// session selection. Changes are filtered using the specified path glob
+// pattern, if any.
+func HistoryWithSelection(
+	daemonConnection *grpc.ClientConn,
+	selection *selection.Selection,
+	pattern string,
+) error {
+	// Perform the query.
+	synchronizationService := synchronizationsvc.NewSynchronizationClient(daemonConnection)
+	request := &synchronizationsvc.HistoryRequest{
+		Selection: selection,
+	}
+	response, err := synchronizationService.History(context.Background(), request)
+	if err != nil {
+		return grpcutil.PeelAwayRPCErrorLayer(err)
+	} else if err = response.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid history response received: %w", err)
+	}
+
+	// Print histories.
+	for h, history := range response.Histories {
+		if h > 0 {
+			fmt.Println()
+		}
+		printHistory(history, pattern)
+	}
+
+	// Success.
+	return nil
+}
+
+// historyMain is the entry point for the history command.
+func historyMain(_ *cobra.Command, arguments []string) error {
+	// Ensure that at least one session has been specified.
+	if len(arguments) == 0 {
+		return errors.New("no sessions specified")
+	}
+
+	// Validate the path pattern.
+	if historyConfiguration.path != "" && !doublestar.ValidatePattern(historyConfiguration.path) {
+		return errors.New("invalid path pattern")
+	}
+
+	// Create session selection specification.
+	selection := &selection.Selection{
+		Specifications: arguments,
+	}
+	if err := selection.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid session selection specification: %w", err)
+	}
+
+	// Connect to the daemon and defer closure of the connection.
+	daemonConnection, err := daemon.Connect(true, true)
+	if err != nil {
+		return fmt.Errorf("unable to connect to daemon: %w", err)
+	}
+	defer daemonConnection.Close()
+
+	// Perform the query.
+	return HistoryWithSelection(daemonConnection, selection, historyConfiguration.path)
+}
+
+// historyCommand is the history command.
+var historyCommand = &cobra.Command{
+	Use:          "history <session>...",
+	Short:        "Show recent synchronization cycles for sessions",
+	RunE:         historyMain,
+	SilenceUsage: true,
+}
+
+// historyConfiguration stores configuration for the history command.
+var historyConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// path is a glob pattern used to filter changes by path.
+	path string
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := historyCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&historyConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up history flags.
+	flags.StringVar(&historyConfiguration.path, "path", "", "Only show changes with paths matching the specified glob pattern")
+}
@@ -0,0 +1,224 @@
+package sync
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+
+	"github.com/mutagen-io/mutagen/pkg/encoding"
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/project"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// importMain is the entry point for the import command.
+func importMain(_ *cobra.Command, arguments []string) error {
+	// Validate and extract the specification path.
+	if len(arguments) != 1 {
+		return errors.New("a single specification file must be specified")
+	}
+	path := arguments[0]
+
+	// Load the specification file.
+	configuration, err := project.LoadConfiguration(path)
+	if err != nil {
+		return fmt.Errorf("unable to load specification file: %w", err)
+	}
+
+	// Extract and sort session names, ignoring defaults.
+	var names []string
+	for name := range configuration.Synchronization {
+		if name != "defaults" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return errors.New("no synchronization sessions found in specification file")
+	}
+
+	// Load the ancestor archive, if specified. Since an archive corresponds to
+	// the contents of a particular pair of endpoints, we only allow it to be
+	// used when a single session is being imported.
+	var archive *core.Archive
+	if importConfiguration.archive != "" {
+		if len(names) != 1 {
+			return errors.New("an archive can only be imported with a single session")
+		}
+		archive = &core.Archive{}
+		if err := encoding.LoadAndUnmarshalProtobuf(importConfiguration.archive, archive); err != nil {
+			return fmt.Errorf("unable to load archive: %w", err)
+		} else if err = archive.EnsureValid(true); err != nil {
+			return fmt.Errorf("invalid archive: %w", err)
+		} else if size := proto.Size(archive); size > grpcutil.MaximumMessageSize {
+			return fmt.Errorf("archive too large to import (%d bytes, maximum %d bytes)", size, grpcutil.MaximumMessageSize)
+		}
+	}
+
+	// Exported specifications contain effective session configurations, which
+	// already incorporate any global configuration that was applied when the
+	// sessions were originally created, so we don't merge in the global
+	// configuration file here (doing so would duplicate list-based settings on
+	// each export and import round trip).
+	defaultConfiguration := &synchronization.Configuration{}
+
+	// Extract and validate defaults.
+	var defaultAlpha, defaultBeta string
+	defaultConfigurationAlpha := &synchronization.Configuration{}
+	defaultConfigurationBeta := &synchronization.Configuration{}
+	if defaults, ok := configuration.Synchronization["defaults"]; ok {
+		defaultAlpha = defaults.Alpha
+		defaultBeta = defaults.Beta
+		configuration := defaults.Configuration.ToInternal()
+		if err := configuration.EnsureValid(false); err != nil {
+			return fmt.Errorf("invalid default synchronization configuration: %w", err)
+		}
+		defaultConfiguration = synchronization.MergeConfigurations(defaultConfiguration, configuration)
+		defaultConfigurationAlpha = defaults.ConfigurationAlpha.ToInternal()
+		if err := defaultConfigurationAlpha.EnsureValid(true); err != nil {
+			return fmt.Errorf("invalid default synchronization alpha configuration: %w", err)
+		}
+		defaultConfigurationBeta = defaults.ConfigurationBeta.ToInternal()
+		if err := defaultConfigurationBeta.EnsureValid(true); err != nil {
+			return fmt.Errorf("invalid default synchronization beta configuration: %w", err)
+		}
+	}
+
+	// Generate session creation specifications.
+	specifications := make([]*synchronizationsvc.CreationSpecification, 0, len(names))
+	for _, name := range names {
+		session := configuration.Synchronization[name]
+
+		// Verify that the name is valid.
+		if err := selection.EnsureNameValid(name); err != nil {
+			return fmt.Errorf("invalid session name (%s): %w", name, err)
+		}
+
+		// Validate labels. We don't allow the project label to be specified,
+		// since that would cause the session to appear as if it were managed
+		// by a project.
+		for key, value := range session.Labels {
+			if err := selection.EnsureLabelKeyValid(key); err != nil {
+				return fmt.Errorf("invalid label key for %s: %w", name, err)
+			} else if key == project.LabelKey {
+				return fmt.Errorf("reserved label key for %s: %s", name, key)
+			} else if err = selection.EnsureLabelValueValid(value); err != nil {
+				return fmt.Errorf("invalid label value for %s: %w", name, err)
+			}
+		}
+
+		// Compute URLs.
+		alpha := session.Alpha
+		if alpha == "" {
+			alpha = defaultAlpha
+		}
+		beta := session.Beta
+		if beta == "" {
+			beta = defaultBeta
+		}
+
+		// Parse URLs.
+		alphaURL, err := url.Parse(alpha, url.Kind_Synchronization, true)
+		if err != nil {
+			return fmt.Errorf("unable to parse alpha URL for %s (%s): %w", name, alpha, err)
+		}
+		betaURL, err := url.Parse(beta, url.Kind_Synchronization, false)
+		if err != nil {
+			return fmt.Errorf("unable to parse beta URL for %s (%s): %w", name, beta, err)
+		}
+
+		// Compute configurations.
+		configuration := session.Configuration.ToInternal()
+		if err := configuration.EnsureValid(false); err != nil {
+			return fmt.Errorf("invalid configuration for %s: %w", name, err)
+		}
+		configuration = synchronization.MergeConfigurations(defaultConfiguration, configuration)
+		alphaConfiguration := session.ConfigurationAlpha.ToInternal()
+		if err := alphaConfiguration.EnsureValid(true); err != nil {
+			return fmt.Errorf("invalid alpha configuration for %s: %w", name, err)
+		}
+		alphaConfiguration = synchronization.MergeConfigurations(defaultConfigurationAlpha, alphaConfiguration)
+		betaConfiguration := session.ConfigurationBeta.ToInternal()
+		if err := betaConfiguration.EnsureValid(true); err != nil {
+			return fmt.Errorf("invalid beta configuration for %s: %w", name, err)
+		}
+		betaConfiguration = synchronization.MergeConfigurations(defaultConfigurationBeta, betaConfiguration)
+
+		// Record the specification.
+		specifications = append(specifications, &synchronizationsvc.CreationSpecification{
+			Alpha:              alphaURL,
+			Beta:               betaURL,
+			Configuration:      configuration,
+			ConfigurationAlpha: alphaConfiguration,
+			ConfigurationBeta:  betaConfiguration,
+			Name:               name,
+			Labels:             session.Labels,
+			Paused:             importConfiguration.paused,
+			Archive:            archive,
+		})
+	}
+
+	// Connect to the daemon and defer closure of the connection.
+	daemonConnection, err := daemon.Connect(true, true)
+	if err != nil {
+		return fmt.Errorf("unable to connect to daemon: %w", err)
+	}
+	defer daemonConnection.Close()
+
+	// Create sessions.
+	for _, specification := range specifications {
+		identifier, err := CreateWithSpecification(daemonConnection, specification)
+		if err != nil {
+			return fmt.Errorf("unable to create session %s: %w", specification.Name, err)
+		}
+		fmt.Printf("Created session %s (%s)\n", identifier, specification.Name)
+	}
+
+	// Success.
+	return nil
+}
+
+// importCommand is the import command.
+var importCommand = &cobra.Command{
+	Use:          "import <specification-file>",
+	Short:        "Create synchronization sessions from an exported YAML specification",
+	RunE:         importMain,
+	SilenceUsage: true,
+}
+
+// importConfiguration stores configuration for the import command.
+var importConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// paused indicates whether or not to create sessions pre-paused.
+	paused bool
+	// archive is the path to an exported ancestor archive to use for the
+	// imported session.
+	archive string
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := importCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&importConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up import flags.
+	flags.BoolVarP(&importConfiguration.paused, "paused", "p", false, "Create the sessions pre-paused")
+	flags.StringVar(&importConfiguration.archive, "archive", "", "Use an exported ancestor archive for the imported session (only safe if both endpoints match the exported session's last synchronized state, and limited to 25 MB)")
+}
@@ -46,10 +46,15 @@ func init() {
 		createCommand,
 		listCommand,
 		monitorCommand,
+		eventsCommand,
+		historyCommand,
 		flushCommand,
 		pauseCommand,
 		resumeCommand,
 		resetCommand,
+		editCommand,
+		exportCommand,
+		importCommand,
 		terminateCommand,
 	)
 }
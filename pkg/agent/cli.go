@@ -11,4 +11,7 @@ const (
 	// FlagLogLevel is the flag for specifying the log level for the forwarder
 	// and synchronizer commands (without the preceding double-dash).
 	FlagLogLevel = "log-level"
+	// FlagLogFormat is the flag for specifying the log output format for the
+	// forwarder and synchronizer commands (without the preceding double-dash).
+	FlagLogFormat = "log-format"
 )
@@ -65,7 +65,11 @@ func connect(logger *logging.Logger, transport Transport, mode, prompter string,
 	}, pathSeparator)
 
 	// Compute the command to invoke.
-	command := fmt.Sprintf("%s %s --%s=%s", agentInvocationPath, mode, FlagLogLevel, logger.Level())
+	command := fmt.Sprintf("%s %s --%s=%s --%s=%s",
+		agentInvocationPath, mode,
+		FlagLogLevel, logger.Level(),
+		FlagLogFormat, logger.Format(),
+	)
 
 	// Set up (but do not start) an agent process.
 	message := "Connecting to agent (POSIX)..."
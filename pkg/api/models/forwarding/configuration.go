@@ -7,31 +7,86 @@ import (
 
 // Configuration represents forwarding session configuration.
 type Configuration struct {
+	// Destinations contains parameters related to destination handling.
+	Destinations struct {
+		// SelectionMode specifies the mode used to select between multiple
+		// destinations.
+		SelectionMode forwarding.DestinationSelectionMode `json:"selectionMode,omitempty" yaml:"selectionMode,omitempty" mapstructure:"selectionMode"`
+	} `json:"destinations" yaml:"destinations,omitempty" mapstructure:"destinations"`
+	// Network contains parameters related to TCP listener access control.
+	Network struct {
+		// Allow specifies the networks (in CIDR notation or as single IP
+		// addresses) from which TCP listeners will accept connections.
+		Allow []string `json:"allow,omitempty" yaml:"allow,omitempty" mapstructure:"allow"`
+		// Deny specifies the networks (in CIDR notation or as single IP
+		// addresses) from which TCP listeners will refuse connections.
+		Deny []string `json:"deny,omitempty" yaml:"deny,omitempty" mapstructure:"deny"`
+	} `json:"network" yaml:"network,omitempty" mapstructure:"network"`
 	// Socket contains parameters related to Unix domain socket handling.
 	Socket struct {
 		// OverwriteMode specifies the default socket overwrite mode to use for
 		// Unix domain socket endpoints.
-		OverwriteMode forwarding.SocketOverwriteMode `json:"overwriteMode,omitempty" yaml:"overwriteMode" mapstructure:"overwriteMode"`
+		OverwriteMode forwarding.SocketOverwriteMode `json:"overwriteMode,omitempty" yaml:"overwriteMode,omitempty" mapstructure:"overwriteMode"`
 		// Owner specifies the owner identifier to use for Unix domain listener
 		// sockets.
-		Owner string `json:"owner,omitempty" yaml:"owner" mapstructure:"owner"`
+		Owner string `json:"owner,omitempty" yaml:"owner,omitempty" mapstructure:"owner"`
 		// Group specifies the group identifier to use for Unix domain listener
 		// sockets.
-		Group string `json:"group,omitempty" yaml:"group" mapstructure:"group"`
+		Group string `json:"group,omitempty" yaml:"group,omitempty" mapstructure:"group"`
 		// PermissionMode specifies the permission mode to use for Unix domain
 		// listener sockets.
-		PermissionMode filesystem.Mode `json:"permissionMode,omitempty" yaml:"permissionMode" mapstructure:"permissionMode"`
-	} `json:"socket" yaml:"socket" mapstructure:"socket"`
+		PermissionMode filesystem.Mode `json:"permissionMode,omitempty" yaml:"permissionMode,omitempty" mapstructure:"permissionMode"`
+		// AllowedUsers specifies the user identifiers whose peer processes are
+		// allowed to connect to Unix domain listener sockets.
+		AllowedUsers []string `json:"allowedUsers,omitempty" yaml:"allowedUsers,omitempty" mapstructure:"allowedUsers"`
+		// AllowedGroups specifies the group identifiers whose peer processes
+		// are allowed to connect to Unix domain listener sockets.
+		AllowedGroups []string `json:"allowedGroups,omitempty" yaml:"allowedGroups,omitempty" mapstructure:"allowedGroups"`
+	} `json:"socket" yaml:"socket,omitempty" mapstructure:"socket"`
+	// TLS contains parameters related to TLS termination and origination. These
+	// may only be specified on an endpoint-specific basis.
+	TLS struct {
+		// Mode specifies whether or not TLS should be terminated (on listener
+		// endpoints) or originated (on dialer endpoints).
+		Mode forwarding.TLSMode `json:"mode,omitempty" yaml:"mode,omitempty" mapstructure:"mode"`
+		// Certificate specifies the path to a PEM-encoded certificate (chain).
+		Certificate string `json:"certificate,omitempty" yaml:"certificate,omitempty" mapstructure:"certificate"`
+		// Key specifies the path to the PEM-encoded private key corresponding
+		// to Certificate.
+		Key string `json:"key,omitempty" yaml:"key,omitempty" mapstructure:"key"`
+		// CertificateAuthority specifies the path to a PEM-encoded certificate
+		// authority bundle used to verify peer certificates.
+		CertificateAuthority string `json:"certificateAuthority,omitempty" yaml:"certificateAuthority,omitempty" mapstructure:"certificateAuthority"`
+		// ServerName specifies the server name to use for server certificate
+		// verification and SNI on dialer endpoints.
+		ServerName string `json:"serverName,omitempty" yaml:"serverName,omitempty" mapstructure:"serverName"`
+	} `json:"tls" yaml:"tls,omitempty" mapstructure:"tls"`
 }
 
 // loadFromInternal sets a configuration to match an internal Protocol Buffers
 // representation. The configuration must be valid.
 func (c *Configuration) loadFromInternal(configuration *forwarding.Configuration) {
+	// Propagate destination configuration.
+	c.Destinations.SelectionMode = configuration.DestinationSelectionMode
+
+	// Propagate network configuration.
+	c.Network.Allow = configuration.AllowedNetworks
+	c.Network.Deny = configuration.DeniedNetworks
+
 	// Propagate socket configuration.
 	c.Socket.OverwriteMode = configuration.SocketOverwriteMode
 	c.Socket.Owner = configuration.SocketOwner
 	c.Socket.Group = configuration.SocketGroup
 	c.Socket.PermissionMode = filesystem.Mode(configuration.SocketPermissionMode)
+	c.Socket.AllowedUsers = configuration.SocketAllowedUsers
+	c.Socket.AllowedGroups = configuration.SocketAllowedGroups
+
+	// Propagate TLS configuration.
+	c.TLS.Mode = configuration.TlsMode
+	c.TLS.Certificate = configuration.TlsCertificate
+	c.TLS.Key = configuration.TlsKey
+	c.TLS.CertificateAuthority = configuration.TlsCertificateAuthority
+	c.TLS.ServerName = configuration.TlsServerName
 }
 
 // ToInternal converts a public configuration representation to an internal
@@ -39,9 +94,28 @@ func (c *Configuration) loadFromInternal(configuration *forwarding.Configuration
 // configuration.
 func (c *Configuration) ToInternal() *forwarding.Configuration {
 	return &forwarding.Configuration{
-		SocketOverwriteMode:  c.Socket.OverwriteMode,
-		SocketOwner:          c.Socket.Owner,
-		SocketGroup:          c.Socket.Group,
-		SocketPermissionMode: uint32(c.Socket.PermissionMode),
+		DestinationSelectionMode: c.Destinations.SelectionMode,
+		AllowedNetworks:          c.Network.Allow,
+		DeniedNetworks:           c.Network.Deny,
+		SocketOverwriteMode:      c.Socket.OverwriteMode,
+		SocketOwner:              c.Socket.Owner,
+		SocketGroup:              c.Socket.Group,
+		SocketPermissionMode:     uint32(c.Socket.PermissionMode),
+		SocketAllowedUsers:       c.Socket.AllowedUsers,
+		SocketAllowedGroups:      c.Socket.AllowedGroups,
+		TlsMode:                  c.TLS.Mode,
+		TlsCertificate:           c.TLS.Certificate,
+		TlsKey:                   c.TLS.Key,
+		TlsCertificateAuthority:  c.TLS.CertificateAuthority,
+		TlsServerName:            c.TLS.ServerName,
 	}
 }
+
+// ExportConfiguration converts an internal Protocol Buffers session
+// configuration to a public configuration representation. The configuration
+// must be valid.
+func ExportConfiguration(configuration *forwarding.Configuration) *Configuration {
+	result := &Configuration{}
+	result.loadFromInternal(configuration)
+	return result
+}
@@ -4,27 +4,58 @@ import (
 	"os"
 	"testing"
 
+	"google.golang.org/protobuf/proto"
+
+	"github.com/mutagen-io/mutagen/pkg/comparison"
 	"github.com/mutagen-io/mutagen/pkg/encoding"
 	"github.com/mutagen-io/mutagen/pkg/forwarding"
 )
 
 const (
 	testYAMLConfiguration = `
+destinations:
+  selectionMode: "round-robin"
+network:
+  allow:
+    - "10.0.0.0/8"
+    - "127.0.0.1"
+  deny:
+    - "10.0.0.13"
 socket:
   overwriteMode: "overwrite"
   owner: "george"
   group: "presidents"
   permissionMode: 0600
+  allowedUsers:
+    - "george"
+  allowedGroups:
+    - "id:1789"
+tls:
+  mode: "enabled"
+  certificate: "/etc/mutagen/cert.pem"
+  key: "/etc/mutagen/key.pem"
+  certificateAuthority: "/etc/mutagen/ca.pem"
+  serverName: "example.com"
 `
 )
 
 // expectedConfiguration is the configuration that's expected based on the
 // human-readable configuration given above.
 var expectedConfiguration = &forwarding.Configuration{
-	SocketOverwriteMode:  forwarding.SocketOverwriteMode_SocketOverwriteModeOverwrite,
-	SocketOwner:          "george",
-	SocketGroup:          "presidents",
-	SocketPermissionMode: 0600,
+	DestinationSelectionMode: forwarding.DestinationSelectionMode_DestinationSelectionModeRoundRobin,
+	AllowedNetworks:          []string{"10.0.0.0/8", "127.0.0.1"},
+	DeniedNetworks:           []string{"10.0.0.13"},
+	SocketOverwriteMode:      forwarding.SocketOverwriteMode_SocketOverwriteModeOverwrite,
+	SocketOwner:              "george",
+	SocketGroup:              "presidents",
+	SocketPermissionMode:     0600,
+	SocketAllowedUsers:       []string{"george"},
+	SocketAllowedGroups:      []string{"id:1789"},
+	TlsMode:                  forwarding.TLSMode_TLSModeEnabled,
+	TlsCertificate:           "/etc/mutagen/cert.pem",
+	TlsKey:                   "/etc/mutagen/key.pem",
+	TlsCertificateAuthority:  "/etc/mutagen/ca.pem",
+	TlsServerName:            "example.com",
 }
 
 // TestLoadConfiguration tests loading a YAML-based session configuration.
@@ -49,12 +80,35 @@ func TestLoadConfiguration(t *testing.T) {
 	// Compute the Protocol Buffers session representation.
 	configuration := yamlConfiguration.ToInternal()
 
-	// Ensure that the resulting configuration is valid.
-	if err := configuration.EnsureValid(false); err != nil {
-		t.Error("derived configuration invalid:", err)
+	// Ensure that the resulting configuration is valid. The destination
+	// selection mode is only valid at the session level and TLS settings are
+	// only valid on an endpoint-specific basis, so we validate each case with
+	// the other settings cleared.
+	sessionConfiguration := proto.Clone(configuration).(*forwarding.Configuration)
+	sessionConfiguration.TlsMode = forwarding.TLSMode_TLSModeDefault
+	sessionConfiguration.TlsCertificate = ""
+	sessionConfiguration.TlsKey = ""
+	sessionConfiguration.TlsCertificateAuthority = ""
+	sessionConfiguration.TlsServerName = ""
+	if err := sessionConfiguration.EnsureValid(false); err != nil {
+		t.Error("derived session configuration invalid:", err)
+	}
+	endpointConfiguration := proto.Clone(configuration).(*forwarding.Configuration)
+	endpointConfiguration.DestinationSelectionMode = forwarding.DestinationSelectionMode_DestinationSelectionModeDefault
+	if err := endpointConfiguration.EnsureValid(true); err != nil {
+		t.Error("derived endpoint configuration invalid:", err)
 	}
 
 	// Verify that the configuration matches what's expected.
+	if configuration.DestinationSelectionMode != expectedConfiguration.DestinationSelectionMode {
+		t.Error("destination selection mode mismatch:", configuration.DestinationSelectionMode, "!=", expectedConfiguration.DestinationSelectionMode)
+	}
+	if !comparison.StringSlicesEqual(configuration.AllowedNetworks, expectedConfiguration.AllowedNetworks) {
+		t.Error("allowed networks mismatch:", configuration.AllowedNetworks, "!=", expectedConfiguration.AllowedNetworks)
+	}
+	if !comparison.StringSlicesEqual(configuration.DeniedNetworks, expectedConfiguration.DeniedNetworks) {
+		t.Error("denied networks mismatch:", configuration.DeniedNetworks, "!=", expectedConfiguration.DeniedNetworks)
+	}
 	if configuration.SocketOverwriteMode != expectedConfiguration.SocketOverwriteMode {
 		t.Error("socket overwrite mode mismatch:", configuration.SocketOverwriteMode, "!=", expectedConfiguration.SocketOverwriteMode)
 	}
@@ -67,6 +121,27 @@ func TestLoadConfiguration(t *testing.T) {
 	if configuration.SocketPermissionMode != expectedConfiguration.SocketPermissionMode {
 		t.Errorf("socket permission mode mismatch: %o != %o", configuration.SocketPermissionMode, expectedConfiguration.SocketPermissionMode)
 	}
+	if !comparison.StringSlicesEqual(configuration.SocketAllowedUsers, expectedConfiguration.SocketAllowedUsers) {
+		t.Error("allowed socket users mismatch:", configuration.SocketAllowedUsers, "!=", expectedConfiguration.SocketAllowedUsers)
+	}
+	if !comparison.StringSlicesEqual(configuration.SocketAllowedGroups, expectedConfiguration.SocketAllowedGroups) {
+		t.Error("allowed socket groups mismatch:", configuration.SocketAllowedGroups, "!=", expectedConfiguration.SocketAllowedGroups)
+	}
+	if configuration.TlsMode != expectedConfiguration.TlsMode {
+		t.Error("TLS mode mismatch:", configuration.TlsMode, "!=", expectedConfiguration.TlsMode)
+	}
+	if configuration.TlsCertificate != expectedConfiguration.TlsCertificate {
+		t.Error("TLS certificate mismatch:", configuration.TlsCertificate, "!=", expectedConfiguration.TlsCertificate)
+	}
+	if configuration.TlsKey != expectedConfiguration.TlsKey {
+		t.Error("TLS key mismatch:", configuration.TlsKey, "!=", expectedConfiguration.TlsKey)
+	}
+	if configuration.TlsCertificateAuthority != expectedConfiguration.TlsCertificateAuthority {
+		t.Error("TLS certificate authority mismatch:", configuration.TlsCertificateAuthority, "!=", expectedConfiguration.TlsCertificateAuthority)
+	}
+	if configuration.TlsServerName != expectedConfiguration.TlsServerName {
+		t.Error("TLS server name mismatch:", configuration.TlsServerName, "!=", expectedConfiguration.TlsServerName)
+	}
 }
 
 // TODO: Expand tests, including testing for invalid configurations.
@@ -32,7 +32,14 @@ type Endpoint struct {
 }
 
 // EndpointState encodes the current state of a forwarding endpoint.
-type EndpointState struct{}
+type EndpointState struct {
+	// ListenerAddresses are the addresses on which the endpoint is listening.
+	// They are only populated for listener (source) endpoints.
+	ListenerAddresses []string `json:"listenerAddresses,omitempty"`
+	// Unhealthy indicates that the endpoint has recently failed to open a
+	// connection. It is only relevant for destination endpoints.
+	Unhealthy bool `json:"unhealthy,omitempty"`
+}
 
 // loadFromInternal sets an Endpoint to match internal Protocol Buffers
 // representations. All parameters must be valid.
@@ -56,6 +63,9 @@ func (e *Endpoint) loadFromInternal(url *url.URL, configuration *forwarding.Conf
 	if !e.Connected {
 		e.EndpointState = nil
 	} else {
-		e.EndpointState = &EndpointState{}
+		e.EndpointState = &EndpointState{
+			ListenerAddresses: state.ListenerAddresses,
+			Unhealthy:         state.Unhealthy,
+		}
 	}
 }
@@ -0,0 +1,50 @@
+package forwarding
+
+import (
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+)
+
+// Event represents a forwarding session event.
+type Event struct {
+	// Kind is the event kind.
+	Kind forwarding.EventKind `json:"kind"`
+	// Time is the event timestamp.
+	Time string `json:"time"`
+	// Session is the identifier of the session associated with the event.
+	Session string `json:"session"`
+	// SessionName is the name of the session associated with the event.
+	SessionName string `json:"sessionName,omitempty"`
+	// PreviousStatus is the previous session status. It is only set for status
+	// change events.
+	PreviousStatus *forwarding.Status `json:"previousStatus,omitempty"`
+	// Status is the session status at the time of the event.
+	Status forwarding.Status `json:"status"`
+	// Error is the error message for error events.
+	Error string `json:"error,omitempty"`
+}
+
+// ExportEvent converts an internal event representation to a public event
+// representation. The event must be valid.
+func ExportEvent(event *forwarding.Event) *Event {
+	// Propagate basic information.
+	result := &Event{
+		Kind:        event.Kind,
+		Time:        event.Time.AsTime().Format(time.RFC3339Nano),
+		Session:     event.Session,
+		SessionName: event.SessionName,
+		Status:      event.Status,
+		Error:       event.Error,
+	}
+
+	// Only propagate the previous status for status change events, since its
+	// zero value is meaningful.
+	if event.Kind == forwarding.EventKind_EventKindStatusChanged {
+		previousStatus := event.PreviousStatus
+		result.PreviousStatus = &previousStatus
+	}
+
+	// Done.
+	return result
+}
@@ -0,0 +1,57 @@
+package forwarding
+
+import (
+	"encoding/json"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+)
+
+// TestExportEvent tests ExportEvent.
+func TestExportEvent(t *testing.T) {
+	// Export a status change event and verify that the previous status is
+	// included even though it has a zero value.
+	event := ExportEvent(&forwarding.Event{
+		Kind:           forwarding.EventKind_EventKindStatusChanged,
+		Time:           timestamppb.Now(),
+		Session:        "fwrd_test",
+		PreviousStatus: forwarding.Status_Disconnected,
+		Status:         forwarding.Status_ConnectingSource,
+	})
+	if event.PreviousStatus == nil || *event.PreviousStatus != forwarding.Status_Disconnected {
+		t.Error("previous status not exported for status change event")
+	}
+
+	// Verify JSON encoding.
+	encoded, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal("unable to encode event:", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal("unable to decode event:", err)
+	}
+	if decoded["kind"] != "status-changed" {
+		t.Error("unexpected encoded event kind:", decoded["kind"])
+	}
+	if decoded["previousStatus"] != "disconnected" {
+		t.Error("unexpected encoded previous status:", decoded["previousStatus"])
+	}
+	if decoded["status"] != "connecting-source" {
+		t.Error("unexpected encoded status:", decoded["status"])
+	}
+
+	// Export an error event and verify that the previous status is omitted.
+	event = ExportEvent(&forwarding.Event{
+		Kind:    forwarding.EventKind_EventKindError,
+		Time:    timestamppb.Now(),
+		Session: "fwrd_test",
+		Status:  forwarding.Status_Disconnected,
+		Error:   "failure",
+	})
+	if event.PreviousStatus != nil {
+		t.Error("previous status exported for error event")
+	}
+}
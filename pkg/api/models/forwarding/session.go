@@ -21,6 +21,9 @@ type Session struct {
 	Source Endpoint `json:"source"`
 	// Destination stores the destination endpoint's configuration and state.
 	Destination Endpoint `json:"destination"`
+	// AdditionalDestinations stores the configuration and state of any
+	// additional destination endpoints.
+	AdditionalDestinations []Endpoint `json:"additionalDestinations,omitempty"`
 	// Configuration is the session configuration.
 	Configuration
 	// Name is the session name.
@@ -52,6 +55,10 @@ type SessionState struct {
 	// TotalInboundData is the total amount of data (in bytes) that has been
 	// transmitted from destination to source across all forwarded connections.
 	TotalInboundData uint64 `json:"totalInboundData"`
+	// RefusedConnections is the number of incoming connections that have been
+	// refused due to access control restrictions. It is only tracked for local
+	// source endpoints, because remote listeners don't report refusals.
+	RefusedConnections uint64 `json:"refusedConnections"`
 }
 
 // loadFromInternal sets a session to match an internal Protocol Buffers session
@@ -82,6 +89,18 @@ func (s *Session) loadFromInternal(state *forwarding.State) {
 		state.Session.ConfigurationDestination,
 		state.DestinationState,
 	)
+	if count := len(state.Session.AdditionalDestinations); count > 0 {
+		s.AdditionalDestinations = make([]Endpoint, count)
+		for i, destination := range state.Session.AdditionalDestinations {
+			s.AdditionalDestinations[i].loadFromInternal(
+				destination,
+				state.Session.ConfigurationDestination,
+				state.AdditionalDestinationStates[i],
+			)
+		}
+	} else {
+		s.AdditionalDestinations = nil
+	}
 
 	// Propagate configuration information.
 	s.Configuration.loadFromInternal(state.Session.Configuration)
@@ -91,11 +110,12 @@ func (s *Session) loadFromInternal(state *forwarding.State) {
 		s.SessionState = nil
 	} else {
 		s.SessionState = &SessionState{
-			LastError:         state.LastError,
-			OpenConnections:   state.OpenConnections,
-			TotalConnections:  state.TotalConnections,
-			TotalOutboundData: state.TotalOutboundData,
-			TotalInboundData:  state.TotalInboundData,
+			LastError:          state.LastError,
+			OpenConnections:    state.OpenConnections,
+			TotalConnections:   state.TotalConnections,
+			TotalOutboundData:  state.TotalOutboundData,
+			TotalInboundData:   state.TotalInboundData,
+			RefusedConnections: state.RefusedConnections,
 		}
 	}
 }
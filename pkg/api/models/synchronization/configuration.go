@@ -13,67 +13,67 @@ import (
 // Configuration represents synchronization session configuration.
 type Configuration struct {
 	// Mode specifies the default synchronization mode.
-	Mode core.SynchronizationMode `json:"mode,omitempty" yaml:"mode" mapstructure:"mode"`
+	Mode core.SynchronizationMode `json:"mode,omitempty" yaml:"mode,omitempty" mapstructure:"mode"`
 	// HashingAlgorithm specifies the hashing algorithm to use for content.
-	HashingAlgorithm hashing.Algorithm `json:"hashingAlgorithm,omitempty" yaml:"hashingAlgorithm" mapstructure:"hashingAlgorithm"`
+	HashingAlgorithm hashing.Algorithm `json:"hashingAlgorithm,omitempty" yaml:"hashingAlgorithm,omitempty" mapstructure:"hashingAlgorithm"`
 	// MaximumEntryCount specifies the maximum number of filesystem entries
 	// that endpoints will tolerate managing.
-	MaximumEntryCount uint64 `json:"maxEntryCount,omitempty" yaml:"maxEntryCount" mapstructure:"maxEntryCount"`
+	MaximumEntryCount uint64 `json:"maxEntryCount,omitempty" yaml:"maxEntryCount,omitempty" mapstructure:"maxEntryCount"`
 	// MaximumStagingFileSize is the maximum (individual) file size that
 	// endpoints will stage. It can be specified in human-friendly units.
-	MaximumStagingFileSize types.ByteSize `json:"maxStagingFileSize,omitempty" yaml:"maxStagingFileSize" mapstructure:"maxStagingFileSize"`
+	MaximumStagingFileSize types.ByteSize `json:"maxStagingFileSize,omitempty" yaml:"maxStagingFileSize,omitempty" mapstructure:"maxStagingFileSize"`
 	// ProbeMode specifies the filesystem probing mode.
-	ProbeMode behavior.ProbeMode `json:"probeMode,omitempty" yaml:"probeMode" mapstructure:"probeMode"`
+	ProbeMode behavior.ProbeMode `json:"probeMode,omitempty" yaml:"probeMode,omitempty" mapstructure:"probeMode"`
 	// ScanMode specifies the filesystem scanning mode.
-	ScanMode synchronization.ScanMode `json:"scanMode,omitempty" yaml:"scanMode" mapstructure:"scanMode"`
+	ScanMode synchronization.ScanMode `json:"scanMode,omitempty" yaml:"scanMode,omitempty" mapstructure:"scanMode"`
 	// StageMode specifies the filesystem staging mode.
-	StageMode synchronization.StageMode `json:"stageMode,omitempty" yaml:"stageMode" mapstructure:"stageMode"`
+	StageMode synchronization.StageMode `json:"stageMode,omitempty" yaml:"stageMode,omitempty" mapstructure:"stageMode"`
 	// Ignore contains parameters related to synchronization ignore
 	// specifications.
 	Ignore struct {
 		// Paths specifies the default list of ignore specifications.
-		Paths []string `json:"paths,omitempty" yaml:"paths" mapstructure:"paths"`
+		Paths []string `json:"paths,omitempty" yaml:"paths,omitempty" mapstructure:"paths"`
 		// VCS specifies the VCS ignore mode.
-		VCS core.IgnoreVCSMode `json:"vcs,omitempty" yaml:"vcs" mapstructure:"vcs"`
-	} `json:"ignore" yaml:"ignore" mapstructure:"ignore"`
+		VCS core.IgnoreVCSMode `json:"vcs,omitempty" yaml:"vcs,omitempty" mapstructure:"vcs"`
+	} `json:"ignore" yaml:"ignore,omitempty" mapstructure:"ignore"`
 	// Symlink contains parameters related to symbolic link handling.
 	Symlink struct {
 		// Mode specifies the symbolic link mode.
-		Mode core.SymbolicLinkMode `json:"mode,omitempty" yaml:"mode" mapstructure:"mode"`
-	} `json:"symlink" yaml:"symlink" mapstructure:"symlink"`
+		Mode core.SymbolicLinkMode `json:"mode,omitempty" yaml:"mode,omitempty" mapstructure:"mode"`
+	} `json:"symlink" yaml:"symlink,omitempty" mapstructure:"symlink"`
 	// Watch contains parameters related to filesystem monitoring.
 	Watch struct {
 		// Mode specifies the file watching mode.
-		Mode synchronization.WatchMode `json:"mode,omitempty" yaml:"mode" mapstructure:"mode"`
+		Mode synchronization.WatchMode `json:"mode,omitempty" yaml:"mode,omitempty" mapstructure:"mode"`
 		// PollingInterval specifies the interval (in seconds) for poll-based
 		// file monitoring. A value of 0 specifies that Mutagen's internal
 		// default interval should be used.
-		PollingInterval uint32 `json:"pollingInterval,omitempty" yaml:"pollingInterval" mapstructure:"pollingInterval"`
-	} `json:"watch" yaml:"watch" mapstructure:"watch"`
+		PollingInterval uint32 `json:"pollingInterval,omitempty" yaml:"pollingInterval,omitempty" mapstructure:"pollingInterval"`
+	} `json:"watch" yaml:"watch,omitempty" mapstructure:"watch"`
 	// Permissions contains parameters related to permission handling.
 	Permissions struct {
 		// Mode specifies the permissions mode.
-		Mode core.PermissionsMode `json:"mode,omitempty" yaml:"mode" mapstructure:"mode"`
+		Mode core.PermissionsMode `json:"mode,omitempty" yaml:"mode,omitempty" mapstructure:"mode"`
 		// DefaultFileMode specifies the default permission mode to use for new
 		// files in "portable" permission propagation mode.
-		DefaultFileMode filesystem.Mode `json:"defaultFileMode,omitempty" yaml:"defaultFileMode" mapstructure:"defaultFileMode"`
+		DefaultFileMode filesystem.Mode `json:"defaultFileMode,omitempty" yaml:"defaultFileMode,omitempty" mapstructure:"defaultFileMode"`
 		// DefaultDirectoryMode specifies the default permission mode to use for
 		// new files in "portable" permission propagation mode.
-		DefaultDirectoryMode filesystem.Mode `json:"defaultDirectoryMode,omitempty" yaml:"defaultDirectoryMode" mapstructure:"defaultDirectoryMode"`
+		DefaultDirectoryMode filesystem.Mode `json:"defaultDirectoryMode,omitempty" yaml:"defaultDirectoryMode,omitempty" mapstructure:"defaultDirectoryMode"`
 		// DefaultOwner specifies the default owner identifier to use when
 		// setting ownership of new files and directories in "portable"
 		// permission propagation mode.
-		DefaultOwner string `json:"defaultOwner,omitempty" yaml:"defaultOwner" mapstructure:"defaultOwner"`
+		DefaultOwner string `json:"defaultOwner,omitempty" yaml:"defaultOwner,omitempty" mapstructure:"defaultOwner"`
 		// DefaultGroup specifies the default group identifier to use when
 		// setting ownership of new files and directories in "portable"
 		// permission propagation mode.
-		DefaultGroup string `json:"defaultGroup,omitempty" yaml:"defaultGroup" mapstructure:"defaultGroup"`
-	} `json:"permissions" yaml:"permissions" mapstructure:"permissions"`
+		DefaultGroup string `json:"defaultGroup,omitempty" yaml:"defaultGroup,omitempty" mapstructure:"defaultGroup"`
+	} `json:"permissions" yaml:"permissions,omitempty" mapstructure:"permissions"`
 	// Compression contains parameters related to compression.
 	Compression struct {
 		// Algorithm specifies the compression algorithm.
-		Algorithm compression.Algorithm `json:"algorithm,omitempty" yaml:"algorithm" mapstructure:"algorithm"`
-	} `json:"compression" yaml:"compression" mapstructure:"compression"`
+		Algorithm compression.Algorithm `json:"algorithm,omitempty" yaml:"algorithm,omitempty" mapstructure:"algorithm"`
+	} `json:"compression" yaml:"compression,omitempty" mapstructure:"compression"`
 }
 
 // loadFromInternal sets a configuration to match an internal
@@ -137,3 +137,12 @@ func (c *Configuration) ToInternal() *synchronization.Configuration {
 		CompressionAlgorithm:   c.Compression.Algorithm,
 	}
 }
+
+// ExportConfiguration converts an internal Protocol Buffers session
+// configuration to a public configuration representation. The configuration
+// must be valid.
+func ExportConfiguration(configuration *synchronization.Configuration) *Configuration {
+	result := &Configuration{}
+	result.loadFromInternal(configuration)
+	return result
+}
@@ -0,0 +1,65 @@
+package synchronization
+
+import (
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+)
+
+// Event represents a synchronization session event.
+type Event struct {
+	// Kind is the event kind.
+	Kind synchronization.EventKind `json:"kind"`
+	// Time is the event timestamp.
+	Time string `json:"time"`
+	// Session is the identifier of the session associated with the event.
+	Session string `json:"session"`
+	// SessionName is the name of the session associated with the event.
+	SessionName string `json:"sessionName,omitempty"`
+	// PreviousStatus is the previous session status. It is only set for status
+	// change events.
+	PreviousStatus *synchronization.Status `json:"previousStatus,omitempty"`
+	// Status is the session status at the time of the event.
+	Status synchronization.Status `json:"status"`
+	// AlphaChanges is the number of changes applied to alpha during a cycle.
+	AlphaChanges uint64 `json:"alphaChanges,omitempty"`
+	// BetaChanges is the number of changes applied to beta during a cycle.
+	BetaChanges uint64 `json:"betaChanges,omitempty"`
+	// Conflicts is the number of conflicts at the time of the event.
+	Conflicts uint64 `json:"conflicts,omitempty"`
+	// AlphaProblems is the number of alpha scan and transition problems.
+	AlphaProblems uint64 `json:"alphaProblems,omitempty"`
+	// BetaProblems is the number of beta scan and transition problems.
+	BetaProblems uint64 `json:"betaProblems,omitempty"`
+	// Error is the error message for error events.
+	Error string `json:"error,omitempty"`
+}
+
+// ExportEvent converts an internal event representation to a public event
+// representation. The event must be valid.
+func ExportEvent(event *synchronization.Event) *Event {
+	// Propagate basic information.
+	result := &Event{
+		Kind:          event.Kind,
+		Time:          event.Time.AsTime().Format(time.RFC3339Nano),
+		Session:       event.Session,
+		SessionName:   event.SessionName,
+		Status:        event.Status,
+		AlphaChanges:  event.AlphaChanges,
+		BetaChanges:   event.BetaChanges,
+		Conflicts:     event.Conflicts,
+		AlphaProblems: event.AlphaProblems,
+		BetaProblems:  event.BetaProblems,
+		Error:         event.Error,
+	}
+
+	// Only propagate the previous status for status change events, since its
+	// zero value is meaningful.
+	if event.Kind == synchronization.EventKind_EventKindStatusChanged {
+		previousStatus := event.PreviousStatus
+		result.PreviousStatus = &previousStatus
+	}
+
+	// Done.
+	return result
+}
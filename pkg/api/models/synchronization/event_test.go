@@ -0,0 +1,58 @@
+package synchronization
+
+import (
+	"encoding/json"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+)
+
+// TestExportEvent tests ExportEvent.
+func TestExportEvent(t *testing.T) {
+	// Export a status change event and verify that the previous status is
+	// included even though it has a zero value.
+	event := ExportEvent(&synchronization.Event{
+		Kind:           synchronization.EventKind_EventKindStatusChanged,
+		Time:           timestamppb.Now(),
+		Session:        "sync_test",
+		PreviousStatus: synchronization.Status_Disconnected,
+		Status:         synchronization.Status_ConnectingAlpha,
+	})
+	if event.PreviousStatus == nil || *event.PreviousStatus != synchronization.Status_Disconnected {
+		t.Error("previous status not exported for status change event")
+	}
+
+	// Verify JSON encoding.
+	encoded, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal("unable to encode event:", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal("unable to decode event:", err)
+	}
+	if decoded["kind"] != "status-changed" {
+		t.Error("unexpected encoded event kind:", decoded["kind"])
+	}
+	if decoded["previousStatus"] != "disconnected" {
+		t.Error("unexpected encoded previous status:", decoded["previousStatus"])
+	}
+	if decoded["status"] != "connecting-alpha" {
+		t.Error("unexpected encoded status:", decoded["status"])
+	}
+
+	// Export a cycle completion event and verify that the previous status is
+	// omitted.
+	event = ExportEvent(&synchronization.Event{
+		Kind:         synchronization.EventKind_EventKindCycleCompleted,
+		Time:         timestamppb.Now(),
+		Session:      "sync_test",
+		Status:       synchronization.Status_Watching,
+		AlphaChanges: 2,
+	})
+	if event.PreviousStatus != nil {
+		t.Error("previous status exported for cycle completion event")
+	}
+}
@@ -0,0 +1,143 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc"
+
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/prompting"
+	daemonsvc "github.com/mutagen-io/mutagen/pkg/service/daemon"
+	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
+	promptingsvc "github.com/mutagen-io/mutagen/pkg/service/prompting"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+)
+
+// discardPrompter is a prompting.Prompter that discards messages and rejects
+// prompts. It is used when callers don't provide a prompter.
+type discardPrompter struct{}
+
+// Message implements prompting.Prompter.Message.
+func (discardPrompter) Message(_ string) error {
+	return nil
+}
+
+// Prompt implements prompting.Prompter.Prompt.
+func (discardPrompter) Prompt(_ string) (string, error) {
+	return "", errors.New("prompting not supported")
+}
+
+// Client is a Mutagen daemon client. It is safe for concurrent usage.
+type Client struct {
+	// connection is the underlying daemon connection.
+	connection *grpc.ClientConn
+	// daemon is the daemon service client.
+	daemon daemonsvc.DaemonClient
+	// prompting is the prompting service client.
+	prompting promptingsvc.PromptingClient
+	// synchronization is the synchronization session client.
+	synchronization *SynchronizationClient
+	// forwarding is the forwarding session client.
+	forwarding *ForwardingClient
+}
+
+// New connects to the daemon using the specified configuration and creates a
+// new client. A nil configuration is treated as the zero value.
+func New(configuration *Configuration) (*Client, error) {
+	connection, err := Dial(configuration)
+	if err != nil {
+		return nil, err
+	}
+	return NewWithConnection(connection), nil
+}
+
+// NewWithConnection creates a new client using an existing daemon connection.
+// The client takes ownership of the connection and will close it when the
+// client is closed.
+func NewWithConnection(connection *grpc.ClientConn) *Client {
+	client := &Client{
+		connection: connection,
+		daemon:     daemonsvc.NewDaemonClient(connection),
+		prompting:  promptingsvc.NewPromptingClient(connection),
+	}
+	client.synchronization = &SynchronizationClient{
+		client:  client,
+		service: synchronizationsvc.NewSynchronizationClient(connection),
+	}
+	client.forwarding = &ForwardingClient{
+		client:  client,
+		service: forwardingsvc.NewForwardingClient(connection),
+	}
+	return client
+}
+
+// Connection returns the underlying daemon connection.
+func (c *Client) Connection() *grpc.ClientConn {
+	return c.connection
+}
+
+// Synchronization returns a client for managing synchronization sessions.
+func (c *Client) Synchronization() *SynchronizationClient {
+	return c.synchronization
+}
+
+// Forwarding returns a client for managing forwarding sessions.
+func (c *Client) Forwarding() *ForwardingClient {
+	return c.forwarding
+}
+
+// Version returns the daemon's version information.
+func (c *Client) Version(ctx context.Context) (*daemonsvc.VersionResponse, error) {
+	response, err := c.daemon.Version(ctx, &daemonsvc.VersionRequest{})
+	if err != nil {
+		return nil, grpcutil.PeelAwayRPCErrorLayer(err)
+	}
+	return response, nil
+}
+
+// TerminateDaemon requests that the daemon terminate. The client should be
+// closed afterward, since the connection will no longer be usable.
+func (c *Client) TerminateDaemon(ctx context.Context) error {
+	if _, err := c.daemon.Terminate(ctx, &daemonsvc.TerminateRequest{}); err != nil {
+		return grpcutil.PeelAwayRPCErrorLayer(err)
+	}
+	return nil
+}
+
+// Close closes the client's daemon connection.
+func (c *Client) Close() error {
+	return c.connection.Close()
+}
+
+// withPrompter hosts the specified prompter with the daemon for the duration
+// of an operation, passing the prompter identifier to the operation. If
+// prompter is nil, then messages are discarded and prompting is disallowed.
+func (c *Client) withPrompter(
+	ctx context.Context,
+	prompter prompting.Prompter, allowPrompts bool,
+	operation func(string) error,
+) error {
+	// Handle the absence of a prompter.
+	if prompter == nil {
+		prompter = discardPrompter{}
+		allowPrompts = false
+	}
+
+	// Start hosting.
+	promptingCtx, promptingCancel := context.WithCancel(ctx)
+	identifier, promptingErrors, err := promptingsvc.Host(
+		promptingCtx, c.prompting, prompter, allowPrompts,
+	)
+	if err != nil {
+		promptingCancel()
+		return fmt.Errorf("unable to initiate prompting: %w", err)
+	}
+
+	// Perform the operation and terminate hosting.
+	err = operation(identifier)
+	promptingCancel()
+	<-promptingErrors
+	return err
+}
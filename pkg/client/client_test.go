@@ -0,0 +1,332 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	_ "github.com/mutagen-io/mutagen/pkg/forwarding/protocols/local"
+	_ "github.com/mutagen-io/mutagen/pkg/synchronization/protocols/local"
+
+	"github.com/mutagen-io/mutagen/pkg/daemon"
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/ipc"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	daemonsvc "github.com/mutagen-io/mutagen/pkg/service/daemon"
+	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
+	promptingsvc "github.com/mutagen-io/mutagen/pkg/service/prompting"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// runDaemon runs an in-process daemon within a temporary data directory until
+// the returned function is invoked.
+func runDaemon() (func(), error) {
+	// Create and use a temporary data directory.
+	dataDirectory, err := os.MkdirTemp("", "mutagen_client")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create data directory: %w", err)
+	}
+	os.Setenv("MUTAGEN_DATA_DIRECTORY", dataDirectory)
+
+	// Create session managers.
+	forwardingManager, err := forwarding.NewManager(nil)
+	if err != nil {
+		os.RemoveAll(dataDirectory)
+		return nil, fmt.Errorf("unable to create forwarding session manager: %w", err)
+	}
+	synchronizationManager, err := synchronization.NewManager(nil)
+	if err != nil {
+		forwardingManager.Shutdown()
+		os.RemoveAll(dataDirectory)
+		return nil, fmt.Errorf("unable to create synchronization session manager: %w", err)
+	}
+
+	// Create the gRPC server and register services.
+	server := grpc.NewServer(
+		grpc.MaxSendMsgSize(grpcutil.MaximumMessageSize),
+		grpc.MaxRecvMsgSize(grpcutil.MaximumMessageSize),
+	)
+	daemonServer := daemonsvc.NewServer()
+	daemonsvc.RegisterDaemonServer(server, daemonServer)
+	promptingsvc.RegisterPromptingServer(server, promptingsvc.NewServer())
+	forwardingsvc.RegisterForwardingServer(server, forwardingsvc.NewServer(forwardingManager))
+	synchronizationsvc.RegisterSynchronizationServer(server, synchronizationsvc.NewServer(synchronizationManager))
+
+	// Create the daemon listener and start serving.
+	endpoint, err := daemon.EndpointPath()
+	if err != nil {
+		synchronizationManager.Shutdown()
+		forwardingManager.Shutdown()
+		os.RemoveAll(dataDirectory)
+		return nil, fmt.Errorf("unable to compute endpoint path: %w", err)
+	}
+	listener, err := ipc.NewListener(endpoint)
+	if err != nil {
+		synchronizationManager.Shutdown()
+		forwardingManager.Shutdown()
+		os.RemoveAll(dataDirectory)
+		return nil, fmt.Errorf("unable to create daemon listener: %w", err)
+	}
+	go server.Serve(listener)
+
+	// Create the shutdown function.
+	return func() {
+		server.Stop()
+		daemonServer.Shutdown()
+		synchronizationManager.Shutdown()
+		forwardingManager.Shutdown()
+		os.RemoveAll(dataDirectory)
+	}, nil
+}
+
+// TestMain runs tests against an in-process daemon.
+func TestMain(m *testing.M) {
+	// Start the daemon.
+	shutdown, err := runDaemon()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "unable to start daemon:", err)
+		os.Exit(1)
+	}
+
+	// Run tests.
+	result := m.Run()
+
+	// Shut down the daemon and exit.
+	shutdown()
+	os.Exit(result)
+}
+
+// errWatchDone is used to terminate watching in tests.
+var errWatchDone = errors.New("watch done")
+
+// recordingPrompter is a prompter that records messages.
+type recordingPrompter struct {
+	// lock serializes access to messages.
+	lock sync.Mutex
+	// messages are the recorded messages.
+	messages []string
+}
+
+// Message implements prompting.Prompter.Message.
+func (p *recordingPrompter) Message(message string) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.messages = append(p.messages, message)
+	return nil
+}
+
+// Prompt implements prompting.Prompter.Prompt.
+func (p *recordingPrompter) Prompt(_ string) (string, error) {
+	return "", nil
+}
+
+// count returns the number of recorded messages.
+func (p *recordingPrompter) count() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return len(p.messages)
+}
+
+// TestDialNoDaemon tests that dialing fails without autostart when no daemon is
+// listening.
+func TestDialNoDaemon(t *testing.T) {
+	// Point the client at an empty data directory.
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+
+	// Ensure that dialing fails.
+	if _, err := New(nil); err == nil {
+		t.Fatal("connection succeeded without daemon")
+	}
+}
+
+// TestDialRemoteInvalidCredentials tests that dialing a remote daemon fails
+// without valid credentials.
+func TestDialRemoteInvalidCredentials(t *testing.T) {
+	if _, err := New(&Configuration{Address: "127.0.0.1:1"}); err == nil {
+		t.Fatal("connection succeeded without credentials")
+	}
+}
+
+// TestSynchronizationLifecycle tests synchronization session management.
+func TestSynchronizationLifecycle(t *testing.T) {
+	// Create a client.
+	client, err := New(nil)
+	if err != nil {
+		t.Fatal("unable to create client:", err)
+	}
+	defer client.Close()
+	sessions := client.Synchronization()
+
+	// Create endpoint directories with some content.
+	alpha, beta := t.TempDir(), t.TempDir()
+	if err := os.WriteFile(filepath.Join(alpha, "file"), []byte("content"), 0600); err != nil {
+		t.Fatal("unable to create test file:", err)
+	}
+
+	// Start watching events.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	terminated := make(chan string, 1)
+	watchErrors := make(chan error, 1)
+	go func() {
+		watchErrors <- sessions.Watch(ctx, &selection.Selection{All: true}, func(event *synchronization.Event) error {
+			if event.Kind == synchronization.EventKind_EventKindSessionTerminated {
+				terminated <- event.Session
+				return errWatchDone
+			}
+			return nil
+		})
+	}()
+
+	// Create a session.
+	alphaURL, err := url.Parse(alpha, url.Kind_Synchronization, true)
+	if err != nil {
+		t.Fatal("unable to parse alpha URL:", err)
+	}
+	betaURL, err := url.Parse(beta, url.Kind_Synchronization, false)
+	if err != nil {
+		t.Fatal("unable to parse beta URL:", err)
+	}
+	prompter := &recordingPrompter{}
+	identifier, err := sessions.Create(ctx, &synchronizationsvc.CreationSpecification{
+		Alpha:              alphaURL,
+		Beta:               betaURL,
+		Configuration:      &synchronization.Configuration{},
+		ConfigurationAlpha: &synchronization.Configuration{},
+		ConfigurationBeta:  &synchronization.Configuration{},
+		Name:               "client",
+	}, prompter)
+	if err != nil {
+		t.Fatal("unable to create session:", err)
+	} else if prompter.count() == 0 {
+		t.Error("no messages received by prompter")
+	}
+	selection := &selection.Selection{Specifications: []string{identifier}}
+
+	// Flush the session and verify that synchronization occurred.
+	if err := sessions.Flush(ctx, selection, false, nil); err != nil {
+		t.Fatal("unable to flush session:", err)
+	}
+	if contents, err := os.ReadFile(filepath.Join(beta, "file")); err != nil {
+		t.Error("unable to read synchronized file:", err)
+	} else if string(contents) != "content" {
+		t.Error("synchronized file contents incorrect")
+	}
+
+	// Verify that the session is listed.
+	if _, states, err := sessions.List(ctx, selection, 0); err != nil {
+		t.Fatal("unable to list session:", err)
+	} else if len(states) != 1 {
+		t.Fatal("unexpected number of sessions listed:", len(states))
+	} else if states[0].Session.Name != "client" {
+		t.Error("listed session has incorrect name:", states[0].Session.Name)
+	}
+
+	// Perform lifecycle operations.
+	if err := sessions.Pause(ctx, selection, nil); err != nil {
+		t.Fatal("unable to pause session:", err)
+	}
+	if err := sessions.Reset(ctx, selection, nil); err != nil {
+		t.Fatal("unable to reset session:", err)
+	}
+	if err := sessions.Resume(ctx, selection, nil); err != nil {
+		t.Fatal("unable to resume session:", err)
+	}
+	if err := sessions.Terminate(ctx, selection, nil); err != nil {
+		t.Fatal("unable to terminate session:", err)
+	}
+
+	// Verify that termination was observed.
+	select {
+	case session := <-terminated:
+		if session != identifier {
+			t.Error("termination event for unexpected session:", session)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for termination event")
+	}
+	if err := <-watchErrors; err != errWatchDone {
+		t.Error("unexpected watch error:", err)
+	}
+
+	// Verify that the session is gone.
+	if _, _, err := sessions.List(ctx, selection, 0); err == nil {
+		t.Error("terminated session still listed")
+	}
+}
+
+// TestForwardingLifecycle tests forwarding session management.
+func TestForwardingLifecycle(t *testing.T) {
+	// Create a client.
+	client, err := New(nil)
+	if err != nil {
+		t.Fatal("unable to create client:", err)
+	}
+	defer client.Close()
+	sessions := client.Forwarding()
+
+	// Compute source and destination addresses.
+	source, destination := freeAddress(t), freeAddress(t)
+	sourceURL, err := url.Parse("tcp:"+source, url.Kind_Forwarding, true)
+	if err != nil {
+		t.Fatal("unable to parse source URL:", err)
+	}
+	destinationURL, err := url.Parse("tcp:"+destination, url.Kind_Forwarding, false)
+	if err != nil {
+		t.Fatal("unable to parse destination URL:", err)
+	}
+
+	// Create a session.
+	ctx := context.Background()
+	identifier, err := sessions.Create(ctx, &forwardingsvc.CreationSpecification{
+		Source:                   sourceURL,
+		Destination:              destinationURL,
+		Configuration:            &forwarding.Configuration{},
+		ConfigurationSource:      &forwarding.Configuration{},
+		ConfigurationDestination: &forwarding.Configuration{},
+	}, nil)
+	if err != nil {
+		t.Fatal("unable to create session:", err)
+	}
+	selection := &selection.Selection{Specifications: []string{identifier}}
+
+	// Verify that the session is listed.
+	if _, states, err := sessions.List(ctx, selection, 0); err != nil {
+		t.Fatal("unable to list session:", err)
+	} else if len(states) != 1 {
+		t.Fatal("unexpected number of sessions listed:", len(states))
+	}
+
+	// Perform lifecycle operations.
+	if err := sessions.Pause(ctx, selection, nil); err != nil {
+		t.Fatal("unable to pause session:", err)
+	}
+	if err := sessions.Resume(ctx, selection, nil); err != nil {
+		t.Fatal("unable to resume session:", err)
+	}
+	if err := sessions.Terminate(ctx, selection, nil); err != nil {
+		t.Fatal("unable to terminate session:", err)
+	}
+}
+
+// freeAddress returns a loopback TCP address that is (at least momentarily)
+// available.
+func freeAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("unable to create listener:", err)
+	}
+	defer listener.Close()
+	return listener.Addr().String()
+}
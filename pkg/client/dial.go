@@ -0,0 +1,245 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/mutagen-io/mutagen/pkg/daemon"
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/ipc"
+	"github.com/mutagen-io/mutagen/pkg/mutagen"
+	"github.com/mutagen-io/mutagen/pkg/platform"
+	daemonsvc "github.com/mutagen-io/mutagen/pkg/service/daemon"
+)
+
+const (
+	// dialTimeout is the timeout to use when attempting to connect to the
+	// daemon IPC endpoint.
+	dialTimeout = 500 * time.Millisecond
+	// autostartWaitInterval is the wait period between reconnect attempts after
+	// autostarting the daemon.
+	autostartWaitInterval = 100 * time.Millisecond
+	// autostartRetryCount is the number of times to try reconnecting after
+	// autostarting the daemon.
+	autostartRetryCount = 10
+	// remoteDialTimeout is the timeout to use when attempting to connect to a
+	// remote daemon.
+	remoteDialTimeout = 10 * time.Second
+)
+
+// Configuration encodes daemon connection parameters. The zero value connects
+// to the local daemon without autostart and with version verification.
+type Configuration struct {
+	// Address is the TCP address (<host>:<port>) of a remote daemon. If empty,
+	// then the local daemon is used.
+	Address string
+	// Credentials are the credentials used to access a remote daemon. They are
+	// required if Address is set and ignored otherwise.
+	Credentials *daemon.RemoteCredentials
+	// Autostart indicates whether or not the local daemon should be started if
+	// it isn't running. It is ignored for remote daemons.
+	Autostart bool
+	// Start is the function used to start the local daemon when autostarting.
+	// If nil, then StartDaemon is invoked with an empty executable path.
+	Start func() error
+	// SkipVersionCheck indicates whether or not verification that the daemon
+	// version matches the client version should be skipped.
+	SkipVersionCheck bool
+}
+
+// StartDaemon starts the local daemon in the background. If the daemon is
+// registered with the system, then the system's start mechanism is used.
+// Otherwise, the specified Mutagen executable is invoked to run the daemon. If
+// executable is empty, then the Mutagen executable is located using the PATH
+// environment variable. StartDaemon does not wait for the daemon to become
+// available.
+func StartDaemon(executable string) error {
+	// If the daemon is registered with the system, it may have a different
+	// start mechanism, so see if the system should handle it.
+	if handled, err := daemon.RegisteredStart(); err != nil {
+		return fmt.Errorf("unable to start daemon using system mechanism: %w", err)
+	} else if handled {
+		return nil
+	}
+
+	// Locate the Mutagen executable if necessary.
+	if executable == "" {
+		if path, err := exec.LookPath(platform.ExecutableName("mutagen", runtime.GOOS)); err != nil {
+			return fmt.Errorf("unable to locate Mutagen executable: %w", err)
+		} else {
+			executable = path
+		}
+	}
+
+	// Start the daemon in the background.
+	daemonProcess := &exec.Cmd{
+		Path:        executable,
+		Args:        []string{"mutagen", "daemon", "run"},
+		SysProcAttr: daemonProcessAttributes,
+	}
+	if err := daemonProcess.Start(); err != nil {
+		return fmt.Errorf("unable to fork daemon: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
+// checkVersion verifies that the daemon version matches the current process'
+// version.
+func checkVersion(connection *grpc.ClientConn) error {
+	daemonService := daemonsvc.NewDaemonClient(connection)
+	version, err := daemonService.Version(context.Background(), &daemonsvc.VersionRequest{})
+	if err != nil {
+		return fmt.Errorf("unable to query daemon version: %w", err)
+	}
+	versionMatch := version.Major == mutagen.VersionMajor &&
+		version.Minor == mutagen.VersionMinor &&
+		version.Patch == mutagen.VersionPatch &&
+		version.Tag == mutagen.VersionTag
+	if !versionMatch {
+		return errors.New("client/daemon version mismatch (daemon restart recommended)")
+	}
+	return nil
+}
+
+// dialRemote creates a new client connection to a remote daemon.
+func dialRemote(address string, remoteCredentials *daemon.RemoteCredentials) (*grpc.ClientConn, error) {
+	// Validate the credentials and create the TLS configuration.
+	if err := remoteCredentials.EnsureValid(); err != nil {
+		return nil, fmt.Errorf("invalid remote daemon credentials: %w", err)
+	}
+	tlsConfiguration, err := remoteCredentials.ClientTLSConfiguration()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create TLS configuration: %w", err)
+	}
+
+	// Attempt to dial.
+	ctx, cancel := context.WithTimeout(context.Background(), remoteDialTimeout)
+	connection, err := grpc.DialContext(
+		ctx, address,
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfiguration)),
+		grpc.WithPerRPCCredentials(grpcutil.NewBearerTokenCredentials(remoteCredentials.Token)),
+		grpc.WithBlock(),
+		grpc.WithReturnConnectionError(),
+		grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(grpcutil.MaximumMessageSize)),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(grpcutil.MaximumMessageSize)),
+	)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to remote daemon: %w", err)
+	}
+
+	// Success.
+	return connection, nil
+}
+
+// dialLocal creates a new client connection to the local daemon, optionally
+// starting the daemon if it isn't running.
+func dialLocal(autostart bool, start func() error) (*grpc.ClientConn, error) {
+	// Compute the path to the daemon IPC endpoint.
+	endpoint, err := daemon.EndpointPath()
+	if err != nil {
+		return nil, fmt.Errorf("unable to compute endpoint path: %w", err)
+	}
+
+	// Perform dialing in a loop until failure or success.
+	remainingPostAutostatAttempts := autostartRetryCount
+	invokedStart := false
+	for {
+		// Create a context to timeout the dial.
+		ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+
+		// Attempt to dial.
+		connection, err := grpc.DialContext(
+			ctx, endpoint,
+			grpc.WithInsecure(),
+			grpc.WithContextDialer(ipc.DialContext),
+			grpc.WithBlock(),
+			grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(grpcutil.MaximumMessageSize)),
+			grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(grpcutil.MaximumMessageSize)),
+		)
+
+		// Cancel the dialing context. If the dialing operation has already
+		// succeeded, this has no effect, but it is necessary to clean up the
+		// Goroutine that backs the context.
+		cancel()
+
+		// Check for errors.
+		if err != nil {
+			// Handle failure due to timeouts.
+			if err == context.DeadlineExceeded {
+				// If autostart is enabled, and we have attempts remaining, then
+				// try autostarting, waiting, and retrying.
+				if autostart && remainingPostAutostatAttempts > 0 {
+					if !invokedStart {
+						if err := start(); err != nil {
+							return nil, fmt.Errorf("unable to start daemon: %w", err)
+						}
+						invokedStart = true
+					}
+					time.Sleep(autostartWaitInterval)
+					remainingPostAutostatAttempts--
+					continue
+				}
+
+				// Otherwise just fail due to the timeout.
+				return nil, errors.New("connection timed out (is the daemon running?)")
+			}
+
+			// If we failed for any other reason, then bail.
+			return nil, err
+		}
+
+		// Success.
+		return connection, nil
+	}
+}
+
+// Dial creates a new daemon client connection using the specified
+// configuration. Most callers should use New instead, but Dial is useful for
+// callers that need direct access to the daemon's gRPC services. A nil
+// configuration is treated as the zero value.
+func Dial(configuration *Configuration) (*grpc.ClientConn, error) {
+	// Handle a nil configuration.
+	if configuration == nil {
+		configuration = &Configuration{}
+	}
+
+	// Connect to the appropriate daemon.
+	var connection *grpc.ClientConn
+	var err error
+	if configuration.Address != "" {
+		connection, err = dialRemote(configuration.Address, configuration.Credentials)
+	} else {
+		start := configuration.Start
+		if start == nil {
+			start = func() error {
+				return StartDaemon("")
+			}
+		}
+		connection, err = dialLocal(configuration.Autostart, start)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Unless disabled, verify that the daemon version matches the current
+	// process' version.
+	if !configuration.SkipVersionCheck {
+		if err := checkVersion(connection); err != nil {
+			connection.Close()
+			return nil, err
+		}
+	}
+
+	// Success.
+	return connection, nil
+}
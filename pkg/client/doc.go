@@ -0,0 +1,8 @@
+// Package client provides a supported Go API for controlling the Mutagen daemon.
+// It wraps the daemon's gRPC services with typed methods for managing
+// synchronization and forwarding sessions, handles daemon connection
+// (including autostart and version verification), and hosts prompters on
+// behalf of callers. Programs embedding Mutagen should prefer this package over
+// direct use of the generated service clients, whose interfaces may change
+// between releases.
+package client
@@ -0,0 +1,87 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/mutagen-io/mutagen/pkg/client"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// printPrompter is a prompter that prints messages and declines prompts.
+type printPrompter struct{}
+
+// Message implements prompting.Prompter.Message.
+func (printPrompter) Message(message string) error {
+	fmt.Println(message)
+	return nil
+}
+
+// Prompt implements prompting.Prompter.Prompt.
+func (printPrompter) Prompt(message string) (string, error) {
+	return "", fmt.Errorf("unable to respond to prompt: %s", message)
+}
+
+func Example() {
+	// Connect to the local daemon, starting it if necessary.
+	mutagen, err := client.New(&client.Configuration{Autostart: true})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer mutagen.Close()
+
+	// Create a synchronization session.
+	alpha, err := url.Parse("/path/to/alpha", url.Kind_Synchronization, true)
+	if err != nil {
+		log.Fatal(err)
+	}
+	beta, err := url.Parse("user@example.org:/path/to/beta", url.Kind_Synchronization, false)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ctx := context.Background()
+	identifier, err := mutagen.Synchronization().Create(ctx, &synchronizationsvc.CreationSpecification{
+		Alpha:              alpha,
+		Beta:               beta,
+		Configuration:      &synchronization.Configuration{},
+		ConfigurationAlpha: &synchronization.Configuration{},
+		ConfigurationBeta:  &synchronization.Configuration{},
+		Name:               "example",
+	}, printPrompter{})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Wait for a synchronization cycle to complete.
+	if err := mutagen.Synchronization().Flush(ctx, &selection.Selection{
+		Specifications: []string{identifier},
+	}, false, printPrompter{}); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func ExampleSynchronizationClient_Watch() {
+	// Connect to the local daemon.
+	mutagen, err := client.New(nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer mutagen.Close()
+
+	// Print events for sessions with a particular label until an error occurs.
+	err = mutagen.Synchronization().Watch(
+		context.Background(),
+		&selection.Selection{LabelSelector: "project=example"},
+		func(event *synchronization.Event) error {
+			fmt.Println(event.Session, event.Kind)
+			return nil
+		},
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
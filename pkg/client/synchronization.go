@@ -0,0 +1,222 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/prompting"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+)
+
+// SynchronizationClient provides methods for managing synchronization
+// sessions. Operations that accept a prompter use it to display status messages
+// and, where applicable, to respond to prompts (e.g. for SSH authentication). A
+// nil prompter may be provided, in which case messages are discarded and
+// operations requiring prompts will fail.
+type SynchronizationClient struct {
+	// client is the parent client.
+	client *Client
+	// service is the synchronization service client.
+	service synchronizationsvc.SynchronizationClient
+}
+
+// Create creates a new synchronization session and returns its identifier.
+func (c *SynchronizationClient) Create(
+	ctx context.Context,
+	specification *synchronizationsvc.CreationSpecification,
+	prompter prompting.Prompter,
+) (string, error) {
+	var session string
+	err := c.client.withPrompter(ctx, prompter, true, func(identifier string) error {
+		request := &synchronizationsvc.CreateRequest{
+			Prompter:      identifier,
+			Specification: specification,
+		}
+		response, err := c.service.Create(ctx, request)
+		if err != nil {
+			return grpcutil.PeelAwayRPCErrorLayer(err)
+		} else if err = response.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid create response received: %w", err)
+		}
+		session = response.Session
+		return nil
+	})
+	return session, err
+}
+
+// List returns the states of the selected synchronization sessions along with
+// the associated state index. If previousStateIndex is non-zero, then the
+// call blocks until the state index changes from that value, allowing callers
+// to efficiently poll for changes.
+func (c *SynchronizationClient) List(
+	ctx context.Context,
+	selection *selection.Selection,
+	previousStateIndex uint64,
+) (uint64, []*synchronization.State, error) {
+	request := &synchronizationsvc.ListRequest{
+		Selection:          selection,
+		PreviousStateIndex: previousStateIndex,
+	}
+	response, err := c.service.List(ctx, request)
+	if err != nil {
+		return 0, nil, grpcutil.PeelAwayRPCErrorLayer(err)
+	} else if err = response.EnsureValid(); err != nil {
+		return 0, nil, fmt.Errorf("invalid list response received: %w", err)
+	}
+	return response.StateIndex, response.SessionStates, nil
+}
+
+// Flush flushes the selected synchronization sessions. If skipWait is true,
+// then the flush cycles are requested but not waited on.
+func (c *SynchronizationClient) Flush(
+	ctx context.Context,
+	selection *selection.Selection,
+	skipWait bool,
+	prompter prompting.Prompter,
+) error {
+	return c.client.withPrompter(ctx, prompter, false, func(identifier string) error {
+		request := &synchronizationsvc.FlushRequest{
+			Prompter:  identifier,
+			Selection: selection,
+			SkipWait:  skipWait,
+		}
+		response, err := c.service.Flush(ctx, request)
+		if err != nil {
+			return grpcutil.PeelAwayRPCErrorLayer(err)
+		} else if err = response.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid flush response received: %w", err)
+		}
+		return nil
+	})
+}
+
+// Pause pauses the selected synchronization sessions.
+func (c *SynchronizationClient) Pause(
+	ctx context.Context,
+	selection *selection.Selection,
+	prompter prompting.Prompter,
+) error {
+	return c.client.withPrompter(ctx, prompter, false, func(identifier string) error {
+		request := &synchronizationsvc.PauseRequest{
+			Prompter:  identifier,
+			Selection: selection,
+		}
+		response, err := c.service.Pause(ctx, request)
+		if err != nil {
+			return grpcutil.PeelAwayRPCErrorLayer(err)
+		} else if err = response.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid pause response received: %w", err)
+		}
+		return nil
+	})
+}
+
+// Resume resumes the selected synchronization sessions.
+func (c *SynchronizationClient) Resume(
+	ctx context.Context,
+	selection *selection.Selection,
+	prompter prompting.Prompter,
+) error {
+	return c.client.withPrompter(ctx, prompter, true, func(identifier string) error {
+		request := &synchronizationsvc.ResumeRequest{
+			Prompter:  identifier,
+			Selection: selection,
+		}
+		response, err := c.service.Resume(ctx, request)
+		if err != nil {
+			return grpcutil.PeelAwayRPCErrorLayer(err)
+		} else if err = response.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid resume response received: %w", err)
+		}
+		return nil
+	})
+}
+
+// Reset resets the synchronization history of the selected synchronization
+// sessions.
+func (c *SynchronizationClient) Reset(
+	ctx context.Context,
+	selection *selection.Selection,
+	prompter prompting.Prompter,
+) error {
+	return c.client.withPrompter(ctx, prompter, true, func(identifier string) error {
+		request := &synchronizationsvc.ResetRequest{
+			Prompter:  identifier,
+			Selection: selection,
+		}
+		response, err := c.service.Reset(ctx, request)
+		if err != nil {
+			return grpcutil.PeelAwayRPCErrorLayer(err)
+		} else if err = response.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid reset response received: %w", err)
+		}
+		return nil
+	})
+}
+
+// Terminate terminates the selected synchronization sessions.
+func (c *SynchronizationClient) Terminate(
+	ctx context.Context,
+	selection *selection.Selection,
+	prompter prompting.Prompter,
+) error {
+	return c.client.withPrompter(ctx, prompter, false, func(identifier string) error {
+		request := &synchronizationsvc.TerminateRequest{
+			Prompter:  identifier,
+			Selection: selection,
+		}
+		response, err := c.service.Terminate(ctx, request)
+		if err != nil {
+			return grpcutil.PeelAwayRPCErrorLayer(err)
+		} else if err = response.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid terminate response received: %w", err)
+		}
+		return nil
+	})
+}
+
+// Watch streams events for the selected synchronization sessions to the
+// specified handler until the context is cancelled, the daemon closes the
+// stream, or the handler returns an error. Cancellation of the context results
+// in the context's error being returned. Handler errors are returned verbatim.
+func (c *SynchronizationClient) Watch(
+	ctx context.Context,
+	selection *selection.Selection,
+	handler func(*synchronization.Event) error,
+) error {
+	// Create a subcontext that we can use to terminate the stream in the event
+	// of a handler error.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Initiate the stream.
+	request := &synchronizationsvc.WatchRequest{
+		Selection: selection,
+	}
+	stream, err := c.service.Watch(ctx, request)
+	if err != nil {
+		return grpcutil.PeelAwayRPCErrorLayer(err)
+	}
+
+	// Receive and dispatch events.
+	for {
+		response, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return grpcutil.PeelAwayRPCErrorLayer(err)
+		} else if err = response.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid watch response received: %w", err)
+		} else if err = handler(response.Event); err != nil {
+			return err
+		}
+	}
+}
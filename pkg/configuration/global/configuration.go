@@ -1,6 +1,8 @@
 package global
 
 import (
+	"fmt"
+
 	"github.com/mutagen-io/mutagen/pkg/api/models/forwarding"
 	"github.com/mutagen-io/mutagen/pkg/api/models/synchronization"
 	"github.com/mutagen-io/mutagen/pkg/encoding"
@@ -18,6 +20,26 @@ type Configuration struct {
 		// Defaults are the global synchronization configuration defaults.
 		Defaults synchronization.Configuration `yaml:"defaults"`
 	} `yaml:"sync"`
+	// Presets are named sets of session configuration parameters that can be
+	// selected when creating sessions. They are layered on top of the global
+	// defaults.
+	Presets map[string]Preset `yaml:"presets"`
+}
+
+// Preset is a named set of session configuration parameters.
+type Preset struct {
+	// Forwarding is the forwarding configuration for the preset.
+	Forwarding forwarding.Configuration `yaml:"forward"`
+	// Synchronization is the synchronization configuration for the preset.
+	Synchronization synchronization.Configuration `yaml:"sync"`
+}
+
+// Preset looks up the preset with the specified name.
+func (c *Configuration) Preset(name string) (*Preset, error) {
+	if preset, ok := c.Presets[name]; ok {
+		return &preset, nil
+	}
+	return nil, fmt.Errorf("unknown preset: %s", name)
 }
 
 // LoadConfiguration attempts to load a YAML-based Mutagen global configuration
@@ -1,3 +1,73 @@
 package global
 
-// TODO: Implement.
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+// testConfigurationWithPresets is a global configuration with presets.
+const testConfigurationWithPresets = `sync:
+  defaults:
+    mode: "two-way-resolved"
+presets:
+  node:
+    sync:
+      ignore:
+        paths:
+          - "node_modules"
+  large-binaries:
+    sync:
+      mode: "one-way-replica"
+      maxStagingFileSize: "1 GB"
+    forward:
+      socket:
+        overwriteMode: "overwrite"
+`
+
+// TestLoadConfigurationPresets tests loading of presets by LoadConfiguration
+// and their lookup using Configuration.Preset.
+func TestLoadConfigurationPresets(t *testing.T) {
+	// Write the configuration file.
+	path := filepath.Join(t.TempDir(), "mutagen.yml")
+	if err := os.WriteFile(path, []byte(testConfigurationWithPresets), 0600); err != nil {
+		t.Fatal("unable to write configuration file:", err)
+	}
+
+	// Load the configuration.
+	configuration, err := LoadConfiguration(path)
+	if err != nil {
+		t.Fatal("unable to load configuration:", err)
+	}
+
+	// Verify that defaults are still loaded.
+	if configuration.Synchronization.Defaults.Mode != core.SynchronizationMode_SynchronizationModeTwoWayResolved {
+		t.Error("synchronization defaults not loaded correctly")
+	}
+
+	// Look up and verify presets.
+	if preset, err := configuration.Preset("node"); err != nil {
+		t.Error("unable to look up preset:", err)
+	} else if !reflect.DeepEqual(preset.Synchronization.Ignore.Paths, []string{"node_modules"}) {
+		t.Error("preset ignore paths do not match expected:", preset.Synchronization.Ignore.Paths)
+	}
+	if preset, err := configuration.Preset("large-binaries"); err != nil {
+		t.Error("unable to look up preset:", err)
+	} else {
+		if preset.Synchronization.Mode != core.SynchronizationMode_SynchronizationModeOneWayReplica {
+			t.Error("preset synchronization mode does not match expected")
+		}
+		if err := preset.Synchronization.ToInternal().EnsureValid(false); err != nil {
+			t.Error("preset synchronization configuration invalid:", err)
+		}
+		if err := preset.Forwarding.ToInternal().EnsureValid(false); err != nil {
+			t.Error("preset forwarding configuration invalid:", err)
+		}
+	}
+	if _, err := configuration.Preset("python"); err == nil {
+		t.Error("lookup of unknown preset succeeded")
+	}
+}
@@ -0,0 +1,3 @@
+// Package schema provides JSON Schema generation for Mutagen's YAML-based
+// configuration files.
+package schema
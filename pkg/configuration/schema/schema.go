@@ -0,0 +1,274 @@
+package schema
+
+import (
+	"encoding"
+	"reflect"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/mutagen-io/mutagen/pkg/configuration/global"
+	"github.com/mutagen-io/mutagen/pkg/project"
+)
+
+const (
+	// dialect is the JSON Schema dialect used for generated schemas.
+	dialect = "https://json-schema.org/draft/2020-12/schema"
+	// variableReferencePattern is the pattern used to match string values
+	// that contain variable references, which may be used in place of
+	// non-string values in interpolated sections of project configuration
+	// files.
+	variableReferencePattern = `\$\{`
+	// durationPattern is the pattern used to match duration strings.
+	durationPattern = `^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+)
+
+// Schema represents a JSON Schema document or subschema. Only the subset of
+// JSON Schema required to describe Mutagen's configuration files is supported.
+type Schema struct {
+	// Schema is the JSON Schema dialect. It is only set on root schemas.
+	Schema string `json:"$schema,omitempty"`
+	// Title is the schema title.
+	Title string `json:"title,omitempty"`
+	// Type is the allowed type, either as a string or as a slice of strings.
+	Type any `json:"type,omitempty"`
+	// Properties are the schemas for known object properties.
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	// AdditionalProperties specifies the handling of unknown object
+	// properties, either as a boolean or as a schema.
+	AdditionalProperties any `json:"additionalProperties,omitempty"`
+	// Items is the schema for array items.
+	Items *Schema `json:"items,omitempty"`
+	// Enum are the allowed values.
+	Enum []any `json:"enum,omitempty"`
+	// Pattern is a regular expression that string values must match.
+	Pattern string `json:"pattern,omitempty"`
+	// AnyOf are alternative schemas, at least one of which must match.
+	AnyOf []*Schema `json:"anyOf,omitempty"`
+}
+
+// enumerationSchema creates a schema for an enumeration type from the encoded
+// representations of its values. Values that can't be encoded or that encode
+// to empty values (such as default values) are omitted. Values are encoded
+// using MarshalYAML if implemented and MarshalText otherwise, and values
+// encoded using MarshalText are only included if they can be decoded by
+// UnmarshalText.
+func enumerationSchema(values []reflect.Value) *Schema {
+	// Encode values.
+	result := &Schema{}
+	for _, value := range values {
+		if marshaler, ok := value.Interface().(yaml.Marshaler); ok {
+			if encoded, err := marshaler.MarshalYAML(); err == nil && encoded != nil {
+				result.Enum = append(result.Enum, encoded)
+			}
+		} else if marshaler, ok := value.Interface().(encoding.TextMarshaler); ok {
+			encoded, err := marshaler.MarshalText()
+			if err != nil || len(encoded) == 0 {
+				continue
+			}
+			decoded := reflect.New(value.Type())
+			if unmarshaler, ok := decoded.Interface().(encoding.TextUnmarshaler); !ok {
+				continue
+			} else if unmarshaler.UnmarshalText(encoded) != nil {
+				continue
+			}
+			result.Enum = append(result.Enum, string(encoded))
+		}
+	}
+
+	// Set the type based on the encoded values.
+	if len(result.Enum) > 0 {
+		switch result.Enum[0].(type) {
+		case bool:
+			result.Type = "boolean"
+		case string:
+			result.Type = "string"
+		}
+	}
+
+	// Done.
+	return result
+}
+
+// overrides are schemas for types whose encoding can't be determined by
+// reflection.
+var overrides = map[reflect.Type]*Schema{
+	reflect.TypeOf(time.Duration(0)): {
+		Type:    "string",
+		Pattern: durationPattern,
+	},
+	reflect.TypeOf(project.FlushOnCreateBehavior(0)): {
+		Type: "boolean",
+	},
+	reflect.TypeOf(project.RestartPolicy(0)): enumerationSchema([]reflect.Value{
+		reflect.ValueOf(project.RestartPolicyNo),
+		reflect.ValueOf(project.RestartPolicyOnFailure),
+		reflect.ValueOf(project.RestartPolicyAlways),
+	}),
+}
+
+var (
+	// protocolBuffersEnumerationType is the reflected type of
+	// protoreflect.Enum.
+	protocolBuffersEnumerationType = reflect.TypeOf((*protoreflect.Enum)(nil)).Elem()
+	// textUnmarshalerType is the reflected type of encoding.TextUnmarshaler.
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// generator generates schemas from Go types using their YAML encodings.
+type generator struct {
+	// interpolated indicates whether or not strings containing variable
+	// references should be accepted in place of non-string scalar values.
+	interpolated bool
+}
+
+// scalar adjusts a scalar schema to accept variable references if the
+// generator is generating schemas for interpolated values.
+func (g *generator) scalar(schema *Schema) *Schema {
+	if !g.interpolated || (schema.Type == "string" && schema.Pattern == "" && len(schema.Enum) == 0) {
+		return schema
+	}
+	return &Schema{AnyOf: []*Schema{
+		schema,
+		{Type: "string", Pattern: variableReferencePattern},
+	}}
+}
+
+// generate generates a schema for the specified type.
+func (g *generator) generate(t reflect.Type) *Schema {
+	// Handle types with overridden schemas.
+	if override, ok := overrides[t]; ok {
+		return g.scalar(override)
+	}
+
+	// Handle Protocol Buffers enumerations.
+	if t.Implements(protocolBuffersEnumerationType) {
+		descriptors := reflect.Zero(t).Interface().(protoreflect.Enum).Descriptor().Values()
+		values := make([]reflect.Value, descriptors.Len())
+		for i := range values {
+			values[i] = reflect.New(t).Elem()
+			values[i].SetInt(int64(descriptors.Get(i).Number()))
+		}
+		return g.scalar(enumerationSchema(values))
+	}
+
+	// Handle other types that are decoded from text. Those with integer
+	// representations may also be specified numerically.
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return g.scalar(&Schema{Type: []string{"string", "integer"}})
+		default:
+			return g.scalar(&Schema{Type: "string"})
+		}
+	}
+
+	// Handle types based on their kind.
+	switch t.Kind() {
+	case reflect.Bool:
+		return g.scalar(&Schema{Type: "boolean"})
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return g.scalar(&Schema{Type: "integer"})
+	case reflect.Float32, reflect.Float64:
+		return g.scalar(&Schema{Type: "number"})
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.generate(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.generate(t.Elem())}
+	case reflect.Pointer:
+		return g.generate(t.Elem())
+	case reflect.Struct:
+		result := &Schema{
+			Type:                 "object",
+			Properties:           make(map[string]*Schema),
+			AdditionalProperties: false,
+		}
+		g.addProperties(result, t)
+		return result
+	default:
+		return &Schema{}
+	}
+}
+
+// addProperties adds schemas for the fields of a struct type to an object
+// schema, following the field naming and inlining rules used for YAML
+// encoding.
+func (g *generator) addProperties(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		// Skip unexported fields.
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		// Parse the field tag.
+		tag := field.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		components := strings.Split(tag, ",")
+		name, options := components[0], components[1:]
+
+		// Handle inlined fields.
+		var inline bool
+		for _, option := range options {
+			if option == "inline" {
+				inline = true
+			}
+		}
+		if inline {
+			g.addProperties(schema, field.Type)
+			continue
+		}
+
+		// Add the field.
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		schema.Properties[name] = g.generate(field.Type)
+	}
+}
+
+// Project generates a schema for project configuration files.
+func Project() *Schema {
+	// Generate the base schema.
+	result := (&generator{}).generate(reflect.TypeOf(project.Configuration{}))
+	result.Schema = dialect
+	result.Title = "Mutagen project configuration"
+
+	// Regenerate schemas for interpolated sections.
+	interpolating := &generator{interpolated: true}
+	configurationType := reflect.TypeOf(project.Configuration{})
+	for _, key := range project.InterpolatedKeys {
+		for i := 0; i < configurationType.NumField(); i++ {
+			field := configurationType.Field(i)
+			if name, _, _ := strings.Cut(field.Tag.Get("yaml"), ","); name == key {
+				result.Properties[key] = interpolating.generate(field.Type)
+			}
+		}
+	}
+
+	// Add the include key.
+	result.Properties[project.IncludeKey] = &Schema{AnyOf: []*Schema{
+		{Type: "string"},
+		{Type: "array", Items: &Schema{Type: "string"}},
+	}}
+
+	// Done.
+	return result
+}
+
+// Global generates a schema for the global configuration file.
+func Global() *Schema {
+	result := (&generator{}).generate(reflect.TypeOf(global.Configuration{}))
+	result.Schema = dialect
+	result.Title = "Mutagen global configuration"
+	return result
+}
@@ -0,0 +1,131 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// TestProject tests Project.
+func TestProject(t *testing.T) {
+	// Generate the schema and ensure that it can be encoded.
+	schema := Project()
+	if _, err := json.Marshal(schema); err != nil {
+		t.Fatal("unable to encode schema:", err)
+	}
+
+	// Verify that unknown top-level keys are rejected and that the include key
+	// is supported.
+	if schema.AdditionalProperties != false {
+		t.Error("schema allows unknown top-level keys")
+	}
+	if _, ok := schema.Properties["include"]; !ok {
+		t.Error("schema missing include key")
+	}
+
+	// Verify that lifecycle hooks aren't subject to interpolation.
+	if hooks := schema.Properties["beforeCreate"]; hooks == nil || hooks.Type != "array" {
+		t.Fatal("schema missing or incorrect for lifecycle hooks")
+	} else if hooks.Items.Type != "string" {
+		t.Error("lifecycle hook items have incorrect schema")
+	}
+
+	// Extract the synchronization session schema.
+	synchronization, ok := schema.Properties["sync"].AdditionalProperties.(*Schema)
+	if !ok {
+		t.Fatal("schema missing synchronization session schema")
+	}
+	if synchronization.AdditionalProperties != false {
+		t.Error("synchronization session schema allows unknown keys")
+	}
+
+	// Verify that inlined session configuration is present and that enumeration
+	// values are derived from their text encodings. Since session
+	// specifications are interpolated, variable references should also be
+	// allowed in place of enumeration values.
+	mode := synchronization.Properties["mode"]
+	if mode == nil || len(mode.AnyOf) != 2 {
+		t.Fatal("synchronization mode schema missing or not interpolated")
+	}
+	expectedModes := []any{"two-way-safe", "two-way-resolved", "one-way-safe", "one-way-replica"}
+	if !reflect.DeepEqual(mode.AnyOf[0].Enum, expectedModes) {
+		t.Error("synchronization modes do not match expected:", mode.AnyOf[0].Enum)
+	}
+	if mode.AnyOf[1].Pattern != variableReferencePattern {
+		t.Error("synchronization mode schema doesn't allow variable references")
+	}
+
+	// Verify that values encoded via MarshalYAML are handled.
+	vcs := synchronization.Properties["ignore"].Properties["vcs"]
+	if vcs == nil || len(vcs.AnyOf) != 2 {
+		t.Fatal("VCS ignore mode schema missing or not interpolated")
+	} else if !reflect.DeepEqual(vcs.AnyOf[0].Enum, []any{true, false}) {
+		t.Error("VCS ignore modes do not match expected:", vcs.AnyOf[0].Enum)
+	}
+
+	// Verify that plain string fields aren't modified by interpolation.
+	if alpha := synchronization.Properties["alpha"]; alpha == nil || alpha.Type != "string" {
+		t.Error("alpha URL schema missing or incorrect")
+	}
+	if preset := synchronization.Properties["preset"]; preset == nil || preset.Type != "string" {
+		t.Error("preset schema missing or incorrect")
+	}
+
+	// Verify service restart policies.
+	services, ok := schema.Properties["services"].AdditionalProperties.(*Schema)
+	if !ok {
+		t.Fatal("schema missing service schema")
+	}
+	expectedPolicies := []any{"no", "on-failure", "always"}
+	if restart := services.Properties["restart"]; restart == nil {
+		t.Error("service schema missing restart policy")
+	} else if !reflect.DeepEqual(restart.Enum, expectedPolicies) {
+		t.Error("restart policies do not match expected:", restart.Enum)
+	}
+}
+
+// TestGlobal tests Global.
+func TestGlobal(t *testing.T) {
+	// Generate the schema and ensure that it can be encoded.
+	schema := Global()
+	if _, err := json.Marshal(schema); err != nil {
+		t.Fatal("unable to encode schema:", err)
+	}
+
+	// Verify that the expected top-level keys are present.
+	for _, key := range []string{"forward", "sync"} {
+		if section := schema.Properties[key]; section == nil {
+			t.Error("schema missing key:", key)
+		} else if _, ok := section.Properties["defaults"]; !ok {
+			t.Error("schema missing defaults for key:", key)
+		}
+	}
+
+	// Verify that presets are supported.
+	presets, ok := schema.Properties["presets"].AdditionalProperties.(*Schema)
+	if !ok {
+		t.Fatal("schema missing preset schema")
+	}
+	for _, key := range []string{"forward", "sync"} {
+		if _, ok := presets.Properties[key]; !ok {
+			t.Error("preset schema missing key:", key)
+		}
+	}
+
+	// Verify that the global configuration isn't interpolated.
+	mode := schema.Properties["sync"].Properties["defaults"].Properties["mode"]
+	if mode == nil || mode.Type != "string" || len(mode.Enum) == 0 {
+		t.Error("synchronization mode schema missing or incorrect")
+	}
+
+	// Verify that enumerations exclude default values.
+	selection := schema.Properties["forward"].Properties["defaults"].Properties["destinations"].Properties["selectionMode"]
+	if selection == nil {
+		t.Fatal("destination selection mode schema missing")
+	}
+	for _, value := range selection.Enum {
+		if value == "" {
+			t.Error("destination selection modes include default value")
+		}
+	}
+}
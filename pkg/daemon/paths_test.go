@@ -39,3 +39,12 @@ func TestEndpointPath(t *testing.T) {
 		t.Error("empty IPC endpoint path returned")
 	}
 }
+
+// TestLogPath tests that LogPath succeeds.
+func TestLogPath(t *testing.T) {
+	if path, err := LogPath(); err != nil {
+		t.Fatal("unable to compute log path:", err)
+	} else if path == "" {
+		t.Error("empty log path returned")
+	}
+}
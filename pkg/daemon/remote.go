@@ -0,0 +1,234 @@
+package daemon
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/encoding"
+)
+
+const (
+	// remoteServerCredentialsName is the name of the file storing the daemon's
+	// remote access credentials. It resides within the daemon subdirectory of
+	// the Mutagen directory.
+	remoteServerCredentialsName = "remote_server.yml"
+	// remoteCredentialsName is the name of the file storing the credentials
+	// used by clients to access a remote daemon. It resides within the daemon
+	// subdirectory of the Mutagen directory.
+	remoteCredentialsName = "remote_client.yml"
+	// remoteTokenLength is the number of random bytes used to generate remote
+	// access tokens.
+	remoteTokenLength = 32
+	// remoteCertificateValidity is the validity period for generated remote
+	// access certificates.
+	remoteCertificateValidity = 10 * 365 * 24 * time.Hour
+)
+
+// RemoteCredentials are the credentials used by a client to connect to and
+// authenticate with a remote daemon. They contain only public information about
+// the daemon and thus can be distributed to clients, though the token itself
+// must be treated as a secret.
+type RemoteCredentials struct {
+	// Certificate is the PEM-encoded TLS certificate used by the daemon. It is
+	// pinned by clients in lieu of verification against a certificate
+	// authority.
+	Certificate string `yaml:"certificate"`
+	// Token is the bearer token used to authenticate with the daemon.
+	Token string `yaml:"token"`
+}
+
+// EnsureValid ensures that RemoteCredentials' invariants are respected.
+func (c *RemoteCredentials) EnsureValid() error {
+	// A nil credentials object is not valid.
+	if c == nil {
+		return errors.New("nil credentials")
+	}
+
+	// Ensure that the certificate is valid.
+	if _, err := parseCertificate(c.Certificate); err != nil {
+		return fmt.Errorf("invalid certificate: %w", err)
+	}
+
+	// Ensure that the token is non-empty.
+	if c.Token == "" {
+		return errors.New("empty token")
+	}
+
+	// Success.
+	return nil
+}
+
+// ClientTLSConfiguration creates a client TLS configuration that only accepts
+// the daemon certificate contained in the credentials. The credentials must be
+// valid.
+func (c *RemoteCredentials) ClientTLSConfiguration() (*tls.Config, error) {
+	// Parse the pinned certificate.
+	certificate, err := parseCertificate(c.Certificate)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse certificate: %w", err)
+	}
+
+	// Create the configuration. We disable the standard verification because
+	// the certificate is self-signed and the daemon may be addressed by any
+	// host name, but we perform an exact match against the pinned certificate
+	// instead.
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCertificates [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCertificates) == 0 {
+				return errors.New("no daemon certificate presented")
+			} else if !bytes.Equal(rawCertificates[0], certificate.Raw) {
+				return errors.New("daemon certificate does not match pinned certificate")
+			}
+			return nil
+		},
+	}, nil
+}
+
+// RemoteServerCredentials are the credentials used by the daemon to serve
+// remote access requests.
+type RemoteServerCredentials struct {
+	// RemoteCredentials are the client-facing credentials.
+	RemoteCredentials `yaml:",inline"`
+	// Key is the PEM-encoded private key corresponding to the certificate.
+	Key string `yaml:"key"`
+}
+
+// ServerTLSConfiguration creates a server TLS configuration using the
+// credentials.
+func (c *RemoteServerCredentials) ServerTLSConfiguration() (*tls.Config, error) {
+	certificate, err := tls.X509KeyPair([]byte(c.Certificate), []byte(c.Key))
+	if err != nil {
+		return nil, fmt.Errorf("unable to load key pair: %w", err)
+	}
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{certificate},
+	}, nil
+}
+
+// parseCertificate parses a PEM-encoded certificate.
+func parseCertificate(encoded string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(encoded))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM-encoded certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// newRemoteServerCredentials generates a new self-signed certificate and a new
+// random token.
+func newRemoteServerCredentials() (*RemoteServerCredentials, error) {
+	// Generate the private key.
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate private key: %w", err)
+	}
+
+	// Generate the certificate.
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate certificate serial number: %w", err)
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{CommonName: "Mutagen daemon"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(remoteCertificateValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create certificate: %w", err)
+	}
+
+	// Encode the private key.
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal private key: %w", err)
+	}
+
+	// Generate the token.
+	token := make([]byte, remoteTokenLength)
+	if _, err := rand.Read(token); err != nil {
+		return nil, fmt.Errorf("unable to generate token: %w", err)
+	}
+
+	// Success.
+	return &RemoteServerCredentials{
+		RemoteCredentials: RemoteCredentials{
+			Certificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate})),
+			Token:       hex.EncodeToString(token),
+		},
+		Key: string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes})),
+	}, nil
+}
+
+// LoadOrCreateRemoteServerCredentials loads the daemon's remote access
+// credentials from the daemon subdirectory, generating and saving them if they
+// don't exist.
+func LoadOrCreateRemoteServerCredentials() (*RemoteServerCredentials, error) {
+	// Compute the credentials path.
+	path, err := subpath(remoteServerCredentialsName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compute credentials path: %w", err)
+	}
+
+	// Attempt to load existing credentials.
+	credentials := &RemoteServerCredentials{}
+	if err := encoding.LoadAndUnmarshalYAML(path, credentials); err == nil {
+		if err := credentials.EnsureValid(); err != nil {
+			return nil, fmt.Errorf("invalid credentials found on disk: %w", err)
+		}
+		return credentials, nil
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("unable to load credentials: %w", err)
+	}
+
+	// Generate and save new credentials.
+	credentials, err = newRemoteServerCredentials()
+	if err != nil {
+		return nil, err
+	}
+	if err := encoding.MarshalAndSaveYAML(path, credentials); err != nil {
+		return nil, fmt.Errorf("unable to save credentials: %w", err)
+	}
+
+	// Success.
+	return credentials, nil
+}
+
+// RemoteCredentialsPath computes the default path to the credentials used by
+// clients to access a remote daemon, creating any intermediate directories as
+// necessary.
+func RemoteCredentialsPath() (string, error) {
+	return subpath(remoteCredentialsName)
+}
+
+// LoadRemoteCredentials loads and validates remote daemon access credentials
+// from the specified path.
+func LoadRemoteCredentials(path string) (*RemoteCredentials, error) {
+	credentials := &RemoteCredentials{}
+	if err := encoding.LoadAndUnmarshalYAML(path, credentials); err != nil {
+		return nil, err
+	} else if err = credentials.EnsureValid(); err != nil {
+		return nil, fmt.Errorf("invalid credentials: %w", err)
+	}
+	return credentials, nil
+}
@@ -0,0 +1,136 @@
+package daemon
+
+import (
+	"crypto/tls"
+	"net"
+	"testing"
+)
+
+// TestRemoteCredentialsEnsureValid tests RemoteCredentials.EnsureValid.
+func TestRemoteCredentialsEnsureValid(t *testing.T) {
+	// Generate valid credentials.
+	credentials, err := newRemoteServerCredentials()
+	if err != nil {
+		t.Fatal("unable to generate credentials:", err)
+	}
+
+	// Define test cases.
+	tests := []struct {
+		credentials *RemoteCredentials
+		expected    bool
+	}{
+		{nil, false},
+		{&RemoteCredentials{}, false},
+		{&RemoteCredentials{Certificate: credentials.Certificate}, false},
+		{&RemoteCredentials{Certificate: "invalid", Token: credentials.Token}, false},
+		{&credentials.RemoteCredentials, true},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		if err := test.credentials.EnsureValid(); err == nil && !test.expected {
+			t.Errorf("test index %d: invalid credentials classified as valid", i)
+		} else if err != nil && test.expected {
+			t.Errorf("test index %d: valid credentials classified as invalid: %v", i, err)
+		}
+	}
+}
+
+// testRemoteHandshake performs a TLS handshake between a server using the
+// specified server credentials and a client using the specified client
+// credentials.
+func testRemoteHandshake(t *testing.T, server *RemoteServerCredentials, client *RemoteCredentials) error {
+	// Create TLS configurations.
+	serverConfiguration, err := server.ServerTLSConfiguration()
+	if err != nil {
+		t.Fatal("unable to create server TLS configuration:", err)
+	}
+	clientConfiguration, err := client.ClientTLSConfiguration()
+	if err != nil {
+		t.Fatal("unable to create client TLS configuration:", err)
+	}
+
+	// Create a loopback listener and defer its closure.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("unable to create listener:", err)
+	}
+	defer listener.Close()
+
+	// Perform the server handshake in the background.
+	serverErrors := make(chan error, 1)
+	go func() {
+		connection, err := listener.Accept()
+		if err != nil {
+			serverErrors <- err
+			return
+		}
+		serverErrors <- tls.Server(connection, serverConfiguration).Handshake()
+		connection.Close()
+	}()
+
+	// Perform the client handshake.
+	connection, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatal("unable to connect to listener:", err)
+	}
+	err = tls.Client(connection, clientConfiguration).Handshake()
+	connection.Close()
+	<-serverErrors
+	return err
+}
+
+// TestRemoteCredentialsPinning tests that client TLS configurations only accept
+// the pinned daemon certificate.
+func TestRemoteCredentialsPinning(t *testing.T) {
+	// Generate two sets of credentials.
+	first, err := newRemoteServerCredentials()
+	if err != nil {
+		t.Fatal("unable to generate credentials:", err)
+	}
+	second, err := newRemoteServerCredentials()
+	if err != nil {
+		t.Fatal("unable to generate credentials:", err)
+	}
+
+	// Verify that a matching certificate is accepted.
+	if err := testRemoteHandshake(t, first, &first.RemoteCredentials); err != nil {
+		t.Error("handshake failed with pinned certificate:", err)
+	}
+
+	// Verify that a different certificate is rejected.
+	if err := testRemoteHandshake(t, second, &first.RemoteCredentials); err == nil {
+		t.Error("handshake succeeded with mismatched certificate")
+	}
+}
+
+// TestLoadOrCreateRemoteServerCredentials tests that remote server credentials
+// are generated once and then reloaded.
+func TestLoadOrCreateRemoteServerCredentials(t *testing.T) {
+	// Use a temporary data directory to avoid modifying real credentials.
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+
+	// Load or create credentials.
+	first, err := LoadOrCreateRemoteServerCredentials()
+	if err != nil {
+		t.Fatal("unable to load or create credentials:", err)
+	}
+
+	// Reload them and ensure that they match.
+	second, err := LoadOrCreateRemoteServerCredentials()
+	if err != nil {
+		t.Fatal("unable to reload credentials:", err)
+	}
+	if *first != *second {
+		t.Error("reloaded credentials don't match")
+	}
+}
+
+// TestRemoteCredentialsPath tests that RemoteCredentialsPath succeeds.
+func TestRemoteCredentialsPath(t *testing.T) {
+	if path, err := RemoteCredentialsPath(); err != nil {
+		t.Fatal("unable to compute remote credentials path:", err)
+	} else if path == "" {
+		t.Error("empty remote credentials path returned")
+	}
+}
@@ -11,3 +11,11 @@ func LoadAndUnmarshalYAML(path string, value any) error {
 		return yaml.UnmarshalStrict(data, value)
 	})
 }
+
+// MarshalAndSaveYAML marshals the specified value as YAML and saves it to the
+// specified path.
+func MarshalAndSaveYAML(path string, value any) error {
+	return MarshalAndSave(path, func() ([]byte, error) {
+		return yaml.Marshal(value)
+	})
+}
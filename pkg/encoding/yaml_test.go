@@ -2,6 +2,7 @@ package encoding
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -54,3 +55,38 @@ func TestLoadAndUnmarshalYAML(t *testing.T) {
 		t.Error("test message age mismatch:", value.Section.Age, "!=", testMessageYAMLAge)
 	}
 }
+
+// TestYAMLCycle tests a YAML marshal/save/load/unmarshal cycle.
+func TestYAMLCycle(t *testing.T) {
+	// Create a temporary directory and defer its cleanup.
+	directory, err := os.MkdirTemp("", "mutagen_encoding")
+	if err != nil {
+		t.Fatal("unable to create temporary directory:", err)
+	}
+	defer os.RemoveAll(directory)
+
+	// Compute the file path.
+	path := filepath.Join(directory, "file.yml")
+
+	// Create and save a value.
+	value := &testMessageYAML{}
+	value.Section.Name = testMessageYAMLName
+	value.Section.Age = testMessageYAMLAge
+	if err := MarshalAndSaveYAML(path, value); err != nil {
+		t.Fatal("unable to marshal and save value:", err)
+	}
+
+	// Reload the value.
+	decoded := &testMessageYAML{}
+	if err := LoadAndUnmarshalYAML(path, decoded); err != nil {
+		t.Fatal("unable to load and unmarshal value:", err)
+	}
+
+	// Verify test value names.
+	if decoded.Section.Name != testMessageYAMLName {
+		t.Error("test message name mismatch:", decoded.Section.Name, "!=", testMessageYAMLName)
+	}
+	if decoded.Section.Age != testMessageYAMLAge {
+		t.Error("test message age mismatch:", decoded.Section.Age, "!=", testMessageYAMLAge)
+	}
+}
@@ -51,6 +51,11 @@ const (
 	// directory.
 	MutagenSynchronizationStagingDirectoryName = "staging"
 
+	// MutagenSynchronizationHistoriesDirectoryName is the name of the
+	// synchronization history storage directory within the Mutagen data
+	// directory.
+	MutagenSynchronizationHistoriesDirectoryName = "histories"
+
 	// MutagenForwardingDirectoryName is the name of the forwarding data
 	// directory within the Mutagen data directory.
 	MutagenForwardingDirectoryName = "forwarding"
@@ -0,0 +1,130 @@
+package forwarding
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// ParseNetwork parses a network specification used for listener access
+// control. The specification may be provided in CIDR notation or as a single IP
+// address, in which case it is treated as a network containing only that
+// address.
+func ParseNetwork(specification string) (netip.Prefix, error) {
+	// Handle CIDR notation.
+	if strings.ContainsRune(specification, '/') {
+		prefix, err := netip.ParsePrefix(specification)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid network specification: %w", err)
+		}
+		return prefix.Masked(), nil
+	}
+
+	// Handle single addresses.
+	address, err := netip.ParseAddr(specification)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid address specification: %w", err)
+	}
+	address = address.Unmap()
+	return netip.PrefixFrom(address, address.BitLen()), nil
+}
+
+// NetworkFilter performs address-based access control for incoming
+// connections. A nil filter allows all addresses.
+type NetworkFilter struct {
+	// allowed is the list of allowed networks. If empty, all networks that
+	// aren't explicitly denied are allowed.
+	allowed []netip.Prefix
+	// denied is the list of denied networks.
+	denied []netip.Prefix
+}
+
+// NewNetworkFilter creates a new network filter from the specified allowed and
+// denied network specifications. If both lists are empty, then it returns a
+// nil filter.
+func NewNetworkFilter(allowed, denied []string) (*NetworkFilter, error) {
+	// If there are no restrictions, then there's no need for a filter.
+	if len(allowed) == 0 && len(denied) == 0 {
+		return nil, nil
+	}
+
+	// Parse allowed networks.
+	result := &NetworkFilter{}
+	for _, specification := range allowed {
+		if prefix, err := ParseNetwork(specification); err != nil {
+			return nil, fmt.Errorf("invalid allowed network (%s): %w", specification, err)
+		} else {
+			result.allowed = append(result.allowed, prefix)
+		}
+	}
+
+	// Parse denied networks.
+	for _, specification := range denied {
+		if prefix, err := ParseNetwork(specification); err != nil {
+			return nil, fmt.Errorf("invalid denied network (%s): %w", specification, err)
+		} else {
+			result.denied = append(result.denied, prefix)
+		}
+	}
+
+	// Success.
+	return result, nil
+}
+
+// Allows determines whether or not the filter allows connections from the
+// specified address. Denied networks take precedence over allowed networks.
+func (f *NetworkFilter) Allows(address netip.Addr) bool {
+	// A nil filter allows everything.
+	if f == nil {
+		return true
+	}
+
+	// Treat IPv4-mapped IPv6 addresses as IPv4 addresses so that dual-stack
+	// listeners behave as expected with IPv4 network specifications.
+	address = address.Unmap()
+
+	// Check for explicit denials.
+	for _, prefix := range f.denied {
+		if prefix.Contains(address) {
+			return false
+		}
+	}
+
+	// If no allowed networks have been specified, then anything not denied is
+	// allowed.
+	if len(f.allowed) == 0 {
+		return true
+	}
+
+	// Otherwise require an explicit allowance.
+	for _, prefix := range f.allowed {
+		if prefix.Contains(address) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsRemoteAddress determines whether or not the filter allows connections
+// from the specified remote address. Addresses that aren't TCP addresses are
+// always refused by non-nil filters.
+func (f *NetworkFilter) AllowsRemoteAddress(address net.Addr) bool {
+	// A nil filter allows everything.
+	if f == nil {
+		return true
+	}
+
+	// Extract the IP address.
+	tcpAddress, ok := address.(*net.TCPAddr)
+	if !ok {
+		return false
+	}
+	ip, ok := netip.AddrFromSlice(tcpAddress.IP)
+	if !ok {
+		return false
+	}
+
+	// Perform the check.
+	return f.Allows(ip)
+}
@@ -0,0 +1,106 @@
+package forwarding
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+)
+
+// TestParseNetwork tests ParseNetwork.
+func TestParseNetwork(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		specification string
+		expected      string
+		expectFailure bool
+	}{
+		{"", "", true},
+		{"asdf", "", true},
+		{"10.0.0.0/33", "", true},
+		{"10.0.0.0/8", "10.0.0.0/8", false},
+		{"10.1.2.3/8", "10.0.0.0/8", false},
+		{"192.168.1.1", "192.168.1.1/32", false},
+		{"::ffff:192.168.1.1", "192.168.1.1/32", false},
+		{"fd00::/8", "fd00::/8", false},
+		{"::1", "::1/128", false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if prefix, err := ParseNetwork(testCase.specification); err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("unable to parse network (%s): %v", testCase.specification, err)
+			}
+		} else if testCase.expectFailure {
+			t.Error("parsing succeeded unexpectedly for network:", testCase.specification)
+		} else if prefix.String() != testCase.expected {
+			t.Errorf("parsed network (%s) does not match expected (%s)", prefix, testCase.expected)
+		}
+	}
+}
+
+// TestNetworkFilter tests NetworkFilter.
+func TestNetworkFilter(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		allowed       []string
+		denied        []string
+		address       string
+		expectAllowed bool
+	}{
+		{nil, nil, "203.0.113.1", true},
+		{[]string{"127.0.0.1"}, nil, "127.0.0.1", true},
+		{[]string{"127.0.0.1"}, nil, "::ffff:127.0.0.1", true},
+		{[]string{"127.0.0.1"}, nil, "127.0.0.2", false},
+		{[]string{"10.0.0.0/8"}, []string{"10.0.0.13"}, "10.0.0.12", true},
+		{[]string{"10.0.0.0/8"}, []string{"10.0.0.13"}, "10.0.0.13", false},
+		{nil, []string{"192.168.0.0/16"}, "192.168.4.4", false},
+		{nil, []string{"192.168.0.0/16"}, "172.16.0.1", true},
+		{[]string{"::1"}, nil, "::1", true},
+		{[]string{"::1"}, nil, "127.0.0.1", false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		filter, err := NewNetworkFilter(testCase.allowed, testCase.denied)
+		if err != nil {
+			t.Fatal("unable to create network filter:", err)
+		}
+		address := netip.MustParseAddr(testCase.address)
+		if allowed := filter.Allows(address); allowed != testCase.expectAllowed {
+			t.Errorf("address %s allowance (%t) does not match expected (%t)",
+				testCase.address, allowed, testCase.expectAllowed,
+			)
+		}
+		remote := &net.TCPAddr{IP: net.ParseIP(testCase.address), Port: 12345}
+		if allowed := filter.AllowsRemoteAddress(remote); allowed != testCase.expectAllowed {
+			t.Errorf("remote address %s allowance (%t) does not match expected (%t)",
+				remote, allowed, testCase.expectAllowed,
+			)
+		}
+	}
+}
+
+// TestNetworkFilterInvalid tests that NewNetworkFilter rejects invalid network
+// specifications.
+func TestNetworkFilterInvalid(t *testing.T) {
+	if _, err := NewNetworkFilter([]string{"asdf"}, nil); err == nil {
+		t.Error("network filter creation succeeded with invalid allowed network")
+	}
+	if _, err := NewNetworkFilter(nil, []string{"10.0.0.0/64"}); err == nil {
+		t.Error("network filter creation succeeded with invalid denied network")
+	}
+}
+
+// TestNilNetworkFilter tests that a nil network filter allows all addresses.
+func TestNilNetworkFilter(t *testing.T) {
+	filter, err := NewNetworkFilter(nil, nil)
+	if err != nil {
+		t.Fatal("unable to create network filter:", err)
+	} else if filter != nil {
+		t.Fatal("non-nil filter returned for empty restrictions")
+	}
+	if !filter.AllowsRemoteAddress(&net.UnixAddr{Name: "socket", Net: "unix"}) {
+		t.Error("nil filter refused remote address")
+	}
+}
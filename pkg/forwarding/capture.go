@@ -0,0 +1,327 @@
+package forwarding
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/stream"
+)
+
+const (
+	// DefaultCaptureMaximumFileSize is the default size (in bytes) at which
+	// capture files are rotated.
+	DefaultCaptureMaximumFileSize = 64 * 1024 * 1024
+	// DefaultCaptureMaximumFileCount is the default number of capture files
+	// retained for each capture.
+	DefaultCaptureMaximumFileCount = 5
+)
+
+const (
+	// captureTimestampFormat is the format used for the timestamp included in
+	// capture file names.
+	captureTimestampFormat = "20060102T150405Z"
+)
+
+// captureEncoder is the interface implemented by capture file formats. All
+// methods are invoked with the capture lock held.
+type captureEncoder interface {
+	// header writes any file header required at the start of each capture
+	// file.
+	header(writer io.Writer) error
+	// open records the opening of a connection.
+	open(writer io.Writer, timestamp time.Time, connection *captureConnection) error
+	// data records data transmitted on a connection.
+	data(writer io.Writer, timestamp time.Time, connection *captureConnection, outbound bool, data []byte) error
+	// close records the closure of a connection.
+	close(writer io.Writer, timestamp time.Time, connection *captureConnection) error
+}
+
+// rotatingFile is an io.Writer that writes to a file, rotating it once it
+// exceeds a maximum size. Rotated files are renamed with an increasing numeric
+// suffix (inserted before the extension), with the oldest files being removed.
+type rotatingFile struct {
+	// path is the path of the current file.
+	path string
+	// maximumSize is the size at which the file will be rotated.
+	maximumSize uint64
+	// maximumCount is the maximum number of files (including the current file)
+	// to retain.
+	maximumCount int
+	// header is invoked to write any header required at the start of each
+	// file.
+	header func(io.Writer) error
+	// file is the current file.
+	file *os.File
+	// size is the number of bytes written to the current file.
+	size uint64
+	// writingHeader indicates that the header is currently being written, in
+	// which case rotation is suppressed.
+	writingHeader bool
+}
+
+// newRotatingFile creates a new rotating file, creating (or truncating) the
+// file at the specified path and writing its header.
+func newRotatingFile(path string, maximumSize uint64, maximumCount int, header func(io.Writer) error) (*rotatingFile, error) {
+	result := &rotatingFile{
+		path:         path,
+		maximumSize:  maximumSize,
+		maximumCount: maximumCount,
+		header:       header,
+	}
+	if err := result.open(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// rotatedPath computes the path for the rotated file with the specified index.
+// Index 0 corresponds to the current file.
+func (f *rotatingFile) rotatedPath(index int) string {
+	if index == 0 {
+		return f.path
+	}
+	extension := filepath.Ext(f.path)
+	return fmt.Sprintf("%s.%d%s", strings.TrimSuffix(f.path, extension), index, extension)
+}
+
+// open opens the current file and writes its header.
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to open capture file: %w", err)
+	}
+	f.file = file
+	f.size = 0
+	f.writingHeader = true
+	err = f.header(f)
+	f.writingHeader = false
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("unable to write capture file header: %w", err)
+	}
+	return nil
+}
+
+// rotate closes the current file, shifts existing files, and opens a new file.
+func (f *rotatingFile) rotate() error {
+	// Close the current file.
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("unable to close capture file: %w", err)
+	}
+
+	// Remove the oldest file and shift the remaining files. We ignore errors
+	// here since older files may not exist. If only a single file is retained,
+	// then the current file is simply truncated when it's reopened.
+	if f.maximumCount > 1 {
+		os.Remove(f.rotatedPath(f.maximumCount - 1))
+		for i := f.maximumCount - 2; i >= 0; i-- {
+			os.Rename(f.rotatedPath(i), f.rotatedPath(i+1))
+		}
+	}
+
+	// Open a new file.
+	return f.open()
+}
+
+// Write implements io.Writer.Write.
+func (f *rotatingFile) Write(data []byte) (int, error) {
+	// Rotate if necessary. We never rotate while writing a header or when the
+	// file is empty, even if the write is larger than the maximum size.
+	if !f.writingHeader && f.size > 0 && f.size+uint64(len(data)) > f.maximumSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	// Perform the write.
+	n, err := f.file.Write(data)
+	f.size += uint64(n)
+	return n, err
+}
+
+// Close closes the current file.
+func (f *rotatingFile) Close() error {
+	return f.file.Close()
+}
+
+// capture records forwarded traffic to rotating capture files.
+type capture struct {
+	// logger is the underlying logger.
+	logger *logging.Logger
+	// path is the path of the current capture file.
+	path string
+	// serverPort is the port used as the synthetic server port in capture
+	// formats that require one.
+	serverPort uint16
+	// lock serializes access to the capture.
+	lock sync.Mutex
+	// encoder is the capture format encoder.
+	encoder captureEncoder
+	// file is the rotating capture file.
+	file *rotatingFile
+	// nextConnection is the identifier to assign to the next connection.
+	nextConnection uint32
+	// failed indicates that an error has occurred, in which case recording has
+	// been halted.
+	failed bool
+	// closed indicates whether or not the capture has been closed.
+	closed bool
+}
+
+// newCapture creates a new capture for the session with the specified
+// identifier. Capture files will be created in the specified directory, which
+// will be created if it doesn't exist. Capture file names include the session
+// identifier and the capture's start time (with a numeric suffix if necessary
+// to avoid collisions), so each capture has its own set of files and never
+// overwrites an earlier capture. The server port is used as the synthetic
+// server port for formats that require one.
+func newCapture(
+	logger *logging.Logger,
+	identifier, directory string,
+	format CaptureFormat,
+	maximumFileSize uint64,
+	maximumFileCount uint32,
+	serverPort uint16,
+) (*capture, error) {
+	// Apply defaults.
+	if format.IsDefault() {
+		format = CaptureFormat_CaptureFormatPCAPNG
+	} else if !format.Supported() {
+		return nil, errors.New("unknown or unsupported capture format")
+	}
+	if maximumFileSize == 0 {
+		maximumFileSize = DefaultCaptureMaximumFileSize
+	}
+	if maximumFileCount == 0 {
+		maximumFileCount = DefaultCaptureMaximumFileCount
+	}
+
+	// Create the encoder.
+	var encoder captureEncoder
+	switch format {
+	case CaptureFormat_CaptureFormatPCAPNG:
+		encoder = &pcapngEncoder{}
+	case CaptureFormat_CaptureFormatTranscript:
+		encoder = &transcriptEncoder{}
+	default:
+		panic("unhandled capture format")
+	}
+
+	// Ensure that the capture directory exists.
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return nil, fmt.Errorf("unable to create capture directory: %w", err)
+	}
+
+	// Compute a capture file path that's not already in use.
+	base := filepath.Join(directory, identifier+"-"+time.Now().UTC().Format(captureTimestampFormat))
+	path := base + format.extension()
+	for i := 2; ; i++ {
+		if _, err := os.Lstat(path); err != nil {
+			break
+		}
+		path = fmt.Sprintf("%s-%d%s", base, i, format.extension())
+	}
+
+	// Create the capture file.
+	file, err := newRotatingFile(path, maximumFileSize, int(maximumFileCount), encoder.header)
+	if err != nil {
+		return nil, err
+	}
+
+	// Success.
+	return &capture{
+		logger:     logger,
+		path:       path,
+		serverPort: serverPort,
+		encoder:    encoder,
+		file:       file,
+	}, nil
+}
+
+// record invokes the specified recording operation, disabling recording if
+// it fails.
+func (c *capture) record(operation func(writer io.Writer, timestamp time.Time) error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.closed || c.failed {
+		return
+	}
+	if err := operation(c.file, time.Now()); err != nil {
+		c.logger.Warn("Traffic capture failed:", err)
+		c.failed = true
+	}
+}
+
+// connection registers a new connection with the capture and records its
+// opening.
+func (c *capture) connection() *captureConnection {
+	// Allocate a connection identifier.
+	c.lock.Lock()
+	c.nextConnection++
+	result := &captureConnection{
+		capture:    c,
+		identifier: c.nextConnection,
+		serverPort: c.serverPort,
+	}
+	c.lock.Unlock()
+
+	// Record the opening.
+	c.record(func(writer io.Writer, timestamp time.Time) error {
+		return c.encoder.open(writer, timestamp, result)
+	})
+
+	// Done.
+	return result
+}
+
+// close terminates recording and closes the capture file.
+func (c *capture) close() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	return c.file.Close()
+}
+
+// captureConnection tracks a single forwarded connection within a capture.
+type captureConnection struct {
+	// capture is the parent capture.
+	capture *capture
+	// identifier is the connection identifier within the capture.
+	identifier uint32
+	// serverPort is the synthetic server port.
+	serverPort uint16
+	// clientSequence is the next synthetic TCP sequence number for data sent
+	// from the client (source) side. It is only used by the pcapng encoder.
+	clientSequence uint32
+	// serverSequence is the next synthetic TCP sequence number for data sent
+	// from the server (destination) side. It is only used by the pcapng
+	// encoder.
+	serverSequence uint32
+}
+
+// recorder returns a recorder for data in the specified direction. Outbound
+// data is data sent from the source side to the destination side.
+func (c *captureConnection) recorder(outbound bool) stream.Recorder {
+	return func(data []byte) {
+		c.capture.record(func(writer io.Writer, timestamp time.Time) error {
+			return c.capture.encoder.data(writer, timestamp, c, outbound, data)
+		})
+	}
+}
+
+// close records the closure of the connection.
+func (c *captureConnection) close() {
+	c.capture.record(func(writer io.Writer, timestamp time.Time) error {
+		return c.capture.encoder.close(writer, timestamp, c)
+	})
+}
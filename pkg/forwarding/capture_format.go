@@ -0,0 +1,84 @@
+package forwarding
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the capture format is
+// CaptureFormat_CaptureFormatDefault.
+func (f CaptureFormat) IsDefault() bool {
+	return f == CaptureFormat_CaptureFormatDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (f CaptureFormat) MarshalText() ([]byte, error) {
+	var result string
+	switch f {
+	case CaptureFormat_CaptureFormatDefault:
+	case CaptureFormat_CaptureFormatPCAPNG:
+		result = "pcapng"
+	case CaptureFormat_CaptureFormatTranscript:
+		result = "transcript"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (f *CaptureFormat) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a capture format.
+	switch text {
+	case "pcapng":
+		*f = CaptureFormat_CaptureFormatPCAPNG
+	case "transcript":
+		*f = CaptureFormat_CaptureFormatTranscript
+	default:
+		return fmt.Errorf("unknown capture format specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular capture format is a valid,
+// non-default value.
+func (f CaptureFormat) Supported() bool {
+	switch f {
+	case CaptureFormat_CaptureFormatPCAPNG:
+		return true
+	case CaptureFormat_CaptureFormatTranscript:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a capture format.
+func (f CaptureFormat) Description() string {
+	switch f {
+	case CaptureFormat_CaptureFormatDefault:
+		return "Default"
+	case CaptureFormat_CaptureFormatPCAPNG:
+		return "pcapng"
+	case CaptureFormat_CaptureFormatTranscript:
+		return "Transcript"
+	default:
+		return "Unknown"
+	}
+}
+
+// extension returns the file extension used for capture files in the format.
+func (f CaptureFormat) extension() string {
+	switch f {
+	case CaptureFormat_CaptureFormatPCAPNG:
+		return ".pcapng"
+	case CaptureFormat_CaptureFormatTranscript:
+		return ".txt"
+	default:
+		panic("unhandled capture format")
+	}
+}
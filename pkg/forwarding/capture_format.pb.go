@@ -0,0 +1,146 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.12
+// source: forwarding/capture_format.proto
+
+package forwarding
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CaptureFormat specifies the file format used to record forwarded traffic.
+type CaptureFormat int32
+
+const (
+	// CaptureFormat_CaptureFormatDefault represents an unspecified capture
+	// format. It should be converted to one of the following values based on
+	// the desired default behavior.
+	CaptureFormat_CaptureFormatDefault CaptureFormat = 0
+	// CaptureFormat_CaptureFormatPCAPNG specifies that traffic should be
+	// recorded in pcapng format, with synthetic TCP framing for each forwarded
+	// connection.
+	CaptureFormat_CaptureFormatPCAPNG CaptureFormat = 1
+	// CaptureFormat_CaptureFormatTranscript specifies that traffic should be
+	// recorded as a timestamped, human-readable transcript.
+	CaptureFormat_CaptureFormatTranscript CaptureFormat = 2
+)
+
+// Enum value maps for CaptureFormat.
+var (
+	CaptureFormat_name = map[int32]string{
+		0: "CaptureFormatDefault",
+		1: "CaptureFormatPCAPNG",
+		2: "CaptureFormatTranscript",
+	}
+	CaptureFormat_value = map[string]int32{
+		"CaptureFormatDefault":    0,
+		"CaptureFormatPCAPNG":     1,
+		"CaptureFormatTranscript": 2,
+	}
+)
+
+func (x CaptureFormat) Enum() *CaptureFormat {
+	p := new(CaptureFormat)
+	*p = x
+	return p
+}
+
+func (x CaptureFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CaptureFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_forwarding_capture_format_proto_enumTypes[0].Descriptor()
+}
+
+func (CaptureFormat) Type() protoreflect.EnumType {
+	return &file_forwarding_capture_format_proto_enumTypes[0]
+}
+
+func (x CaptureFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CaptureFormat.Descriptor instead.
+func (CaptureFormat) EnumDescriptor() ([]byte, []int) {
+	return file_forwarding_capture_format_proto_rawDescGZIP(), []int{0}
+}
+
+var File_forwarding_capture_format_proto protoreflect.FileDescriptor
+
+var file_forwarding_capture_format_proto_rawDesc = []byte{
+	0x0a, 0x1f, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2f, 0x63, 0x61, 0x70,
+	0x74, 0x75, 0x72, 0x65, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x0a, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2a, 0x5f, 0x0a,
+	0x0d, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x18,
+	0x0a, 0x14, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x44,
+	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x61, 0x70, 0x74,
+	0x75, 0x72, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x50, 0x43, 0x41, 0x50, 0x4e, 0x47, 0x10,
+	0x01, 0x12, 0x1b, 0x0a, 0x17, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x46, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x10, 0x02, 0x42, 0x2e,
+	0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x75, 0x74,
+	0x61, 0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2f,
+	0x70, 0x6b, 0x67, 0x2f, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_forwarding_capture_format_proto_rawDescOnce sync.Once
+	file_forwarding_capture_format_proto_rawDescData = file_forwarding_capture_format_proto_rawDesc
+)
+
+func file_forwarding_capture_format_proto_rawDescGZIP() []byte {
+	file_forwarding_capture_format_proto_rawDescOnce.Do(func() {
+		file_forwarding_capture_format_proto_rawDescData = protoimpl.X.CompressGZIP(file_forwarding_capture_format_proto_rawDescData)
+	})
+	return file_forwarding_capture_format_proto_rawDescData
+}
+
+var file_forwarding_capture_format_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_forwarding_capture_format_proto_goTypes = []interface{}{
+	(CaptureFormat)(0), // 0: forwarding.CaptureFormat
+}
+var file_forwarding_capture_format_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_forwarding_capture_format_proto_init() }
+func file_forwarding_capture_format_proto_init() {
+	if File_forwarding_capture_format_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_forwarding_capture_format_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_forwarding_capture_format_proto_goTypes,
+		DependencyIndexes: file_forwarding_capture_format_proto_depIdxs,
+		EnumInfos:         file_forwarding_capture_format_proto_enumTypes,
+	}.Build()
+	File_forwarding_capture_format_proto = out.File
+	file_forwarding_capture_format_proto_rawDesc = nil
+	file_forwarding_capture_format_proto_goTypes = nil
+	file_forwarding_capture_format_proto_depIdxs = nil
+}
@@ -0,0 +1,269 @@
+package forwarding
+
+import (
+	"encoding/binary"
+	"io"
+	"time"
+)
+
+const (
+	// pcapngBlockTypeSectionHeader is the pcapng section header block type.
+	pcapngBlockTypeSectionHeader = 0x0A0D0D0A
+	// pcapngBlockTypeInterfaceDescription is the pcapng interface description
+	// block type.
+	pcapngBlockTypeInterfaceDescription = 0x00000001
+	// pcapngBlockTypeEnhancedPacket is the pcapng enhanced packet block type.
+	pcapngBlockTypeEnhancedPacket = 0x00000006
+	// pcapngByteOrderMagic is the pcapng byte order magic value.
+	pcapngByteOrderMagic = 0x1A2B3C4D
+	// pcapngLinkTypeRaw is the LINKTYPE_RAW link type, indicating that packets
+	// begin with a raw IPv4 or IPv6 header.
+	pcapngLinkTypeRaw = 101
+
+	// pcapngIPv4HeaderSize is the size of the synthetic IPv4 header.
+	pcapngIPv4HeaderSize = 20
+	// pcapngTCPHeaderSize is the size of the synthetic TCP header.
+	pcapngTCPHeaderSize = 20
+	// pcapngMaximumSegmentSize is the maximum payload size for a synthetic TCP
+	// segment, chosen to keep the total packet size within the IPv4 limit.
+	pcapngMaximumSegmentSize = 65535 - pcapngIPv4HeaderSize - pcapngTCPHeaderSize
+
+	// pcapngTCPFlagFIN is the TCP FIN flag.
+	pcapngTCPFlagFIN = 0x01
+	// pcapngTCPFlagSYN is the TCP SYN flag.
+	pcapngTCPFlagSYN = 0x02
+	// pcapngTCPFlagPSH is the TCP PSH flag.
+	pcapngTCPFlagPSH = 0x08
+	// pcapngTCPFlagACK is the TCP ACK flag.
+	pcapngTCPFlagACK = 0x10
+
+	// pcapngDefaultServerPort is the synthetic server port used when the
+	// destination port can't be determined.
+	pcapngDefaultServerPort = 80
+	// pcapngClientPortBase is the base for synthetic client ports, which are
+	// derived from connection identifiers.
+	pcapngClientPortBase = 49152
+)
+
+var (
+	// pcapngClientAddress is the synthetic client (source side) IPv4 address.
+	pcapngClientAddress = [4]byte{10, 0, 0, 1}
+	// pcapngServerAddress is the synthetic server (destination side) IPv4
+	// address.
+	pcapngServerAddress = [4]byte{10, 0, 0, 2}
+)
+
+// pcapngEncoder is a captureEncoder that records traffic in pcapng format.
+// Since forwarded traffic is captured at the stream level, each connection is
+// represented as a synthetic IPv4 TCP flow between fixed addresses, allowing
+// the capture to be analyzed with standard tools.
+type pcapngEncoder struct{}
+
+// pcapngPad computes the padded length of a block body.
+func pcapngPad(length int) int {
+	return (length + 3) &^ 3
+}
+
+// writeBlock writes a pcapng block with the specified type and body.
+func (e *pcapngEncoder) writeBlock(writer io.Writer, blockType uint32, body []byte) error {
+	// Compute the total block length.
+	padded := pcapngPad(len(body))
+	length := 12 + padded
+
+	// Encode the block.
+	block := make([]byte, length)
+	binary.LittleEndian.PutUint32(block[0:4], blockType)
+	binary.LittleEndian.PutUint32(block[4:8], uint32(length))
+	copy(block[8:], body)
+	binary.LittleEndian.PutUint32(block[length-4:], uint32(length))
+
+	// Write the block.
+	_, err := writer.Write(block)
+	return err
+}
+
+// header implements captureEncoder.header.
+func (e *pcapngEncoder) header(writer io.Writer) error {
+	// Write the section header block.
+	section := make([]byte, 16)
+	binary.LittleEndian.PutUint32(section[0:4], pcapngByteOrderMagic)
+	binary.LittleEndian.PutUint16(section[4:6], 1)
+	binary.LittleEndian.PutUint16(section[6:8], 0)
+	binary.LittleEndian.PutUint64(section[8:16], ^uint64(0))
+	if err := e.writeBlock(writer, pcapngBlockTypeSectionHeader, section); err != nil {
+		return err
+	}
+
+	// Write the interface description block. We use the default timestamp
+	// resolution (microseconds) and no snapshot length limit.
+	description := make([]byte, 8)
+	binary.LittleEndian.PutUint16(description[0:2], pcapngLinkTypeRaw)
+	binary.LittleEndian.PutUint32(description[4:8], 0)
+	return e.writeBlock(writer, pcapngBlockTypeInterfaceDescription, description)
+}
+
+// checksum computes the Internet checksum of the specified data with the
+// specified initial sum.
+func checksum(sum uint32, data []byte) uint16 {
+	for len(data) >= 2 {
+		sum += uint32(binary.BigEndian.Uint16(data))
+		data = data[2:]
+	}
+	if len(data) == 1 {
+		sum += uint32(data[0]) << 8
+	}
+	for sum > 0xffff {
+		sum = (sum >> 16) + (sum & 0xffff)
+	}
+	return ^uint16(sum)
+}
+
+// writePacket writes a synthetic TCP segment as an enhanced packet block.
+func (e *pcapngEncoder) writePacket(
+	writer io.Writer,
+	timestamp time.Time,
+	connection *captureConnection,
+	outbound bool,
+	flags byte,
+	sequence, acknowledgement uint32,
+	payload []byte,
+) error {
+	// Compute addresses and ports.
+	clientPort := uint16(pcapngClientPortBase + connection.identifier%(65536-pcapngClientPortBase))
+	serverPort := connection.serverPort
+	if serverPort == 0 {
+		serverPort = pcapngDefaultServerPort
+	}
+	sourceAddress, destinationAddress := pcapngClientAddress, pcapngServerAddress
+	sourcePort, destinationPort := clientPort, serverPort
+	if !outbound {
+		sourceAddress, destinationAddress = destinationAddress, sourceAddress
+		sourcePort, destinationPort = destinationPort, sourcePort
+	}
+
+	// Allocate the packet.
+	length := pcapngIPv4HeaderSize + pcapngTCPHeaderSize + len(payload)
+	packet := make([]byte, length)
+
+	// Encode the IPv4 header.
+	ip := packet[:pcapngIPv4HeaderSize]
+	ip[0] = 0x45
+	binary.BigEndian.PutUint16(ip[2:4], uint16(length))
+	ip[6] = 0x40
+	ip[8] = 64
+	ip[9] = 6
+	copy(ip[12:16], sourceAddress[:])
+	copy(ip[16:20], destinationAddress[:])
+	binary.BigEndian.PutUint16(ip[10:12], checksum(0, ip))
+
+	// Encode the TCP header and payload.
+	tcp := packet[pcapngIPv4HeaderSize:]
+	binary.BigEndian.PutUint16(tcp[0:2], sourcePort)
+	binary.BigEndian.PutUint16(tcp[2:4], destinationPort)
+	binary.BigEndian.PutUint32(tcp[4:8], sequence)
+	binary.BigEndian.PutUint32(tcp[8:12], acknowledgement)
+	tcp[12] = (pcapngTCPHeaderSize / 4) << 4
+	tcp[13] = flags
+	binary.BigEndian.PutUint16(tcp[14:16], 65535)
+	copy(tcp[pcapngTCPHeaderSize:], payload)
+
+	// Compute the TCP checksum using the IPv4 pseudo-header.
+	var pseudo uint32
+	pseudo += uint32(binary.BigEndian.Uint16(sourceAddress[0:2]))
+	pseudo += uint32(binary.BigEndian.Uint16(sourceAddress[2:4]))
+	pseudo += uint32(binary.BigEndian.Uint16(destinationAddress[0:2]))
+	pseudo += uint32(binary.BigEndian.Uint16(destinationAddress[2:4]))
+	pseudo += 6
+	pseudo += uint32(len(tcp))
+	binary.BigEndian.PutUint16(tcp[16:18], checksum(pseudo, tcp))
+
+	// Encode the enhanced packet block body.
+	microseconds := uint64(timestamp.UnixNano() / 1000)
+	body := make([]byte, 20+pcapngPad(length))
+	binary.LittleEndian.PutUint32(body[0:4], 0)
+	binary.LittleEndian.PutUint32(body[4:8], uint32(microseconds>>32))
+	binary.LittleEndian.PutUint32(body[8:12], uint32(microseconds))
+	binary.LittleEndian.PutUint32(body[12:16], uint32(length))
+	binary.LittleEndian.PutUint32(body[16:20], uint32(length))
+	copy(body[20:], packet)
+
+	// Write the block.
+	return e.writeBlock(writer, pcapngBlockTypeEnhancedPacket, body)
+}
+
+// open implements captureEncoder.open.
+func (e *pcapngEncoder) open(writer io.Writer, timestamp time.Time, connection *captureConnection) error {
+	// Perform a synthetic three-way handshake. The SYN and SYN-ACK segments
+	// each consume a sequence number.
+	if err := e.writePacket(writer, timestamp, connection, true,
+		pcapngTCPFlagSYN, 0, 0, nil,
+	); err != nil {
+		return err
+	}
+	if err := e.writePacket(writer, timestamp, connection, false,
+		pcapngTCPFlagSYN|pcapngTCPFlagACK, 0, 1, nil,
+	); err != nil {
+		return err
+	}
+	connection.clientSequence = 1
+	connection.serverSequence = 1
+	return e.writePacket(writer, timestamp, connection, true,
+		pcapngTCPFlagACK, connection.clientSequence, connection.serverSequence, nil,
+	)
+}
+
+// data implements captureEncoder.data.
+func (e *pcapngEncoder) data(writer io.Writer, timestamp time.Time, connection *captureConnection, outbound bool, data []byte) error {
+	for len(data) > 0 {
+		// Determine the segment size.
+		size := len(data)
+		if size > pcapngMaximumSegmentSize {
+			size = pcapngMaximumSegmentSize
+		}
+
+		// Write the segment and update sequence numbers.
+		if outbound {
+			if err := e.writePacket(writer, timestamp, connection, true,
+				pcapngTCPFlagPSH|pcapngTCPFlagACK,
+				connection.clientSequence, connection.serverSequence,
+				data[:size],
+			); err != nil {
+				return err
+			}
+			connection.clientSequence += uint32(size)
+		} else {
+			if err := e.writePacket(writer, timestamp, connection, false,
+				pcapngTCPFlagPSH|pcapngTCPFlagACK,
+				connection.serverSequence, connection.clientSequence,
+				data[:size],
+			); err != nil {
+				return err
+			}
+			connection.serverSequence += uint32(size)
+		}
+		data = data[size:]
+	}
+	return nil
+}
+
+// close implements captureEncoder.close.
+func (e *pcapngEncoder) close(writer io.Writer, timestamp time.Time, connection *captureConnection) error {
+	// Perform a synthetic connection teardown.
+	if err := e.writePacket(writer, timestamp, connection, true,
+		pcapngTCPFlagFIN|pcapngTCPFlagACK,
+		connection.clientSequence, connection.serverSequence, nil,
+	); err != nil {
+		return err
+	}
+	connection.clientSequence++
+	if err := e.writePacket(writer, timestamp, connection, false,
+		pcapngTCPFlagFIN|pcapngTCPFlagACK,
+		connection.serverSequence, connection.clientSequence, nil,
+	); err != nil {
+		return err
+	}
+	connection.serverSequence++
+	return e.writePacket(writer, timestamp, connection, true,
+		pcapngTCPFlagACK, connection.clientSequence, connection.serverSequence, nil,
+	)
+}
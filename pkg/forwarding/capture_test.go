@@ -0,0 +1,191 @@
+package forwarding
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestCapturePCAPNG tests that pcapng captures are well-formed.
+func TestCapturePCAPNG(t *testing.T) {
+	// Create a capture.
+	directory := t.TempDir()
+	capture, err := newCapture(nil, "session", directory, CaptureFormat_CaptureFormatPCAPNG, 0, 0, 8080)
+	if err != nil {
+		t.Fatal("unable to create capture:", err)
+	}
+
+	// Record a connection.
+	connection := capture.connection()
+	connection.recorder(true)([]byte("request"))
+	connection.recorder(false)([]byte("response"))
+	connection.close()
+	if err := capture.close(); err != nil {
+		t.Fatal("unable to close capture:", err)
+	}
+
+	// Read the capture file.
+	contents, err := os.ReadFile(capture.path)
+	if err != nil {
+		t.Fatal("unable to read capture file:", err)
+	}
+
+	// Walk the blocks and verify their structure.
+	var blockTypes []uint32
+	var payloads [][]byte
+	for offset := 0; offset < len(contents); {
+		if len(contents)-offset < 12 {
+			t.Fatal("truncated block")
+		}
+		blockType := binary.LittleEndian.Uint32(contents[offset:])
+		length := int(binary.LittleEndian.Uint32(contents[offset+4:]))
+		if length%4 != 0 || offset+length > len(contents) {
+			t.Fatal("invalid block length:", length)
+		} else if trailing := binary.LittleEndian.Uint32(contents[offset+length-4:]); int(trailing) != length {
+			t.Fatal("trailing block length does not match")
+		}
+		if blockType == pcapngBlockTypeEnhancedPacket {
+			captured := binary.LittleEndian.Uint32(contents[offset+20:])
+			packet := contents[offset+28 : offset+28+int(captured)]
+			payloads = append(payloads, packet[pcapngIPv4HeaderSize+pcapngTCPHeaderSize:])
+			if len(payloads) == 1 {
+				if port := binary.BigEndian.Uint16(packet[pcapngIPv4HeaderSize+2:]); port != 8080 {
+					t.Error("unexpected server port:", port)
+				}
+			}
+		}
+		blockTypes = append(blockTypes, blockType)
+		offset += length
+	}
+
+	// Verify the header blocks.
+	if len(blockTypes) < 2 ||
+		blockTypes[0] != pcapngBlockTypeSectionHeader ||
+		blockTypes[1] != pcapngBlockTypeInterfaceDescription {
+		t.Fatal("capture does not begin with section header and interface description")
+	}
+
+	// Verify the packets: a three-way handshake, two data segments, and a
+	// three-segment teardown.
+	if len(payloads) != 8 {
+		t.Fatal("unexpected packet count:", len(payloads))
+	}
+	if string(payloads[3]) != "request" {
+		t.Error("outbound payload does not match expected")
+	}
+	if string(payloads[4]) != "response" {
+		t.Error("inbound payload does not match expected")
+	}
+}
+
+// TestCaptureTranscript tests transcript captures.
+func TestCaptureTranscript(t *testing.T) {
+	// Create a capture.
+	directory := t.TempDir()
+	capture, err := newCapture(nil, "session", directory, CaptureFormat_CaptureFormatTranscript, 0, 0, 0)
+	if err != nil {
+		t.Fatal("unable to create capture:", err)
+	}
+
+	// Record a connection.
+	connection := capture.connection()
+	connection.recorder(true)([]byte("request"))
+	connection.close()
+	if err := capture.close(); err != nil {
+		t.Fatal("unable to close capture:", err)
+	}
+
+	// Verify the transcript.
+	contents, err := os.ReadFile(capture.path)
+	if err != nil {
+		t.Fatal("unable to read capture file:", err)
+	}
+	transcript := string(contents)
+	for _, expected := range []string{
+		"connection 1 opened",
+		"connection 1 outbound 7 bytes",
+		"request",
+		"connection 1 closed",
+	} {
+		if !strings.Contains(transcript, expected) {
+			t.Error("transcript missing expected content:", expected)
+		}
+	}
+}
+
+// TestCaptureRotation tests capture file rotation.
+func TestCaptureRotation(t *testing.T) {
+	// Create a capture with a small maximum file size.
+	directory := t.TempDir()
+	capture, err := newCapture(nil, "session", directory, CaptureFormat_CaptureFormatPCAPNG, 1024, 3, 0)
+	if err != nil {
+		t.Fatal("unable to create capture:", err)
+	}
+
+	// Record enough data to force multiple rotations.
+	connection := capture.connection()
+	payload := bytes.Repeat([]byte{0xff}, 512)
+	for i := 0; i < 20; i++ {
+		connection.recorder(true)(payload)
+	}
+	connection.close()
+	if err := capture.close(); err != nil {
+		t.Fatal("unable to close capture:", err)
+	}
+
+	// Verify that the expected files exist and that each begins with a header.
+	for i := 0; i < 3; i++ {
+		name := capture.file.rotatedPath(i)
+		contents, err := os.ReadFile(name)
+		if err != nil {
+			t.Error("unable to read capture file:", err)
+		} else if len(contents) < 4 || binary.LittleEndian.Uint32(contents) != pcapngBlockTypeSectionHeader {
+			t.Error("capture file does not begin with section header:", name)
+		}
+	}
+
+	// Verify that older files have been removed.
+	if _, err := os.Stat(capture.file.rotatedPath(3)); !os.IsNotExist(err) {
+		t.Error("rotated capture file retained beyond maximum count")
+	}
+}
+
+// TestCaptureRotationSingleFile tests that capture files are truncated (rather
+// than growing without bound) when only a single file is retained.
+func TestCaptureRotationSingleFile(t *testing.T) {
+	// Create a capture with a small maximum file size that retains only a
+	// single file.
+	directory := t.TempDir()
+	capture, err := newCapture(nil, "session", directory, CaptureFormat_CaptureFormatPCAPNG, 1024, 1, 0)
+	if err != nil {
+		t.Fatal("unable to create capture:", err)
+	}
+
+	// Record enough data to force multiple rotations.
+	connection := capture.connection()
+	payload := bytes.Repeat([]byte{0xff}, 512)
+	for i := 0; i < 20; i++ {
+		connection.recorder(true)(payload)
+	}
+	connection.close()
+	if err := capture.close(); err != nil {
+		t.Fatal("unable to close capture:", err)
+	}
+
+	// Verify that the file is bounded in size and begins with a header.
+	contents, err := os.ReadFile(capture.path)
+	if err != nil {
+		t.Fatal("unable to read capture file:", err)
+	} else if len(contents) > 1024 {
+		t.Error("capture file exceeds maximum size:", len(contents))
+	} else if len(contents) < 4 || binary.LittleEndian.Uint32(contents) != pcapngBlockTypeSectionHeader {
+		t.Error("capture file does not begin with section header")
+	}
+
+	// Verify that no rotated files were created.
+	if _, err := os.Stat(capture.file.rotatedPath(1)); !os.IsNotExist(err) {
+		t.Error("rotated capture file created with single file retention")
+	}
+}
@@ -0,0 +1,49 @@
+package forwarding
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+)
+
+// transcriptEncoder is a captureEncoder that records traffic as a timestamped,
+// human-readable transcript with hexadecimal dumps of transmitted data.
+type transcriptEncoder struct{}
+
+// header implements captureEncoder.header.
+func (e *transcriptEncoder) header(writer io.Writer) error {
+	_, err := fmt.Fprintln(writer, "# Mutagen forwarding traffic transcript")
+	return err
+}
+
+// open implements captureEncoder.open.
+func (e *transcriptEncoder) open(writer io.Writer, timestamp time.Time, connection *captureConnection) error {
+	_, err := fmt.Fprintf(writer, "%s connection %d opened\n",
+		timestamp.UTC().Format(time.RFC3339Nano), connection.identifier,
+	)
+	return err
+}
+
+// data implements captureEncoder.data.
+func (e *transcriptEncoder) data(writer io.Writer, timestamp time.Time, connection *captureConnection, outbound bool, data []byte) error {
+	direction := "inbound"
+	if outbound {
+		direction = "outbound"
+	}
+	if _, err := fmt.Fprintf(writer, "%s connection %d %s %d bytes\n",
+		timestamp.UTC().Format(time.RFC3339Nano), connection.identifier, direction, len(data),
+	); err != nil {
+		return err
+	}
+	_, err := io.WriteString(writer, hex.Dump(data))
+	return err
+}
+
+// close implements captureEncoder.close.
+func (e *transcriptEncoder) close(writer io.Writer, timestamp time.Time, connection *captureConnection) error {
+	_, err := fmt.Fprintf(writer, "%s connection %d closed\n",
+		timestamp.UTC().Format(time.RFC3339Nano), connection.identifier,
+	)
+	return err
+}
@@ -2,7 +2,9 @@ package forwarding
 
 import (
 	"errors"
+	"fmt"
 
+	"github.com/mutagen-io/mutagen/pkg/comparison"
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
 )
 
@@ -15,6 +17,28 @@ func (c *Configuration) EnsureValid(endpointSpecific bool) error {
 		return errors.New("nil configuration")
 	}
 
+	// Verify that the destination selection mode is unspecified or supported.
+	// It only has meaning at the session level.
+	if !c.DestinationSelectionMode.IsDefault() {
+		if endpointSpecific {
+			return errors.New("destination selection mode cannot be specified on an endpoint-specific basis")
+		} else if !c.DestinationSelectionMode.Supported() {
+			return errors.New("unknown or unsupported destination selection mode")
+		}
+	}
+
+	// Verify that any allowed or denied networks are valid.
+	for _, network := range c.AllowedNetworks {
+		if _, err := ParseNetwork(network); err != nil {
+			return fmt.Errorf("invalid allowed network (%s): %w", network, err)
+		}
+	}
+	for _, network := range c.DeniedNetworks {
+		if _, err := ParseNetwork(network); err != nil {
+			return fmt.Errorf("invalid denied network (%s): %w", network, err)
+		}
+	}
+
 	// Verify that the socket overwrite mode is unspecified or supported.
 	if !(c.SocketOverwriteMode.IsDefault() || c.SocketOverwriteMode.Supported()) {
 		return errors.New("unknown or unsupported socket overwrite mode")
@@ -37,6 +61,43 @@ func (c *Configuration) EnsureValid(endpointSpecific bool) error {
 	// We don't verify the socket permission mode because there's not really any
 	// way to know if it's a sane value.
 
+	// Verify the allowed socket peer user and group specifications.
+	for _, user := range c.SocketAllowedUsers {
+		if kind, _ := filesystem.ParseOwnershipIdentifier(user); kind == filesystem.OwnershipIdentifierKindInvalid {
+			return fmt.Errorf("invalid allowed socket user specification: %s", user)
+		}
+	}
+	for _, group := range c.SocketAllowedGroups {
+		if kind, _ := filesystem.ParseOwnershipIdentifier(group); kind == filesystem.OwnershipIdentifierKindInvalid {
+			return fmt.Errorf("invalid allowed socket group specification: %s", group)
+		}
+	}
+
+	// Verify that TLS settings are only specified on an endpoint-specific
+	// basis. They have different meanings on listeners and dialers, so
+	// applying them to both endpoints of a session doesn't make sense.
+	if !endpointSpecific {
+		if !c.TlsMode.IsDefault() {
+			return errors.New("TLS mode can only be specified on an endpoint-specific basis")
+		} else if c.TlsCertificate != "" || c.TlsKey != "" {
+			return errors.New("TLS certificate and key can only be specified on an endpoint-specific basis")
+		} else if c.TlsCertificateAuthority != "" {
+			return errors.New("TLS certificate authority can only be specified on an endpoint-specific basis")
+		} else if c.TlsServerName != "" {
+			return errors.New("TLS server name can only be specified on an endpoint-specific basis")
+		}
+	}
+
+	// Verify that the TLS mode is unspecified or supported.
+	if !(c.TlsMode.IsDefault() || c.TlsMode.Supported()) {
+		return errors.New("unknown or unsupported TLS mode")
+	}
+
+	// Verify that the TLS certificate and key are specified together.
+	if (c.TlsCertificate == "") != (c.TlsKey == "") {
+		return errors.New("TLS certificate and key must be specified together")
+	}
+
 	// Success.
 	return nil
 }
@@ -50,10 +111,20 @@ func (c *Configuration) Equal(other *Configuration) bool {
 	}
 
 	// Perform an equivalence check.
-	return c.SocketOverwriteMode == other.SocketOverwriteMode &&
+	return c.DestinationSelectionMode == other.DestinationSelectionMode &&
+		comparison.StringSlicesEqual(c.AllowedNetworks, other.AllowedNetworks) &&
+		comparison.StringSlicesEqual(c.DeniedNetworks, other.DeniedNetworks) &&
+		c.SocketOverwriteMode == other.SocketOverwriteMode &&
 		c.SocketOwner == other.SocketOwner &&
 		c.SocketGroup == other.SocketGroup &&
-		c.SocketPermissionMode == other.SocketPermissionMode
+		c.SocketPermissionMode == other.SocketPermissionMode &&
+		comparison.StringSlicesEqual(c.SocketAllowedUsers, other.SocketAllowedUsers) &&
+		comparison.StringSlicesEqual(c.SocketAllowedGroups, other.SocketAllowedGroups) &&
+		c.TlsMode == other.TlsMode &&
+		c.TlsCertificate == other.TlsCertificate &&
+		c.TlsKey == other.TlsKey &&
+		c.TlsCertificateAuthority == other.TlsCertificateAuthority &&
+		c.TlsServerName == other.TlsServerName
 }
 
 // MergeConfigurations merges two configurations of differing priorities. Both
@@ -62,6 +133,19 @@ func MergeConfigurations(lower, higher *Configuration) *Configuration {
 	// Create the resulting configuration.
 	result := &Configuration{}
 
+	// Merge destination selection mode.
+	if !higher.DestinationSelectionMode.IsDefault() {
+		result.DestinationSelectionMode = higher.DestinationSelectionMode
+	} else {
+		result.DestinationSelectionMode = lower.DestinationSelectionMode
+	}
+
+	// Merge allowed and denied networks.
+	result.AllowedNetworks = append(result.AllowedNetworks, lower.AllowedNetworks...)
+	result.AllowedNetworks = append(result.AllowedNetworks, higher.AllowedNetworks...)
+	result.DeniedNetworks = append(result.DeniedNetworks, lower.DeniedNetworks...)
+	result.DeniedNetworks = append(result.DeniedNetworks, higher.DeniedNetworks...)
+
 	// Merge socket overwrite mode.
 	if !higher.SocketOverwriteMode.IsDefault() {
 		result.SocketOverwriteMode = higher.SocketOverwriteMode
@@ -90,6 +174,43 @@ func MergeConfigurations(lower, higher *Configuration) *Configuration {
 		result.SocketPermissionMode = lower.SocketPermissionMode
 	}
 
+	// Merge allowed socket users and groups.
+	result.SocketAllowedUsers = append(result.SocketAllowedUsers, lower.SocketAllowedUsers...)
+	result.SocketAllowedUsers = append(result.SocketAllowedUsers, higher.SocketAllowedUsers...)
+	result.SocketAllowedGroups = append(result.SocketAllowedGroups, lower.SocketAllowedGroups...)
+	result.SocketAllowedGroups = append(result.SocketAllowedGroups, higher.SocketAllowedGroups...)
+
+	// Merge TLS mode.
+	if !higher.TlsMode.IsDefault() {
+		result.TlsMode = higher.TlsMode
+	} else {
+		result.TlsMode = lower.TlsMode
+	}
+
+	// Merge TLS certificate and key. These are merged as a pair since they're
+	// only meaningful together.
+	if higher.TlsCertificate != "" {
+		result.TlsCertificate = higher.TlsCertificate
+		result.TlsKey = higher.TlsKey
+	} else {
+		result.TlsCertificate = lower.TlsCertificate
+		result.TlsKey = lower.TlsKey
+	}
+
+	// Merge TLS certificate authority.
+	if higher.TlsCertificateAuthority != "" {
+		result.TlsCertificateAuthority = higher.TlsCertificateAuthority
+	} else {
+		result.TlsCertificateAuthority = lower.TlsCertificateAuthority
+	}
+
+	// Merge TLS server name.
+	if higher.TlsServerName != "" {
+		result.TlsServerName = higher.TlsServerName
+	} else {
+		result.TlsServerName = lower.TlsServerName
+	}
+
 	// Done.
 	return result
 }
@@ -29,6 +29,17 @@ type Configuration struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// DestinationSelectionMode specifies how destinations are selected for
+	// each forwarded connection when a session has multiple destinations.
+	DestinationSelectionMode DestinationSelectionMode `protobuf:"varint,1,opt,name=destinationSelectionMode,proto3,enum=forwarding.DestinationSelectionMode" json:"destinationSelectionMode,omitempty"`
+	// AllowedNetworks specifies the networks (in CIDR notation or as single
+	// IP addresses) from which TCP listeners will accept connections. If empty,
+	// connections are accepted from any address not explicitly denied.
+	AllowedNetworks []string `protobuf:"bytes,21,rep,name=allowedNetworks,proto3" json:"allowedNetworks,omitempty"`
+	// DeniedNetworks specifies the networks (in CIDR notation or as single IP
+	// addresses) from which TCP listeners will refuse connections. Denials
+	// take precedence over allowances.
+	DeniedNetworks []string `protobuf:"bytes,22,rep,name=deniedNetworks,proto3" json:"deniedNetworks,omitempty"`
 	// SocketOverwriteMode specifies whether or not existing Unix domain sockets
 	// should be overwritten when creating new listener sockets.
 	SocketOverwriteMode SocketOverwriteMode `protobuf:"varint,41,opt,name=socketOverwriteMode,proto3,enum=forwarding.SocketOverwriteMode" json:"socketOverwriteMode,omitempty"`
@@ -41,6 +52,36 @@ type Configuration struct {
 	// SocketPermissionMode specifies the permission mode to use for Unix domain
 	// listener sockets.
 	SocketPermissionMode uint32 `protobuf:"varint,44,opt,name=socketPermissionMode,proto3" json:"socketPermissionMode,omitempty"`
+	// SocketAllowedUsers specifies the user identifiers whose peer processes
+	// are allowed to connect to Unix domain listener sockets. If both this and
+	// SocketAllowedGroups are empty, then no peer credential checks are
+	// performed.
+	SocketAllowedUsers []string `protobuf:"bytes,45,rep,name=socketAllowedUsers,proto3" json:"socketAllowedUsers,omitempty"`
+	// SocketAllowedGroups specifies the group identifiers whose peer processes
+	// are allowed to connect to Unix domain listener sockets.
+	SocketAllowedGroups []string `protobuf:"bytes,46,rep,name=socketAllowedGroups,proto3" json:"socketAllowedGroups,omitempty"`
+	// TlsMode specifies whether or not TLS should be terminated (on listener
+	// endpoints) or originated (on dialer endpoints).
+	TlsMode TLSMode `protobuf:"varint,61,opt,name=tlsMode,proto3,enum=forwarding.TLSMode" json:"tlsMode,omitempty"`
+	// TlsCertificate specifies the path to a PEM-encoded certificate (chain).
+	// On listener endpoints, this is the server certificate, and if it's not
+	// specified then a self-signed certificate will be generated (which remains
+	// stable across reconnects, but not across daemon restarts). On dialer
+	// endpoints, this is an optional client certificate. Paths are resolved on
+	// the daemon host.
+	TlsCertificate string `protobuf:"bytes,62,opt,name=tlsCertificate,proto3" json:"tlsCertificate,omitempty"`
+	// TlsKey specifies the path to the PEM-encoded private key corresponding
+	// to TlsCertificate.
+	TlsKey string `protobuf:"bytes,63,opt,name=tlsKey,proto3" json:"tlsKey,omitempty"`
+	// TlsCertificateAuthority specifies the path to a PEM-encoded certificate
+	// authority bundle. On listener endpoints, this enables verification of
+	// client certificates. On dialer endpoints, this is used instead of the
+	// system certificate pool to verify server certificates.
+	TlsCertificateAuthority string `protobuf:"bytes,64,opt,name=tlsCertificateAuthority,proto3" json:"tlsCertificateAuthority,omitempty"`
+	// TlsServerName specifies the server name to use for server certificate
+	// verification and SNI on dialer endpoints. If unspecified, it is derived
+	// from the dialing address.
+	TlsServerName string `protobuf:"bytes,65,opt,name=tlsServerName,proto3" json:"tlsServerName,omitempty"`
 }
 
 func (x *Configuration) Reset() {
@@ -75,6 +116,27 @@ func (*Configuration) Descriptor() ([]byte, []int) {
 	return file_forwarding_configuration_proto_rawDescGZIP(), []int{0}
 }
 
+func (x *Configuration) GetDestinationSelectionMode() DestinationSelectionMode {
+	if x != nil {
+		return x.DestinationSelectionMode
+	}
+	return DestinationSelectionMode_DestinationSelectionModeDefault
+}
+
+func (x *Configuration) GetAllowedNetworks() []string {
+	if x != nil {
+		return x.AllowedNetworks
+	}
+	return nil
+}
+
+func (x *Configuration) GetDeniedNetworks() []string {
+	if x != nil {
+		return x.DeniedNetworks
+	}
+	return nil
+}
+
 func (x *Configuration) GetSocketOverwriteMode() SocketOverwriteMode {
 	if x != nil {
 		return x.SocketOverwriteMode
@@ -103,32 +165,116 @@ func (x *Configuration) GetSocketPermissionMode() uint32 {
 	return 0
 }
 
+func (x *Configuration) GetSocketAllowedUsers() []string {
+	if x != nil {
+		return x.SocketAllowedUsers
+	}
+	return nil
+}
+
+func (x *Configuration) GetSocketAllowedGroups() []string {
+	if x != nil {
+		return x.SocketAllowedGroups
+	}
+	return nil
+}
+
+func (x *Configuration) GetTlsMode() TLSMode {
+	if x != nil {
+		return x.TlsMode
+	}
+	return TLSMode_TLSModeDefault
+}
+
+func (x *Configuration) GetTlsCertificate() string {
+	if x != nil {
+		return x.TlsCertificate
+	}
+	return ""
+}
+
+func (x *Configuration) GetTlsKey() string {
+	if x != nil {
+		return x.TlsKey
+	}
+	return ""
+}
+
+func (x *Configuration) GetTlsCertificateAuthority() string {
+	if x != nil {
+		return x.TlsCertificateAuthority
+	}
+	return ""
+}
+
+func (x *Configuration) GetTlsServerName() string {
+	if x != nil {
+		return x.TlsServerName
+	}
+	return ""
+}
+
 var File_forwarding_configuration_proto protoreflect.FileDescriptor
 
 var file_forwarding_configuration_proto_rawDesc = []byte{
 	0x0a, 0x1e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2f, 0x63, 0x6f, 0x6e,
 	0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x12, 0x0a, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x1a, 0x26, 0x66, 0x6f,
-	0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2f, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x5f,
-	0x6f, 0x76, 0x65, 0x72, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xda, 0x01, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x51, 0x0a, 0x13, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74,
-	0x4f, 0x76, 0x65, 0x72, 0x77, 0x72, 0x69, 0x74, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x29, 0x20,
-	0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67,
-	0x2e, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x77, 0x72, 0x69, 0x74, 0x65,
-	0x4d, 0x6f, 0x64, 0x65, 0x52, 0x13, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72,
-	0x77, 0x72, 0x69, 0x74, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x6f, 0x63,
-	0x6b, 0x65, 0x74, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x2a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
-	0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x73,
-	0x6f, 0x63, 0x6b, 0x65, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x2b, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0b, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x32, 0x0a,
-	0x14, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x2c, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x73, 0x6f, 0x63,
+	0x12, 0x0a, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x1a, 0x2b, 0x66, 0x6f,
+	0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2f, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d,
+	0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x26, 0x66, 0x6f, 0x72, 0x77, 0x61,
+	0x72, 0x64, 0x69, 0x6e, 0x67, 0x2f, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x6f, 0x76, 0x65,
+	0x72, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x19, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2f, 0x74, 0x6c,
+	0x73, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xbf, 0x05, 0x0a,
+	0x0d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x60,
+	0x0a, 0x18, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x24, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x44, 0x65,
+	0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x18, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65,
+	0x12, 0x28, 0x0a, 0x0f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x4e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x73, 0x18, 0x15, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x61, 0x6c, 0x6c, 0x6f, 0x77,
+	0x65, 0x64, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x64, 0x65,
+	0x6e, 0x69, 0x65, 0x64, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x18, 0x16, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0e, 0x64, 0x65, 0x6e, 0x69, 0x65, 0x64, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
+	0x6b, 0x73, 0x12, 0x51, 0x0a, 0x13, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72,
+	0x77, 0x72, 0x69, 0x74, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x29, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1f, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x6f, 0x63,
+	0x6b, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x77, 0x72, 0x69, 0x74, 0x65, 0x4d, 0x6f, 0x64, 0x65,
+	0x52, 0x13, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x77, 0x72, 0x69, 0x74,
+	0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4f,
+	0x77, 0x6e, 0x65, 0x72, 0x18, 0x2a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x6f, 0x63, 0x6b,
+	0x65, 0x74, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x6f, 0x63, 0x6b, 0x65,
+	0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x2b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x6f,
+	0x63, 0x6b, 0x65, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x32, 0x0a, 0x14, 0x73, 0x6f, 0x63,
 	0x6b, 0x65, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64,
-	0x65, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67,
-	0x65, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e,
-	0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x18, 0x2c, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x50,
+	0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x2e, 0x0a,
+	0x12, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x55, 0x73,
+	0x65, 0x72, 0x73, 0x18, 0x2d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x12, 0x73, 0x6f, 0x63, 0x6b, 0x65,
+	0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12, 0x30, 0x0a,
+	0x13, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x73, 0x18, 0x2e, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x73, 0x6f, 0x63, 0x6b,
+	0x65, 0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12,
+	0x2d, 0x0a, 0x07, 0x74, 0x6c, 0x73, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x3d, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x13, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x4c,
+	0x53, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x07, 0x74, 0x6c, 0x73, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x26,
+	0x0a, 0x0e, 0x74, 0x6c, 0x73, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x18, 0x3e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x74, 0x6c, 0x73, 0x43, 0x65, 0x72, 0x74, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6c, 0x73, 0x4b, 0x65, 0x79,
+	0x18, 0x3f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x6c, 0x73, 0x4b, 0x65, 0x79, 0x12, 0x38,
+	0x0a, 0x17, 0x74, 0x6c, 0x73, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x40, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x17, 0x74, 0x6c, 0x73, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x41,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x24, 0x0a, 0x0d, 0x74, 0x6c, 0x73, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x41, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x74, 0x6c, 0x73, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x42, 0x2e,
+	0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x75, 0x74,
+	0x61, 0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2f,
+	0x70, 0x6b, 0x67, 0x2f, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -145,16 +291,20 @@ func file_forwarding_configuration_proto_rawDescGZIP() []byte {
 
 var file_forwarding_configuration_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
 var file_forwarding_configuration_proto_goTypes = []interface{}{
-	(*Configuration)(nil),    // 0: forwarding.Configuration
-	(SocketOverwriteMode)(0), // 1: forwarding.SocketOverwriteMode
+	(*Configuration)(nil),         // 0: forwarding.Configuration
+	(DestinationSelectionMode)(0), // 1: forwarding.DestinationSelectionMode
+	(SocketOverwriteMode)(0),      // 2: forwarding.SocketOverwriteMode
+	(TLSMode)(0),                  // 3: forwarding.TLSMode
 }
 var file_forwarding_configuration_proto_depIdxs = []int32{
-	1, // 0: forwarding.Configuration.socketOverwriteMode:type_name -> forwarding.SocketOverwriteMode
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	1, // 0: forwarding.Configuration.destinationSelectionMode:type_name -> forwarding.DestinationSelectionMode
+	2, // 1: forwarding.Configuration.socketOverwriteMode:type_name -> forwarding.SocketOverwriteMode
+	3, // 2: forwarding.Configuration.tlsMode:type_name -> forwarding.TLSMode
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_forwarding_configuration_proto_init() }
@@ -162,7 +312,9 @@ func file_forwarding_configuration_proto_init() {
 	if File_forwarding_configuration_proto != nil {
 		return
 	}
+	file_forwarding_destination_selection_mode_proto_init()
 	file_forwarding_socket_overwrite_mode_proto_init()
+	file_forwarding_tls_mode_proto_init()
 	if !protoimpl.UnsafeEnabled {
 		file_forwarding_configuration_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Configuration); i {
@@ -1,3 +1,38 @@
 package forwarding
 
-// TODO: Implement.
+import (
+	"testing"
+)
+
+// TestConfigurationEnsureValidTLS tests that Configuration.EnsureValid only
+// allows TLS settings on an endpoint-specific basis.
+func TestConfigurationEnsureValidTLS(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		configuration    *Configuration
+		endpointSpecific bool
+		expectValid      bool
+	}{
+		{&Configuration{}, false, true},
+		{&Configuration{}, true, true},
+		{&Configuration{TlsMode: TLSMode_TLSModeEnabled}, false, false},
+		{&Configuration{TlsMode: TLSMode_TLSModeEnabled}, true, true},
+		{&Configuration{TlsCertificate: "cert.pem", TlsKey: "key.pem"}, false, false},
+		{&Configuration{TlsCertificate: "cert.pem", TlsKey: "key.pem"}, true, true},
+		{&Configuration{TlsCertificate: "cert.pem"}, true, false},
+		{&Configuration{TlsCertificateAuthority: "ca.pem"}, false, false},
+		{&Configuration{TlsCertificateAuthority: "ca.pem"}, true, true},
+		{&Configuration{TlsServerName: "example.com"}, false, false},
+		{&Configuration{TlsServerName: "example.com"}, true, true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		err := testCase.configuration.EnsureValid(testCase.endpointSpecific)
+		if testCase.expectValid && err != nil {
+			t.Errorf("test case %d: unexpected validation failure: %v", i, err)
+		} else if !testCase.expectValid && err == nil {
+			t.Errorf("test case %d: validation succeeded unexpectedly", i)
+		}
+	}
+}
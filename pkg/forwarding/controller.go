@@ -2,9 +2,12 @@ package forwarding
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"net"
 	"os"
+	"strconv"
 	"sync"
 	"time"
 
@@ -17,6 +20,7 @@ import (
 	"github.com/mutagen-io/mutagen/pkg/prompting"
 	"github.com/mutagen-io/mutagen/pkg/state"
 	"github.com/mutagen-io/mutagen/pkg/url"
+	forwardingurl "github.com/mutagen-io/mutagen/pkg/url/forwarding"
 )
 
 const (
@@ -34,22 +38,38 @@ type controller struct {
 	// stateLock guards and tracks changes to session's Paused field and state.
 	stateLock *state.TrackingLock
 	// session encodes the associated session metadata. It is considered static
-	// and safe for concurrent access except for its Paused field, for which
-	// stateLock should be held. It should be saved to disk any time it is
-	// modified.
+	// and safe for concurrent access except for its Paused and configuration
+	// fields, for which stateLock should be held. The configuration fields may
+	// only be modified while holding the lifecycle lock with no forwarding loop
+	// running. It should be saved to disk any time it is modified.
 	session *Session
 	// mergedSourceConfiguration is the source-specific configuration object
 	// (computed from the core configuration and source-specific overrides). It
-	// is considered static and safe for concurrent access. It is a derived
-	// field and not saved to disk.
+	// is subject to the same rules as the session's configuration fields. It
+	// is a derived field and not saved to disk.
 	mergedSourceConfiguration *Configuration
 	// mergedDestinationConfiguration is the destination-specific configuration
 	// object (computed from the core configuration and destination-specific
-	// overrides). It is considered static and safe for concurrent access. It is
-	// a derived field and not saved to disk.
+	// overrides). It is subject to the same rules as the session's
+	// configuration fields. It is a derived field and not saved to disk.
 	mergedDestinationConfiguration *Configuration
 	// state represents the current forwarding state.
 	state *State
+	// events is the broadcaster used to publish session events.
+	events *eventBroadcaster
+	// eventSummary is a summary of state at the time of the last state
+	// observation. It is guarded by stateLock.
+	eventSummary eventSummary
+	// capture is the active traffic capture, if any. It is guarded by
+	// stateLock. It is not saved to disk and does not persist across daemon
+	// restarts.
+	capture *capture
+	// selfSignedCertificate is the self-signed certificate used to terminate
+	// TLS on the source if no certificate is configured. It is generated on
+	// first use and then reused so that it remains stable across reconnects
+	// for the lifetime of the controller. It should only be accessed by the
+	// forwarding loop.
+	selfSignedCertificate *tls.Certificate
 	// lifecycleLock guards access to disabled, cancel, and done. Only the
 	// current holder of the lifecycle lock may set any of these fields or
 	// invoke cancel. The forwarding loop may close done without holding the
@@ -76,8 +96,10 @@ func newSession(
 	ctx context.Context,
 	logger *logging.Logger,
 	tracker *state.Tracker,
+	events *eventBroadcaster,
 	identifier string,
 	source, destination *url.URL,
+	additionalDestinations []*url.URL,
 	configuration, configurationSource, configurationDestination *Configuration,
 	name string,
 	labels map[string]string,
@@ -103,17 +125,20 @@ func newSession(
 	// If the session isn't being created paused, then try to connect to the
 	// endpoints. Before doing so, set up a deferred handler that will shut down
 	// any endpoints that aren't handed off to the run loop due to errors.
-	var sourceEndpoint, destinationEndpoint Endpoint
+	var sourceEndpoint Endpoint
+	var destinationEndpoints []Endpoint
 	var err error
 	defer func() {
 		if sourceEndpoint != nil {
 			sourceEndpoint.Shutdown()
 			sourceEndpoint = nil
 		}
-		if destinationEndpoint != nil {
-			destinationEndpoint.Shutdown()
-			destinationEndpoint = nil
+		for _, endpoint := range destinationEndpoints {
+			if endpoint != nil {
+				endpoint.Shutdown()
+			}
 		}
+		destinationEndpoints = nil
 	}()
 	if !paused {
 		logger.Info("Connecting to source endpoint")
@@ -131,20 +156,33 @@ func newSession(
 			logger.Info("Source connection failure:", err)
 			return nil, fmt.Errorf("unable to connect to source: %w", err)
 		}
-		logger.Info("Connecting to destination endpoint")
-		destinationEndpoint, err = connect(
-			ctx,
-			logger.Sublogger("destination"),
-			destination,
-			prompter,
-			identifier,
-			version,
-			mergedDestinationConfiguration,
-			false,
-		)
-		if err != nil {
-			logger.Info("Destination connection failure:", err)
-			return nil, fmt.Errorf("unable to connect to destination: %w", err)
+		destinations := append([]*url.URL{destination}, additionalDestinations...)
+		destinationEndpoints = make([]Endpoint, len(destinations))
+		var destinationConnectErr error
+		var destinationConnected bool
+		for i, d := range destinations {
+			logger.Info("Connecting to destination endpoint", i)
+			destinationEndpoints[i], err = connect(
+				ctx,
+				logger.Sublogger(destinationLoggerName(i)),
+				d,
+				prompter,
+				identifier,
+				version,
+				mergedDestinationConfiguration,
+				false,
+			)
+			if err != nil {
+				logger.Info("Destination connection failure:", err)
+				if destinationConnectErr == nil {
+					destinationConnectErr = err
+				}
+			} else {
+				destinationConnected = true
+			}
+		}
+		if !destinationConnected {
+			return nil, fmt.Errorf("unable to connect to destination: %w", destinationConnectErr)
 		}
 	}
 
@@ -158,6 +196,7 @@ func newSession(
 		CreatingVersionPatch:     mutagen.VersionPatch,
 		Source:                   source,
 		Destination:              destination,
+		AdditionalDestinations:   additionalDestinations,
 		Configuration:            configuration,
 		ConfigurationSource:      configurationSource,
 		ConfigurationDestination: configurationDestination,
@@ -181,16 +220,13 @@ func newSession(
 	controller := &controller{
 		logger:                         logger,
 		sessionPath:                    sessionPath,
-		stateLock:                      state.NewTrackingLock(tracker),
 		session:                        session,
 		mergedSourceConfiguration:      mergedSourceConfiguration,
 		mergedDestinationConfiguration: mergedDestinationConfiguration,
-		state: &State{
-			Session:          session,
-			SourceState:      &EndpointState{},
-			DestinationState: &EndpointState{},
-		},
+		state:                          newState(session),
+		events:                         events,
 	}
+	controller.stateLock = state.NewObservedTrackingLock(tracker, controller.observeState)
 
 	// If the session isn't being created paused, then start a forwarding loop
 	// and mark the endpoints as handed off to that loop so that we don't defer
@@ -199,9 +235,9 @@ func newSession(
 		ctx, cancel := context.WithCancel(context.Background())
 		controller.cancel = cancel
 		controller.done = make(chan struct{})
-		go controller.run(ctx, sourceEndpoint, destinationEndpoint)
+		go controller.run(ctx, sourceEndpoint, destinationEndpoints)
 		sourceEndpoint = nil
-		destinationEndpoint = nil
+		destinationEndpoints = nil
 	}
 
 	// Success.
@@ -210,7 +246,7 @@ func newSession(
 }
 
 // loadSession loads an existing session and creates a corresponding controller.
-func loadSession(logger *logging.Logger, tracker *state.Tracker, identifier string) (*controller, error) {
+func loadSession(logger *logging.Logger, tracker *state.Tracker, events *eventBroadcaster, identifier string) (*controller, error) {
 	// Compute the session path.
 	sessionPath, err := pathForSession(identifier)
 	if err != nil {
@@ -230,7 +266,6 @@ func loadSession(logger *logging.Logger, tracker *state.Tracker, identifier stri
 	controller := &controller{
 		logger:      logger,
 		sessionPath: sessionPath,
-		stateLock:   state.NewTrackingLock(tracker),
 		session:     session,
 		mergedSourceConfiguration: MergeConfigurations(
 			session.Configuration,
@@ -240,12 +275,10 @@ func loadSession(logger *logging.Logger, tracker *state.Tracker, identifier stri
 			session.Configuration,
 			session.ConfigurationDestination,
 		),
-		state: &State{
-			Session:          session,
-			SourceState:      &EndpointState{},
-			DestinationState: &EndpointState{},
-		},
+		state:  newState(session),
+		events: events,
 	}
+	controller.stateLock = state.NewObservedTrackingLock(tracker, controller.observeState)
 
 	// If the session isn't marked as paused, start a forwarding loop.
 	if !session.Paused {
@@ -260,6 +293,119 @@ func loadSession(logger *logging.Logger, tracker *state.Tracker, identifier stri
 	return controller, nil
 }
 
+// newState creates a new state object for the specified session with empty
+// endpoint states for each of its endpoints.
+func newState(session *Session) *State {
+	state := &State{
+		Session:          session,
+		SourceState:      &EndpointState{},
+		DestinationState: &EndpointState{},
+	}
+	if count := len(session.AdditionalDestinations); count > 0 {
+		state.AdditionalDestinationStates = make([]*EndpointState, count)
+		for i := range state.AdditionalDestinationStates {
+			state.AdditionalDestinationStates[i] = &EndpointState{}
+		}
+	}
+	return state
+}
+
+// destinationLoggerName returns the sublogger name to use for the destination
+// with the specified index. Index 0 corresponds to the primary destination.
+func destinationLoggerName(index int) string {
+	if index == 0 {
+		return "destination"
+	}
+	return fmt.Sprintf("destination%d", index)
+}
+
+// destinationCount returns the total number of destinations for the session.
+func (c *controller) destinationCount() int {
+	return 1 + len(c.session.AdditionalDestinations)
+}
+
+// destinationState returns the endpoint state for the destination with the
+// specified index. It must be called with the state lock held.
+func (c *controller) destinationState(index int) *EndpointState {
+	if index == 0 {
+		return c.state.DestinationState
+	}
+	return c.state.AdditionalDestinationStates[index-1]
+}
+
+// connectDestination attempts to connect to the destination with the specified
+// index. Index 0 corresponds to the primary destination.
+func (c *controller) connectDestination(ctx context.Context, index int, prompter string) (Endpoint, error) {
+	destination := c.session.Destination
+	if index > 0 {
+		destination = c.session.AdditionalDestinations[index-1]
+	}
+	return connect(
+		ctx,
+		c.logger.Sublogger(destinationLoggerName(index)),
+		destination,
+		prompter,
+		c.session.Identifier,
+		c.session.Version,
+		c.mergedDestinationConfiguration,
+		false,
+	)
+}
+
+// listenerAddresses returns the listening addresses reported by an endpoint, if
+// any. It is safe to call with a nil endpoint.
+func listenerAddresses(endpoint Endpoint) []string {
+	if listener, ok := endpoint.(ListenerEndpoint); ok {
+		return listener.ListenerAddresses()
+	}
+	return nil
+}
+
+// newEvent creates a new event of the specified kind for the session. The
+// caller must hold the state lock.
+func (c *controller) newEvent(kind EventKind) *Event {
+	return &Event{
+		Kind:        kind,
+		Time:        timestamppb.Now(),
+		Session:     c.session.Identifier,
+		SessionName: c.session.Name,
+		Status:      c.state.Status,
+	}
+}
+
+// observeState is the state lock observer. It compares the current state with
+// the state seen at the last observation and publishes any resulting events.
+// It is invoked with the state lock held.
+func (c *controller) observeState() {
+	// Summarize the current state and swap it in.
+	current := summarizeState(c.state)
+	previous := c.eventSummary
+	c.eventSummary = current
+
+	// Publish status changes.
+	if current.status != previous.status {
+		event := c.newEvent(EventKind_EventKindStatusChanged)
+		event.PreviousStatus = previous.status
+		c.events.publish(c.session, event)
+	}
+
+	// Publish errors.
+	if current.lastError != previous.lastError && current.lastError != "" {
+		event := c.newEvent(EventKind_EventKindError)
+		event.Error = current.lastError
+		c.events.publish(c.session, event)
+	}
+}
+
+// publishLifecycleEvent publishes a session lifecycle event (i.e. creation or
+// termination).
+func (c *controller) publishLifecycleEvent(kind EventKind) {
+	c.stateLock.Lock()
+	event := c.newEvent(kind)
+	c.stateLock.UnlockWithoutNotify()
+	c.events.publish(c.session, event)
+}
+
 // currentState creates a static snapshot of the current session state.
 func (c *controller) currentState() *State {
 	// Lock the session state and defer its release. It's very important that we
@@ -269,18 +415,103 @@ func (c *controller) currentState() *State {
 	defer c.stateLock.UnlockWithoutNotify()
 
 	// Create a static copy of the state.
-	return proto.Clone(c.state).(*State)
+	result := proto.Clone(c.state).(*State)
+
+	// Record the capture path, if any.
+	if c.capture != nil {
+		result.CapturePath = c.capture.path
+	}
+
+	// Done.
+	return result
+}
+
+// loadSelfSignedCertificate returns the controller's self-signed certificate,
+// generating it if necessary. It should only be invoked by the forwarding loop.
+func (c *controller) loadSelfSignedCertificate() (tls.Certificate, error) {
+	if c.selfSignedCertificate == nil {
+		certificate, err := generateSelfSignedCertificate()
+		if err != nil {
+			return tls.Certificate{}, err
+		}
+		c.selfSignedCertificate = &certificate
+	}
+	return *c.selfSignedCertificate, nil
+}
+
+// captureServerPort determines the synthetic server port to use for traffic
+// captures. It uses the primary destination's port if the destination is a TCP
+// endpoint and returns 0 otherwise.
+func (c *controller) captureServerPort() uint16 {
+	protocol, address, err := forwardingurl.Parse(c.session.Destination.Path)
+	if err != nil || !(protocol == "tcp" || protocol == "tcp4" || protocol == "tcp6") {
+		return 0
+	}
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return 0
+	}
+	result, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return 0
+	}
+	return uint16(result)
+}
+
+// setCapture enables or disables traffic capture for the session. If capture
+// is enabled, then any existing capture is replaced.
+func (c *controller) setCapture(
+	enable bool,
+	directory string,
+	format CaptureFormat,
+	maximumFileSize uint64,
+	maximumFileCount uint32,
+) error {
+	// Create the new capture, if any.
+	var replacement *capture
+	if enable {
+		var err error
+		replacement, err = newCapture(
+			c.logger,
+			c.session.Identifier, directory,
+			format, maximumFileSize, maximumFileCount,
+			c.captureServerPort(),
+		)
+		if err != nil {
+			return fmt.Errorf("unable to start capture: %w", err)
+		}
+	}
+
+	// Swap in the new capture.
+	c.stateLock.Lock()
+	previous := c.capture
+	c.capture = replacement
+	c.stateLock.Unlock()
+
+	// Close any previous capture.
+	if previous != nil {
+		if err := previous.close(); err != nil {
+			return fmt.Errorf("unable to close previous capture: %w", err)
+		}
+	}
+
+	// Success.
+	return nil
 }
 
 // resume attempts to reconnect and resume the session if it isn't currently
-// connected and forwarding.
-func (c *controller) resume(ctx context.Context, prompter string) error {
+// connected and forwarding. If lifecycleLockHeld is true, then resume will
+// assume that the lifecycle lock is held by the caller and will not attempt to
+// acquire it.
+func (c *controller) resume(ctx context.Context, prompter string, lifecycleLockHeld bool) error {
 	// Update status.
 	prompting.Message(prompter, fmt.Sprintf("Resuming session %s...", c.session.Identifier))
 
-	// Lock the controller's lifecycle and defer its release.
-	c.lifecycleLock.Lock()
-	defer c.lifecycleLock.Unlock()
+	// If not already held, acquire the lifecycle lock and defer its release.
+	if !lifecycleLockHeld {
+		c.lifecycleLock.Lock()
+		defer c.lifecycleLock.Unlock()
+	}
 
 	// Don't allow any resume operations if the controller is disabled.
 	if c.disabled {
@@ -348,31 +579,34 @@ func (c *controller) resume(ctx context.Context, prompter string) error {
 	c.state.SourceState.Connected = (source != nil)
 	c.stateLock.Unlock()
 
-	// Attempt to connect to destination.
+	// Attempt to connect to destinations. We only treat destination connection
+	// as having failed if we can't connect to any destination.
 	c.stateLock.Lock()
 	c.state.Status = Status_ConnectingDestination
 	c.stateLock.Unlock()
-	destination, destinationConnectErr := connect(
-		ctx,
-		c.logger.Sublogger("destination"),
-		c.session.Destination,
-		prompter,
-		c.session.Identifier,
-		c.session.Version,
-		c.mergedDestinationConfiguration,
-		false,
-	)
-	c.stateLock.Lock()
-	c.state.DestinationState.Connected = (destination != nil)
-	c.stateLock.Unlock()
+	destinations := make([]Endpoint, c.destinationCount())
+	var destinationConnectErr error
+	var destinationConnected bool
+	for i := range destinations {
+		var err error
+		destinations[i], err = c.connectDestination(ctx, i, prompter)
+		if err != nil && destinationConnectErr == nil {
+			destinationConnectErr = err
+		} else if err == nil {
+			destinationConnected = true
+		}
+		c.stateLock.Lock()
+		c.destinationState(i).Connected = (destinations[i] != nil)
+		c.stateLock.Unlock()
+	}
 
-	// Start the forwarding loop with what we have. Source or destination may
+	// Start the forwarding loop with what we have. Source or destinations may
 	// have failed to connect (and be nil), but in any case that'll just make
 	// the run loop keep trying to connect.
 	ctx, cancel := context.WithCancel(context.Background())
 	c.cancel = cancel
 	c.done = make(chan struct{})
-	go c.run(ctx, source, destination)
+	go c.run(ctx, source, destinations)
 
 	// Report any errors. Since we always want to start a forwarding loop, even
 	// on partial or complete failure (since it might be able to auto-reconnect
@@ -381,7 +615,7 @@ func (c *controller) resume(ctx context.Context, prompter string) error {
 		return fmt.Errorf("unable to save session: %w", saveErr)
 	} else if sourceConnectErr != nil {
 		return fmt.Errorf("unable to connect to source: %w", sourceConnectErr)
-	} else if destinationConnectErr != nil {
+	} else if !destinationConnected {
 		return fmt.Errorf("unable to connect to destination: %w", destinationConnectErr)
 	}
 
@@ -417,14 +651,18 @@ func (m controllerHaltMode) description() string {
 	}
 }
 
-// halt halts the session with the specified behavior.
-func (c *controller) halt(_ context.Context, mode controllerHaltMode, prompter string) error {
+// halt halts the session with the specified behavior. If lifecycleLockHeld is
+// true, then halt will assume that the lifecycle lock is held by the caller and
+// will not attempt to acquire it.
+func (c *controller) halt(_ context.Context, mode controllerHaltMode, prompter string, lifecycleLockHeld bool) error {
 	// Update status.
 	prompting.Message(prompter, fmt.Sprintf("%s session %s...", mode.description(), c.session.Identifier))
 
-	// Lock the controller's lifecycle and defer its release.
-	c.lifecycleLock.Lock()
-	defer c.lifecycleLock.Unlock()
+	// If not already held, acquire the lifecycle lock and defer its release.
+	if !lifecycleLockHeld {
+		c.lifecycleLock.Lock()
+		defer c.lifecycleLock.Unlock()
+	}
 
 	// Don't allow any additional halt operations if the controller is disabled,
 	// because either this session is being terminated or the service is
@@ -460,10 +698,16 @@ func (c *controller) halt(_ context.Context, mode controllerHaltMode, prompter s
 	} else if mode == controllerHaltModeShutdown {
 		// Disable the controller.
 		c.disabled = true
+
+		// Stop any traffic capture.
+		c.setCapture(false, "", CaptureFormat_CaptureFormatDefault, 0, 0)
 	} else if mode == controllerHaltModeTerminate {
 		// Disable the controller.
 		c.disabled = true
 
+		// Stop any traffic capture.
+		c.setCapture(false, "", CaptureFormat_CaptureFormatDefault, 0, 0)
+
 		// Wipe the session information from disk.
 		sessionRemoveErr := os.Remove(c.sessionPath)
 		if sessionRemoveErr != nil {
@@ -477,9 +721,76 @@ func (c *controller) halt(_ context.Context, mode controllerHaltMode, prompter s
 	return nil
 }
 
+// update replaces the session configuration by pausing the session (if it's
+// running), saving the new configuration to disk, and then resuming the
+// session (if it was previously running).
+func (c *controller) update(
+	ctx context.Context,
+	configuration, configurationSource, configurationDestination *Configuration,
+	prompter string,
+) error {
+	// Lock the controller's lifecycle and defer its release.
+	c.lifecycleLock.Lock()
+	defer c.lifecycleLock.Unlock()
+
+	// Don't allow any update operations if the controller is disabled.
+	if c.disabled {
+		return errors.New("controller disabled")
+	}
+
+	// Check if the session is currently running.
+	running := c.cancel != nil
+
+	// If the session is running, pause it.
+	if running {
+		if err := c.halt(ctx, controllerHaltModePause, prompter, true); err != nil {
+			return fmt.Errorf("unable to pause session: %w", err)
+		}
+	}
+
+	// Update status.
+	prompting.Message(prompter, fmt.Sprintf("Updating session %s...", c.session.Identifier))
+
+	// Save the updated session to disk and, only if that succeeds, update the
+	// in-memory configuration.
+	c.logger.Infof("Updating configuration")
+	c.stateLock.Lock()
+	updated := proto.Clone(c.session).(*Session)
+	updated.Configuration = configuration
+	updated.ConfigurationSource = configurationSource
+	updated.ConfigurationDestination = configurationDestination
+	saveErr := encoding.MarshalAndSaveProtobuf(c.sessionPath, updated)
+	if saveErr == nil {
+		c.session.Configuration = configuration
+		c.session.ConfigurationSource = configurationSource
+		c.session.ConfigurationDestination = configurationDestination
+		c.mergedSourceConfiguration = MergeConfigurations(configuration, configurationSource)
+		c.mergedDestinationConfiguration = MergeConfigurations(configuration, configurationDestination)
+	}
+	c.stateLock.Unlock()
+
+	// Resume the session if it was previously running. We do this even if
+	// saving failed, in which case the session will continue to run with its
+	// previous configuration.
+	var resumeErr error
+	if running {
+		resumeErr = c.resume(ctx, prompter, true)
+	}
+
+	// Report any errors.
+	if saveErr != nil {
+		return fmt.Errorf("unable to save session: %w", saveErr)
+	} else if resumeErr != nil {
+		return fmt.Errorf("unable to resume session: %w", resumeErr)
+	}
+
+	// Success.
+	return nil
+}
+
 // run is the main run loop for the controller, managing connectivity and
 // forwarding.
-func (c *controller) run(ctx context.Context, source, destination Endpoint) {
+func (c *controller) run(ctx context.Context, source Endpoint, destinations []Endpoint) {
 	// Log run loop entry.
 	c.logger.Debug("Run loop commencing")
 
@@ -491,17 +802,15 @@ func (c *controller) run(ctx context.Context, source, destination Endpoint) {
 		if source != nil {
 			source.Shutdown()
 		}
-		if destination != nil {
-			destination.Shutdown()
+		for _, destination := range destinations {
+			if destination != nil {
+				destination.Shutdown()
+			}
 		}
 
 		// Reset the state.
 		c.stateLock.Lock()
-		c.state = &State{
-			Session:          c.session,
-			SourceState:      &EndpointState{},
-			DestinationState: &EndpointState{},
-		}
+		c.state = newState(c.session)
 		c.stateLock.Unlock()
 
 		// Log run loop termination.
@@ -511,6 +820,18 @@ func (c *controller) run(ctx context.Context, source, destination Endpoint) {
 		close(c.done)
 	}()
 
+	// Ensure that we have a slot for each destination. This might be nil if
+	// the run loop is starting without any connected endpoints.
+	if destinations == nil {
+		destinations = make([]Endpoint, c.destinationCount())
+	}
+
+	// Determine the destination selection mode.
+	selectionMode := c.session.Configuration.DestinationSelectionMode
+	if selectionMode.IsDefault() {
+		selectionMode = c.session.Version.DefaultDestinationSelectionMode()
+	}
+
 	// Track the last time that forwarding failed.
 	var lastForwardingFailureTime time.Time
 
@@ -540,6 +861,7 @@ func (c *controller) run(ctx context.Context, source, destination Endpoint) {
 			}
 			c.stateLock.Lock()
 			c.state.SourceState.Connected = (source != nil)
+			c.state.SourceState.ListenerAddresses = listenerAddresses(source)
 			if sourceConnectErr != nil {
 				c.state.LastError = fmt.Errorf("unable to connect to source: %w", sourceConnectErr).Error()
 			}
@@ -554,35 +876,36 @@ func (c *controller) run(ctx context.Context, source, destination Endpoint) {
 			default:
 			}
 
-			// Ensure that destination is connected.
-			var destinationConnectErr error
-			if destination == nil {
+			// Ensure that destinations are connected. We only require that
+			// one destination be connected, but we make an attempt to connect
+			// to each of them. Any that fail will be reconnected by the
+			// destination pool in the background.
+			var destinationConnected bool
+			for i := range destinations {
+				var destinationConnectErr error
+				if destinations[i] == nil {
+					c.stateLock.Lock()
+					c.state.Status = Status_ConnectingDestination
+					c.stateLock.Unlock()
+					destinations[i], destinationConnectErr = c.connectDestination(ctx, i, "")
+				}
 				c.stateLock.Lock()
-				c.state.Status = Status_ConnectingDestination
+				c.destinationState(i).Connected = (destinations[i] != nil)
+				if destinationConnectErr != nil {
+					c.state.LastError = fmt.Errorf("unable to connect to destination: %w", destinationConnectErr).Error()
+				}
 				c.stateLock.Unlock()
-				destination, destinationConnectErr = connect(
-					ctx,
-					c.logger.Sublogger("destination"),
-					c.session.Destination,
-					"",
-					c.session.Identifier,
-					c.session.Version,
-					c.mergedDestinationConfiguration,
-					false,
-				)
+				if destinations[i] != nil {
+					destinationConnected = true
+				}
 			}
-			c.stateLock.Lock()
-			c.state.DestinationState.Connected = (destination != nil)
-			if destinationConnectErr != nil {
-				c.state.LastError = fmt.Errorf("unable to connect to destination: %w", destinationConnectErr).Error()
-			}
-			c.stateLock.Unlock()
 
-			// If both endpoints are connected, we're done. We perform this
-			// check here (rather than in the loop condition) because if we did
-			// it in the loop condition we'd still need a check here to avoid a
-			// sleep every time (even if already successfully connected).
-			if source != nil && destination != nil {
+			// If the source and at least one destination are connected, we're
+			// done. We perform this check here (rather than in the loop
+			// condition) because if we did it in the loop condition we'd still
+			// need a check here to avoid a sleep every time (even if already
+			// successfully connected).
+			if source != nil && destinationConnected {
 				break
 			}
 
@@ -595,9 +918,28 @@ func (c *controller) run(ctx context.Context, source, destination Endpoint) {
 			}
 		}
 
-		// Grab transport error channels for each endpoint.
+		// Hand off destinations to a destination pool, which will handle
+		// destination selection, health tracking, and reconnection.
+		pool := newDestinationPool(
+			c.logger,
+			selectionMode,
+			destinations,
+			func(ctx context.Context, index int) (Endpoint, error) {
+				return c.connectDestination(ctx, index, "")
+			},
+			func(index int, connected, unhealthy bool) {
+				c.stateLock.Lock()
+				destinationState := c.destinationState(index)
+				destinationState.Connected = connected
+				destinationState.Unhealthy = unhealthy
+				c.stateLock.Unlock()
+			},
+		)
+		destinations = make([]Endpoint, len(destinations))
+
+		// Grab transport error channels for the source and destination pool.
 		sourceTransportErrors := source.TransportErrors()
-		destinationTransportErrors := destination.TransportErrors()
+		destinationTransportErrors := pool.Failures()
 
 		// Create a cancellable subcontext that we can use to manage shutdown.
 		shutdownCtx, forceShutdown := context.WithCancel(ctx)
@@ -608,7 +950,7 @@ func (c *controller) run(ctx context.Context, source, destination Endpoint) {
 		go func() {
 			<-shutdownCtx.Done()
 			source.Shutdown()
-			destination.Shutdown()
+			pool.shutdown()
 			close(shutdownComplete)
 		}()
 
@@ -616,7 +958,7 @@ func (c *controller) run(ctx context.Context, source, destination Endpoint) {
 		forwardingErrors := make(chan error, 1)
 		go func() {
 			c.logger.Debug("Entering forwarding loop")
-			forwardingErrors <- c.forward(source, destination)
+			forwardingErrors <- c.forward(source, pool)
 		}()
 
 		// Wait for cancellation, an error from forwarding, or an error from
@@ -655,19 +997,16 @@ func (c *controller) run(ctx context.Context, source, destination Endpoint) {
 			c.logger.Debug("Forwarding loop terminated")
 		}
 
-		// Nil out endpoints to update our state.
+		// Nil out the source endpoint to update our state. Destination
+		// endpoints have already been handed off to (and shut down by) the
+		// destination pool.
 		source = nil
-		destination = nil
 
 		// Reset the forwarding state, but propagate the error that caused
 		// failure.
 		c.stateLock.Lock()
-		c.state = &State{
-			Session:          c.session,
-			LastError:        sessionErr.Error(),
-			SourceState:      &EndpointState{},
-			DestinationState: &EndpointState{},
-		}
+		c.state = newState(c.session)
+		c.state.LastError = sessionErr.Error()
 		c.stateLock.Unlock()
 
 		// If we were cancelled, then return immediately.
@@ -690,7 +1029,7 @@ func (c *controller) run(ctx context.Context, source, destination Endpoint) {
 }
 
 // forward is the main forwarding loop for the controller.
-func (c *controller) forward(source, destination Endpoint) error {
+func (c *controller) forward(source Endpoint, destinations *destinationPool) error {
 	// Create a context that we can use to regulate the lifecycle of forwarding
 	// Goroutines and defer its cancellation.
 	ctx, cancel := context.WithCancel(context.Background())
@@ -722,6 +1061,38 @@ func (c *controller) forward(source, destination Endpoint) error {
 		c.stateLock.Unlock()
 	}
 
+	// Create TLS configurations for the source and each destination. These are
+	// nil if TLS is disabled for the corresponding endpoint.
+	sourceTLS, err := newListenerTLSConfiguration(
+		c.mergedSourceConfiguration, c.session.Version,
+		c.loadSelfSignedCertificate,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to configure source TLS: %w", err)
+	}
+	destinationTLS := make([]*tls.Config, c.destinationCount())
+	for i := range destinationTLS {
+		destination := c.session.Destination
+		if i > 0 {
+			destination = c.session.AdditionalDestinations[i-1]
+		}
+		destinationTLS[i], err = newDialerTLSConfiguration(c.mergedDestinationConfiguration, c.session.Version, destination)
+		if err != nil {
+			return fmt.Errorf("unable to configure destination TLS: %w", err)
+		}
+	}
+
+	// If the source endpoint performs access control, then track refused
+	// connections. Only local listeners support this, so refusals on remote
+	// listeners aren't counted.
+	if accessControlled, ok := source.(AccessControlledEndpoint); ok {
+		accessControlled.SetRefusalHandler(func() {
+			c.stateLock.Lock()
+			state.RefusedConnections++
+			c.stateLock.Unlock()
+		})
+	}
+
 	// Accept and forward connections until there's an error.
 	for {
 		// Accept a connection from the source.
@@ -730,28 +1101,60 @@ func (c *controller) forward(source, destination Endpoint) error {
 			return fmt.Errorf("unable to accept connection: %w", err)
 		}
 
-		// Open the outgoing connection to which we should forward.
-		outgoing, err := destination.Open()
+		// Open the outgoing connection to which we should forward. If no
+		// destination is able to open a connection, then we just drop the
+		// incoming connection and rely on the destination pool to route future
+		// connections to a destination once one becomes healthy. We record the
+		// failure, but we don't treat it as terminal.
+		outgoing, index, err := destinations.open()
 		if err != nil {
 			incoming.Close()
-			return fmt.Errorf("unable to open forwarding connection: %w", err)
+			c.stateLock.Lock()
+			state.LastError = fmt.Errorf("unable to open forwarding connection: %w", err).Error()
+			c.stateLock.Unlock()
+			continue
 		}
 
-		// Increment the open and total connection counts.
+		// Increment the open and total connection counts, clear any error from
+		// a previous failure to open a forwarding connection, and grab the
+		// active traffic capture (if any).
 		c.stateLock.Lock()
+		state.LastError = ""
 		state.OpenConnections++
 		state.TotalConnections++
+		activeCapture := c.capture
 		c.stateLock.Unlock()
 
 		// Perform forwarding and update state in a background Goroutine.
-		go func() {
-			// Perform forwarding.
-			ForwardAndClose(ctx, incoming, outgoing, incomingAuditor, outgoingAuditor)
+		go func(incoming, outgoing net.Conn, outgoingTLS *tls.Config, capture *capture) {
+			// Perform TLS termination and origination, if enabled. Failures
+			// only affect this connection.
+			var err error
+			if incoming, err = wrapTLS(ctx, incoming, sourceTLS, true); err != nil {
+				c.logger.Warn("Unable to terminate TLS on incoming connection:", err)
+				outgoing.Close()
+			} else if outgoing, err = wrapTLS(ctx, outgoing, outgoingTLS, false); err != nil {
+				c.logger.Warn("Unable to originate TLS on outgoing connection:", err)
+				incoming.Close()
+			} else if capture != nil {
+				// Perform forwarding with traffic capture. Data written to the
+				// incoming connection is inbound and data written to the
+				// outgoing connection is outbound.
+				connection := capture.connection()
+				ForwardAndClose(ctx, incoming, outgoing,
+					incomingAuditor, outgoingAuditor,
+					connection.recorder(false), connection.recorder(true),
+				)
+				connection.close()
+			} else {
+				// Perform forwarding.
+				ForwardAndClose(ctx, incoming, outgoing, incomingAuditor, outgoingAuditor, nil, nil)
+			}
 
 			// Decrement open connection counts.
 			c.stateLock.Lock()
 			state.OpenConnections--
 			c.stateLock.Unlock()
-		}()
+		}(incoming, outgoing, destinationTLS[index], activeCapture)
 	}
 }
@@ -1,3 +1,326 @@
 package forwarding
 
-// TODO: Implement.
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/comparison"
+	"github.com/mutagen-io/mutagen/pkg/encoding"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/state"
+	urlpkg "github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// testEndpoint is an in-memory Endpoint implementation for controller tests.
+// It never yields connections and blocks in Open until shut down.
+type testEndpoint struct {
+	// shutdownOnce guards closure of shutdown.
+	shutdownOnce sync.Once
+	// shutdown is closed when the endpoint is shut down.
+	shutdown chan struct{}
+}
+
+// newTestEndpoint creates a new test endpoint.
+func newTestEndpoint() *testEndpoint {
+	return &testEndpoint{shutdown: make(chan struct{})}
+}
+
+// TransportErrors implements Endpoint.TransportErrors.
+func (e *testEndpoint) TransportErrors() <-chan error {
+	return make(chan error)
+}
+
+// Open implements Endpoint.Open.
+func (e *testEndpoint) Open() (net.Conn, error) {
+	<-e.shutdown
+	return nil, errors.New("endpoint shut down")
+}
+
+// Shutdown implements Endpoint.Shutdown.
+func (e *testEndpoint) Shutdown() error {
+	e.shutdownOnce.Do(func() {
+		close(e.shutdown)
+	})
+	return nil
+}
+
+// testProtocolHandler is a ProtocolHandler that creates testEndpoint instances
+// and records the configurations used to connect to source endpoints.
+type testProtocolHandler struct {
+	// lock guards sourceConfigurations.
+	lock sync.Mutex
+	// sourceConfigurations are the configurations provided to Connect for
+	// source endpoints, in order.
+	sourceConfigurations []*Configuration
+}
+
+// Connect implements ProtocolHandler.Connect.
+func (h *testProtocolHandler) Connect(
+	_ context.Context,
+	_ *logging.Logger,
+	_ *urlpkg.URL,
+	_ string,
+	_ string,
+	_ Version,
+	configuration *Configuration,
+	source bool,
+) (Endpoint, error) {
+	if source {
+		h.lock.Lock()
+		h.sourceConfigurations = append(h.sourceConfigurations, configuration)
+		h.lock.Unlock()
+	}
+	return newTestEndpoint(), nil
+}
+
+// sourceConnectionCount returns the number of source connections that have
+// been made.
+func (h *testProtocolHandler) sourceConnectionCount() int {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return len(h.sourceConfigurations)
+}
+
+// lastSourceConfiguration returns the configuration used for the most recent
+// source connection.
+func (h *testProtocolHandler) lastSourceConfiguration() *Configuration {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return h.sourceConfigurations[len(h.sourceConfigurations)-1]
+}
+
+var (
+	// testSourceURL is the source URL used for test sessions.
+	testSourceURL = &urlpkg.URL{Kind: urlpkg.Kind_Forwarding, Protocol: urlpkg.Protocol_Local, Path: "tcp:localhost:8080"}
+	// testDestinationURL is the destination URL used for test sessions.
+	testDestinationURL = &urlpkg.URL{Kind: urlpkg.Kind_Forwarding, Protocol: urlpkg.Protocol_Local, Path: "tcp:localhost:80"}
+)
+
+// setupTestEnvironment registers the specified protocol handler for the local
+// protocol and redirects the Mutagen data directory to a temporary directory
+// for the duration of the test.
+func setupTestEnvironment(t *testing.T, handler *testProtocolHandler) {
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+	ProtocolHandlers[urlpkg.Protocol_Local] = handler
+	t.Cleanup(func() {
+		delete(ProtocolHandlers, urlpkg.Protocol_Local)
+	})
+}
+
+// newTestController creates a controller for a session whose endpoints are
+// handled by the specified protocol handler.
+func newTestController(t *testing.T, handler *testProtocolHandler, configuration *Configuration, paused bool) *controller {
+	// Set up the environment.
+	setupTestEnvironment(t, handler)
+
+	// Create the controller.
+	controller, err := newSession(
+		context.Background(),
+		nil,
+		state.NewTracker(),
+		newEventBroadcaster(),
+		"fwrd_test",
+		testSourceURL, testDestinationURL,
+		nil,
+		configuration, &Configuration{}, &Configuration{},
+		"",
+		nil,
+		paused,
+		"",
+	)
+	if err != nil {
+		t.Fatal("unable to create session:", err)
+	}
+	t.Cleanup(func() {
+		controller.halt(context.Background(), controllerHaltModeShutdown, "", false)
+	})
+
+	// Success.
+	return controller
+}
+
+// loadTestSession loads the session stored on disk for a controller.
+func loadTestSession(t *testing.T, controller *controller) *Session {
+	session := &Session{}
+	if err := encoding.LoadAndUnmarshalProtobuf(controller.sessionPath, session); err != nil {
+		t.Fatal("unable to load session:", err)
+	}
+	return session
+}
+
+// TestControllerUpdateRunning tests updating the configuration of a running
+// session.
+func TestControllerUpdateRunning(t *testing.T) {
+	// Create a running session.
+	handler := &testProtocolHandler{}
+	controller := newTestController(t, handler, &Configuration{}, false)
+
+	// Update the configuration.
+	configuration := &Configuration{AllowedNetworks: []string{"127.0.0.1"}}
+	if err := controller.update(context.Background(), configuration, &Configuration{}, &Configuration{}, ""); err != nil {
+		t.Fatal("unable to update session:", err)
+	}
+
+	// Verify that the session was reconnected using the new configuration and
+	// that it's still running.
+	if count := handler.sourceConnectionCount(); count != 2 {
+		t.Error("unexpected source connection count:", count)
+	} else if !comparison.StringSlicesEqual(handler.lastSourceConfiguration().AllowedNetworks, configuration.AllowedNetworks) {
+		t.Error("session not reconnected with updated configuration")
+	}
+	if controller.cancel == nil {
+		t.Error("session not resumed after update")
+	}
+
+	// Verify that the updated configuration was persisted and that the session
+	// isn't marked as paused.
+	if session := loadTestSession(t, controller); !session.Configuration.Equal(configuration) {
+		t.Error("updated configuration not saved")
+	} else if session.Paused {
+		t.Error("session saved as paused")
+	}
+}
+
+// TestControllerUpdatePaused tests updating the configuration of a paused
+// session.
+func TestControllerUpdatePaused(t *testing.T) {
+	// Create a paused session.
+	handler := &testProtocolHandler{}
+	controller := newTestController(t, handler, &Configuration{}, true)
+
+	// Update the configuration.
+	configuration := &Configuration{AllowedNetworks: []string{"127.0.0.1"}}
+	if err := controller.update(context.Background(), configuration, &Configuration{}, &Configuration{}, ""); err != nil {
+		t.Fatal("unable to update session:", err)
+	}
+
+	// Verify that the session wasn't resumed.
+	if count := handler.sourceConnectionCount(); count != 0 {
+		t.Error("paused session connected during update")
+	}
+	if controller.cancel != nil {
+		t.Error("paused session resumed during update")
+	}
+
+	// Verify that the updated configuration was applied and persisted.
+	if !controller.session.Configuration.Equal(configuration) {
+		t.Error("updated configuration not applied")
+	}
+	if session := loadTestSession(t, controller); !session.Configuration.Equal(configuration) {
+		t.Error("updated configuration not saved")
+	} else if !session.Paused {
+		t.Error("session not saved as paused")
+	}
+}
+
+// TestControllerUpdateSaveFailure tests that a failure to save the updated
+// session leaves the existing configuration and state in place.
+func TestControllerUpdateSaveFailure(t *testing.T) {
+	// Create a paused session and then redirect its session path to a location
+	// where it can't be saved.
+	handler := &testProtocolHandler{}
+	original := &Configuration{DeniedNetworks: []string{"10.0.0.0/8"}}
+	controller := newTestController(t, handler, original, true)
+	sessionPath := controller.sessionPath
+	controller.sessionPath = filepath.Join(t.TempDir(), "missing", "session")
+
+	// Attempt to update the configuration.
+	configuration := &Configuration{AllowedNetworks: []string{"127.0.0.1"}}
+	if err := controller.update(context.Background(), configuration, &Configuration{}, &Configuration{}, ""); err == nil {
+		t.Fatal("update succeeded despite save failure")
+	}
+
+	// Verify that the existing configuration and state are unchanged.
+	if !controller.session.Configuration.Equal(original) {
+		t.Error("configuration changed despite save failure")
+	}
+	if !comparison.StringSlicesEqual(controller.mergedSourceConfiguration.DeniedNetworks, original.DeniedNetworks) {
+		t.Error("merged source configuration changed despite save failure")
+	}
+	if !controller.session.Paused || controller.cancel != nil {
+		t.Error("session state changed despite save failure")
+	}
+	controller.sessionPath = sessionPath
+	if session := loadTestSession(t, controller); !session.Configuration.Equal(original) {
+		t.Error("saved configuration changed despite save failure")
+	}
+}
+
+// TestControllerCaptureRestart tests that enabling capture for a session that's
+// already capturing doesn't overwrite the earlier capture's files.
+func TestControllerCaptureRestart(t *testing.T) {
+	// Create a paused session.
+	handler := &testProtocolHandler{}
+	controller := newTestController(t, handler, &Configuration{}, true)
+
+	// Enable capture and record data.
+	directory := t.TempDir()
+	if err := controller.setCapture(true, directory, CaptureFormat_CaptureFormatTranscript, 0, 0); err != nil {
+		t.Fatal("unable to enable capture:", err)
+	}
+	first := controller.capture
+	connection := first.connection()
+	connection.recorder(true)([]byte("first capture"))
+	connection.close()
+
+	// Enable capture again and record data.
+	if err := controller.setCapture(true, directory, CaptureFormat_CaptureFormatTranscript, 0, 0); err != nil {
+		t.Fatal("unable to re-enable capture:", err)
+	}
+	second := controller.capture
+	connection = second.connection()
+	connection.recorder(true)([]byte("second capture"))
+	connection.close()
+
+	// Disable capture.
+	if err := controller.setCapture(false, "", CaptureFormat_CaptureFormatDefault, 0, 0); err != nil {
+		t.Fatal("unable to disable capture:", err)
+	}
+
+	// Verify that both captures' data are on disk.
+	if first.path == second.path {
+		t.Fatal("captures used the same file:", first.path)
+	}
+	if contents, err := os.ReadFile(first.path); err != nil {
+		t.Error("unable to read first capture file:", err)
+	} else if !strings.Contains(string(contents), "first capture") {
+		t.Error("first capture data not retained")
+	}
+	if contents, err := os.ReadFile(second.path); err != nil {
+		t.Error("unable to read second capture file:", err)
+	} else if !strings.Contains(string(contents), "second capture") {
+		t.Error("second capture data not recorded")
+	}
+}
+
+// TestControllerSelfSignedCertificateStable tests that a controller reuses its
+// self-signed TLS certificate across forwarding loop iterations.
+func TestControllerSelfSignedCertificateStable(t *testing.T) {
+	// Create a paused session so that no forwarding loop is running.
+	handler := &testProtocolHandler{}
+	controller := newTestController(t, handler, &Configuration{}, true)
+
+	// Create TLS configurations as would be done by successive forwarding loop
+	// iterations.
+	configuration := &Configuration{TlsMode: TLSMode_TLSModeEnabled}
+	first, err := newListenerTLSConfiguration(configuration, Version_Version1, controller.loadSelfSignedCertificate)
+	if err != nil {
+		t.Fatal("unable to create first listener TLS configuration:", err)
+	}
+	second, err := newListenerTLSConfiguration(configuration, Version_Version1, controller.loadSelfSignedCertificate)
+	if err != nil {
+		t.Fatal("unable to create second listener TLS configuration:", err)
+	}
+
+	// Verify that the same certificate was used.
+	if !bytes.Equal(first.Certificates[0].Certificate[0], second.Certificates[0].Certificate[0]) {
+		t.Error("self-signed certificate regenerated")
+	}
+}
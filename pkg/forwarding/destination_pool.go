@@ -0,0 +1,342 @@
+package forwarding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/logging"
+)
+
+const (
+	// destinationRetryInterval is the period of time for which a destination
+	// that has failed to open a connection will be deprioritized. It is also
+	// the interval at which reconnection to disconnected destinations will be
+	// attempted.
+	destinationRetryInterval = 5 * time.Second
+)
+
+// errNoDestinationAvailable indicates that no destination was able to open a
+// connection.
+var errNoDestinationAvailable = errors.New("no destination available")
+
+// destinationConnector is the connection function used by destinationPool to
+// (re)connect to the destination at the specified index.
+type destinationConnector func(ctx context.Context, index int) (Endpoint, error)
+
+// destinationStateUpdater is the callback used by destinationPool to report
+// changes in the connectivity or health of the destination at the specified
+// index. It is invoked with the pool's lock held, so it must not call back
+// into the pool.
+type destinationStateUpdater func(index int, connected, unhealthy bool)
+
+// destinationPoolEntry tracks the state of a single destination.
+type destinationPoolEntry struct {
+	// endpoint is the destination endpoint. It is nil if the destination is
+	// disconnected.
+	endpoint Endpoint
+	// connecting indicates whether or not a reconnection attempt is in
+	// progress.
+	connecting bool
+	// unhealthy indicates whether or not the endpoint has failed to open a
+	// connection since it was last successful.
+	unhealthy bool
+	// retryAfter is the time after which an unhealthy destination should be
+	// retried or after which a disconnected destination should be reconnected.
+	retryAfter time.Time
+}
+
+// destinationPool manages the destination endpoints for a forwarding loop and
+// selects a destination for each forwarded connection. Destinations that fail
+// to open connections are marked unhealthy and deprioritized for a period of
+// time, while destinations that suffer transport failures are reconnected in
+// the background.
+type destinationPool struct {
+	// logger is the underlying logger.
+	logger *logging.Logger
+	// mode is the destination selection mode. It is never the default mode.
+	mode DestinationSelectionMode
+	// connect is the reconnection function.
+	connect destinationConnector
+	// update is the state update callback.
+	update destinationStateUpdater
+	// ctx regulates the lifetime of background Goroutines.
+	ctx context.Context
+	// cancel cancels ctx.
+	cancel context.CancelFunc
+	// workers tracks background Goroutines.
+	workers sync.WaitGroup
+	// failures is populated if all destinations become disconnected.
+	failures chan error
+	// lock guards entries and next.
+	lock sync.Mutex
+	// entries are the destination entries.
+	entries []*destinationPoolEntry
+	// next is the index at which the next round-robin selection will start.
+	next int
+}
+
+// newDestinationPool creates a new destination pool. It takes ownership of the
+// provided endpoints, of which at least one must be non-nil. The pool will
+// attempt to reconnect to any nil endpoints in the background.
+func newDestinationPool(
+	logger *logging.Logger,
+	mode DestinationSelectionMode,
+	endpoints []Endpoint,
+	connect destinationConnector,
+	update destinationStateUpdater,
+) *destinationPool {
+	// Create a context to regulate background Goroutines.
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Create the pool.
+	pool := &destinationPool{
+		logger:   logger,
+		mode:     mode,
+		connect:  connect,
+		update:   update,
+		ctx:      ctx,
+		cancel:   cancel,
+		failures: make(chan error, 1),
+		entries:  make([]*destinationPoolEntry, len(endpoints)),
+	}
+
+	// Initialize entries and start monitoring connected endpoints. We don't
+	// need to hold the lock here since no background Goroutines are running.
+	now := time.Now()
+	for i, endpoint := range endpoints {
+		pool.entries[i] = &destinationPoolEntry{endpoint: endpoint}
+		if endpoint != nil {
+			pool.monitor(i, endpoint)
+		} else {
+			pool.entries[i].retryAfter = now.Add(destinationRetryInterval)
+		}
+	}
+
+	// Start the reconnection Goroutine.
+	pool.workers.Add(1)
+	go pool.maintain()
+
+	// Done.
+	return pool
+}
+
+// monitor starts a Goroutine that monitors the specified endpoint for transport
+// errors. It must be called with the lock held (or before any background
+// Goroutines have started).
+func (p *destinationPool) monitor(index int, endpoint Endpoint) {
+	p.workers.Add(1)
+	go func() {
+		defer p.workers.Done()
+
+		// Wait for a transport error or cancellation.
+		var err error
+		select {
+		case err = <-endpoint.TransportErrors():
+		case <-p.ctx.Done():
+			return
+		}
+
+		// Lock the pool and defer its release.
+		p.lock.Lock()
+		defer p.lock.Unlock()
+
+		// If the endpoint has already been replaced, then there's nothing to
+		// do.
+		entry := p.entries[index]
+		if entry.endpoint != endpoint {
+			return
+		}
+
+		// Disconnect the endpoint and schedule reconnection.
+		p.logger.Warnf("Destination %d transport failure: %v", index, err)
+		endpoint.Shutdown()
+		entry.endpoint = nil
+		entry.unhealthy = false
+		entry.retryAfter = time.Now().Add(destinationRetryInterval)
+		p.update(index, false, false)
+
+		// If no destinations remain connected, then signal failure.
+		for _, e := range p.entries {
+			if e.endpoint != nil {
+				return
+			}
+		}
+		select {
+		case p.failures <- fmt.Errorf("all destinations disconnected: %w", err):
+		default:
+		}
+	}()
+}
+
+// maintain is the background reconnection loop for the pool.
+func (p *destinationPool) maintain() {
+	defer p.workers.Done()
+
+	// Create a ticker to regulate reconnection attempts and defer its shutdown.
+	ticker := time.NewTicker(destinationRetryInterval)
+	defer ticker.Stop()
+
+	// Loop until cancelled.
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// Start reconnection for any disconnected destinations that are due.
+		p.lock.Lock()
+		now := time.Now()
+		for i, entry := range p.entries {
+			if entry.endpoint == nil && !entry.connecting && !now.Before(entry.retryAfter) {
+				entry.connecting = true
+				p.workers.Add(1)
+				go p.reconnect(i)
+			}
+		}
+		p.lock.Unlock()
+	}
+}
+
+// reconnect attempts to reconnect to the destination at the specified index.
+func (p *destinationPool) reconnect(index int) {
+	defer p.workers.Done()
+
+	// Attempt to connect.
+	endpoint, err := p.connect(p.ctx, index)
+
+	// Lock the pool and defer its release.
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	// Mark the connection attempt as complete.
+	entry := p.entries[index]
+	entry.connecting = false
+
+	// If the pool has been shut down, then discard any endpoint.
+	if p.ctx.Err() != nil {
+		if endpoint != nil {
+			endpoint.Shutdown()
+		}
+		return
+	}
+
+	// Handle connection failure.
+	if err != nil {
+		p.logger.Debugf("Unable to reconnect to destination %d: %v", index, err)
+		entry.retryAfter = time.Now().Add(destinationRetryInterval)
+		return
+	}
+
+	// Record the endpoint and start monitoring it.
+	p.logger.Infof("Reconnected to destination %d", index)
+	entry.endpoint = endpoint
+	entry.unhealthy = false
+	p.monitor(index, endpoint)
+	p.update(index, true, false)
+}
+
+// selectionOrder computes the order in which destinations should be tried for
+// the next connection. It must be called with the lock held.
+func (p *destinationPool) selectionOrder() []int {
+	count := len(p.entries)
+	order := make([]int, count)
+	start := 0
+	if p.mode == DestinationSelectionMode_DestinationSelectionModeRoundRobin {
+		start = p.next
+		p.next = (p.next + 1) % count
+	}
+	for i := 0; i < count; i++ {
+		order[i] = (start + i) % count
+	}
+	return order
+}
+
+// open opens a connection to a destination, selecting the destination based on
+// the pool's selection mode and destination health. Destinations that are
+// healthy (or whose retry interval has elapsed) are tried first, followed by
+// unhealthy destinations as a last resort. It returns the connection along with
+// the index of the destination that opened it. It must not be called
+// concurrently.
+func (p *destinationPool) open() (net.Conn, int, error) {
+	// Compute the selection order.
+	p.lock.Lock()
+	order := p.selectionOrder()
+	p.lock.Unlock()
+
+	// Attempt to open a connection, first considering only healthy endpoints
+	// and then considering unhealthy endpoints.
+	var lastErr error
+	for pass := 0; pass < 2; pass++ {
+		for _, index := range order {
+			// Determine whether or not to try this destination in this pass.
+			p.lock.Lock()
+			entry := p.entries[index]
+			endpoint := entry.endpoint
+			healthy := !entry.unhealthy || !time.Now().Before(entry.retryAfter)
+			p.lock.Unlock()
+			if endpoint == nil || (pass == 0) != healthy {
+				continue
+			}
+
+			// Attempt to open a connection. We don't hold the lock while doing
+			// so since opening may take time.
+			connection, err := endpoint.Open()
+
+			// Update the destination health.
+			p.lock.Lock()
+			if entry.endpoint == endpoint {
+				if err != nil {
+					p.logger.Warnf("Destination %d failed to open connection: %v", index, err)
+					entry.unhealthy = true
+					entry.retryAfter = time.Now().Add(destinationRetryInterval)
+					p.update(index, true, true)
+				} else if entry.unhealthy {
+					p.logger.Infof("Destination %d recovered", index)
+					entry.unhealthy = false
+					p.update(index, true, false)
+				}
+			}
+			p.lock.Unlock()
+
+			// If we succeeded, then we're done.
+			if err == nil {
+				return connection, index, nil
+			}
+			lastErr = err
+		}
+	}
+
+	// No destination was able to open a connection. If a destination was
+	// tried, then report its error, since it's more informative.
+	if lastErr != nil {
+		return nil, 0, lastErr
+	}
+	return nil, 0, errNoDestinationAvailable
+}
+
+// Failures returns a channel that will be populated if all destinations in the
+// pool become disconnected due to transport failures.
+func (p *destinationPool) Failures() <-chan error {
+	return p.failures
+}
+
+// shutdown terminates background operations and shuts down all destination
+// endpoints. It is safe to call concurrently with open, which it will unblock.
+func (p *destinationPool) shutdown() {
+	// Cancel background operations and wait for them to complete. We shut down
+	// endpoints first to unblock any in-flight Open calls.
+	p.cancel()
+	p.lock.Lock()
+	for _, entry := range p.entries {
+		if entry.endpoint != nil {
+			entry.endpoint.Shutdown()
+		}
+	}
+	p.lock.Unlock()
+	p.workers.Wait()
+}
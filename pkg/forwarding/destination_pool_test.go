@@ -0,0 +1,189 @@
+package forwarding
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+)
+
+// testDestinationEndpoint is a fake destination endpoint used for testing
+// destinationPool.
+type testDestinationEndpoint struct {
+	// index is the destination index reported by opened connections.
+	index int
+	// lock guards fail.
+	lock sync.Mutex
+	// fail indicates whether or not Open should fail.
+	fail bool
+	// transportErrors is the transport error channel.
+	transportErrors chan error
+}
+
+// newTestDestinationEndpoint creates a new test destination endpoint.
+func newTestDestinationEndpoint(index int) *testDestinationEndpoint {
+	return &testDestinationEndpoint{
+		index:           index,
+		transportErrors: make(chan error, 1),
+	}
+}
+
+// setFail sets whether or not Open should fail.
+func (e *testDestinationEndpoint) setFail(fail bool) {
+	e.lock.Lock()
+	e.fail = fail
+	e.lock.Unlock()
+}
+
+// TransportErrors implements Endpoint.TransportErrors.
+func (e *testDestinationEndpoint) TransportErrors() <-chan error {
+	return e.transportErrors
+}
+
+// Open implements Endpoint.Open.
+func (e *testDestinationEndpoint) Open() (net.Conn, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.fail {
+		return nil, errors.New("open failed")
+	}
+	return &testDestinationConnection{index: e.index}, nil
+}
+
+// Shutdown implements Endpoint.Shutdown.
+func (e *testDestinationEndpoint) Shutdown() error {
+	return nil
+}
+
+// testDestinationConnection is a fake connection that records the index of
+// the destination that opened it.
+type testDestinationConnection struct {
+	net.Conn
+	// index is the index of the destination that opened the connection.
+	index int
+}
+
+// newTestDestinationPool creates a destination pool with the specified number
+// of test endpoints.
+func newTestDestinationPool(mode DestinationSelectionMode, count int) (*destinationPool, []*testDestinationEndpoint) {
+	testEndpoints := make([]*testDestinationEndpoint, count)
+	endpoints := make([]Endpoint, count)
+	for i := range endpoints {
+		testEndpoints[i] = newTestDestinationEndpoint(i)
+		endpoints[i] = testEndpoints[i]
+	}
+	pool := newDestinationPool(nil, mode, endpoints,
+		func(_ context.Context, _ int) (Endpoint, error) {
+			return nil, errors.New("reconnection not supported")
+		},
+		func(_ int, _, _ bool) {},
+	)
+	return pool, testEndpoints
+}
+
+// openIndex opens a connection using the pool and returns the index of the
+// destination that opened it.
+func openIndex(t *testing.T, pool *destinationPool) int {
+	t.Helper()
+	connection, index, err := pool.open()
+	if err != nil {
+		t.Fatal("unable to open connection:", err)
+	} else if connection.(*testDestinationConnection).index != index {
+		t.Fatal("reported destination index does not match connection")
+	}
+	return index
+}
+
+// TestDestinationPoolFailover tests failover destination selection.
+func TestDestinationPoolFailover(t *testing.T) {
+	// Create the pool and defer its shutdown.
+	pool, endpoints := newTestDestinationPool(DestinationSelectionMode_DestinationSelectionModeFailover, 3)
+	defer pool.shutdown()
+
+	// Verify that the primary destination is always selected when healthy.
+	for i := 0; i < 3; i++ {
+		if index := openIndex(t, pool); index != 0 {
+			t.Fatal("unexpected destination selected:", index)
+		}
+	}
+
+	// Mark the primary as failing and verify that we fail over.
+	endpoints[0].setFail(true)
+	if index := openIndex(t, pool); index != 1 {
+		t.Fatal("unexpected destination selected after primary failure:", index)
+	}
+
+	// Verify that the unhealthy primary is skipped even once it recovers
+	// (since it's still in its retry interval).
+	endpoints[0].setFail(false)
+	if index := openIndex(t, pool); index != 1 {
+		t.Fatal("unexpected destination selected during retry interval:", index)
+	}
+}
+
+// TestDestinationPoolRoundRobin tests round-robin destination selection.
+func TestDestinationPoolRoundRobin(t *testing.T) {
+	// Create the pool and defer its shutdown.
+	pool, endpoints := newTestDestinationPool(DestinationSelectionMode_DestinationSelectionModeRoundRobin, 3)
+	defer pool.shutdown()
+
+	// Verify that destinations are rotated.
+	for i := 0; i < 6; i++ {
+		if index := openIndex(t, pool); index != i%3 {
+			t.Fatalf("unexpected destination selected: %d != %d", index, i%3)
+		}
+	}
+
+	// Mark a destination as failing and verify that it's skipped.
+	endpoints[1].setFail(true)
+	for i := 0; i < 6; i++ {
+		if index := openIndex(t, pool); index == 1 {
+			t.Fatal("failing destination selected")
+		}
+	}
+}
+
+// TestDestinationPoolAllUnhealthy tests that unhealthy destinations are still
+// tried as a last resort and that failure is reported if all destinations fail.
+func TestDestinationPoolAllUnhealthy(t *testing.T) {
+	// Create the pool and defer its shutdown.
+	pool, endpoints := newTestDestinationPool(DestinationSelectionMode_DestinationSelectionModeFailover, 2)
+	defer pool.shutdown()
+
+	// Mark all destinations as failing and verify that opening fails.
+	endpoints[0].setFail(true)
+	endpoints[1].setFail(true)
+	if _, _, err := pool.open(); err == nil {
+		t.Fatal("open succeeded with all destinations failing")
+	}
+
+	// Allow the secondary destination to recover and verify that it's used
+	// as a last resort, even though it's still within its retry interval.
+	endpoints[1].setFail(false)
+	if index := openIndex(t, pool); index != 1 {
+		t.Fatal("unexpected destination selected:", index)
+	}
+}
+
+// TestDestinationPoolTransportFailure tests that a transport failure on all
+// destinations is reported by the pool.
+func TestDestinationPoolTransportFailure(t *testing.T) {
+	// Create the pool and defer its shutdown.
+	pool, endpoints := newTestDestinationPool(DestinationSelectionMode_DestinationSelectionModeFailover, 2)
+	defer pool.shutdown()
+
+	// Fail the primary's transport and verify that the secondary is used.
+	endpoints[0].transportErrors <- errors.New("transport failed")
+	for {
+		if index := openIndex(t, pool); index == 1 {
+			break
+		}
+	}
+
+	// Fail the secondary's transport and verify that failure is reported.
+	endpoints[1].transportErrors <- errors.New("transport failed")
+	if err := <-pool.Failures(); err == nil {
+		t.Fatal("nil failure reported")
+	}
+}
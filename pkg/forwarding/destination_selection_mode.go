@@ -0,0 +1,73 @@
+package forwarding
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the destination selection mode is
+// DestinationSelectionMode_DestinationSelectionModeDefault.
+func (m DestinationSelectionMode) IsDefault() bool {
+	return m == DestinationSelectionMode_DestinationSelectionModeDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m DestinationSelectionMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case DestinationSelectionMode_DestinationSelectionModeDefault:
+	case DestinationSelectionMode_DestinationSelectionModeFailover:
+		result = "failover"
+	case DestinationSelectionMode_DestinationSelectionModeRoundRobin:
+		result = "round-robin"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *DestinationSelectionMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a destination selection mode.
+	switch text {
+	case "failover":
+		*m = DestinationSelectionMode_DestinationSelectionModeFailover
+	case "round-robin":
+		*m = DestinationSelectionMode_DestinationSelectionModeRoundRobin
+	default:
+		return fmt.Errorf("unknown destination selection mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular destination selection mode
+// is a valid, non-default value.
+func (m DestinationSelectionMode) Supported() bool {
+	switch m {
+	case DestinationSelectionMode_DestinationSelectionModeFailover:
+		return true
+	case DestinationSelectionMode_DestinationSelectionModeRoundRobin:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a destination selection
+// mode.
+func (m DestinationSelectionMode) Description() string {
+	switch m {
+	case DestinationSelectionMode_DestinationSelectionModeDefault:
+		return "Default"
+	case DestinationSelectionMode_DestinationSelectionModeFailover:
+		return "Failover"
+	case DestinationSelectionMode_DestinationSelectionModeRoundRobin:
+		return "Round-robin"
+	default:
+		return "Unknown"
+	}
+}
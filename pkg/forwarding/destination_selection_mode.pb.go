@@ -0,0 +1,152 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.12
+// source: forwarding/destination_selection_mode.proto
+
+package forwarding
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// DestinationSelectionMode specifies the behavior for selecting a destination
+// for each forwarded connection when a session has multiple destinations.
+type DestinationSelectionMode int32
+
+const (
+	// DestinationSelectionMode_DestinationSelectionModeDefault represents an
+	// unspecified destination selection mode. It should be converted to one of
+	// the following values based on the desired default behavior.
+	DestinationSelectionMode_DestinationSelectionModeDefault DestinationSelectionMode = 0
+	// DestinationSelectionMode_DestinationSelectionModeFailover specifies that
+	// connections should be forwarded to the first healthy destination in the
+	// order in which destinations were specified.
+	DestinationSelectionMode_DestinationSelectionModeFailover DestinationSelectionMode = 1
+	// DestinationSelectionMode_DestinationSelectionModeRoundRobin specifies
+	// that connections should be distributed across healthy destinations in a
+	// round-robin fashion.
+	DestinationSelectionMode_DestinationSelectionModeRoundRobin DestinationSelectionMode = 2
+)
+
+// Enum value maps for DestinationSelectionMode.
+var (
+	DestinationSelectionMode_name = map[int32]string{
+		0: "DestinationSelectionModeDefault",
+		1: "DestinationSelectionModeFailover",
+		2: "DestinationSelectionModeRoundRobin",
+	}
+	DestinationSelectionMode_value = map[string]int32{
+		"DestinationSelectionModeDefault":    0,
+		"DestinationSelectionModeFailover":   1,
+		"DestinationSelectionModeRoundRobin": 2,
+	}
+)
+
+func (x DestinationSelectionMode) Enum() *DestinationSelectionMode {
+	p := new(DestinationSelectionMode)
+	*p = x
+	return p
+}
+
+func (x DestinationSelectionMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DestinationSelectionMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_forwarding_destination_selection_mode_proto_enumTypes[0].Descriptor()
+}
+
+func (DestinationSelectionMode) Type() protoreflect.EnumType {
+	return &file_forwarding_destination_selection_mode_proto_enumTypes[0]
+}
+
+func (x DestinationSelectionMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DestinationSelectionMode.Descriptor instead.
+func (DestinationSelectionMode) EnumDescriptor() ([]byte, []int) {
+	return file_forwarding_destination_selection_mode_proto_rawDescGZIP(), []int{0}
+}
+
+var File_forwarding_destination_selection_mode_proto protoreflect.FileDescriptor
+
+var file_forwarding_destination_selection_mode_proto_rawDesc = []byte{
+	0x0a, 0x2b, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2f, 0x64, 0x65, 0x73,
+	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x66,
+	0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2a, 0x8d, 0x01, 0x0a, 0x18, 0x44, 0x65,
+	0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x1f, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f,
+	0x64, 0x65, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x10, 0x00, 0x12, 0x24, 0x0a, 0x20, 0x44,
+	0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x6f, 0x76, 0x65, 0x72, 0x10,
+	0x01, 0x12, 0x26, 0x0a, 0x22, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x6f, 0x75,
+	0x6e, 0x64, 0x52, 0x6f, 0x62, 0x69, 0x6e, 0x10, 0x02, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2d,
+	0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x66,
+	0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_forwarding_destination_selection_mode_proto_rawDescOnce sync.Once
+	file_forwarding_destination_selection_mode_proto_rawDescData = file_forwarding_destination_selection_mode_proto_rawDesc
+)
+
+func file_forwarding_destination_selection_mode_proto_rawDescGZIP() []byte {
+	file_forwarding_destination_selection_mode_proto_rawDescOnce.Do(func() {
+		file_forwarding_destination_selection_mode_proto_rawDescData = protoimpl.X.CompressGZIP(file_forwarding_destination_selection_mode_proto_rawDescData)
+	})
+	return file_forwarding_destination_selection_mode_proto_rawDescData
+}
+
+var file_forwarding_destination_selection_mode_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_forwarding_destination_selection_mode_proto_goTypes = []interface{}{
+	(DestinationSelectionMode)(0), // 0: forwarding.DestinationSelectionMode
+}
+var file_forwarding_destination_selection_mode_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_forwarding_destination_selection_mode_proto_init() }
+func file_forwarding_destination_selection_mode_proto_init() {
+	if File_forwarding_destination_selection_mode_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_forwarding_destination_selection_mode_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_forwarding_destination_selection_mode_proto_goTypes,
+		DependencyIndexes: file_forwarding_destination_selection_mode_proto_depIdxs,
+		EnumInfos:         file_forwarding_destination_selection_mode_proto_enumTypes,
+	}.Build()
+	File_forwarding_destination_selection_mode_proto = out.File
+	file_forwarding_destination_selection_mode_proto_rawDesc = nil
+	file_forwarding_destination_selection_mode_proto_goTypes = nil
+	file_forwarding_destination_selection_mode_proto_depIdxs = nil
+}
@@ -0,0 +1,91 @@
+package forwarding
+
+import (
+	"testing"
+)
+
+// TestDestinationSelectionModeUnmarshal tests that unmarshaling from a string
+// specification succeeeds for DestinationSelectionMode.
+func TestDestinationSelectionModeUnmarshal(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		text          string
+		expectedMode  DestinationSelectionMode
+		expectFailure bool
+	}{
+		{"", DestinationSelectionMode_DestinationSelectionModeDefault, true},
+		{"asdf", DestinationSelectionMode_DestinationSelectionModeDefault, true},
+		{"failover", DestinationSelectionMode_DestinationSelectionModeFailover, false},
+		{"round-robin", DestinationSelectionMode_DestinationSelectionModeRoundRobin, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		var mode DestinationSelectionMode
+		if err := mode.UnmarshalText([]byte(testCase.text)); err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", testCase.text, err)
+			}
+		} else if testCase.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", testCase.text)
+		} else if mode != testCase.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				testCase.expectedMode,
+			)
+		}
+	}
+}
+
+// TestDestinationSelectionModeSupported tests that DestinationSelectionMode
+// support detection works as expected.
+func TestDestinationSelectionModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            DestinationSelectionMode
+		expectSupported bool
+	}{
+		{DestinationSelectionMode_DestinationSelectionModeDefault, false},
+		{DestinationSelectionMode_DestinationSelectionModeFailover, true},
+		{DestinationSelectionMode_DestinationSelectionModeRoundRobin, true},
+		{(DestinationSelectionMode_DestinationSelectionModeRoundRobin + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestDestinationSelectionModeDescription tests that DestinationSelectionMode
+// description generation works as expected.
+func TestDestinationSelectionModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                DestinationSelectionMode
+		expectedDescription string
+	}{
+		{DestinationSelectionMode_DestinationSelectionModeDefault, "Default"},
+		{DestinationSelectionMode_DestinationSelectionModeFailover, "Failover"},
+		{DestinationSelectionMode_DestinationSelectionModeRoundRobin, "Round-robin"},
+		{(DestinationSelectionMode_DestinationSelectionModeRoundRobin + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
@@ -32,3 +32,26 @@ type Endpoint interface {
 	// Open call.
 	Shutdown() error
 }
+
+// AccessControlledEndpoint is an optional interface that can be implemented by
+// listener endpoints that enforce access control on incoming connections. It
+// is only implemented by local listeners, so refusals by remote listeners
+// (which enforce access control within the agent) aren't reported.
+type AccessControlledEndpoint interface {
+	Endpoint
+	// SetRefusalHandler sets a callback that will be invoked (from within Open)
+	// each time an incoming connection is refused due to access control
+	// restrictions. It must not be called concurrently with Open.
+	SetRefusalHandler(handler func())
+}
+
+// ListenerEndpoint is an optional interface that can be implemented by listener
+// endpoints to report the addresses on which they're listening.
+type ListenerEndpoint interface {
+	Endpoint
+	// ListenerAddresses returns the addresses on which the endpoint is
+	// listening. If the endpoint hasn't yet bound its underlying listeners
+	// (e.g. due to lazy initialization), then it should return the requested
+	// addresses. It must be safe for concurrent invocation with Open.
+	ListenerAddresses() []string
+}
@@ -0,0 +1,142 @@
+package local
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os/user"
+	"strconv"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+)
+
+// peerFilter performs peer credential based access control for incoming Unix
+// domain socket connections. A nil filter allows all peers.
+type peerFilter struct {
+	// users is the set of allowed user IDs.
+	users map[uint32]bool
+	// groups is the set of allowed group IDs.
+	groups map[uint32]bool
+}
+
+// resolvePOSIXIdentifier resolves a user or group ownership identifier to its
+// numeric POSIX identifier. If group is true, then name-based identifiers are
+// resolved as group names, otherwise they're resolved as user names.
+func resolvePOSIXIdentifier(specification string, group bool) (uint32, error) {
+	// Parse the identifier and extract its numeric representation.
+	var numeric string
+	switch kind, identifier := filesystem.ParseOwnershipIdentifier(specification); kind {
+	case filesystem.OwnershipIdentifierKindInvalid:
+		return 0, errors.New("invalid identifier specification")
+	case filesystem.OwnershipIdentifierKindPOSIXID:
+		numeric = identifier
+	case filesystem.OwnershipIdentifierKindWindowsSID:
+		return 0, errors.New("Windows SIDs not supported for peer credential checks")
+	case filesystem.OwnershipIdentifierKindName:
+		if group {
+			if groupObject, err := user.LookupGroup(identifier); err != nil {
+				return 0, fmt.Errorf("unable to lookup group by name: %w", err)
+			} else {
+				numeric = groupObject.Gid
+			}
+		} else {
+			if userObject, err := user.Lookup(identifier); err != nil {
+				return 0, fmt.Errorf("unable to lookup user by name: %w", err)
+			} else {
+				numeric = userObject.Uid
+			}
+		}
+	default:
+		panic("unhandled ownership identifier kind")
+	}
+
+	// Convert the identifier.
+	value, err := strconv.ParseUint(numeric, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("unable to convert identifier to numeric value: %w", err)
+	}
+	return uint32(value), nil
+}
+
+// newPeerFilter creates a new peer filter from the specified user and group
+// specifications. If both lists are empty, then it returns a nil filter.
+func newPeerFilter(users, groups []string) (*peerFilter, error) {
+	// If there are no restrictions, then there's no need for a filter.
+	if len(users) == 0 && len(groups) == 0 {
+		return nil, nil
+	}
+
+	// Ensure that peer credentials are available on this platform.
+	if !peerCredentialsSupported {
+		return nil, errors.New("peer credential checks not supported on this platform")
+	}
+
+	// Resolve users.
+	result := &peerFilter{
+		users:  make(map[uint32]bool, len(users)),
+		groups: make(map[uint32]bool, len(groups)),
+	}
+	for _, specification := range users {
+		if uid, err := resolvePOSIXIdentifier(specification, false); err != nil {
+			return nil, fmt.Errorf("unable to resolve allowed user (%s): %w", specification, err)
+		} else {
+			result.users[uid] = true
+		}
+	}
+
+	// Resolve groups.
+	for _, specification := range groups {
+		if gid, err := resolvePOSIXIdentifier(specification, true); err != nil {
+			return nil, fmt.Errorf("unable to resolve allowed group (%s): %w", specification, err)
+		} else {
+			result.groups[gid] = true
+		}
+	}
+
+	// Success.
+	return result, nil
+}
+
+// allows determines whether or not the filter allows the specified peer
+// credentials. A peer is allowed if its user ID or any of its group IDs is
+// allowed.
+func (f *peerFilter) allows(uid uint32, gids []uint32) bool {
+	// A nil filter allows everything.
+	if f == nil {
+		return true
+	}
+
+	// Check the user.
+	if f.users[uid] {
+		return true
+	}
+
+	// Check the groups.
+	for _, gid := range gids {
+		if f.groups[gid] {
+			return true
+		}
+	}
+
+	// The peer isn't allowed.
+	return false
+}
+
+// allowsConnection determines whether or not the filter allows the peer of the
+// specified connection. Failure to determine peer credentials results in an
+// error.
+func (f *peerFilter) allowsConnection(connection net.Conn) (bool, error) {
+	// A nil filter allows everything.
+	if f == nil {
+		return true, nil
+	}
+
+	// Query peer credentials.
+	uid, gids, err := peerCredentials(connection)
+	if err != nil {
+		return false, fmt.Errorf("unable to query peer credentials: %w", err)
+	}
+
+	// Perform the check.
+	return f.allows(uid, gids), nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
 	"github.com/mutagen-io/mutagen/pkg/forwarding"
 	"github.com/mutagen-io/mutagen/pkg/logging"
+	forwardingurl "github.com/mutagen-io/mutagen/pkg/url/forwarding"
 )
 
 // DisableLazyListenerInitialization indicates that lazy listener initialization
@@ -32,14 +33,33 @@ type listenerEndpoint struct {
 	protocol string
 	// address is the listening address.
 	address string
+	// addresses are the individual listening addresses. For TCP port range
+	// specifications, there will be one address for each port in the range.
+	// In all other cases, this will contain only address.
+	addresses []string
 	// lazy indicates whether or not the endpoint uses lazy initialization.
 	lazy bool
 	// initializeOnce is used to guard calls to initialize.
 	initializeOnce sync.Once
 	// listener is the underlying listener. It is set by initialize.
 	listener net.Listener
+	// networkFilter is the network filter for TCP listeners. It is set by
+	// initialize and may be nil if no network restrictions apply.
+	networkFilter *forwarding.NetworkFilter
+	// peerFilter is the peer credential filter for Unix domain socket
+	// listeners. It is set by initialize and may be nil if no peer credential
+	// restrictions apply.
+	peerFilter *peerFilter
 	// initializeError is any error that occurred during initialization.
 	initializeError error
+	// boundAddressesLock guards boundAddresses.
+	boundAddressesLock sync.Mutex
+	// boundAddresses are the addresses to which the listener is bound. It is
+	// set by initialize.
+	boundAddresses []string
+	// refusalHandler is the callback to invoke when a connection is refused. It
+	// may be nil.
+	refusalHandler func()
 }
 
 // NewListenerEndpoint creates a new forwarding.Endpoint that behaves as a
@@ -81,6 +101,19 @@ func NewListenerEndpoint(
 		lazy = false
 	}
 
+	// Expand any port range specification.
+	addresses, err := forwardingurl.ExpandAddress(protocol, address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid listening address: %w", err)
+	}
+
+	// If any address relies on the operating system to allocate a port, then
+	// disable lazy initialization so that the bound address can be reported
+	// immediately.
+	if lazy && requiresPortAllocation(protocol, addresses) {
+		lazy = false
+	}
+
 	// Create the endpoint.
 	endpoint := &listenerEndpoint{
 		logger:        logger,
@@ -88,6 +121,7 @@ func NewListenerEndpoint(
 		configuration: configuration,
 		protocol:      protocol,
 		address:       address,
+		addresses:     addresses,
 		lazy:          lazy,
 	}
 
@@ -103,6 +137,23 @@ func NewListenerEndpoint(
 	return endpoint, nil
 }
 
+// requiresPortAllocation determines whether or not any of the specified
+// listening addresses rely on the operating system to allocate a port.
+func requiresPortAllocation(protocol string, addresses []string) bool {
+	// Only TCP listeners can request port allocation.
+	if !(protocol == "tcp" || protocol == "tcp4" || protocol == "tcp6") {
+		return false
+	}
+
+	// Check for addresses with a zero port.
+	for _, address := range addresses {
+		if _, port, err := net.SplitHostPort(address); err == nil && (port == "0" || port == "") {
+			return true
+		}
+	}
+	return false
+}
+
 // initialize performs initialization for the endpoint. It will set either the
 // listener member or listenError member. It should be invoked using the
 // initializeOnce member.
@@ -113,15 +164,65 @@ func (e *listenerEndpoint) initialize(shutdown bool) {
 		return
 	}
 
+	// Set up access control. Network restrictions only apply to TCP listeners
+	// and peer credential restrictions only apply to Unix domain socket
+	// listeners, with the latter being unsupported for Windows named pipes.
+	switch e.protocol {
+	case "tcp", "tcp4", "tcp6":
+		e.networkFilter, e.initializeError = forwarding.NewNetworkFilter(
+			e.configuration.AllowedNetworks,
+			e.configuration.DeniedNetworks,
+		)
+	case "unix":
+		e.peerFilter, e.initializeError = newPeerFilter(
+			e.configuration.SocketAllowedUsers,
+			e.configuration.SocketAllowedGroups,
+		)
+	case "npipe":
+		if len(e.configuration.SocketAllowedUsers) > 0 || len(e.configuration.SocketAllowedGroups) > 0 {
+			e.initializeError = errors.New("peer credential restrictions not supported for Windows named pipes")
+		}
+	}
+	if e.initializeError != nil {
+		e.initializeError = fmt.Errorf("unable to configure access control: %w", e.initializeError)
+		return
+	}
+
 	// If we're dealing with a Windows named pipe target, then perform listening
 	// using the platform-specific listening function.
 	if e.protocol == "npipe" {
 		e.listener, e.initializeError = listenWindowsNamedPipe(e.address)
+		if e.initializeError == nil {
+			e.setBoundAddresses([]string{e.address})
+		}
+		return
+	}
+
+	// If we're dealing with a TCP port range, then create a listener for each
+	// port in the range and aggregate them.
+	if len(e.addresses) > 1 {
+		listeners := make([]net.Listener, 0, len(e.addresses))
+		for _, address := range e.addresses {
+			listener, err := net.Listen(e.protocol, address)
+			if err != nil {
+				for _, l := range listeners {
+					l.Close()
+				}
+				e.initializeError = fmt.Errorf("unable to listen on %s: %w", address, err)
+				return
+			}
+			listeners = append(listeners, listener)
+		}
+		aggregate := newMultiListener(listeners)
+		e.setBoundAddresses(aggregate.addresses())
+		e.listener = aggregate
 		return
 	}
 
-	// Otherwise attempt to create a listener using the generic method.
-	listener, err := net.Listen(e.protocol, e.address)
+	// Otherwise attempt to create a listener using the generic method. We use
+	// the expanded address since a single-port range (e.g. 9000-9000) expands
+	// to a single address that differs from the original address.
+	listener, err := net.Listen(e.protocol, e.addresses[0])
 	if err != nil {
 		// If we're not targeting a Unix domain socket or the error isn't due to
 		// a conflicting socket, then abort.
@@ -150,7 +251,7 @@ func (e *listenerEndpoint) initialize(shutdown bool) {
 		}
 
 		// Retry listening.
-		listener, err = net.Listen(e.protocol, e.address)
+		listener, err = net.Listen(e.protocol, e.addresses[0])
 		if err != nil {
 			e.initializeError = fmt.Errorf("unable to create listener after conflicting socket removal: %w", err)
 			return
@@ -198,9 +299,31 @@ func (e *listenerEndpoint) initialize(shutdown bool) {
 	}
 
 	// Success.
+	e.setBoundAddresses([]string{listener.Addr().String()})
 	e.listener = listener
 }
 
+// setBoundAddresses records the addresses to which the listener is bound.
+func (e *listenerEndpoint) setBoundAddresses(addresses []string) {
+	e.boundAddressesLock.Lock()
+	e.boundAddresses = addresses
+	e.boundAddressesLock.Unlock()
+}
+
+// ListenerAddresses implements forwarding.ListenerEndpoint.ListenerAddresses.
+func (e *listenerEndpoint) ListenerAddresses() []string {
+	// Lock the bound addresses and defer their release.
+	e.boundAddressesLock.Lock()
+	defer e.boundAddressesLock.Unlock()
+
+	// If the listener has been bound, then return the bound addresses,
+	// otherwise return the requested addresses.
+	if e.boundAddresses != nil {
+		return e.boundAddresses
+	}
+	return e.addresses
+}
+
 // TransportErrors implements forwarding.Endpoint.TransportErrors.
 func (e *listenerEndpoint) TransportErrors() <-chan error {
 	return nil
@@ -217,8 +340,52 @@ func (e *listenerEndpoint) Open() (net.Conn, error) {
 		}
 	}
 
-	// Accept a connection.
-	return e.listener.Accept()
+	// Accept connections until one passes access control.
+	for {
+		// Accept a connection.
+		connection, err := e.listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+
+		// Perform access control checks.
+		if err := e.authorize(connection); err != nil {
+			e.logger.Warn("Refused connection:", err)
+			connection.Close()
+			if e.refusalHandler != nil {
+				e.refusalHandler()
+			}
+			continue
+		}
+
+		// Success.
+		return connection, nil
+	}
+}
+
+// authorize performs access control checks on an incoming connection. It
+// returns a non-nil error describing the reason for refusal if the connection
+// should be refused.
+func (e *listenerEndpoint) authorize(connection net.Conn) error {
+	// Perform network-based checks.
+	if !e.networkFilter.AllowsRemoteAddress(connection.RemoteAddr()) {
+		return fmt.Errorf("address %s not allowed", connection.RemoteAddr())
+	}
+
+	// Perform peer credential checks.
+	if allowed, err := e.peerFilter.allowsConnection(connection); err != nil {
+		return err
+	} else if !allowed {
+		return errors.New("peer credentials not allowed")
+	}
+
+	// Success.
+	return nil
+}
+
+// SetRefusalHandler implements forwarding.AccessControlledEndpoint.SetRefusalHandler.
+func (e *listenerEndpoint) SetRefusalHandler(handler func()) {
+	e.refusalHandler = handler
 }
 
 // Shutdown implements forwarding.Endpoint.Shutdown.
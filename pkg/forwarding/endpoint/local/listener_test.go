@@ -1,3 +1,272 @@
 package local
 
-// TODO: Implement.
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+)
+
+// TestListenerNetworkRestrictions tests that TCP listeners enforce network
+// restrictions and report refused connections.
+func TestListenerNetworkRestrictions(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		configuration *forwarding.Configuration
+		expectAllowed bool
+	}{
+		{&forwarding.Configuration{}, true},
+		{&forwarding.Configuration{AllowedNetworks: []string{"127.0.0.0/8"}}, true},
+		{&forwarding.Configuration{AllowedNetworks: []string{"10.0.0.0/8"}}, false},
+		{&forwarding.Configuration{DeniedNetworks: []string{"127.0.0.1"}}, false},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Create the endpoint.
+		endpoint, err := NewListenerEndpoint(nil, forwarding.Version_Version1, testCase.configuration, "tcp", "127.0.0.1:0", false)
+		if err != nil {
+			t.Fatalf("test case %d: unable to create listener endpoint: %v", i, err)
+		}
+		address := endpoint.(*listenerEndpoint).listener.Addr().String()
+
+		// Track refusals.
+		refusals := make(chan struct{}, 1)
+		endpoint.(forwarding.AccessControlledEndpoint).SetRefusalHandler(func() {
+			refusals <- struct{}{}
+		})
+
+		// Start accepting in the background.
+		accepted := make(chan error, 1)
+		go func() {
+			connection, err := endpoint.Open()
+			if err == nil {
+				connection.Close()
+			}
+			accepted <- err
+		}()
+
+		// Dial the listener.
+		connection, err := net.Dial("tcp", address)
+		if err != nil {
+			t.Fatalf("test case %d: unable to dial listener: %v", i, err)
+		}
+		connection.Close()
+
+		// Verify the behavior.
+		if testCase.expectAllowed {
+			if err := <-accepted; err != nil {
+				t.Errorf("test case %d: connection not accepted: %v", i, err)
+			}
+			endpoint.Shutdown()
+		} else {
+			<-refusals
+			endpoint.Shutdown()
+			if err := <-accepted; err == nil {
+				t.Errorf("test case %d: connection accepted unexpectedly", i)
+			}
+		}
+	}
+}
+
+// TestListenerPeerRestrictions tests that Unix domain socket listeners enforce
+// peer credential restrictions.
+func TestListenerPeerRestrictions(t *testing.T) {
+	// Skip this test on platforms without peer credential support.
+	if !peerCredentialsSupported {
+		t.Skip()
+	}
+
+	// Compute the current user and group identifiers.
+	uid := fmt.Sprintf("id:%d", os.Getuid())
+	gid := fmt.Sprintf("id:%d", os.Getgid())
+
+	// Set up test cases.
+	testCases := []struct {
+		configuration *forwarding.Configuration
+		expectAllowed bool
+	}{
+		{&forwarding.Configuration{SocketAllowedUsers: []string{uid}}, true},
+		{&forwarding.Configuration{SocketAllowedGroups: []string{gid}}, true},
+		{&forwarding.Configuration{SocketAllowedUsers: []string{"id:4294967294"}}, false},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Create the endpoint.
+		address := filepath.Join(t.TempDir(), "socket")
+		endpoint, err := NewListenerEndpoint(nil, forwarding.Version_Version1, testCase.configuration, "unix", address, false)
+		if err != nil {
+			t.Fatalf("test case %d: unable to create listener endpoint: %v", i, err)
+		}
+
+		// Track refusals.
+		refusals := make(chan struct{}, 1)
+		endpoint.(forwarding.AccessControlledEndpoint).SetRefusalHandler(func() {
+			refusals <- struct{}{}
+		})
+
+		// Start accepting in the background.
+		accepted := make(chan error, 1)
+		go func() {
+			connection, err := endpoint.Open()
+			if err == nil {
+				connection.Close()
+			}
+			accepted <- err
+		}()
+
+		// Dial the listener.
+		connection, err := net.Dial("unix", address)
+		if err != nil {
+			t.Fatalf("test case %d: unable to dial listener: %v", i, err)
+		}
+		connection.Close()
+
+		// Verify the behavior.
+		if testCase.expectAllowed {
+			if err := <-accepted; err != nil {
+				t.Errorf("test case %d: connection not accepted: %v", i, err)
+			}
+			endpoint.Shutdown()
+		} else {
+			<-refusals
+			endpoint.Shutdown()
+			if err := <-accepted; err == nil {
+				t.Errorf("test case %d: connection accepted unexpectedly", i)
+			}
+		}
+	}
+}
+
+// TestListenerPortRange tests that TCP listeners support port ranges and
+// report the addresses to which they're bound.
+func TestListenerPortRange(t *testing.T) {
+	// Find a free block of ports by allocating an ephemeral port and using it
+	// as the base for a small range. This isn't guaranteed to succeed, so we
+	// retry a few times if binding fails.
+	var endpoint forwarding.Endpoint
+	var err error
+	for attempt := 0; attempt < 10; attempt++ {
+		var probe net.Listener
+		if probe, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+			t.Fatal("unable to create probe listener:", err)
+		}
+		base := probe.Addr().(*net.TCPAddr).Port
+		probe.Close()
+		if base > 65533 {
+			continue
+		}
+		address := fmt.Sprintf("127.0.0.1:%d-%d", base, base+2)
+		endpoint, err = NewListenerEndpoint(nil, forwarding.Version_Version1, &forwarding.Configuration{}, "tcp", address, false)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		t.Fatal("unable to create port range listener endpoint:", err)
+	}
+	defer endpoint.Shutdown()
+
+	// Verify that all addresses were bound.
+	addresses := endpoint.(forwarding.ListenerEndpoint).ListenerAddresses()
+	if len(addresses) != 3 {
+		t.Fatal("unexpected number of listener addresses:", len(addresses))
+	}
+
+	// Verify that connections to each port are accepted.
+	for _, address := range addresses {
+		accepted := make(chan error, 1)
+		go func() {
+			connection, err := endpoint.Open()
+			if err == nil {
+				connection.Close()
+			}
+			accepted <- err
+		}()
+		connection, err := net.Dial("tcp", address)
+		if err != nil {
+			t.Fatal("unable to dial listener:", err)
+		}
+		connection.Close()
+		if err := <-accepted; err != nil {
+			t.Error("connection not accepted:", err)
+		}
+	}
+}
+
+// TestListenerSinglePortRange tests that TCP listeners support port ranges
+// containing a single port.
+func TestListenerSinglePortRange(t *testing.T) {
+	// Find a free port by allocating an ephemeral port. This isn't guaranteed
+	// to remain free, so we retry a few times if binding fails.
+	var endpoint forwarding.Endpoint
+	var expected string
+	var err error
+	for attempt := 0; attempt < 10; attempt++ {
+		var probe net.Listener
+		if probe, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+			t.Fatal("unable to create probe listener:", err)
+		}
+		port := probe.Addr().(*net.TCPAddr).Port
+		probe.Close()
+		expected = fmt.Sprintf("127.0.0.1:%d", port)
+		address := fmt.Sprintf("127.0.0.1:%d-%d", port, port)
+		endpoint, err = NewListenerEndpoint(nil, forwarding.Version_Version1, &forwarding.Configuration{}, "tcp", address, false)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		t.Fatal("unable to create single-port range listener endpoint:", err)
+	}
+	defer endpoint.Shutdown()
+
+	// Verify that the port was bound.
+	addresses := endpoint.(forwarding.ListenerEndpoint).ListenerAddresses()
+	if len(addresses) != 1 {
+		t.Fatal("unexpected number of listener addresses:", len(addresses))
+	} else if addresses[0] != expected {
+		t.Error("unexpected listener address:", addresses[0], "!=", expected)
+	}
+
+	// Verify that connections are accepted.
+	accepted := make(chan error, 1)
+	go func() {
+		connection, err := endpoint.Open()
+		if err == nil {
+			connection.Close()
+		}
+		accepted <- err
+	}()
+	connection, err := net.Dial("tcp", expected)
+	if err != nil {
+		t.Fatal("unable to dial listener:", err)
+	}
+	connection.Close()
+	if err := <-accepted; err != nil {
+		t.Error("connection not accepted:", err)
+	}
+}
+
+// TestListenerPortAllocation tests that TCP listeners with OS-allocated ports
+// are initialized eagerly and report their bound address.
+func TestListenerPortAllocation(t *testing.T) {
+	// Create a lazy endpoint and defer its shutdown.
+	endpoint, err := NewListenerEndpoint(nil, forwarding.Version_Version1, &forwarding.Configuration{}, "tcp", "127.0.0.1:0", true)
+	if err != nil {
+		t.Fatal("unable to create listener endpoint:", err)
+	}
+	defer endpoint.Shutdown()
+
+	// Verify that the endpoint reports an allocated port.
+	addresses := endpoint.(forwarding.ListenerEndpoint).ListenerAddresses()
+	if len(addresses) != 1 {
+		t.Fatal("unexpected number of listener addresses:", len(addresses))
+	} else if addresses[0] == "127.0.0.1:0" {
+		t.Error("listener address does not reflect allocated port")
+	}
+}
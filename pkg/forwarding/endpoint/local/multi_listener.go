@@ -0,0 +1,111 @@
+package local
+
+import (
+	"net"
+	"sync"
+)
+
+// multiListener implements net.Listener by aggregating connections accepted by
+// multiple underlying listeners. It is used to implement port range listening.
+type multiListener struct {
+	// listeners are the underlying listeners.
+	listeners []net.Listener
+	// connections is used to deliver accepted connections.
+	connections chan net.Conn
+	// acceptErrors is used to deliver the first accept error that occurs on an
+	// underlying listener.
+	acceptErrors chan error
+	// closeOnce guards closure of closed.
+	closeOnce sync.Once
+	// closed is closed when the listener is closed.
+	closed chan struct{}
+}
+
+// newMultiListener creates a new aggregate listener from the specified
+// listeners. It takes ownership of the listeners and starts accepting
+// connections on them immediately. The listener slice must be non-empty.
+func newMultiListener(listeners []net.Listener) *multiListener {
+	// Create the listener.
+	result := &multiListener{
+		listeners:    listeners,
+		connections:  make(chan net.Conn),
+		acceptErrors: make(chan error, 1),
+		closed:       make(chan struct{}),
+	}
+
+	// Start accepting connections on each listener.
+	for _, listener := range listeners {
+		go result.accept(listener)
+	}
+
+	// Done.
+	return result
+}
+
+// accept is the accept loop for an individual underlying listener.
+func (l *multiListener) accept(listener net.Listener) {
+	for {
+		// Accept the next connection. If an error occurs, then attempt to
+		// record it (unless another error has already been recorded).
+		connection, err := listener.Accept()
+		if err != nil {
+			select {
+			case l.acceptErrors <- err:
+			default:
+			}
+			return
+		}
+
+		// Deliver the connection or close it if the listener is closed.
+		select {
+		case l.connections <- connection:
+		case <-l.closed:
+			connection.Close()
+			return
+		}
+	}
+}
+
+// Accept implements net.Listener.Accept.
+func (l *multiListener) Accept() (net.Conn, error) {
+	select {
+	case connection := <-l.connections:
+		return connection, nil
+	case err := <-l.acceptErrors:
+		return nil, err
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+// Close implements net.Listener.Close.
+func (l *multiListener) Close() error {
+	// Close the underlying listeners, tracking the first error.
+	var firstErr error
+	l.closeOnce.Do(func() {
+		close(l.closed)
+		for _, listener := range l.listeners {
+			if err := listener.Close(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	})
+
+	// Done.
+	return firstErr
+}
+
+// Addr implements net.Listener.Addr. It returns the address of the first
+// underlying listener.
+func (l *multiListener) Addr() net.Addr {
+	return l.listeners[0].Addr()
+}
+
+// addresses returns the addresses of all underlying listeners.
+func (l *multiListener) addresses() []string {
+	result := make([]string, len(l.listeners))
+	for i, listener := range l.listeners {
+		result[i] = listener.Addr().String()
+	}
+	return result
+}
@@ -0,0 +1,52 @@
+//go:build darwin || freebsd
+
+package local
+
+import (
+	"errors"
+	"net"
+
+	"golang.org/x/sys/unix"
+)
+
+// peerCredentialsSupported indicates whether or not peerCredentials is
+// supported on the current platform.
+const peerCredentialsSupported = true
+
+// peerCredentials returns the user and group identifiers of the process at the
+// other end of a Unix domain socket connection. On BSD systems, this
+// information is queried using LOCAL_PEERCRED, which provides the full group
+// list.
+func peerCredentials(connection net.Conn) (uint32, []uint32, error) {
+	// Extract the raw connection.
+	unixConnection, ok := connection.(*net.UnixConn)
+	if !ok {
+		return 0, nil, errors.New("connection is not a Unix domain socket connection")
+	}
+	rawConnection, err := unixConnection.SyscallConn()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// Query the peer credentials.
+	var credentials *unix.Xucred
+	var credentialsErr error
+	if err := rawConnection.Control(func(descriptor uintptr) {
+		credentials, credentialsErr = unix.GetsockoptXucred(int(descriptor), unix.SOL_LOCAL, unix.LOCAL_PEERCRED)
+	}); err != nil {
+		return 0, nil, err
+	} else if credentialsErr != nil {
+		return 0, nil, credentialsErr
+	}
+
+	// Extract the group list, guarding against invalid group counts.
+	groupCount := int(credentials.Ngroups)
+	if groupCount < 0 || groupCount > len(credentials.Groups) {
+		return 0, nil, errors.New("invalid peer group count")
+	}
+	groups := make([]uint32, groupCount)
+	copy(groups, credentials.Groups[:groupCount])
+
+	// Success.
+	return credentials.Uid, groups, nil
+}
@@ -0,0 +1,41 @@
+package local
+
+import (
+	"errors"
+	"net"
+
+	"golang.org/x/sys/unix"
+)
+
+// peerCredentialsSupported indicates whether or not peerCredentials is
+// supported on the current platform.
+const peerCredentialsSupported = true
+
+// peerCredentials returns the user and group identifiers of the process at the
+// other end of a Unix domain socket connection. On Linux, this information is
+// queried using SO_PEERCRED, which only provides the primary group identifier.
+func peerCredentials(connection net.Conn) (uint32, []uint32, error) {
+	// Extract the raw connection.
+	unixConnection, ok := connection.(*net.UnixConn)
+	if !ok {
+		return 0, nil, errors.New("connection is not a Unix domain socket connection")
+	}
+	rawConnection, err := unixConnection.SyscallConn()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// Query the peer credentials.
+	var credentials *unix.Ucred
+	var credentialsErr error
+	if err := rawConnection.Control(func(descriptor uintptr) {
+		credentials, credentialsErr = unix.GetsockoptUcred(int(descriptor), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	}); err != nil {
+		return 0, nil, err
+	} else if credentialsErr != nil {
+		return 0, nil, credentialsErr
+	}
+
+	// Success.
+	return credentials.Uid, []uint32{credentials.Gid}, nil
+}
@@ -0,0 +1,18 @@
+//go:build !linux && !darwin && !freebsd
+
+package local
+
+import (
+	"errors"
+	"net"
+)
+
+// peerCredentialsSupported indicates whether or not peerCredentials is
+// supported on the current platform.
+const peerCredentialsSupported = false
+
+// peerCredentials returns an "unsupported" error on platforms without peer
+// credential support.
+func peerCredentials(_ net.Conn) (uint32, []uint32, error) {
+	return 0, nil, errors.New("peer credentials not supported on this platform")
+}
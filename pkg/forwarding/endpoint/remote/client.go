@@ -25,6 +25,9 @@ type client struct {
 	// listener indicates whether or not the remote endpoint is operating as a
 	// listener.
 	listener bool
+	// listenerAddresses are the addresses on which the remote endpoint is
+	// listening, as reported during initialization.
+	listenerAddresses []string
 }
 
 // NewEndpoint creates a new remote forwarding.Endpoint operating over the
@@ -99,10 +102,11 @@ func NewEndpoint(
 
 	// Success.
 	return &client{
-		logger:          logger,
-		transportErrors: transportErrors,
-		multiplexer:     multiplexer,
-		listener:        source,
+		logger:            logger,
+		transportErrors:   transportErrors,
+		multiplexer:       multiplexer,
+		listener:          source,
+		listenerAddresses: response.ListenerAddresses,
 	}, nil
 }
 
@@ -121,6 +125,11 @@ func (c *client) Open() (net.Conn, error) {
 	}
 }
 
+// ListenerAddresses implements forwarding.ListenerEndpoint.ListenerAddresses.
+func (c *client) ListenerAddresses() []string {
+	return c.listenerAddresses
+}
+
 // Shutdown implements forwarding.Endpoint.Shutdown.
 func (c *client) Shutdown() error {
 	return c.multiplexer.Close()
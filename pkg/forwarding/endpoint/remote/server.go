@@ -85,6 +85,8 @@ func ServeEndpoint(logger *logging.Logger, stream io.ReadWriteCloser) error {
 	response := &InitializeForwardingResponse{}
 	if initializationError != nil {
 		response.Error = initializationError.Error()
+	} else if listener, ok := underlying.(forwarding.ListenerEndpoint); ok {
+		response.ListenerAddresses = listener.ListenerAddresses()
 	}
 	if err := encoding.EncodeProtobuf(carrier, response); err != nil {
 		return fmt.Errorf("unable to send initialization response: %w", err)
@@ -145,6 +147,6 @@ func ServeEndpoint(logger *logging.Logger, stream io.ReadWriteCloser) error {
 		}
 
 		// Perform forwarding.
-		go forwarding.ForwardAndClose(context.Background(), incoming, outgoing, nil, nil)
+		go forwarding.ForwardAndClose(context.Background(), incoming, outgoing, nil, nil, nil, nil)
 	}
 }
@@ -15,7 +15,15 @@ import (
 // Both connections must implement CloseWriter or this function will panic. If
 // the caller passes non-nil values for firstAuditor and/or secondAuditor, then
 // auditing will be performed on the write end of the respective connection.
-func ForwardAndClose(ctx context.Context, first, second net.Conn, firstAuditor, secondAuditor stream.Auditor) {
+// Similarly, if the caller passes non-nil values for firstRecorder and/or
+// secondRecorder, then the data written to the respective connection will be
+// recorded.
+func ForwardAndClose(
+	ctx context.Context,
+	first, second net.Conn,
+	firstAuditor, secondAuditor stream.Auditor,
+	firstRecorder, secondRecorder stream.Recorder,
+) {
 	// Defer closure of the connections.
 	defer func() {
 		first.Close()
@@ -32,22 +40,22 @@ func ForwardAndClose(ctx context.Context, first, second net.Conn, firstAuditor,
 		panic("second connection does not implement write closure")
 	}
 
-	// Forward traffic between the connections (with optional auditing) in
-	// separate Goroutines and track their termination. We track their
-	// termination via the error result, though this may be nil in the event
-	// that the source indicates EOF. If we do see an EOF from a source, then
-	// perform write closure on the corresponding destination in order to
+	// Forward traffic between the connections (with optional auditing and
+	// recording) in separate Goroutines and track their termination. We track
+	// their termination via the error result, though this may be nil in the
+	// event that the source indicates EOF. If we do see an EOF from a source,
+	// then perform write closure on the corresponding destination in order to
 	// forward the EOF.
 	copyErrors := make(chan error, 2)
 	go func() {
-		_, err := io.Copy(stream.NewAuditWriter(first, firstAuditor), second)
+		_, err := io.Copy(stream.NewRecordingWriter(stream.NewAuditWriter(first, firstAuditor), firstRecorder), second)
 		if err == nil {
 			firstCloseWriter.CloseWrite()
 		}
 		copyErrors <- err
 	}()
 	go func() {
-		_, err := io.Copy(stream.NewAuditWriter(second, secondAuditor), first)
+		_, err := io.Copy(stream.NewRecordingWriter(stream.NewAuditWriter(second, secondAuditor), secondRecorder), first)
 		if err == nil {
 			secondCloseWriter.CloseWrite()
 		}
@@ -25,6 +25,8 @@ type Manager struct {
 	sessionsLock *state.TrackingLock
 	// sessions maps sessions to their respective controllers.
 	sessions map[string]*controller
+	// events is the broadcaster used to publish session events.
+	events *eventBroadcaster
 }
 
 // NewManager creates a new Manager instance.
@@ -33,6 +35,9 @@ func NewManager(logger *logging.Logger) (*Manager, error) {
 	tracker := state.NewTracker()
 	sessionsLock := state.NewTrackingLock(tracker)
 
+	// Create an event broadcaster.
+	events := newEventBroadcaster()
+
 	// Create the session registry.
 	sessions := make(map[string]*controller)
 
@@ -53,7 +58,7 @@ func NewManager(logger *logging.Logger) (*Manager, error) {
 			continue
 		}
 		logger.Info("Loading session", id)
-		if controller, err := loadSession(logger.Sublogger(identifier.Truncated(id)), tracker, id); err != nil {
+		if controller, err := loadSession(logger.Sublogger(identifier.Truncated(id)).WithSession(id), tracker, events, id); err != nil {
 			logger.Warnf("Failed to load session %s: %v", id, err)
 			continue
 		} else {
@@ -68,6 +73,7 @@ func NewManager(logger *logging.Logger) (*Manager, error) {
 		tracker:      tracker,
 		sessionsLock: sessionsLock,
 		sessions:     sessions,
+		events:       events,
 	}, nil
 }
 
@@ -163,6 +169,37 @@ func (m *Manager) selectControllers(selection *selection.Selection) ([]*controll
 	}
 }
 
+// sessionFilter creates a session filter function using the mechanism specified
+// by the provided selection. Unlike selectControllers, it doesn't require that
+// specifications match existing sessions, since it's used to filter events for
+// sessions that may not exist yet.
+func sessionFilter(criteria *selection.Selection) (func(*Session) bool, error) {
+	// Dispatch filter creation based on the requested mechanism.
+	if criteria.All {
+		return func(_ *Session) bool { return true }, nil
+	} else if len(criteria.Specifications) > 0 {
+		specifications := criteria.Specifications
+		return func(session *Session) bool {
+			for _, specification := range specifications {
+				if session.Identifier == specification || session.Name == specification {
+					return true
+				}
+			}
+			return false
+		}, nil
+	} else if criteria.LabelSelector != "" {
+		selector, err := selection.ParseLabelSelector(criteria.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse label selector: %w", err)
+		}
+		return func(session *Session) bool {
+			return selector.Matches(session.Labels)
+		}, nil
+	} else {
+		return nil, errors.New("invalid session selection")
+	}
+}
+
 // Shutdown tells the manager to gracefully halt sessions.
 func (m *Manager) Shutdown() {
 	// Log the shutdown.
@@ -171,6 +208,9 @@ func (m *Manager) Shutdown() {
 	// Terminate state tracking to terminate monitoring.
 	m.tracker.Terminate()
 
+	// Terminate event broadcasting to terminate event streams.
+	m.events.terminate()
+
 	// Grab the registry lock and defer its release.
 	m.sessionsLock.Lock()
 	defer m.sessionsLock.UnlockWithoutNotify()
@@ -179,7 +219,7 @@ func (m *Manager) Shutdown() {
 	// log any that fail to halt.
 	for _, controller := range m.sessions {
 		m.logger.Info("Halting session", controller.session.Identifier)
-		if err := controller.halt(context.Background(), controllerHaltModeShutdown, ""); err != nil {
+		if err := controller.halt(context.Background(), controllerHaltModeShutdown, "", false); err != nil {
 			m.logger.Warnf("Failed to halt session %s: %v", controller.session.Identifier, err)
 		}
 	}
@@ -189,6 +229,7 @@ func (m *Manager) Shutdown() {
 func (m *Manager) Create(
 	ctx context.Context,
 	source, destination *url.URL,
+	additionalDestinations []*url.URL,
 	configuration, configurationSource, configurationDestination *Configuration,
 	name string,
 	labels map[string]string,
@@ -204,10 +245,12 @@ func (m *Manager) Create(
 	// Attempt to create a session.
 	controller, err := newSession(
 		ctx,
-		m.logger.Sublogger(identifier.Truncated(id)),
+		m.logger.Sublogger(identifier.Truncated(id)).WithSession(id),
 		m.tracker,
+		m.events,
 		id,
 		source, destination,
+		additionalDestinations,
 		configuration, configurationSource, configurationDestination,
 		name,
 		labels,
@@ -223,6 +266,9 @@ func (m *Manager) Create(
 	m.sessions[controller.session.Identifier] = controller
 	m.sessionsLock.Unlock()
 
+	// Publish a creation event.
+	controller.publishLifecycleEvent(EventKind_EventKindSessionCreated)
+
 	// Done.
 	return controller.session.Identifier, nil
 }
@@ -259,6 +305,37 @@ func (m *Manager) List(ctx context.Context, selection *selection.Selection, prev
 	return stateIndex, states, nil
 }
 
+// Watch streams events for sessions matching the given specifications,
+// invoking the specified handler for each event. It returns when the context is
+// cancelled, when the handler returns an error, or when the event stream is
+// terminated (e.g. due to the subscriber falling behind or manager shutdown).
+func (m *Manager) Watch(ctx context.Context, selection *selection.Selection, handler func(*Event) error) error {
+	// Create the session filter.
+	filter, err := sessionFilter(selection)
+	if err != nil {
+		return err
+	}
+
+	// Subscribe to events and defer unsubscription.
+	subscription := m.events.subscribe(filter)
+	defer m.events.unsubscribe(subscription)
+
+	// Forward events until cancellation or failure.
+	for {
+		select {
+		case <-ctx.Done():
+			return context.Canceled
+		case event, ok := <-subscription.events:
+			if !ok {
+				return subscription.err
+			}
+			if err := handler(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
 // Pause tells the manager to pause sessions matching the given specifications.
 func (m *Manager) Pause(ctx context.Context, selection *selection.Selection, prompter string) error {
 	// Extract the controllers for the sessions of interest.
@@ -269,7 +346,7 @@ func (m *Manager) Pause(ctx context.Context, selection *selection.Selection, pro
 
 	// Attempt to pause the sessions.
 	for _, controller := range controllers {
-		if err := controller.halt(ctx, controllerHaltModePause, prompter); err != nil {
+		if err := controller.halt(ctx, controllerHaltModePause, prompter, false); err != nil {
 			return fmt.Errorf("unable to pause session: %w", err)
 		}
 	}
@@ -289,7 +366,7 @@ func (m *Manager) Resume(ctx context.Context, selection *selection.Selection, pr
 
 	// Attempt to resume.
 	for _, controller := range controllers {
-		if err := controller.resume(ctx, prompter); err != nil {
+		if err := controller.resume(ctx, prompter, false); err != nil {
 			return fmt.Errorf("unable to resume session: %w", err)
 		}
 	}
@@ -298,6 +375,61 @@ func (m *Manager) Resume(ctx context.Context, selection *selection.Selection, pr
 	return nil
 }
 
+// Capture tells the manager to enable or disable traffic capture for sessions
+// matching the given specifications. If enable is true, then capture files will
+// be written to the specified directory using the specified format and rotation
+// parameters (with zero values indicating defaults).
+func (m *Manager) Capture(
+	ctx context.Context,
+	selection *selection.Selection,
+	enable bool,
+	directory string,
+	format CaptureFormat,
+	maximumFileSize uint64,
+	maximumFileCount uint32,
+) error {
+	// Extract the controllers for the sessions of interest.
+	controllers, err := m.selectControllers(selection)
+	if err != nil {
+		return fmt.Errorf("unable to locate requested sessions: %w", err)
+	}
+
+	// Attempt to update capture for the sessions.
+	for _, controller := range controllers {
+		if err := controller.setCapture(enable, directory, format, maximumFileSize, maximumFileCount); err != nil {
+			return fmt.Errorf("unable to update capture for session: %w", err)
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+// Update tells the manager to replace the configuration of the session matching
+// the given specification. The specification must match exactly one session.
+func (m *Manager) Update(
+	ctx context.Context,
+	specification string,
+	configuration, configurationSource, configurationDestination *Configuration,
+	prompter string,
+) error {
+	// Extract the controller for the session of interest.
+	controllers, err := m.findControllersBySpecification([]string{specification})
+	if err != nil {
+		return fmt.Errorf("unable to locate requested session: %w", err)
+	} else if len(controllers) > 1 {
+		return fmt.Errorf("specification \"%s\" matched multiple sessions", specification)
+	}
+
+	// Attempt to update the session.
+	if err := controllers[0].update(ctx, configuration, configurationSource, configurationDestination, prompter); err != nil {
+		return fmt.Errorf("unable to update session: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
 // Terminate tells the manager to terminate sessions matching the given
 // specifications.
 func (m *Manager) Terminate(ctx context.Context, selection *selection.Selection, prompter string) error {
@@ -310,12 +442,13 @@ func (m *Manager) Terminate(ctx context.Context, selection *selection.Selection,
 	// Attempt to terminate the sessions. Since we're terminating them, we're
 	// responsible for removing them from the session map.
 	for _, controller := range controllers {
-		if err := controller.halt(ctx, controllerHaltModeTerminate, prompter); err != nil {
+		if err := controller.halt(ctx, controllerHaltModeTerminate, prompter, false); err != nil {
 			return fmt.Errorf("unable to terminate session: %w", err)
 		}
 		m.sessionsLock.Lock()
 		delete(m.sessions, controller.session.Identifier)
 		m.sessionsLock.Unlock()
+		controller.publishLifecycleEvent(EventKind_EventKindSessionTerminated)
 	}
 
 	// Success.
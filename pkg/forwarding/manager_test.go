@@ -1,3 +1,60 @@
 package forwarding
 
-// TODO: Implement.
+import (
+	"context"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/selection"
+)
+
+// TestManagerUpdate tests Manager.Update.
+func TestManagerUpdate(t *testing.T) {
+	// Set up the environment and create a manager.
+	setupTestEnvironment(t, &testProtocolHandler{})
+	manager, err := NewManager(nil)
+	if err != nil {
+		t.Fatal("unable to create manager:", err)
+	}
+	defer manager.Shutdown()
+
+	// Create two paused sessions with the same name.
+	ctx := context.Background()
+	var identifiers []string
+	for i := 0; i < 2; i++ {
+		identifier, err := manager.Create(ctx,
+			testSourceURL, testDestinationURL, nil,
+			&Configuration{}, &Configuration{}, &Configuration{},
+			"web", nil, true, "",
+		)
+		if err != nil {
+			t.Fatal("unable to create session:", err)
+		}
+		identifiers = append(identifiers, identifier)
+	}
+
+	// Verify that updates require an unambiguous specification.
+	configuration := &Configuration{AllowedNetworks: []string{"127.0.0.1"}}
+	if err := manager.Update(ctx, "web", configuration, &Configuration{}, &Configuration{}, ""); err == nil {
+		t.Error("update succeeded with ambiguous specification")
+	}
+	if err := manager.Update(ctx, "missing", configuration, &Configuration{}, &Configuration{}, ""); err == nil {
+		t.Error("update succeeded with unknown specification")
+	}
+
+	// Update a session by identifier.
+	if err := manager.Update(ctx, identifiers[0], configuration, &Configuration{}, &Configuration{}, ""); err != nil {
+		t.Fatal("unable to update session:", err)
+	}
+
+	// Verify that only the targeted session was updated.
+	_, states, err := manager.List(ctx, &selection.Selection{All: true}, 0)
+	if err != nil {
+		t.Fatal("unable to list sessions:", err)
+	}
+	for _, state := range states {
+		updated := state.Session.Configuration.Equal(configuration)
+		if expected := state.Session.Identifier == identifiers[0]; updated != expected {
+			t.Errorf("session %s: unexpected update status: %t", state.Session.Identifier, updated)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package forwarding
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mutagen-io/mutagen/pkg/metrics"
+)
+
+// sessionLabels computes the metric labels identifying a session.
+func sessionLabels(session *Session, extra ...metrics.Label) []metrics.Label {
+	labels := make([]metrics.Label, 0, 2+len(extra))
+	labels = append(labels,
+		metrics.Label{Name: "session", Value: session.Identifier},
+		metrics.Label{Name: "name", Value: session.Name},
+	)
+	return append(labels, extra...)
+}
+
+// CollectMetrics implements metrics.Collector.CollectMetrics.
+func (m *Manager) CollectMetrics(encoder *metrics.Encoder) {
+	// Capture states for all sessions and sort them by creation time so that
+	// output ordering is stable.
+	controllers := m.allControllers()
+	states := make([]*State, 0, len(controllers))
+	for _, controller := range controllers {
+		states = append(states, controller.currentState())
+	}
+	sort.Slice(states, func(i, j int) bool {
+		iTime := states[i].Session.CreationTime
+		jTime := states[j].Session.CreationTime
+		return iTime.Seconds < jTime.Seconds ||
+			(iTime.Seconds == jTime.Seconds && iTime.Nanos < jTime.Nanos)
+	})
+
+	// Write the session count.
+	encoder.Family("mutagen_forwarding_sessions", "Number of forwarding sessions.", metrics.TypeGauge)
+	encoder.Sample("mutagen_forwarding_sessions", nil, float64(len(states)))
+
+	// Write pause state.
+	encoder.Family("mutagen_forwarding_session_paused", "Whether or not the session is paused.", metrics.TypeGauge)
+	for _, s := range states {
+		encoder.Sample("mutagen_forwarding_session_paused", sessionLabels(s.Session), metrics.Bool(s.Session.Paused))
+	}
+
+	// Write status information.
+	encoder.Family("mutagen_forwarding_session_status", "Current session status (1 for the active status, 0 otherwise).", metrics.TypeGauge)
+	for _, s := range states {
+		for value := int32(0); value < int32(len(Status_name)); value++ {
+			status := Status(value)
+			name, _ := status.MarshalText()
+			labels := sessionLabels(s.Session, metrics.Label{Name: "status", Value: string(name)})
+			encoder.Sample("mutagen_forwarding_session_status", labels, metrics.Bool(s.Status == status))
+		}
+	}
+
+	// Write endpoint connectivity. Additional destinations are labeled by their
+	// (1-based) index.
+	encoder.Family("mutagen_forwarding_endpoint_connected", "Whether or not the endpoint is connected.", metrics.TypeGauge)
+	for _, s := range states {
+		encoder.Sample("mutagen_forwarding_endpoint_connected",
+			sessionLabels(s.Session, metrics.Label{Name: "endpoint", Value: "source"}),
+			metrics.Bool(s.SourceState.Connected),
+		)
+		encoder.Sample("mutagen_forwarding_endpoint_connected",
+			sessionLabels(s.Session, metrics.Label{Name: "endpoint", Value: "destination"}),
+			metrics.Bool(s.DestinationState.Connected),
+		)
+		for i, additional := range s.AdditionalDestinationStates {
+			encoder.Sample("mutagen_forwarding_endpoint_connected",
+				sessionLabels(s.Session, metrics.Label{Name: "endpoint", Value: fmt.Sprintf("destination-%d", i+1)}),
+				metrics.Bool(additional.Connected),
+			)
+		}
+	}
+
+	// Write connection and throughput statistics.
+	statistics := []struct {
+		name   string
+		help   string
+		kind   metrics.Type
+		sample func(*State) uint64
+	}{
+		{"mutagen_forwarding_open_connections", "Number of connections currently being forwarded.", metrics.TypeGauge, func(s *State) uint64 {
+			return s.OpenConnections
+		}},
+		{"mutagen_forwarding_connections_total", "Total number of forwarded connections.", metrics.TypeCounter, func(s *State) uint64 {
+			return s.TotalConnections
+		}},
+		{"mutagen_forwarding_refused_connections_total", "Total number of connections refused due to access control restrictions.", metrics.TypeCounter, func(s *State) uint64 {
+			return s.RefusedConnections
+		}},
+		{"mutagen_forwarding_outbound_bytes_total", "Total number of bytes transmitted from source to destination.", metrics.TypeCounter, func(s *State) uint64 {
+			return s.TotalOutboundData
+		}},
+		{"mutagen_forwarding_inbound_bytes_total", "Total number of bytes transmitted from destination to source.", metrics.TypeCounter, func(s *State) uint64 {
+			return s.TotalInboundData
+		}},
+	}
+	for _, statistic := range statistics {
+		encoder.Family(statistic.name, statistic.help, statistic.kind)
+		for _, s := range states {
+			encoder.Sample(statistic.name, sessionLabels(s.Session), float64(statistic.sample(s)))
+		}
+	}
+}
@@ -45,6 +45,16 @@ func (s *Session) EnsureValid() error {
 		return errors.New("destination URL is not a forwarding URL")
 	}
 
+	// Ensure that any additional destination URLs are valid and are forwarding
+	// URLs.
+	for _, destination := range s.AdditionalDestinations {
+		if err := destination.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid additional destination URL: %w", err)
+		} else if destination.Kind != url.Kind_Forwarding {
+			return errors.New("additional destination URL is not a forwarding URL")
+		}
+	}
+
 	// Ensure that the configuration is valid.
 	if err := s.Configuration.EnsureValid(false); err != nil {
 		return fmt.Errorf("invalid configuration: %w", err)
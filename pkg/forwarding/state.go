@@ -83,6 +83,17 @@ func (s *State) EnsureValid() error {
 		return fmt.Errorf("invalid destination endpoint state: %w", err)
 	}
 
+	// Ensure that additional destination endpoint states are valid and that
+	// they correspond to the session's additional destinations.
+	if len(s.AdditionalDestinationStates) != len(s.Session.AdditionalDestinations) {
+		return errors.New("additional destination state count mismatch")
+	}
+	for _, state := range s.AdditionalDestinationStates {
+		if err := state.ensureValid(); err != nil {
+			return fmt.Errorf("invalid additional destination endpoint state: %w", err)
+		}
+	}
+
 	// Success.
 	return nil
 }
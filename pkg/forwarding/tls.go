@@ -0,0 +1,230 @@
+package forwarding
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/url"
+	forwardingurl "github.com/mutagen-io/mutagen/pkg/url/forwarding"
+)
+
+const (
+	// selfSignedCertificateValidity is the validity period for generated
+	// self-signed certificates.
+	selfSignedCertificateValidity = 365 * 24 * time.Hour
+	// tlsHandshakeTimeout is the maximum amount of time allowed for a TLS
+	// handshake on a forwarded connection.
+	tlsHandshakeTimeout = 10 * time.Second
+)
+
+// loadCertificatePool loads a PEM-encoded certificate authority bundle.
+func loadCertificatePool(path string) (*x509.CertPool, error) {
+	// Read the bundle.
+	bundle, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read certificate authority bundle: %w", err)
+	}
+
+	// Create the pool.
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(bundle) {
+		return nil, errors.New("no certificates found in certificate authority bundle")
+	}
+
+	// Success.
+	return pool, nil
+}
+
+// generateSelfSignedCertificate generates an ephemeral self-signed certificate
+// valid for localhost and loopback addresses.
+func generateSelfSignedCertificate() (tls.Certificate, error) {
+	// Generate a private key.
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("unable to generate private key: %w", err)
+	}
+
+	// Generate a serial number.
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("unable to generate serial number: %w", err)
+	}
+
+	// Create the certificate template.
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{Organization: []string{"Mutagen"}},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(selfSignedCertificateValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+
+	// Create the certificate.
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("unable to create certificate: %w", err)
+	}
+
+	// Success.
+	return tls.Certificate{
+		Certificate: [][]byte{certificate},
+		PrivateKey:  key,
+	}, nil
+}
+
+// newListenerTLSConfiguration creates the TLS configuration used to terminate
+// TLS for connections accepted by a listener endpoint. It returns nil if TLS is
+// disabled for the endpoint. If no certificate is configured, then selfSigned
+// is invoked to obtain a self-signed certificate.
+func newListenerTLSConfiguration(
+	configuration *Configuration,
+	version Version,
+	selfSigned func() (tls.Certificate, error),
+) (*tls.Config, error) {
+	// Determine whether or not TLS is enabled.
+	mode := configuration.TlsMode
+	if mode.IsDefault() {
+		mode = version.DefaultTLSMode()
+	}
+	if !mode.Enabled() {
+		return nil, nil
+	}
+
+	// Load or generate the server certificate.
+	var certificate tls.Certificate
+	var err error
+	if configuration.TlsCertificate != "" {
+		certificate, err = tls.LoadX509KeyPair(configuration.TlsCertificate, configuration.TlsKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load TLS certificate: %w", err)
+		}
+	} else {
+		certificate, err = selfSigned()
+		if err != nil {
+			return nil, fmt.Errorf("unable to generate self-signed TLS certificate: %w", err)
+		}
+	}
+
+	// Create the configuration.
+	result := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	// If a certificate authority has been specified, then require and verify
+	// client certificates.
+	if configuration.TlsCertificateAuthority != "" {
+		pool, err := loadCertificatePool(configuration.TlsCertificateAuthority)
+		if err != nil {
+			return nil, err
+		}
+		result.ClientCAs = pool
+		result.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	// Success.
+	return result, nil
+}
+
+// newDialerTLSConfiguration creates the TLS configuration used to originate TLS
+// for connections opened by a dialer endpoint. It returns nil if TLS is
+// disabled for the endpoint.
+func newDialerTLSConfiguration(configuration *Configuration, version Version, destination *url.URL) (*tls.Config, error) {
+	// Determine whether or not TLS is enabled.
+	mode := configuration.TlsMode
+	if mode.IsDefault() {
+		mode = version.DefaultTLSMode()
+	}
+	if !mode.Enabled() {
+		return nil, nil
+	}
+
+	// Create the configuration.
+	result := &tls.Config{
+		ServerName:         configuration.TlsServerName,
+		InsecureSkipVerify: mode == TLSMode_TLSModeInsecure,
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	// If no server name has been specified, then attempt to derive one from
+	// the dialing address.
+	if result.ServerName == "" {
+		if protocol, address, err := forwardingurl.Parse(destination.Path); err != nil {
+			return nil, fmt.Errorf("unable to parse destination address: %w", err)
+		} else if protocol == "tcp" || protocol == "tcp4" || protocol == "tcp6" {
+			if host, _, err := net.SplitHostPort(address); err == nil {
+				result.ServerName = host
+			}
+		}
+	}
+	if result.ServerName == "" && !result.InsecureSkipVerify {
+		return nil, errors.New("TLS server name must be specified for non-TCP destinations")
+	}
+
+	// Load the client certificate, if any.
+	if configuration.TlsCertificate != "" {
+		certificate, err := tls.LoadX509KeyPair(configuration.TlsCertificate, configuration.TlsKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load TLS client certificate: %w", err)
+		}
+		result.Certificates = []tls.Certificate{certificate}
+	}
+
+	// Load the certificate authority, if any.
+	if configuration.TlsCertificateAuthority != "" {
+		pool, err := loadCertificatePool(configuration.TlsCertificateAuthority)
+		if err != nil {
+			return nil, err
+		}
+		result.RootCAs = pool
+	}
+
+	// Success.
+	return result, nil
+}
+
+// wrapTLS wraps a connection with TLS (acting as a server or client based on
+// the server parameter) and performs the TLS handshake. If configuration is
+// nil, then the connection is returned unmodified. If the handshake fails, then
+// the connection is closed.
+func wrapTLS(ctx context.Context, connection net.Conn, configuration *tls.Config, server bool) (net.Conn, error) {
+	// If TLS isn't enabled, then there's nothing to do.
+	if configuration == nil {
+		return connection, nil
+	}
+
+	// Wrap the connection.
+	var result *tls.Conn
+	if server {
+		result = tls.Server(connection, configuration)
+	} else {
+		result = tls.Client(connection, configuration)
+	}
+
+	// Perform the handshake with a timeout.
+	ctx, cancel := context.WithTimeout(ctx, tlsHandshakeTimeout)
+	defer cancel()
+	if err := result.HandshakeContext(ctx); err != nil {
+		connection.Close()
+		return nil, fmt.Errorf("TLS handshake failed: %w", err)
+	}
+
+	// Success.
+	return result, nil
+}
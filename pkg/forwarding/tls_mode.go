@@ -0,0 +1,84 @@
+package forwarding
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the TLS mode is TLSMode_TLSModeDefault.
+func (m TLSMode) IsDefault() bool {
+	return m == TLSMode_TLSModeDefault
+}
+
+// Enabled indicates whether or not the TLS mode is one that enables TLS.
+func (m TLSMode) Enabled() bool {
+	return m == TLSMode_TLSModeEnabled || m == TLSMode_TLSModeInsecure
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m TLSMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case TLSMode_TLSModeDefault:
+	case TLSMode_TLSModeDisabled:
+		result = "disabled"
+	case TLSMode_TLSModeEnabled:
+		result = "enabled"
+	case TLSMode_TLSModeInsecure:
+		result = "insecure"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *TLSMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a TLS mode.
+	switch text {
+	case "disabled":
+		*m = TLSMode_TLSModeDisabled
+	case "enabled":
+		*m = TLSMode_TLSModeEnabled
+	case "insecure":
+		*m = TLSMode_TLSModeInsecure
+	default:
+		return fmt.Errorf("unknown TLS mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular TLS mode is a valid,
+// non-default value.
+func (m TLSMode) Supported() bool {
+	switch m {
+	case TLSMode_TLSModeDisabled:
+		return true
+	case TLSMode_TLSModeEnabled:
+		return true
+	case TLSMode_TLSModeInsecure:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a TLS mode.
+func (m TLSMode) Description() string {
+	switch m {
+	case TLSMode_TLSModeDefault:
+		return "Default"
+	case TLSMode_TLSModeDisabled:
+		return "Disabled"
+	case TLSMode_TLSModeEnabled:
+		return "Enabled"
+	case TLSMode_TLSModeInsecure:
+		return "Enabled (Insecure)"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,152 @@
+package forwarding
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// TestTLSConfigurationDisabled tests that TLS configurations aren't created
+// when TLS is disabled.
+func TestTLSConfigurationDisabled(t *testing.T) {
+	// Test listener configuration.
+	if configuration, err := newListenerTLSConfiguration(&Configuration{}, Version_Version1, generateSelfSignedCertificate); err != nil {
+		t.Fatal("unable to create listener TLS configuration:", err)
+	} else if configuration != nil {
+		t.Error("listener TLS configuration created with TLS disabled")
+	}
+
+	// Test dialer configuration.
+	destination := &url.URL{Kind: url.Kind_Forwarding, Path: "tcp:localhost:8080"}
+	if configuration, err := newDialerTLSConfiguration(&Configuration{}, Version_Version1, destination); err != nil {
+		t.Fatal("unable to create dialer TLS configuration:", err)
+	} else if configuration != nil {
+		t.Error("dialer TLS configuration created with TLS disabled")
+	}
+}
+
+// TestDialerTLSConfigurationServerName tests server name derivation for dialer
+// TLS configurations.
+func TestDialerTLSConfigurationServerName(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		path               string
+		mode               TLSMode
+		serverName         string
+		expectedServerName string
+		expectFailure      bool
+	}{
+		{"tcp:localhost:8080", TLSMode_TLSModeEnabled, "", "localhost", false},
+		{"tcp:[::1]:8080", TLSMode_TLSModeEnabled, "", "::1", false},
+		{"tcp:localhost:8080", TLSMode_TLSModeEnabled, "example.com", "example.com", false},
+		{"unix:/tmp/socket", TLSMode_TLSModeEnabled, "", "", true},
+		{"unix:/tmp/socket", TLSMode_TLSModeEnabled, "example.com", "example.com", false},
+		{"unix:/tmp/socket", TLSMode_TLSModeInsecure, "", "", false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		destination := &url.URL{Kind: url.Kind_Forwarding, Path: testCase.path}
+		configuration := &Configuration{TlsMode: testCase.mode, TlsServerName: testCase.serverName}
+		result, err := newDialerTLSConfiguration(configuration, Version_Version1, destination)
+		if err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("unable to create dialer TLS configuration for %s: %v", testCase.path, err)
+			}
+		} else if testCase.expectFailure {
+			t.Error("dialer TLS configuration creation succeeded unexpectedly for", testCase.path)
+		} else if result.ServerName != testCase.expectedServerName {
+			t.Errorf("server name (%s) does not match expected (%s)", result.ServerName, testCase.expectedServerName)
+		}
+	}
+}
+
+// TestWrapTLSSelfSigned tests TLS termination using a self-signed certificate
+// and origination without verification.
+func TestWrapTLSSelfSigned(t *testing.T) {
+	// Create configurations.
+	serverConfiguration, err := newListenerTLSConfiguration(
+		&Configuration{TlsMode: TLSMode_TLSModeEnabled},
+		Version_Version1,
+		generateSelfSignedCertificate,
+	)
+	if err != nil {
+		t.Fatal("unable to create listener TLS configuration:", err)
+	}
+	clientConfiguration, err := newDialerTLSConfiguration(
+		&Configuration{TlsMode: TLSMode_TLSModeInsecure},
+		Version_Version1,
+		&url.URL{Kind: url.Kind_Forwarding, Path: "tcp:localhost:8080"},
+	)
+	if err != nil {
+		t.Fatal("unable to create dialer TLS configuration:", err)
+	}
+
+	// Create a connection pair.
+	serverConnection, clientConnection := net.Pipe()
+
+	// Perform the server handshake in the background.
+	serverErrors := make(chan error, 1)
+	go func() {
+		wrapped, err := wrapTLS(context.Background(), serverConnection, serverConfiguration, true)
+		if err == nil {
+			_, err = wrapped.Write([]byte("hello"))
+		}
+		serverErrors <- err
+	}()
+
+	// Defer closure of the underlying connections. We avoid closing the TLS
+	// connections since that would block sending closure alerts.
+	defer serverConnection.Close()
+	defer clientConnection.Close()
+
+	// Perform the client handshake and verify that data can be read.
+	wrapped, err := wrapTLS(context.Background(), clientConnection, clientConfiguration, false)
+	if err != nil {
+		t.Fatal("unable to perform client handshake:", err)
+	}
+	buffer := make([]byte, 5)
+	if _, err := wrapped.Read(buffer); err != nil {
+		t.Fatal("unable to read data:", err)
+	} else if string(buffer) != "hello" {
+		t.Error("received data does not match expected")
+	}
+	if err := <-serverErrors; err != nil {
+		t.Fatal("server handshake or write failed:", err)
+	}
+}
+
+// TestWrapTLSVerificationFailure tests that TLS origination fails when a
+// self-signed server certificate can't be verified.
+func TestWrapTLSVerificationFailure(t *testing.T) {
+	// Create configurations.
+	serverConfiguration, err := newListenerTLSConfiguration(
+		&Configuration{TlsMode: TLSMode_TLSModeEnabled},
+		Version_Version1,
+		generateSelfSignedCertificate,
+	)
+	if err != nil {
+		t.Fatal("unable to create listener TLS configuration:", err)
+	}
+	clientConfiguration, err := newDialerTLSConfiguration(
+		&Configuration{TlsMode: TLSMode_TLSModeEnabled},
+		Version_Version1,
+		&url.URL{Kind: url.Kind_Forwarding, Path: "tcp:localhost:8080"},
+	)
+	if err != nil {
+		t.Fatal("unable to create dialer TLS configuration:", err)
+	}
+
+	// Create a connection pair and perform the server handshake in the
+	// background.
+	serverConnection, clientConnection := net.Pipe()
+	defer serverConnection.Close()
+	go wrapTLS(context.Background(), serverConnection, serverConfiguration, true)
+
+	// Verify that the client handshake fails.
+	if _, err := wrapTLS(context.Background(), clientConnection, clientConfiguration, false); err == nil {
+		t.Error("client handshake succeeded with unverifiable server certificate")
+	}
+}
@@ -17,6 +17,27 @@ func (v Version) Supported() bool {
 	}
 }
 
+// DefaultDestinationSelectionMode returns the default destination selection
+// mode for the session version.
+func (v Version) DefaultDestinationSelectionMode() DestinationSelectionMode {
+	switch v {
+	case Version_Version1:
+		return DestinationSelectionMode_DestinationSelectionModeFailover
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultTLSMode returns the default TLS mode for the session version.
+func (v Version) DefaultTLSMode() TLSMode {
+	switch v {
+	case Version_Version1:
+		return TLSMode_TLSModeDisabled
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
 // DefaultSocketOverwriteMode returns the default socket overwrite mode for the
 // session version.
 func (v Version) DefaultSocketOverwriteMode() SocketOverwriteMode {
@@ -0,0 +1,6 @@
+// Package gateway provides an HTTP/JSON gateway to the daemon API for clients
+// that can't easily use gRPC. It mirrors the daemon, synchronization, and
+// forwarding services, encodes sessions and events using the api/models
+// representations, and streams session states and events using Server-Sent
+// Events.
+package gateway
@@ -0,0 +1,180 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	forwardingmodels "github.com/mutagen-io/mutagen/pkg/api/models/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// forwardingCreateRequest is the JSON representation of a forwarding
+// session creation request.
+type forwardingCreateRequest struct {
+	// Source is the source URL.
+	Source string `json:"source"`
+	// Destination is the destination URL.
+	Destination string `json:"destination"`
+	// AdditionalDestinations are additional destination URLs.
+	AdditionalDestinations []string `json:"additionalDestinations,omitempty"`
+	// Name is the session name.
+	Name string `json:"name,omitempty"`
+	// Labels are the session labels.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Paused indicates whether or not to create the session pre-paused.
+	Paused bool `json:"paused,omitempty"`
+	// Configuration is the session configuration.
+	Configuration forwardingmodels.Configuration `json:"configuration"`
+	// ConfigurationSource is the source-specific session configuration.
+	ConfigurationSource forwardingmodels.Configuration `json:"configurationSource"`
+	// ConfigurationDestination is the destination-specific session
+	// configuration.
+	ConfigurationDestination forwardingmodels.Configuration `json:"configurationDestination"`
+}
+
+// forwardingSessions lists (GET) or creates (POST) forwarding sessions.
+func (h *handler) forwardingSessions(writer http.ResponseWriter, request *http.Request) {
+	switch request.Method {
+	case http.MethodGet:
+		// Parse the selection.
+		selection, err := parseSelection(request, true)
+		if err != nil {
+			writeError(writer, http.StatusBadRequest, err)
+			return
+		}
+
+		// Perform the listing.
+		_, states, err := h.client.Forwarding().List(request.Context(), selection, 0)
+		if err != nil {
+			writeError(writer, http.StatusInternalServerError, err)
+			return
+		}
+
+		// Write the response.
+		writeJSON(writer, http.StatusOK, forwardingmodels.ExportSessions(states))
+	case http.MethodPost:
+		// Decode the request.
+		var body forwardingCreateRequest
+		if err := decodeBody(request, &body); err != nil {
+			writeError(writer, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+			return
+		}
+
+		// Parse URLs.
+		source, err := url.Parse(body.Source, url.Kind_Forwarding, true)
+		if err != nil {
+			writeError(writer, http.StatusBadRequest, fmt.Errorf("unable to parse source URL: %w", err))
+			return
+		}
+		destination, err := url.Parse(body.Destination, url.Kind_Forwarding, false)
+		if err != nil {
+			writeError(writer, http.StatusBadRequest, fmt.Errorf("unable to parse destination URL: %w", err))
+			return
+		}
+		var additionalDestinations []*url.URL
+		for _, additionalDestination := range body.AdditionalDestinations {
+			if u, err := url.Parse(additionalDestination, url.Kind_Forwarding, false); err != nil {
+				writeError(writer, http.StatusBadRequest, fmt.Errorf("unable to parse additional destination URL: %w", err))
+				return
+			} else {
+				additionalDestinations = append(additionalDestinations, u)
+			}
+		}
+
+		// Create the session.
+		session, err := h.client.Forwarding().Create(request.Context(), &forwardingsvc.CreationSpecification{
+			Source:                   source,
+			Destination:              destination,
+			AdditionalDestinations:   additionalDestinations,
+			Configuration:            body.Configuration.ToInternal(),
+			ConfigurationSource:      body.ConfigurationSource.ToInternal(),
+			ConfigurationDestination: body.ConfigurationDestination.ToInternal(),
+			Name:                     body.Name,
+			Labels:                   body.Labels,
+			Paused:                   body.Paused,
+		}, nil)
+		if err != nil {
+			writeError(writer, http.StatusInternalServerError, err)
+			return
+		}
+
+		// Write the response.
+		writeJSON(writer, http.StatusCreated, &struct {
+			Session string `json:"session"`
+		}{session})
+	default:
+		writer.Header().Set("Allow", "GET, POST")
+		writeError(writer, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+	}
+}
+
+// forwardingPause pauses forwarding sessions.
+func (h *handler) forwardingPause(writer http.ResponseWriter, request *http.Request) {
+	performAction(writer, request, func(ctx context.Context, selection *selection.Selection) error {
+		return h.client.Forwarding().Pause(ctx, selection, nil)
+	})
+}
+
+// forwardingResume resumes forwarding sessions.
+func (h *handler) forwardingResume(writer http.ResponseWriter, request *http.Request) {
+	performAction(writer, request, func(ctx context.Context, selection *selection.Selection) error {
+		return h.client.Forwarding().Resume(ctx, selection, nil)
+	})
+}
+
+// forwardingTerminate terminates forwarding sessions.
+func (h *handler) forwardingTerminate(writer http.ResponseWriter, request *http.Request) {
+	performAction(writer, request, func(ctx context.Context, selection *selection.Selection) error {
+		return h.client.Forwarding().Terminate(ctx, selection, nil)
+	})
+}
+
+// forwardingStates streams forwarding session states. A sessions
+// event containing the selected sessions is sent immediately and after every
+// subsequent state change.
+func (h *handler) forwardingStates(writer http.ResponseWriter, request *http.Request) {
+	// Parse the selection.
+	selection, err := parseSelection(request, true)
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+
+	// Stream states.
+	streamEvents(writer, request, func(ctx context.Context, send func(string, any) error) error {
+		var previousStateIndex uint64
+		for {
+			stateIndex, states, err := h.client.Forwarding().List(ctx, selection, previousStateIndex)
+			if err != nil {
+				return err
+			} else if err = send("sessions", forwardingmodels.ExportSessions(states)); err != nil {
+				return err
+			}
+			previousStateIndex = stateIndex
+		}
+	})
+}
+
+// forwardingEvents streams forwarding session events. Each event is
+// named according to its kind.
+func (h *handler) forwardingEvents(writer http.ResponseWriter, request *http.Request) {
+	// Parse the selection.
+	selection, err := parseSelection(request, true)
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+
+	// Stream events.
+	streamEvents(writer, request, func(ctx context.Context, send func(string, any) error) error {
+		return h.client.Forwarding().Watch(ctx, selection, func(event *forwarding.Event) error {
+			kind, _ := event.Kind.MarshalText()
+			return send(string(kind), forwardingmodels.ExportEvent(event))
+		})
+	})
+}
@@ -0,0 +1,318 @@
+package gateway
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mutagen-io/mutagen/pkg/client"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+)
+
+const (
+	// bearerPrefix is the authorization header value prefix that precedes
+	// bearer tokens.
+	bearerPrefix = "Bearer "
+	// tokenQueryParameter is the query parameter that may be used to provide
+	// the bearer token. It exists for clients (such as browser EventSource
+	// implementations) that can't set request headers.
+	tokenQueryParameter = "token"
+	// maximumRequestBodySize is the maximum size of request bodies.
+	maximumRequestBodySize = 1024 * 1024
+)
+
+// Listen creates a TCP listener for the gateway at the specified address. The
+// address must use a loopback host, since the gateway serves plain HTTP.
+func Listen(address string) (net.Listener, error) {
+	// Validate the host.
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TCP address: %w", err)
+	}
+	if host != "localhost" {
+		if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+			return nil, errors.New("gateway address must use a loopback host (e.g. 127.0.0.1)")
+		}
+	}
+
+	// Create the listener.
+	return net.Listen("tcp", address)
+}
+
+// handler implements http.Handler for the gateway.
+type handler struct {
+	// client is the daemon client.
+	client *client.Client
+	// token is the bearer token required for requests, if any.
+	token string
+	// mux is the underlying request router.
+	mux *http.ServeMux
+}
+
+// NewHandler creates a new HTTP handler that serves the gateway API using the
+// specified daemon client. If token is non-empty, then requests must provide it
+// as a bearer token (or via the token query parameter). The API is rooted at
+// /api/v1 and mirrors the daemon, synchronization, and forwarding services,
+// using the same JSON representations as the command line templating support.
+func NewHandler(client *client.Client, token string) http.Handler {
+	// Create the handler.
+	h := &handler{
+		client: client,
+		token:  token,
+		mux:    http.NewServeMux(),
+	}
+
+	// Register daemon endpoints.
+	h.mux.HandleFunc("/api/v1/daemon/version", h.daemonVersion)
+	h.mux.HandleFunc("/api/v1/daemon/terminate", h.daemonTerminate)
+
+	// Register synchronization endpoints.
+	h.mux.HandleFunc("/api/v1/synchronization/sessions", h.synchronizationSessions)
+	h.mux.HandleFunc("/api/v1/synchronization/sessions/flush", h.synchronizationFlush)
+	h.mux.HandleFunc("/api/v1/synchronization/sessions/pause", h.synchronizationPause)
+	h.mux.HandleFunc("/api/v1/synchronization/sessions/resume", h.synchronizationResume)
+	h.mux.HandleFunc("/api/v1/synchronization/sessions/reset", h.synchronizationReset)
+	h.mux.HandleFunc("/api/v1/synchronization/sessions/terminate", h.synchronizationTerminate)
+	h.mux.HandleFunc("/api/v1/synchronization/states", h.synchronizationStates)
+	h.mux.HandleFunc("/api/v1/synchronization/events", h.synchronizationEvents)
+
+	// Register forwarding endpoints.
+	h.mux.HandleFunc("/api/v1/forwarding/sessions", h.forwardingSessions)
+	h.mux.HandleFunc("/api/v1/forwarding/sessions/pause", h.forwardingPause)
+	h.mux.HandleFunc("/api/v1/forwarding/sessions/resume", h.forwardingResume)
+	h.mux.HandleFunc("/api/v1/forwarding/sessions/terminate", h.forwardingTerminate)
+	h.mux.HandleFunc("/api/v1/forwarding/states", h.forwardingStates)
+	h.mux.HandleFunc("/api/v1/forwarding/events", h.forwardingEvents)
+
+	// Done.
+	return h
+}
+
+// authenticate verifies that a request carries the required bearer token.
+func (h *handler) authenticate(request *http.Request) bool {
+	// If no token is required, then all requests are authenticated.
+	if h.token == "" {
+		return true
+	}
+
+	// Extract the provided token.
+	var provided string
+	if authorization := request.Header.Get("Authorization"); strings.HasPrefix(authorization, bearerPrefix) {
+		provided = strings.TrimPrefix(authorization, bearerPrefix)
+	} else {
+		provided = request.URL.Query().Get(tokenQueryParameter)
+	}
+
+	// Compare tokens in constant time.
+	return subtle.ConstantTimeCompare([]byte(provided), []byte(h.token)) == 1
+}
+
+// ServeHTTP implements http.Handler.ServeHTTP.
+func (h *handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	// Allow cross-origin requests. Since authentication is performed using
+	// bearer tokens rather than cookies, this doesn't grant any ambient
+	// authority to other origins.
+	writer.Header().Set("Access-Control-Allow-Origin", "*")
+	if request.Method == http.MethodOptions {
+		writer.Header().Set("Access-Control-Allow-Methods", "GET, POST")
+		writer.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+		writer.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	// Authenticate the request.
+	if !h.authenticate(request) {
+		writeError(writer, http.StatusUnauthorized, errors.New("invalid or missing bearer token"))
+		return
+	}
+
+	// Route the request.
+	h.mux.ServeHTTP(writer, request)
+}
+
+// allowMethod verifies that a request uses the specified method, writing an
+// error response if not.
+func allowMethod(writer http.ResponseWriter, request *http.Request, method string) bool {
+	if request.Method != method {
+		writer.Header().Set("Allow", method)
+		writeError(writer, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return false
+	}
+	return true
+}
+
+// writeJSON writes a JSON response.
+func writeJSON(writer http.ResponseWriter, status int, value any) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	json.NewEncoder(writer).Encode(value)
+}
+
+// errorResponse is the JSON representation of an error.
+type errorResponse struct {
+	// Error is the error message.
+	Error string `json:"error"`
+}
+
+// writeError writes an error response.
+func writeError(writer http.ResponseWriter, status int, err error) {
+	writeJSON(writer, status, &errorResponse{Error: err.Error()})
+}
+
+// decodeBody decodes a JSON request body, rejecting unknown fields.
+func decodeBody(request *http.Request, value any) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(nil, request.Body, maximumRequestBodySize))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(value)
+}
+
+// parseSelection extracts a session selection from query parameters. Sessions
+// may be selected using the all, session (which may be repeated), or
+// label-selector parameters. If no selection mechanism is present and
+// defaultAll is true, then all sessions are selected.
+func parseSelection(request *http.Request, defaultAll bool) (*selection.Selection, error) {
+	// Extract parameters.
+	query := request.URL.Query()
+	result := &selection.Selection{
+		Specifications: query["session"],
+		LabelSelector:  query.Get("label-selector"),
+	}
+	if value := query.Get("all"); value != "" {
+		if all, err := strconv.ParseBool(value); err != nil {
+			return nil, fmt.Errorf("invalid all parameter: %w", err)
+		} else {
+			result.All = all
+		}
+	}
+
+	// Apply the default, if necessary.
+	if defaultAll && !result.All && len(result.Specifications) == 0 && result.LabelSelector == "" {
+		result.All = true
+	}
+
+	// Validate the selection.
+	if err := result.EnsureValid(); err != nil {
+		return nil, fmt.Errorf("invalid session selection: %w", err)
+	}
+
+	// Success.
+	return result, nil
+}
+
+// performAction handles a POST request that performs an operation on a
+// selection of sessions.
+func performAction(
+	writer http.ResponseWriter, request *http.Request,
+	action func(context.Context, *selection.Selection) error,
+) {
+	// Validate the method and selection.
+	if !allowMethod(writer, request, http.MethodPost) {
+		return
+	}
+	selection, err := parseSelection(request, false)
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+
+	// Perform the action.
+	if err := action(request.Context(), selection); err != nil {
+		writeError(writer, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Success.
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// streamEvents serves a Server-Sent Events stream. The stream function is
+// invoked with a send callback that writes a named event with a JSON payload.
+// The stream terminates when the stream function returns, typically due to
+// client disconnection.
+func streamEvents(
+	writer http.ResponseWriter, request *http.Request,
+	stream func(context.Context, func(string, any) error) error,
+) {
+	// Validate the method.
+	if !allowMethod(writer, request, http.MethodGet) {
+		return
+	}
+
+	// Ensure that the response can be flushed incrementally.
+	flusher, ok := writer.(http.Flusher)
+	if !ok {
+		writeError(writer, http.StatusInternalServerError, errors.New("streaming not supported"))
+		return
+	}
+
+	// Write headers.
+	writer.Header().Set("Content-Type", "text/event-stream")
+	writer.Header().Set("Cache-Control", "no-cache")
+	writer.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	// Stream events. Once the stream has started, we can't change the response
+	// status, so errors are reported using an error event.
+	err := stream(request.Context(), func(name string, value any) error {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("unable to encode event: %w", err)
+		}
+		if _, err := fmt.Fprintf(writer, "event: %s\ndata: %s\n\n", name, data); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	})
+	if err != nil && request.Context().Err() == nil {
+		data, _ := json.Marshal(&errorResponse{Error: err.Error()})
+		fmt.Fprintf(writer, "event: error\ndata: %s\n\n", data)
+		flusher.Flush()
+	}
+}
+
+// daemonVersion serves the daemon version.
+func (h *handler) daemonVersion(writer http.ResponseWriter, request *http.Request) {
+	// Validate the method.
+	if !allowMethod(writer, request, http.MethodGet) {
+		return
+	}
+
+	// Query the version.
+	version, err := h.client.Version(request.Context())
+	if err != nil {
+		writeError(writer, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Write the response.
+	writeJSON(writer, http.StatusOK, &struct {
+		Major uint64 `json:"major"`
+		Minor uint64 `json:"minor"`
+		Patch uint64 `json:"patch"`
+		Tag   string `json:"tag,omitempty"`
+	}{version.Major, version.Minor, version.Patch, version.Tag})
+}
+
+// daemonTerminate requests daemon termination.
+func (h *handler) daemonTerminate(writer http.ResponseWriter, request *http.Request) {
+	// Validate the method.
+	if !allowMethod(writer, request, http.MethodPost) {
+		return
+	}
+
+	// Request termination.
+	if err := h.client.TerminateDaemon(request.Context()); err != nil {
+		writeError(writer, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Success.
+	writer.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,309 @@
+package gateway
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	_ "github.com/mutagen-io/mutagen/pkg/synchronization/protocols/local"
+
+	"github.com/mutagen-io/mutagen/pkg/client"
+	"github.com/mutagen-io/mutagen/pkg/daemon"
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/ipc"
+	daemonsvc "github.com/mutagen-io/mutagen/pkg/service/daemon"
+	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
+	promptingsvc "github.com/mutagen-io/mutagen/pkg/service/prompting"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+)
+
+// testToken is the bearer token used for testing.
+const testToken = "test-token"
+
+// testServer is the gateway server used for testing.
+var testServer *httptest.Server
+
+// TestMain runs tests against a gateway backed by an in-process daemon.
+func TestMain(m *testing.M) {
+	// Create and use a temporary data directory.
+	dataDirectory, err := os.MkdirTemp("", "mutagen_gateway")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "unable to create data directory:", err)
+		os.Exit(1)
+	}
+	os.Setenv("MUTAGEN_DATA_DIRECTORY", dataDirectory)
+
+	// Run tests and clean up.
+	result := run(m)
+	os.RemoveAll(dataDirectory)
+	os.Exit(result)
+}
+
+// run starts the in-process daemon and gateway, runs tests, and tears down the
+// daemon and gateway.
+func run(m *testing.M) int {
+	// Create session managers.
+	forwardingManager, err := forwarding.NewManager(nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "unable to create forwarding session manager:", err)
+		return 1
+	}
+	defer forwardingManager.Shutdown()
+	synchronizationManager, err := synchronization.NewManager(nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "unable to create synchronization session manager:", err)
+		return 1
+	}
+	defer synchronizationManager.Shutdown()
+
+	// Create the gRPC server and register services.
+	server := grpc.NewServer(
+		grpc.MaxSendMsgSize(grpcutil.MaximumMessageSize),
+		grpc.MaxRecvMsgSize(grpcutil.MaximumMessageSize),
+	)
+	defer server.Stop()
+	daemonServer := daemonsvc.NewServer()
+	defer daemonServer.Shutdown()
+	daemonsvc.RegisterDaemonServer(server, daemonServer)
+	promptingsvc.RegisterPromptingServer(server, promptingsvc.NewServer())
+	forwardingsvc.RegisterForwardingServer(server, forwardingsvc.NewServer(forwardingManager))
+	synchronizationsvc.RegisterSynchronizationServer(server, synchronizationsvc.NewServer(synchronizationManager))
+
+	// Create the daemon listener and start serving.
+	endpoint, err := daemon.EndpointPath()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "unable to compute endpoint path:", err)
+		return 1
+	}
+	listener, err := ipc.NewListener(endpoint)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "unable to create daemon listener:", err)
+		return 1
+	}
+	defer listener.Close()
+	go server.Serve(listener)
+
+	// Create the daemon client.
+	daemonClient, err := client.New(nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "unable to create daemon client:", err)
+		return 1
+	}
+	defer daemonClient.Close()
+
+	// Start the gateway.
+	testServer = httptest.NewServer(NewHandler(daemonClient, testToken))
+	defer testServer.Close()
+
+	// Run tests.
+	return m.Run()
+}
+
+// do performs an authenticated gateway request.
+func do(t *testing.T, method, path, body string) *http.Response {
+	t.Helper()
+	request, err := http.NewRequest(method, testServer.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal("unable to create request:", err)
+	}
+	request.Header.Set("Authorization", bearerPrefix+testToken)
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal("unable to perform request:", err)
+	}
+	return response
+}
+
+// TestListen tests Listen.
+func TestListen(t *testing.T) {
+	// Verify that loopback addresses are accepted.
+	for _, address := range []string{"127.0.0.1:0", "localhost:0"} {
+		if listener, err := Listen(address); err != nil {
+			t.Errorf("unable to listen on %s: %v", address, err)
+		} else {
+			listener.Close()
+		}
+	}
+
+	// Verify that other addresses are rejected.
+	for _, address := range []string{"0.0.0.0:0", ":0", "example.org:80", "invalid"} {
+		if listener, err := Listen(address); err == nil {
+			listener.Close()
+			t.Errorf("listening on %s succeeded", address)
+		}
+	}
+}
+
+// TestAuthentication tests that requests require the bearer token.
+func TestAuthentication(t *testing.T) {
+	// Verify that unauthenticated requests are rejected.
+	response, err := http.Get(testServer.URL + "/api/v1/daemon/version")
+	if err != nil {
+		t.Fatal("unable to perform request:", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusUnauthorized {
+		t.Error("unauthenticated request not rejected:", response.StatusCode)
+	}
+
+	// Verify that the token can be provided via a query parameter.
+	response, err = http.Get(testServer.URL + "/api/v1/daemon/version?token=" + testToken)
+	if err != nil {
+		t.Fatal("unable to perform request:", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Error("query parameter authentication failed:", response.StatusCode)
+	}
+}
+
+// TestDaemonVersion tests the daemon version endpoint.
+func TestDaemonVersion(t *testing.T) {
+	// Perform the request.
+	response := do(t, http.MethodGet, "/api/v1/daemon/version", "")
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatal("unexpected status code:", response.StatusCode)
+	}
+
+	// Verify the response.
+	var version map[string]any
+	if err := json.NewDecoder(response.Body).Decode(&version); err != nil {
+		t.Fatal("unable to decode response:", err)
+	} else if _, ok := version["major"]; !ok {
+		t.Error("version response missing major version")
+	}
+}
+
+// TestInvalidRequests tests that invalid requests are rejected.
+func TestInvalidRequests(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{http.MethodGet, "/api/v1/synchronization/sessions/pause", "", http.StatusMethodNotAllowed},
+		{http.MethodPost, "/api/v1/synchronization/sessions/pause", "", http.StatusBadRequest},
+		{http.MethodPost, "/api/v1/synchronization/sessions/pause?all=true&session=a", "", http.StatusBadRequest},
+		{http.MethodPost, "/api/v1/synchronization/sessions", "{", http.StatusBadRequest},
+		{http.MethodPost, "/api/v1/synchronization/sessions", `{"unknown":true}`, http.StatusBadRequest},
+		{http.MethodPost, "/api/v1/forwarding/sessions", `{"source":"","destination":""}`, http.StatusBadRequest},
+		{http.MethodDelete, "/api/v1/forwarding/sessions", "", http.StatusMethodNotAllowed},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		response := do(t, testCase.method, testCase.path, testCase.body)
+		response.Body.Close()
+		if response.StatusCode != testCase.status {
+			t.Errorf("test case %d: status code mismatch: %d != %d", i, response.StatusCode, testCase.status)
+		}
+	}
+}
+
+// TestSynchronization tests synchronization session management and event
+// streaming.
+func TestSynchronization(t *testing.T) {
+	// Start streaming events.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, testServer.URL+"/api/v1/synchronization/events", nil)
+	if err != nil {
+		t.Fatal("unable to create event stream request:", err)
+	}
+	request.Header.Set("Authorization", bearerPrefix+testToken)
+	events, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal("unable to start event stream:", err)
+	}
+	defer events.Body.Close()
+	if contentType := events.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Fatal("unexpected event stream content type:", contentType)
+	}
+	eventNames := make(chan string, 100)
+	go func() {
+		scanner := bufio.NewScanner(events.Body)
+		for scanner.Scan() {
+			if line := scanner.Text(); strings.HasPrefix(line, "event: ") {
+				eventNames <- strings.TrimPrefix(line, "event: ")
+			}
+		}
+		close(eventNames)
+	}()
+
+	// Create a session.
+	body, err := json.Marshal(map[string]any{
+		"alpha": t.TempDir(),
+		"beta":  t.TempDir(),
+		"name":  "gateway",
+		"configuration": map[string]any{
+			"ignore": map[string]any{"vcs": true},
+		},
+	})
+	if err != nil {
+		t.Fatal("unable to encode creation request:", err)
+	}
+	response := do(t, http.MethodPost, "/api/v1/synchronization/sessions", string(body))
+	var created struct {
+		Session string `json:"session"`
+	}
+	err = json.NewDecoder(response.Body).Decode(&created)
+	response.Body.Close()
+	if response.StatusCode != http.StatusCreated {
+		t.Fatal("unexpected creation status code:", response.StatusCode)
+	} else if err != nil {
+		t.Fatal("unable to decode creation response:", err)
+	}
+
+	// List the session.
+	response = do(t, http.MethodGet, "/api/v1/synchronization/sessions?session="+created.Session, "")
+	var sessions []map[string]any
+	err = json.NewDecoder(response.Body).Decode(&sessions)
+	response.Body.Close()
+	if err != nil {
+		t.Fatal("unable to decode list response:", err)
+	} else if len(sessions) != 1 {
+		t.Fatal("unexpected number of sessions:", len(sessions))
+	} else if sessions[0]["identifier"] != created.Session {
+		t.Error("unexpected session identifier:", sessions[0]["identifier"])
+	} else if sessions[0]["name"] != "gateway" {
+		t.Error("unexpected session name:", sessions[0]["name"])
+	}
+
+	// Perform lifecycle operations.
+	for _, operation := range []string{"flush", "pause", "reset", "resume", "terminate"} {
+		response = do(t, http.MethodPost, "/api/v1/synchronization/sessions/"+operation+"?session="+created.Session, "")
+		response.Body.Close()
+		if response.StatusCode != http.StatusNoContent {
+			t.Fatalf("unexpected %s status code: %d", operation, response.StatusCode)
+		}
+	}
+
+	// Wait for the termination event.
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case name, ok := <-eventNames:
+			if !ok {
+				t.Fatal("event stream terminated")
+			} else if name == "session-terminated" {
+				return
+			}
+		case <-timeout:
+			t.Fatal("timed out waiting for termination event")
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	synchronizationmodels "github.com/mutagen-io/mutagen/pkg/api/models/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// synchronizationCreateRequest is the JSON representation of a synchronization
+// session creation request.
+type synchronizationCreateRequest struct {
+	// Alpha is the alpha URL.
+	Alpha string `json:"alpha"`
+	// Beta is the beta URL.
+	Beta string `json:"beta"`
+	// Name is the session name.
+	Name string `json:"name,omitempty"`
+	// Labels are the session labels.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Paused indicates whether or not to create the session pre-paused.
+	Paused bool `json:"paused,omitempty"`
+	// Configuration is the session configuration.
+	Configuration synchronizationmodels.Configuration `json:"configuration"`
+	// ConfigurationAlpha is the alpha-specific session configuration.
+	ConfigurationAlpha synchronizationmodels.Configuration `json:"configurationAlpha"`
+	// ConfigurationBeta is the beta-specific session configuration.
+	ConfigurationBeta synchronizationmodels.Configuration `json:"configurationBeta"`
+}
+
+// synchronizationSessions lists (GET) or creates (POST) synchronization
+// sessions.
+func (h *handler) synchronizationSessions(writer http.ResponseWriter, request *http.Request) {
+	switch request.Method {
+	case http.MethodGet:
+		// Parse the selection.
+		selection, err := parseSelection(request, true)
+		if err != nil {
+			writeError(writer, http.StatusBadRequest, err)
+			return
+		}
+
+		// Perform the listing.
+		_, states, err := h.client.Synchronization().List(request.Context(), selection, 0)
+		if err != nil {
+			writeError(writer, http.StatusInternalServerError, err)
+			return
+		}
+
+		// Write the response.
+		writeJSON(writer, http.StatusOK, synchronizationmodels.ExportSessions(states))
+	case http.MethodPost:
+		// Decode the request.
+		var body synchronizationCreateRequest
+		if err := decodeBody(request, &body); err != nil {
+			writeError(writer, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+			return
+		}
+
+		// Parse URLs.
+		alpha, err := url.Parse(body.Alpha, url.Kind_Synchronization, true)
+		if err != nil {
+			writeError(writer, http.StatusBadRequest, fmt.Errorf("unable to parse alpha URL: %w", err))
+			return
+		}
+		beta, err := url.Parse(body.Beta, url.Kind_Synchronization, false)
+		if err != nil {
+			writeError(writer, http.StatusBadRequest, fmt.Errorf("unable to parse beta URL: %w", err))
+			return
+		}
+
+		// Create the session.
+		session, err := h.client.Synchronization().Create(request.Context(), &synchronizationsvc.CreationSpecification{
+			Alpha:              alpha,
+			Beta:               beta,
+			Configuration:      body.Configuration.ToInternal(),
+			ConfigurationAlpha: body.ConfigurationAlpha.ToInternal(),
+			ConfigurationBeta:  body.ConfigurationBeta.ToInternal(),
+			Name:               body.Name,
+			Labels:             body.Labels,
+			Paused:             body.Paused,
+		}, nil)
+		if err != nil {
+			writeError(writer, http.StatusInternalServerError, err)
+			return
+		}
+
+		// Write the response.
+		writeJSON(writer, http.StatusCreated, &struct {
+			Session string `json:"session"`
+		}{session})
+	default:
+		writer.Header().Set("Allow", "GET, POST")
+		writeError(writer, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+	}
+}
+
+// synchronizationFlush flushes synchronization sessions. The skip-wait query
+// parameter may be set to avoid waiting for the resulting cycles.
+func (h *handler) synchronizationFlush(writer http.ResponseWriter, request *http.Request) {
+	skipWait := request.URL.Query().Get("skip-wait") == "true"
+	performAction(writer, request, func(ctx context.Context, selection *selection.Selection) error {
+		return h.client.Synchronization().Flush(ctx, selection, skipWait, nil)
+	})
+}
+
+// synchronizationPause pauses synchronization sessions.
+func (h *handler) synchronizationPause(writer http.ResponseWriter, request *http.Request) {
+	performAction(writer, request, func(ctx context.Context, selection *selection.Selection) error {
+		return h.client.Synchronization().Pause(ctx, selection, nil)
+	})
+}
+
+// synchronizationResume resumes synchronization sessions.
+func (h *handler) synchronizationResume(writer http.ResponseWriter, request *http.Request) {
+	performAction(writer, request, func(ctx context.Context, selection *selection.Selection) error {
+		return h.client.Synchronization().Resume(ctx, selection, nil)
+	})
+}
+
+// synchronizationReset resets synchronization sessions.
+func (h *handler) synchronizationReset(writer http.ResponseWriter, request *http.Request) {
+	performAction(writer, request, func(ctx context.Context, selection *selection.Selection) error {
+		return h.client.Synchronization().Reset(ctx, selection, nil)
+	})
+}
+
+// synchronizationTerminate terminates synchronization sessions.
+func (h *handler) synchronizationTerminate(writer http.ResponseWriter, request *http.Request) {
+	performAction(writer, request, func(ctx context.Context, selection *selection.Selection) error {
+		return h.client.Synchronization().Terminate(ctx, selection, nil)
+	})
+}
+
+// synchronizationStates streams synchronization session states. A sessions
+// event containing the selected sessions is sent immediately and after every
+// subsequent state change.
+func (h *handler) synchronizationStates(writer http.ResponseWriter, request *http.Request) {
+	// Parse the selection.
+	selection, err := parseSelection(request, true)
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+
+	// Stream states.
+	streamEvents(writer, request, func(ctx context.Context, send func(string, any) error) error {
+		var previousStateIndex uint64
+		for {
+			stateIndex, states, err := h.client.Synchronization().List(ctx, selection, previousStateIndex)
+			if err != nil {
+				return err
+			} else if err = send("sessions", synchronizationmodels.ExportSessions(states)); err != nil {
+				return err
+			}
+			previousStateIndex = stateIndex
+		}
+	})
+}
+
+// synchronizationEvents streams synchronization session events. Each event is
+// named according to its kind.
+func (h *handler) synchronizationEvents(writer http.ResponseWriter, request *http.Request) {
+	// Parse the selection.
+	selection, err := parseSelection(request, true)
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+
+	// Stream events.
+	streamEvents(writer, request, func(ctx context.Context, send func(string, any) error) error {
+		return h.client.Synchronization().Watch(ctx, selection, func(event *synchronization.Event) error {
+			kind, _ := event.Kind.MarshalText()
+			return send(string(kind), synchronizationmodels.ExportEvent(event))
+		})
+	})
+}
@@ -0,0 +1,99 @@
+package grpcutil
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// authorizationMetadataKey is the metadata key used to transmit bearer
+	// tokens.
+	authorizationMetadataKey = "authorization"
+	// bearerPrefix is the authorization metadata value prefix that precedes
+	// bearer tokens.
+	bearerPrefix = "Bearer "
+)
+
+// bearerTokenCredentials implements credentials.PerRPCCredentials using a
+// bearer token.
+type bearerTokenCredentials struct {
+	// token is the bearer token.
+	token string
+}
+
+// NewBearerTokenCredentials creates per-RPC credentials that transmit the
+// specified bearer token with each request. The resulting credentials require
+// transport security.
+func NewBearerTokenCredentials(token string) credentials.PerRPCCredentials {
+	return &bearerTokenCredentials{token: token}
+}
+
+// GetRequestMetadata implements credentials.PerRPCCredentials.GetRequestMetadata.
+func (c *bearerTokenCredentials) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	return map[string]string{authorizationMetadataKey: bearerPrefix + c.token}, nil
+}
+
+// RequireTransportSecurity implements
+// credentials.PerRPCCredentials.RequireTransportSecurity.
+func (c *bearerTokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// authenticateBearerToken verifies that the incoming request metadata contains
+// the specified bearer token.
+func authenticateBearerToken(ctx context.Context, token string) error {
+	// Extract authorization metadata.
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "missing request metadata")
+	}
+	values := md.Get(authorizationMetadataKey)
+	if len(values) != 1 || !strings.HasPrefix(values[0], bearerPrefix) {
+		return status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+
+	// Compare tokens in constant time.
+	provided := strings.TrimPrefix(values[0], bearerPrefix)
+	if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+		return status.Error(codes.Unauthenticated, "invalid bearer token")
+	}
+
+	// Success.
+	return nil
+}
+
+// BearerTokenServerOptions returns server options that install interceptors
+// requiring the specified bearer token on all unary and streaming requests.
+func BearerTokenServerOptions(token string) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.UnaryInterceptor(func(
+			ctx context.Context,
+			request any,
+			_ *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler,
+		) (any, error) {
+			if err := authenticateBearerToken(ctx, token); err != nil {
+				return nil, err
+			}
+			return handler(ctx, request)
+		}),
+		grpc.StreamInterceptor(func(
+			server any,
+			stream grpc.ServerStream,
+			_ *grpc.StreamServerInfo,
+			handler grpc.StreamHandler,
+		) error {
+			if err := authenticateBearerToken(stream.Context(), token); err != nil {
+				return err
+			}
+			return handler(server, stream)
+		}),
+	}
+}
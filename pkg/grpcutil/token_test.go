@@ -0,0 +1,55 @@
+package grpcutil
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// TestBearerTokenCredentials tests that bearer token credentials generate
+// metadata that's accepted by authenticateBearerToken.
+func TestBearerTokenCredentials(t *testing.T) {
+	// Create credentials and generate request metadata.
+	credentials := NewBearerTokenCredentials("token")
+	if !credentials.RequireTransportSecurity() {
+		t.Error("bearer token credentials don't require transport security")
+	}
+	requestMetadata, err := credentials.GetRequestMetadata(context.Background())
+	if err != nil {
+		t.Fatal("unable to generate request metadata:", err)
+	}
+
+	// Verify that the metadata is accepted.
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.New(requestMetadata))
+	if err := authenticateBearerToken(ctx, "token"); err != nil {
+		t.Error("valid bearer token rejected:", err)
+	}
+
+	// Verify that the metadata is rejected for a different token.
+	if err := authenticateBearerToken(ctx, "other"); err == nil {
+		t.Error("invalid bearer token accepted")
+	}
+}
+
+// TestAuthenticateBearerTokenMissing tests that authenticateBearerToken rejects
+// requests without valid authorization metadata.
+func TestAuthenticateBearerTokenMissing(t *testing.T) {
+	// Define test cases.
+	tests := []context.Context{
+		context.Background(),
+		metadata.NewIncomingContext(context.Background(), metadata.MD{}),
+		metadata.NewIncomingContext(context.Background(), metadata.Pairs(authorizationMetadataKey, "token")),
+		metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+			authorizationMetadataKey, bearerPrefix+"token",
+			authorizationMetadataKey, bearerPrefix+"token",
+		)),
+	}
+
+	// Process test cases.
+	for i, ctx := range tests {
+		if err := authenticateBearerToken(ctx, "token"); err == nil {
+			t.Errorf("test index %d: authentication succeeded unexpectedly", i)
+		}
+	}
+}
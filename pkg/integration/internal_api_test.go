@@ -65,6 +65,7 @@ func testSessionLifecycle(ctx context.Context, prompter string, alpha, beta *url
 		"testSynchronizationSession",
 		nil,
 		false,
+		nil,
 		prompter,
 	)
 	if err != nil {
@@ -508,6 +509,7 @@ func TestForwardingToHTTPDemo(t *testing.T) {
 		ctx,
 		source,
 		destination,
+		nil,
 		&forwarding.Configuration{},
 		&forwarding.Configuration{},
 		&forwarding.Configuration{},
@@ -0,0 +1,135 @@
+package logging
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format represents a log output format.
+type Format uint
+
+const (
+	// FormatText indicates human-readable text output.
+	FormatText Format = iota
+	// FormatJSON indicates JSON output, with one object per line.
+	FormatJSON
+	// FormatLogfmt indicates logfmt output, with one record per line.
+	FormatLogfmt
+)
+
+// formatNames are the human-readable representations of log formats.
+var formatNames = [3]string{
+	"text",
+	"json",
+	"logfmt",
+}
+
+// String provides a human-readable representation of a log format.
+func (f Format) String() string {
+	if f <= FormatLogfmt {
+		return formatNames[f]
+	}
+	return "unknown"
+}
+
+// NameToFormat converts a string-based representation of a log format to the
+// appropriate Format value. It returns a boolean indicating whether or not the
+// conversion was valid. If the name is invalid, FormatText is returned.
+func NameToFormat(name string) (Format, bool) {
+	switch name {
+	case "text":
+		return FormatText, true
+	case "json":
+		return FormatJSON, true
+	case "logfmt":
+		return FormatLogfmt, true
+	default:
+		return FormatText, false
+	}
+}
+
+// structuredTimestampFormat is the format in which timestamps are rendered in
+// structured output formats. Unlike the text format, it includes the time zone
+// so that lines can be correlated across hosts.
+const structuredTimestampFormat = time.RFC3339Nano
+
+// jsonLine is the JSON representation of a log line.
+type jsonLine struct {
+	// Time is the line timestamp.
+	Time string `json:"time"`
+	// Level is the line level name.
+	Level string `json:"level"`
+	// Scope is the logger scope.
+	Scope string `json:"scope,omitempty"`
+	// Session is the associated session identifier.
+	Session string `json:"session,omitempty"`
+	// Message is the logged message.
+	Message string `json:"message"`
+}
+
+// logfmtNeedsQuoting determines whether or not a logfmt value requires quoting.
+func logfmtNeedsQuoting(value string) bool {
+	if value == "" {
+		return true
+	}
+	for _, r := range value {
+		if r <= ' ' || r == '=' || r == '"' || r == '\\' || r == 0x7f {
+			return true
+		}
+	}
+	return false
+}
+
+// appendLogfmtField appends a logfmt key/value pair to a builder.
+func appendLogfmtField(builder *strings.Builder, key, value string) {
+	if builder.Len() > 0 {
+		builder.WriteByte(' ')
+	}
+	builder.WriteString(key)
+	builder.WriteByte('=')
+	if logfmtNeedsQuoting(value) {
+		builder.WriteString(strconv.Quote(value))
+	} else {
+		builder.WriteString(value)
+	}
+}
+
+// formatLine renders a log line in the specified format. The message must not
+// contain a trailing newline, but the resulting line will.
+func formatLine(format Format, line *Line) string {
+	switch format {
+	case FormatJSON:
+		encoded, err := json.Marshal(jsonLine{
+			Time:    line.Timestamp.Format(structuredTimestampFormat),
+			Level:   line.Level.String(),
+			Scope:   line.Scope,
+			Session: line.Session,
+			Message: line.Message,
+		})
+		if err != nil {
+			panic("unable to encode log line as JSON")
+		}
+		return string(encoded) + "\n"
+	case FormatLogfmt:
+		builder := &strings.Builder{}
+		appendLogfmtField(builder, "time", line.Timestamp.Format(structuredTimestampFormat))
+		appendLogfmtField(builder, "level", line.Level.String())
+		if line.Scope != "" {
+			appendLogfmtField(builder, "scope", line.Scope)
+		}
+		if line.Session != "" {
+			appendLogfmtField(builder, "session", line.Session)
+		}
+		appendLogfmtField(builder, "message", line.Message)
+		builder.WriteByte('\n')
+		return builder.String()
+	default:
+		prefix := line.Timestamp.Local().Format(timestampFormat) + " [" + string(line.Level.abbreviation()) + "] "
+		if line.Scope != "" {
+			return prefix + "[" + line.Scope + "] " + line.Message + "\n"
+		}
+		return prefix + line.Message + "\n"
+	}
+}
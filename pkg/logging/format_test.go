@@ -0,0 +1,73 @@
+package logging
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestNameToFormat tests that format names round-trip through NameToFormat.
+func TestNameToFormat(t *testing.T) {
+	for _, format := range []Format{FormatText, FormatJSON, FormatLogfmt} {
+		if f, ok := NameToFormat(format.String()); !ok {
+			t.Error("unable to convert format name:", format)
+		} else if f != format {
+			t.Error("format name conversion mismatch:", f, "!=", format)
+		}
+	}
+	if _, ok := NameToFormat("xml"); ok {
+		t.Error("invalid format name accepted")
+	}
+}
+
+// TestFormatRoundTrip tests that lines in each format can be parsed back.
+func TestFormatRoundTrip(t *testing.T) {
+	for _, format := range []Format{FormatText, FormatJSON, FormatLogfmt} {
+		// Generate a log line.
+		buffer := &bytes.Buffer{}
+		logger := NewFormattedLogger(LevelTrace, format, buffer)
+		logger = logger.Sublogger("sync").Sublogger("sync_abcdefgh").WithSession("sync_abcdefghijklmnop")
+		logger.Debug(`a "quoted" message = value`)
+
+		// Verify that structured formats are recognizable.
+		output := buffer.String()
+		if format == FormatJSON && !strings.HasPrefix(output, "{") {
+			t.Error("JSON output not an object:", output)
+		} else if format == FormatLogfmt && !strings.HasPrefix(output, "time=") {
+			t.Error("logfmt output doesn't start with timestamp:", output)
+		}
+
+		// Parse the line and verify its fields.
+		line, ok := ParseLine(output)
+		if !ok {
+			t.Errorf("unable to parse %s output: %s", format, output)
+			continue
+		}
+		if line.Level != LevelDebug {
+			t.Errorf("unexpected %s level: %s", format, line.Level)
+		}
+		if line.Scope != "sync.sync_abcdefgh" {
+			t.Errorf("unexpected %s scope: %s", format, line.Scope)
+		}
+		if line.Message != `a "quoted" message = value` {
+			t.Errorf("unexpected %s message: %s", format, line.Message)
+		}
+		if format != FormatText && line.Session != "sync_abcdefghijklmnop" {
+			t.Errorf("unexpected %s session: %s", format, line.Session)
+		}
+	}
+}
+
+// TestParseLogfmtInvalid tests that malformed logfmt lines are rejected.
+func TestParseLogfmtInvalid(t *testing.T) {
+	for _, line := range []string{
+		"time=",
+		`time=2022-01-01T00:00:00Z level=info message="unterminated`,
+		"time=2022-01-01T00:00:00Z level=bogus message=x",
+		"time=2022-01-01T00:00:00Z =value",
+	} {
+		if _, ok := ParseLine(line); ok {
+			t.Error("malformed logfmt line parsed successfully:", line)
+		}
+	}
+}
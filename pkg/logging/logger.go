@@ -16,18 +16,33 @@ import (
 type Logger struct {
 	// level is the log level.
 	level Level
+	// format is the logger's output format.
+	format Format
 	// scope is the logger's scope.
 	scope string
+	// session is the identifier of the session associated with the logger, if
+	// any.
+	session string
 	// writer is the underlying writer.
 	writer io.Writer
 }
 
 // NewLogger creates a new logger at the specified log level targeting the
-// specified writer. The writer must be non-nil. The logger and any derived
-// subloggers will coordinate access to the writer.
+// specified writer and using the text output format. The writer must be
+// non-nil. The logger and any derived subloggers will coordinate access to the
+// writer.
 func NewLogger(level Level, writer io.Writer) *Logger {
+	return NewFormattedLogger(level, FormatText, writer)
+}
+
+// NewFormattedLogger creates a new logger at the specified log level targeting
+// the specified writer and using the specified output format. The writer must
+// be non-nil. The logger and any derived subloggers will coordinate access to
+// the writer.
+func NewFormattedLogger(level Level, format Format, writer io.Writer) *Logger {
 	return &Logger{
 		level:  level,
+		format: format,
 		writer: stream.NewConcurrentWriter(writer),
 	}
 }
@@ -45,6 +60,18 @@ func (l *Logger) Level() Level {
 	return l.level
 }
 
+// Format returns the logger's output format. It can be used to propagate the
+// format to subprocesses whose output will be routed through the logger.
+func (l *Logger) Format() Format {
+	// If the logger is nil, then just use the text format.
+	if l == nil {
+		return FormatText
+	}
+
+	// Return the output format.
+	return l.format
+}
+
 // nameMatcher is used to validate names passed to Sublogger.
 var nameMatcher = regexp.MustCompile("^[[:word:]]+$")
 
@@ -72,9 +99,30 @@ func (l *Logger) Sublogger(name string) *Logger {
 
 	// Create the new logger.
 	return &Logger{
-		level:  l.level,
-		scope:  scope,
-		writer: l.writer,
+		level:   l.level,
+		format:  l.format,
+		scope:   scope,
+		session: l.session,
+		writer:  l.writer,
+	}
+}
+
+// WithSession creates a new logger with the same scope that associates its
+// output with the specified session identifier. The identifier is only
+// recorded by structured output formats.
+func (l *Logger) WithSession(session string) *Logger {
+	// If the logger is nil, then the new logger will be as well.
+	if l == nil {
+		return nil
+	}
+
+	// Create the new logger.
+	return &Logger{
+		level:   l.level,
+		format:  l.format,
+		scope:   l.scope,
+		session: session,
+		writer:  l.writer,
 	}
 }
 
@@ -98,16 +146,13 @@ func (l *Logger) write(timestamp time.Time, level Level, message string) {
 	}
 
 	// Compute the log line.
-	var line string
-	if l.scope != "" {
-		line = fmt.Sprintf("%s [%c] [%s] %s",
-			timestamp.Format(timestampFormat), level.abbreviation(), l.scope, message,
-		)
-	} else {
-		line = fmt.Sprintf("%s [%c] %s",
-			timestamp.Format(timestampFormat), level.abbreviation(), message,
-		)
-	}
+	line := formatLine(l.format, &Line{
+		Timestamp: timestamp,
+		Level:     level,
+		Scope:     l.scope,
+		Session:   l.session,
+		Message:   message[:len(message)-1],
+	})
 
 	// Write the line. We can't do much with the error here, so we don't try.
 	// Practically speaking, most io.Writer implementations perform retries if a
@@ -197,15 +242,17 @@ func (l *Logger) Tracef(format string, v ...any) {
 	l.logf(LevelTrace, format, v...)
 }
 
-// linePrefixMatcher matches the timestamp and level prefix of logging lines.
+// linePrefixMatcher matches the timestamp and level prefix of text logging
+// lines.
 var linePrefixMatcher = regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}\.\d{6} \[([` + abbreviations + `])\] `)
 
 // Writer returns an io.Writer that logs incoming lines. If an incoming line is
-// determined to be an output line from another logger, then it will be parsed
-// and gated against this logger's level, its scope will be merged with that of
-// this logger, and the combined line will be written. Otherwise, if an incoming
-// line is not determined to be from another logger, than it will be written as
-// a message with the specificed level.
+// determined to be an output line from another logger (in any output format),
+// then it will be parsed and gated against this logger's level, its scope will
+// be merged with that of this logger, and the combined line will be written in
+// this logger's output format (preserving its original timestamp). Otherwise,
+// if an incoming line is not determined to be from another logger, than it will
+// be written as a message with the specificed level.
 //
 // Note that unlike the Logger itself, the writer returned from this method is
 // not safe for concurrent use by multiple Goroutines. An external locking
@@ -221,35 +268,30 @@ func (l *Logger) Writer(level Level) io.Writer {
 		Callback: func(line string) {
 			// Check if the line is output from a logger. If it's not, then we
 			// just log it as if it were any other message.
-			matches := linePrefixMatcher.FindStringSubmatch(line)
-			if len(matches) != 2 {
+			parsed, ok := ParseLine(line)
+			if !ok {
 				l.log(level, line)
 				return
 			}
 
-			// Decode the log level for the line. If the log level that it
-			// specifies is invalid, then just print an indicator that an
-			// invalid line was received. Otherwise, if the line level is beyond
-			// the threshold of this logger, then just ignore it.
-			if len(matches[1]) != 1 {
-				panic("line prefix matcher returned invalid match")
-			} else if level, ok := abbreviationToLevel(matches[1][0]); !ok {
-				l.Warn("<invalid incoming log line level>")
-				return
-			} else if l.level < level {
+			// If the line level is beyond the threshold of this logger, then
+			// just ignore it.
+			if l.level < parsed.Level {
 				return
 			}
 
-			// If we have a non-empty scope, then inject it into the line. If
-			// not, then just add (back) the newline character.
-			if l.scope != "" {
-				line = fmt.Sprintf("%s[%s] %s\n", matches[0], l.scope, line[len(matches[0]):])
-			} else {
-				line = line + "\n"
+			// Merge our scope and session into the line.
+			if l.scope != "" && parsed.Scope != "" {
+				parsed.Scope = l.scope + "." + parsed.Scope
+			} else if l.scope != "" {
+				parsed.Scope = l.scope
+			}
+			if parsed.Session == "" {
+				parsed.Session = l.session
 			}
 
 			// Write the line to the underlying writer.
-			l.writer.Write([]byte(line))
+			l.writer.Write([]byte(formatLine(l.format, parsed)))
 		},
 	}
 }
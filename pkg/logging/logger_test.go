@@ -1,3 +1,46 @@
 package logging
 
-// TODO: Implement.
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+// TestLoggerWriter tests that logger output routed through Writer is merged
+// into the receiving logger's scope and format.
+func TestLoggerWriter(t *testing.T) {
+	// Create a receiving logger using JSON output.
+	buffer := &bytes.Buffer{}
+	receiver := NewFormattedLogger(LevelDebug, FormatJSON, buffer)
+	receiver = receiver.Sublogger("sync").WithSession("session")
+	writer := receiver.Writer(LevelError)
+
+	// Create a remote logger using text output that targets the writer.
+	remote := NewLogger(LevelTrace, writer).Sublogger("housekeeping")
+	remote.Info("remote message")
+	remote.Trace("filtered message")
+
+	// Write a line that isn't logger output.
+	fmt.Fprintln(writer, "raw output")
+
+	// Verify the output.
+	first, ok := ParseLine(buffer.String()[:bytes.IndexByte(buffer.Bytes(), '\n')+1])
+	if !ok {
+		t.Fatal("unable to parse merged output:", buffer.String())
+	}
+	if first.Scope != "sync.housekeeping" {
+		t.Error("unexpected merged scope:", first.Scope)
+	}
+	if first.Session != "session" {
+		t.Error("unexpected merged session:", first.Session)
+	}
+	if first.Level != LevelInfo || first.Message != "remote message" {
+		t.Error("unexpected merged line:", first.Level, first.Message)
+	}
+	if bytes.Contains(buffer.Bytes(), []byte("filtered message")) {
+		t.Error("line beyond level threshold not filtered")
+	}
+	if !bytes.Contains(buffer.Bytes(), []byte(`"level":"error","scope":"sync","session":"session","message":"raw output"`)) {
+		t.Error("raw output not logged at specified level:", buffer.String())
+	}
+}
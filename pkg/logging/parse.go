@@ -0,0 +1,176 @@
+package logging
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Line represents a parsed log line.
+type Line struct {
+	// Timestamp is the time at which the line was logged.
+	Timestamp time.Time
+	// Level is the line's log level.
+	Level Level
+	// Scope is the scope of the logger that emitted the line. It is empty if
+	// the line was emitted by a root logger.
+	Scope string
+	// Session is the identifier of the session associated with the line, if
+	// any. It is only recorded by structured output formats.
+	Session string
+	// Message is the logged message.
+	Message string
+}
+
+// scopeMatcher matches the scope prefix of a log message.
+var scopeMatcher = regexp.MustCompile(`^\[([[:word:].]+)\] `)
+
+// parseTextLine parses a line of text logger output.
+func parseTextLine(line string) (*Line, bool) {
+	// Match the timestamp and level prefix.
+	matches := linePrefixMatcher.FindStringSubmatch(line)
+	if len(matches) != 2 || len(matches[1]) != 1 {
+		return nil, false
+	}
+	level, ok := abbreviationToLevel(matches[1][0])
+	if !ok {
+		return nil, false
+	}
+	timestamp, err := time.ParseInLocation(timestampFormat, line[:len(timestampFormat)], time.Local)
+	if err != nil {
+		return nil, false
+	}
+	message := line[len(matches[0]):]
+
+	// Extract the scope, if any.
+	var scope string
+	if scopeMatches := scopeMatcher.FindStringSubmatch(message); len(scopeMatches) == 2 {
+		scope = scopeMatches[1]
+		message = message[len(scopeMatches[0]):]
+	}
+
+	// Done.
+	return &Line{
+		Timestamp: timestamp,
+		Level:     level,
+		Scope:     scope,
+		Message:   message,
+	}, true
+}
+
+// parseStructuredFields converts structured field values to a line.
+func parseStructuredFields(timestamp, level, scope, session, message string) (*Line, bool) {
+	parsedTimestamp, err := time.Parse(structuredTimestampFormat, timestamp)
+	if err != nil {
+		return nil, false
+	}
+	parsedLevel, ok := NameToLevel(level)
+	if !ok || parsedLevel == LevelDisabled {
+		return nil, false
+	}
+	return &Line{
+		Timestamp: parsedTimestamp,
+		Level:     parsedLevel,
+		Scope:     scope,
+		Session:   session,
+		Message:   message,
+	}, true
+}
+
+// parseJSONLine parses a line of JSON logger output.
+func parseJSONLine(line string) (*Line, bool) {
+	var fields jsonLine
+	if err := json.Unmarshal([]byte(line), &fields); err != nil {
+		return nil, false
+	}
+	return parseStructuredFields(fields.Time, fields.Level, fields.Scope, fields.Session, fields.Message)
+}
+
+// parseLogfmtFields parses logfmt key/value pairs.
+func parseLogfmtFields(line string) (map[string]string, error) {
+	fields := make(map[string]string)
+	for line != "" {
+		// Extract the key.
+		separator := strings.IndexByte(line, '=')
+		if separator <= 0 {
+			return nil, errors.New("missing key")
+		}
+		key := line[:separator]
+		line = line[separator+1:]
+
+		// Extract the value.
+		var value string
+		if strings.HasPrefix(line, `"`) {
+			end := 1
+			for ; end < len(line); end++ {
+				if line[end] == '\\' {
+					end++
+				} else if line[end] == '"' {
+					break
+				}
+			}
+			if end >= len(line) {
+				return nil, errors.New("unterminated quoted value")
+			}
+			unquoted, err := strconv.Unquote(line[:end+1])
+			if err != nil {
+				return nil, err
+			}
+			value = unquoted
+			line = line[end+1:]
+		} else if end := strings.IndexByte(line, ' '); end >= 0 {
+			value = line[:end]
+			line = line[end:]
+		} else {
+			value = line
+			line = ""
+		}
+		fields[key] = value
+
+		// Consume the separator.
+		if line != "" && line[0] != ' ' {
+			return nil, errors.New("missing field separator")
+		}
+		line = strings.TrimPrefix(line, " ")
+	}
+	return fields, nil
+}
+
+// parseLogfmtLine parses a line of logfmt logger output.
+func parseLogfmtLine(line string) (*Line, bool) {
+	fields, err := parseLogfmtFields(line)
+	if err != nil {
+		return nil, false
+	}
+	return parseStructuredFields(fields["time"], fields["level"], fields["scope"], fields["session"], fields["message"])
+}
+
+// ParseLine parses a line of logger output in any supported format. The line
+// may optionally include a trailing newline. It returns false if the line isn't
+// logger output.
+func ParseLine(line string) (*Line, bool) {
+	// Remove any trailing newline.
+	line = strings.TrimSuffix(line, "\n")
+
+	// Dispatch based on the line's format.
+	if strings.HasPrefix(line, "{") {
+		return parseJSONLine(line)
+	} else if strings.HasPrefix(line, "time=") {
+		return parseLogfmtLine(line)
+	}
+	return parseTextLine(line)
+}
+
+// InScope returns whether or not the line's scope contains the specified name
+// as one of its components.
+func (l *Line) InScope(name string) bool {
+	for _, component := range strings.Split(l.Scope, ".") {
+		if component == name {
+			return true
+		}
+	}
+	return false
+}
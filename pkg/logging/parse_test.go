@@ -0,0 +1,47 @@
+package logging
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestParseLine tests that ParseLine correctly parses logger output.
+func TestParseLine(t *testing.T) {
+	// Generate some logger output.
+	buffer := &bytes.Buffer{}
+	logger := NewLogger(LevelTrace, buffer)
+	logger.Sublogger("sync").Sublogger("sync_abcdefgh").Sublogger("alpha").Warn("message [with] brackets")
+
+	// Parse the line and verify the result.
+	line, ok := ParseLine(buffer.String())
+	if !ok {
+		t.Fatal("unable to parse logger output:", buffer.String())
+	}
+	if line.Level != LevelWarn {
+		t.Error("unexpected level:", line.Level)
+	}
+	if line.Scope != "sync.sync_abcdefgh.alpha" {
+		t.Error("unexpected scope:", line.Scope)
+	}
+	if line.Message != "message [with] brackets" {
+		t.Error("unexpected message:", line.Message)
+	}
+	if line.Timestamp.IsZero() {
+		t.Error("zero timestamp")
+	}
+	if !line.InScope("sync_abcdefgh") {
+		t.Error("line not in expected scope")
+	}
+	if line.InScope("beta") {
+		t.Error("line in unexpected scope")
+	}
+}
+
+// TestParseLineInvalid tests that ParseLine rejects non-logger output.
+func TestParseLineInvalid(t *testing.T) {
+	for _, line := range []string{"", "goroutine 1 [running]:", "2022-01-01 00:00:00.000000 [Q] message"} {
+		if _, ok := ParseLine(line); ok {
+			t.Error("non-logger line parsed successfully:", line)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RotatedPath computes the path of a rotated log file. The index must be
+// positive, with lower indices corresponding to more recently rotated files.
+func RotatedPath(path string, index int) string {
+	return path + "." + strconv.Itoa(index)
+}
+
+// RotatedPaths returns the paths of any existing rotated versions of a log file,
+// ordered from oldest to newest. The path of the active log file itself is not
+// included.
+func RotatedPaths(path string) []string {
+	var result []string
+	for index := 1; ; index++ {
+		rotated := RotatedPath(path, index)
+		if _, err := os.Lstat(rotated); err != nil {
+			break
+		}
+		result = append([]string{rotated}, result...)
+	}
+	return result
+}
+
+// RotatingFile is an io.WriteCloser that appends to a log file, rotating the
+// file once it exceeds a maximum size and pruning rotated files that exceed a
+// maximum count or age. It is safe for concurrent usage.
+type RotatingFile struct {
+	// path is the path of the active log file.
+	path string
+	// maximumSize is the size at which the active log file is rotated.
+	maximumSize int64
+	// maximumCount is the maximum number of rotated files to retain.
+	maximumCount int
+	// maximumAge is the maximum age of rotated files to retain. If zero, then
+	// files are not pruned based on age.
+	maximumAge time.Duration
+	// lock guards file and size.
+	lock sync.Mutex
+	// file is the active log file. It is nil if the writer has been closed.
+	file *os.File
+	// size is the current size of the active log file.
+	size int64
+}
+
+// NewRotatingFile creates a new rotating log file writer. If the active log file
+// already exists, then it is appended to, unless its last modification is
+// older than maximumAge, in which case it is rotated first.
+func NewRotatingFile(path string, maximumSize int64, maximumCount int, maximumAge time.Duration) (*RotatingFile, error) {
+	// Validate parameters.
+	if maximumSize <= 0 {
+		return nil, errors.New("invalid maximum log file size")
+	} else if maximumCount < 0 {
+		return nil, errors.New("invalid maximum log file count")
+	} else if maximumAge < 0 {
+		return nil, errors.New("invalid maximum log file age")
+	}
+
+	// Create the writer.
+	result := &RotatingFile{
+		path:         path,
+		maximumSize:  maximumSize,
+		maximumCount: maximumCount,
+		maximumAge:   maximumAge,
+	}
+
+	// If there's an existing log file that's too old, then rotate it before
+	// appending.
+	if metadata, err := os.Stat(path); err == nil && maximumAge > 0 {
+		if time.Since(metadata.ModTime()) > maximumAge {
+			result.shift()
+		}
+	}
+
+	// Open the active log file.
+	if err := result.open(); err != nil {
+		return nil, err
+	}
+
+	// Prune any expired rotated files.
+	result.prune()
+
+	// Success.
+	return result, nil
+}
+
+// open opens the active log file for appending and records its size.
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to open log file: %w", err)
+	}
+	metadata, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("unable to query log file metadata: %w", err)
+	}
+	f.file = file
+	f.size = metadata.Size()
+	return nil
+}
+
+// shift shifts rotated files up by one index (discarding any beyond the
+// maximum count) and moves the active log file to the first rotated index.
+func (f *RotatingFile) shift() {
+	if f.maximumCount == 0 {
+		os.Remove(f.path)
+		return
+	}
+	os.Remove(RotatedPath(f.path, f.maximumCount))
+	for index := f.maximumCount - 1; index > 0; index-- {
+		os.Rename(RotatedPath(f.path, index), RotatedPath(f.path, index+1))
+	}
+	os.Rename(f.path, RotatedPath(f.path, 1))
+}
+
+// prune removes rotated files that are older than the maximum age.
+func (f *RotatingFile) prune() {
+	if f.maximumAge == 0 {
+		return
+	}
+	for index := 1; index <= f.maximumCount; index++ {
+		rotated := RotatedPath(f.path, index)
+		metadata, err := os.Stat(rotated)
+		if err != nil {
+			break
+		} else if time.Since(metadata.ModTime()) > f.maximumAge {
+			os.Remove(rotated)
+		}
+	}
+}
+
+// rotate closes the active log file, rotates it, and opens a new one.
+func (f *RotatingFile) rotate() error {
+	f.file.Close()
+	f.file = nil
+	f.shift()
+	f.prune()
+	return f.open()
+}
+
+// Write implements io.Writer.Write. Writes are never split across files, so a
+// single write may cause the active log file to exceed the maximum size.
+func (f *RotatingFile) Write(data []byte) (int, error) {
+	// Lock the writer and defer its release.
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	// Ensure that the writer hasn't been closed.
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	// Rotate the active log file if necessary.
+	if f.size > 0 && f.size+int64(len(data)) > f.maximumSize {
+		if err := f.rotate(); err != nil {
+			return 0, fmt.Errorf("unable to rotate log file: %w", err)
+		}
+	}
+
+	// Perform the write.
+	n, err := f.file.Write(data)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close implements io.Closer.Close.
+func (f *RotatingFile) Close() error {
+	// Lock the writer and defer its release.
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	// Close the active log file, if any.
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
@@ -0,0 +1,97 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestRotatingFile tests log file rotation and pruning by count.
+func TestRotatingFile(t *testing.T) {
+	// Create a rotating file with a small size limit.
+	path := filepath.Join(t.TempDir(), "test.log")
+	file, err := NewRotatingFile(path, 10, 2, 0)
+	if err != nil {
+		t.Fatal("unable to create rotating file:", err)
+	}
+
+	// Perform writes that will require multiple rotations.
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		if _, err := file.Write([]byte(line)); err != nil {
+			t.Fatal("unable to write line:", err)
+		}
+	}
+	if err := file.Close(); err != nil {
+		t.Fatal("unable to close rotating file:", err)
+	}
+
+	// Verify file contents.
+	expected := map[string]string{
+		path:                 "fourth\n",
+		RotatedPath(path, 1): "third\n",
+		RotatedPath(path, 2): "second\n",
+	}
+	for p, contents := range expected {
+		if data, err := os.ReadFile(p); err != nil {
+			t.Error("unable to read log file:", err)
+		} else if string(data) != contents {
+			t.Errorf("unexpected contents for %s: %q", p, data)
+		}
+	}
+	if _, err := os.Lstat(RotatedPath(path, 3)); !os.IsNotExist(err) {
+		t.Error("excess rotated file retained")
+	}
+
+	// Verify rotated path ordering.
+	if rotated := RotatedPaths(path); len(rotated) != 2 {
+		t.Error("unexpected number of rotated paths:", len(rotated))
+	} else if rotated[0] != RotatedPath(path, 2) || rotated[1] != RotatedPath(path, 1) {
+		t.Error("rotated paths not ordered from oldest to newest")
+	}
+}
+
+// TestRotatingFileAge tests log file rotation and pruning by age.
+func TestRotatingFileAge(t *testing.T) {
+	// Create an expired log file and an expired rotated log file.
+	path := filepath.Join(t.TempDir(), "test.log")
+	expired := time.Now().Add(-2 * time.Hour)
+	for _, p := range []string{path, RotatedPath(path, 1)} {
+		if err := os.WriteFile(p, []byte("old\n"), 0600); err != nil {
+			t.Fatal("unable to create log file:", err)
+		} else if err := os.Chtimes(p, expired, expired); err != nil {
+			t.Fatal("unable to set log file modification time:", err)
+		}
+	}
+
+	// Create a rotating file and verify that expired content was rotated and
+	// then pruned.
+	file, err := NewRotatingFile(path, 1024, 5, time.Hour)
+	if err != nil {
+		t.Fatal("unable to create rotating file:", err)
+	}
+	defer file.Close()
+	if rotated := RotatedPaths(path); len(rotated) != 0 {
+		t.Error("expired rotated files retained:", rotated)
+	}
+	if metadata, err := os.Stat(path); err != nil {
+		t.Fatal("unable to query active log file:", err)
+	} else if metadata.Size() != 0 {
+		t.Error("expired active log file not rotated")
+	}
+}
+
+// TestNewRotatingFileInvalid tests that NewRotatingFile rejects invalid
+// parameters.
+func TestNewRotatingFileInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	if _, err := NewRotatingFile(path, 0, 1, 0); err == nil {
+		t.Error("zero maximum size accepted")
+	}
+	if _, err := NewRotatingFile(path, 1, -1, 0); err == nil {
+		t.Error("negative maximum count accepted")
+	}
+	if _, err := NewRotatingFile(path, 1, 1, -time.Second); err == nil {
+		t.Error("negative maximum age accepted")
+	}
+}
@@ -0,0 +1,3 @@
+// Package metrics provides facilities for exporting daemon metrics in the
+// Prometheus text exposition format.
+package metrics
@@ -0,0 +1,136 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ContentType is the content type for the Prometheus text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Type is a metric family type.
+type Type string
+
+const (
+	// TypeCounter indicates a counter metric family.
+	TypeCounter Type = "counter"
+	// TypeGauge indicates a gauge metric family.
+	TypeGauge Type = "gauge"
+	// TypeHistogram indicates a histogram metric family.
+	TypeHistogram Type = "histogram"
+)
+
+// Label is a metric label.
+type Label struct {
+	// Name is the label name.
+	Name string
+	// Value is the label value.
+	Value string
+}
+
+// Encoder encodes metrics in the Prometheus text exposition format. All samples
+// for a metric family must be written immediately after the family is
+// declared.
+type Encoder struct {
+	// writer is the underlying buffered writer.
+	writer *bufio.Writer
+}
+
+// NewEncoder creates a new encoder that writes to the specified writer.
+func NewEncoder(writer io.Writer) *Encoder {
+	return &Encoder{writer: bufio.NewWriter(writer)}
+}
+
+// Flush flushes any buffered output to the underlying writer.
+func (e *Encoder) Flush() error {
+	return e.writer.Flush()
+}
+
+// helpEscaper escapes metric help text.
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+// labelValueEscaper escapes metric label values.
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+// Family declares a metric family.
+func (e *Encoder) Family(name, help string, kind Type) {
+	e.writer.WriteString("# HELP ")
+	e.writer.WriteString(name)
+	e.writer.WriteByte(' ')
+	e.writer.WriteString(helpEscaper.Replace(help))
+	e.writer.WriteString("\n# TYPE ")
+	e.writer.WriteString(name)
+	e.writer.WriteByte(' ')
+	e.writer.WriteString(string(kind))
+	e.writer.WriteByte('\n')
+}
+
+// formatValue formats a sample value.
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
+
+// Sample writes a sample for the most recently declared metric family.
+func (e *Encoder) Sample(name string, labels []Label, value float64) {
+	e.writer.WriteString(name)
+	if len(labels) > 0 {
+		e.writer.WriteByte('{')
+		for i, label := range labels {
+			if i > 0 {
+				e.writer.WriteByte(',')
+			}
+			e.writer.WriteString(label.Name)
+			e.writer.WriteString(`="`)
+			e.writer.WriteString(labelValueEscaper.Replace(label.Value))
+			e.writer.WriteByte('"')
+		}
+		e.writer.WriteByte('}')
+	}
+	e.writer.WriteByte(' ')
+	e.writer.WriteString(formatValue(value))
+	e.writer.WriteByte('\n')
+}
+
+// Histogram writes the samples for a histogram in the most recently declared
+// metric family.
+func (e *Encoder) Histogram(name string, labels []Label, histogram *Histogram) {
+	// Grab a snapshot of the histogram.
+	cumulative, sum, count := histogram.snapshot()
+
+	// Create a label set with room for the bucket label.
+	bucketLabels := make([]Label, len(labels), len(labels)+1)
+	copy(bucketLabels, labels)
+	bucketLabels = append(bucketLabels, Label{Name: "le"})
+
+	// Write buckets.
+	for i, bound := range histogram.buckets {
+		bucketLabels[len(labels)].Value = formatValue(bound)
+		e.Sample(name+"_bucket", bucketLabels, float64(cumulative[i]))
+	}
+	bucketLabels[len(labels)].Value = "+Inf"
+	e.Sample(name+"_bucket", bucketLabels, float64(count))
+
+	// Write the sum and count.
+	e.Sample(name+"_sum", labels, sum)
+	e.Sample(name+"_count", labels, float64(count))
+}
+
+// Bool converts a boolean value to a sample value.
+func Bool(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
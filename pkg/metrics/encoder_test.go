@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestEncoder tests metric encoding.
+func TestEncoder(t *testing.T) {
+	// Create a histogram with some observations.
+	histogram := NewHistogram([]float64{1, 0.5})
+	histogram.Observe(0.25)
+	histogram.Observe(0.5)
+	histogram.Observe(0.75)
+	histogram.Observe(2)
+
+	// Encode a set of metric families.
+	buffer := &bytes.Buffer{}
+	encoder := NewEncoder(buffer)
+	encoder.Family("test_gauge", "A gauge\nwith \\ escapes.", TypeGauge)
+	encoder.Sample("test_gauge", nil, 1.5)
+	encoder.Sample("test_gauge", []Label{{"name", "quoted \"value\""}}, Bool(true))
+	encoder.Family("test_duration_seconds", "A histogram.", TypeHistogram)
+	encoder.Histogram("test_duration_seconds", []Label{{"phase", "scan"}}, histogram)
+	if err := encoder.Flush(); err != nil {
+		t.Fatal("unable to flush encoder:", err)
+	}
+
+	// Verify the output.
+	expected := `# HELP test_gauge A gauge\nwith \\ escapes.
+# TYPE test_gauge gauge
+test_gauge 1.5
+test_gauge{name="quoted \"value\""} 1
+# HELP test_duration_seconds A histogram.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{phase="scan",le="0.5"} 2
+test_duration_seconds_bucket{phase="scan",le="1"} 3
+test_duration_seconds_bucket{phase="scan",le="+Inf"} 4
+test_duration_seconds_sum{phase="scan"} 3.5
+test_duration_seconds_count{phase="scan"} 4
+`
+	if output := buffer.String(); output != expected {
+		t.Errorf("unexpected encoder output:\n%s", output)
+	}
+}
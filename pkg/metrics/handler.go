@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"net/http"
+)
+
+// Collector is the interface implemented by types that export metrics.
+type Collector interface {
+	// CollectMetrics writes metric families and samples to the encoder.
+	CollectMetrics(encoder *Encoder)
+}
+
+// handler implements http.Handler for metric collection.
+type handler struct {
+	// collectors are the underlying collectors.
+	collectors []Collector
+}
+
+// NewHandler creates a new HTTP handler that serves metrics from the specified
+// collectors.
+func NewHandler(collectors ...Collector) http.Handler {
+	return &handler{collectors: collectors}
+}
+
+// ServeHTTP implements http.Handler.ServeHTTP.
+func (h *handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	// Only allow retrieval.
+	if request.Method != http.MethodGet && request.Method != http.MethodHead {
+		writer.Header().Set("Allow", "GET, HEAD")
+		http.Error(writer, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Collect and write metrics.
+	writer.Header().Set("Content-Type", ContentType)
+	encoder := NewEncoder(writer)
+	for _, collector := range h.collectors {
+		collector.CollectMetrics(encoder)
+	}
+	encoder.Flush()
+}
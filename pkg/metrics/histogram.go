@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+)
+
+// DefaultDurationBuckets are the default histogram bucket upper bounds (in
+// seconds) used for durations.
+var DefaultDurationBuckets = []float64{
+	0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300,
+}
+
+// Histogram is a cumulative histogram with fixed bucket boundaries. It is safe
+// for concurrent usage.
+type Histogram struct {
+	// buckets are the bucket upper bounds, in increasing order.
+	buckets []float64
+	// lock guards counts, sum, and count.
+	lock sync.Mutex
+	// counts are the (non-cumulative) observation counts for each bucket.
+	counts []uint64
+	// sum is the sum of all observations.
+	sum float64
+	// count is the total number of observations.
+	count uint64
+}
+
+// NewHistogram creates a new histogram with the specified bucket upper bounds.
+// The bounds will be sorted if necessary. An implicit +Inf bucket is always
+// present.
+func NewHistogram(buckets []float64) *Histogram {
+	sorted := make([]float64, len(buckets))
+	copy(sorted, buckets)
+	sort.Float64s(sorted)
+	return &Histogram{
+		buckets: sorted,
+		counts:  make([]uint64, len(sorted)),
+	}
+}
+
+// Observe records an observation.
+func (h *Histogram) Observe(value float64) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if index := sort.SearchFloat64s(h.buckets, value); index < len(h.buckets) {
+		h.counts[index]++
+	}
+	h.sum += value
+	h.count++
+}
+
+// snapshot returns the cumulative bucket counts, the observation sum, and the
+// observation count.
+func (h *Histogram) snapshot() ([]uint64, float64, uint64) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	cumulative := make([]uint64, len(h.counts))
+	var total uint64
+	for i, count := range h.counts {
+		total += count
+		cumulative[i] = total
+	}
+	return cumulative, h.sum, h.count
+}
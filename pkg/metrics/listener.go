@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+)
+
+const (
+	// unixAddressPrefix is the prefix used to indicate a Unix domain socket
+	// listener address.
+	unixAddressPrefix = "unix:"
+	// tcpAddressPrefix is the optional prefix used to indicate a TCP listener
+	// address.
+	tcpAddressPrefix = "tcp:"
+)
+
+// Listen creates a listener for serving metrics. The address may take the form
+// "unix:<path>" for a Unix domain socket or "[tcp:]<host>:<port>" for a TCP
+// socket. Any existing Unix domain socket at the target path is removed before
+// listening, and newly created sockets are only accessible to the current user.
+func Listen(address string) (net.Listener, error) {
+	// Handle Unix domain socket addresses.
+	if strings.HasPrefix(address, unixAddressPrefix) {
+		path := address[len(unixAddressPrefix):]
+		if path == "" {
+			return nil, errors.New("empty socket path")
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("unable to remove existing socket: %w", err)
+		}
+		listener, err := net.Listen("unix", path)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.Chmod(path, 0600); err != nil {
+			listener.Close()
+			return nil, fmt.Errorf("unable to set socket permissions: %w", err)
+		}
+		return listener, nil
+	}
+
+	// Handle TCP addresses.
+	address = strings.TrimPrefix(address, tcpAddressPrefix)
+	if host, _, err := net.SplitHostPort(address); err != nil {
+		return nil, fmt.Errorf("invalid TCP address: %w", err)
+	} else if host == "" {
+		return nil, errors.New("TCP address must specify a host (e.g. localhost)")
+	}
+	return net.Listen("tcp", address)
+}
@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// testCollector is a Collector implementation for testing.
+type testCollector struct{}
+
+// CollectMetrics implements Collector.CollectMetrics.
+func (testCollector) CollectMetrics(encoder *Encoder) {
+	encoder.Family("test_value", "A test value.", TypeGauge)
+	encoder.Sample("test_value", nil, 42)
+}
+
+// TestListenInvalid tests that Listen rejects invalid addresses.
+func TestListenInvalid(t *testing.T) {
+	for _, address := range []string{"", "unix:", "9090", ":9090", "tcp::9090"} {
+		if listener, err := Listen(address); err == nil {
+			listener.Close()
+			t.Error("invalid address accepted:", address)
+		}
+	}
+}
+
+// TestHandlerOverUnixSocket tests serving metrics over a Unix domain socket.
+func TestHandlerOverUnixSocket(t *testing.T) {
+	// Unix domain sockets aren't reliably available on Windows.
+	if runtime.GOOS == "windows" {
+		t.Skip()
+	}
+
+	// Create the listener and serve metrics.
+	path := filepath.Join(t.TempDir(), "metrics.sock")
+	listener, err := Listen("unix:" + path)
+	if err != nil {
+		t.Fatal("unable to create listener:", err)
+	}
+	server := &http.Server{Handler: NewHandler(testCollector{})}
+	defer server.Close()
+	go server.Serve(listener)
+
+	// Create a client that dials the socket.
+	client := &http.Client{
+		Transport: &http.Transport{
+			Dial: func(_, _ string) (net.Conn, error) {
+				return net.Dial("unix", path)
+			},
+		},
+	}
+
+	// Perform a retrieval and verify the result.
+	response, err := client.Get("http://metrics/metrics")
+	if err != nil {
+		t.Fatal("unable to retrieve metrics:", err)
+	}
+	defer response.Body.Close()
+	if response.Header.Get("Content-Type") != ContentType {
+		t.Error("unexpected content type:", response.Header.Get("Content-Type"))
+	}
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatal("unable to read response body:", err)
+	} else if !strings.Contains(string(body), "test_value 42\n") {
+		t.Error("unexpected response body:", string(body))
+	}
+
+	// Verify that modification requests are rejected.
+	response, err = client.Post("http://metrics/metrics", "text/plain", nil)
+	if err != nil {
+		t.Fatal("unable to perform POST request:", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusMethodNotAllowed {
+		t.Error("unexpected POST status code:", response.StatusCode)
+	}
+}
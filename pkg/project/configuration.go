@@ -1,6 +1,13 @@
 package project
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+
 	"github.com/mutagen-io/mutagen/pkg/api/models/forwarding"
 	"github.com/mutagen-io/mutagen/pkg/api/models/synchronization"
 	"github.com/mutagen-io/mutagen/pkg/encoding"
@@ -12,13 +19,31 @@ type ForwardingConfiguration struct {
 	Source string `yaml:"source"`
 	// Destination is the destination URL for the session.
 	Destination string `yaml:"destination"`
+	// AdditionalDestinations are additional destination URLs for the session.
+	// Connections are distributed between the destinations according to the
+	// destination selection mode.
+	AdditionalDestinations []string `yaml:"additionalDestinations,omitempty"`
 	// Configuration is the configuration for the session.
 	Configuration forwarding.Configuration `yaml:",inline"`
 	// ConfigurationSource is the source-specific configuration for the session.
-	ConfigurationSource forwarding.Configuration `yaml:"configurationSource"`
+	ConfigurationSource forwarding.Configuration `yaml:"configurationSource,omitempty"`
 	// ConfigurationDestination is the destination-specific configuration for
 	// the session.
-	ConfigurationDestination forwarding.Configuration `yaml:"configurationDestination"`
+	ConfigurationDestination forwarding.Configuration `yaml:"configurationDestination,omitempty"`
+	// Labels are additional labels for the session.
+	Labels map[string]string `yaml:"labels,omitempty"`
+	// Profiles are the profiles to which the session belongs. If non-empty,
+	// then the session is only created if one of these profiles is active.
+	Profiles []string `yaml:"profiles,omitempty"`
+	// DependsOn are the names of the sessions (forwarding or synchronization)
+	// that must be created before this session. Synchronization session
+	// dependencies with flush-on-create behavior enabled are also flushed
+	// before this session is created.
+	DependsOn []string `yaml:"dependsOn,omitempty"`
+	// Preset is the name of a preset from the global configuration file whose
+	// forwarding configuration is applied on top of the default configuration
+	// for the session.
+	Preset string `yaml:"preset,omitempty"`
 }
 
 // FlushOnCreateBehavior is a custom YAML type that can encode various
@@ -58,6 +83,20 @@ func (b FlushOnCreateBehavior) FlushOnCreate() bool {
 	}
 }
 
+// MarshalYAML implements Marshaler.MarshalYAML.
+func (b FlushOnCreateBehavior) MarshalYAML() (any, error) {
+	switch b {
+	case FlushOnCreateBehaviorDefault:
+		return nil, nil
+	case FlushOnCreateBehaviorNoFlush:
+		return false, nil
+	case FlushOnCreateBehaviorFlush:
+		return true, nil
+	default:
+		return nil, errors.New("unhandled flush-on-create behavior")
+	}
+}
+
 // UnmarshalYAML implements Unmarshaler.UnmarshalYAML.
 func (b *FlushOnCreateBehavior) UnmarshalYAML(unmarshal func(any) error) error {
 	// Call the underlying unmarshaling function.
@@ -84,59 +123,252 @@ type SynchronizationConfiguration struct {
 	// Beta is the beta URL for the session.
 	Beta string `yaml:"beta"`
 	// FlushOnCreate indicates the flush-on-create behavior for the session.
-	FlushOnCreate FlushOnCreateBehavior `yaml:"flushOnCreate"`
+	FlushOnCreate FlushOnCreateBehavior `yaml:"flushOnCreate,omitempty"`
 	// Configuration is the configuration for the session.
 	Configuration synchronization.Configuration `yaml:",inline"`
 	// ConfigurationAlpha is the alpha-specific configuration for the session.
-	ConfigurationAlpha synchronization.Configuration `yaml:"configurationAlpha"`
+	ConfigurationAlpha synchronization.Configuration `yaml:"configurationAlpha,omitempty"`
 	// ConfigurationBeta is the beta-specific configuration for the session.
-	ConfigurationBeta synchronization.Configuration `yaml:"configurationBeta"`
+	ConfigurationBeta synchronization.Configuration `yaml:"configurationBeta,omitempty"`
+	// Labels are additional labels for the session.
+	Labels map[string]string `yaml:"labels,omitempty"`
+	// Profiles are the profiles to which the session belongs. If non-empty,
+	// then the session is only created if one of these profiles is active.
+	Profiles []string `yaml:"profiles,omitempty"`
+	// DependsOn are the names of the sessions (forwarding or synchronization)
+	// that must be created before this session. Synchronization session
+	// dependencies with flush-on-create behavior enabled are also flushed
+	// before this session is created.
+	DependsOn []string `yaml:"dependsOn,omitempty"`
+	// Preset is the name of a preset from the global configuration file whose
+	// synchronization configuration is applied on top of the default configuration
+	// for the session.
+	Preset string `yaml:"preset,omitempty"`
 }
 
 // Configuration is the orchestration configuration object type.
 type Configuration struct {
 	// BeforeCreate are setup commands to be run before session creation.
-	BeforeCreate []string `yaml:"beforeCreate"`
+	BeforeCreate []string `yaml:"beforeCreate,omitempty"`
 	// AfterCreate are setup commands to be run after session creation.
-	AfterCreate []string `yaml:"afterCreate"`
+	AfterCreate []string `yaml:"afterCreate,omitempty"`
 	// BeforePause are setup commands to be run before session pausing.
-	BeforePause []string `yaml:"beforePause"`
+	BeforePause []string `yaml:"beforePause,omitempty"`
 	// AfterPause are setup commands to be run after session pausing.
-	AfterPause []string `yaml:"afterPause"`
+	AfterPause []string `yaml:"afterPause,omitempty"`
 	// BeforeResume are setup commands to be run before session resumption.
-	BeforeResume []string `yaml:"beforeResume"`
+	BeforeResume []string `yaml:"beforeResume,omitempty"`
 	// AfterResume are setup commands to be run after session resumption.
-	AfterResume []string `yaml:"afterResume"`
+	AfterResume []string `yaml:"afterResume,omitempty"`
 	// BeforeTerminate are teardown commands to be run before session
 	// termination.
-	BeforeTerminate []string `yaml:"beforeTerminate"`
+	BeforeTerminate []string `yaml:"beforeTerminate,omitempty"`
 	// AfterTerminate are teardown commands to be run after session termination.
-	AfterTerminate []string `yaml:"afterTerminate"`
+	AfterTerminate []string `yaml:"afterTerminate,omitempty"`
 	// Commands are commands that can be invoked while a project is running.
-	Commands map[string]string `yaml:"commands"`
+	Commands map[string]string `yaml:"commands,omitempty"`
+	// Services are long-running commands that are started after session
+	// creation, supervised according to their restart policies, and stopped
+	// when the project is terminated.
+	Services map[string]ServiceConfiguration `yaml:"services,omitempty"`
+	// WaitFor are readiness conditions to wait for after session creation and
+	// before running post-creation commands.
+	WaitFor []WaitCondition `yaml:"waitFor,omitempty"`
 	// Forwarding represents the forwarding sessions to be created. If a
 	// "defaults" key is present, it is treated as a template upon which other
 	// configurations are layered, thus keeping syntactic compatibility with the
 	// global Mutagen configuration file.
-	Forwarding map[string]ForwardingConfiguration `yaml:"forward"`
+	Forwarding map[string]ForwardingConfiguration `yaml:"forward,omitempty"`
 	// Synchronization represents the forwarding sessions to be created. If a
 	// "defaults" key is present, it is treated as a template upon which other
 	// configurations are layered, thus keeping syntactic compatibility with the
 	// global Mutagen configuration file.
-	Synchronization map[string]SynchronizationConfiguration `yaml:"sync"`
+	Synchronization map[string]SynchronizationConfiguration `yaml:"sync,omitempty"`
+}
+
+const (
+	// IncludeKey is the top-level configuration key used to include other
+	// configuration files.
+	IncludeKey = "include"
+)
+
+// InterpolatedKeys are the top-level configuration keys whose values undergo
+// variable interpolation. Lifecycle hooks and commands are excluded because
+// they're executed by a shell, which performs its own expansion.
+var InterpolatedKeys = []string{"forward", "sync", "waitFor"}
+
+// mergeDocuments merges a decoded YAML mapping into another. Nested mappings
+// are merged recursively, while all other values from source replace those in
+// target.
+func mergeDocuments(target, source map[any]any) {
+	for key, value := range source {
+		if sourceMapping, ok := value.(map[any]any); ok {
+			if targetMapping, ok := target[key].(map[any]any); ok {
+				mergeDocuments(targetMapping, sourceMapping)
+				continue
+			}
+		}
+		target[key] = value
+	}
+}
+
+// loadDocument loads a configuration file as a generic YAML mapping, merging
+// in any files that it includes. Included files are merged in the order
+// specified, and the including file's contents take precedence over them.
+// Include paths may use variable interpolation and are resolved relative to
+// the including file. The stack argument tracks the files currently being
+// loaded in order to detect include cycles. The function passes through
+// os.IsNotExist errors for the file at path.
+func loadDocument(path string, lookup variableLookup, unresolved map[string]bool, stack []string) (map[any]any, error) {
+	// Check for include cycles.
+	absolute, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compute absolute path: %w", err)
+	}
+	for _, loading := range stack {
+		if loading == absolute {
+			return nil, errors.New("include cycle detected")
+		}
+	}
+	stack = append(stack, absolute)
+
+	// Load and decode the file.
+	var document map[any]any
+	if err := encoding.LoadAndUnmarshal(path, func(data []byte) error {
+		return yaml.UnmarshalStrict(data, &document)
+	}); err != nil {
+		return nil, err
+	}
+	if document == nil {
+		document = make(map[any]any)
+	}
+
+	// Extract include paths.
+	var includes []string
+	if value, ok := document[IncludeKey]; ok {
+		delete(document, IncludeKey)
+		switch value := value.(type) {
+		case string:
+			includes = []string{value}
+		case []any:
+			for _, include := range value {
+				if include, ok := include.(string); ok {
+					includes = append(includes, include)
+				} else {
+					return nil, errors.New("include paths must be strings")
+				}
+			}
+		default:
+			return nil, errors.New("include must be a path or list of paths")
+		}
+	}
+
+	// Load and merge included files.
+	result := make(map[any]any)
+	for _, include := range includes {
+		// Interpolate the path. If it can't be fully resolved, then skip the
+		// file, since the unresolved variables will be reported by the caller.
+		unresolvedCount := len(unresolved)
+		include, err := interpolate(include, lookup, unresolved)
+		if err != nil {
+			return nil, fmt.Errorf("invalid include path: %w", err)
+		} else if len(unresolved) != unresolvedCount {
+			continue
+		}
+
+		// Load the included file.
+		if !filepath.IsAbs(include) {
+			include = filepath.Join(filepath.Dir(path), include)
+		}
+		included, err := loadDocument(include, lookup, unresolved, stack)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load included file (%s): %w", include, err)
+		}
+
+		// Merge the included file.
+		mergeDocuments(result, included)
+	}
+	mergeDocuments(result, document)
+
+	// Success.
+	return result, nil
 }
 
 // LoadConfiguration attempts to load a YAML-based Mutagen orchestration
-// configuration file from the specified path.
+// configuration file from the specified path. Files included via the top-level
+// include key are merged into the configuration, and variable references in
+// session specifications are interpolated using the process environment and,
+// with lower precedence, the environment file alongside the configuration
+// file. If any variable references can't be resolved, then an
+// UnresolvedVariablesError is returned.
 func LoadConfiguration(path string) (*Configuration, error) {
-	// Create the target configuration object.
-	result := &Configuration{}
+	return LoadComposedConfiguration([]string{path})
+}
 
-	// Attempt to load. We pass-through os.IsNotExist errors.
-	if err := encoding.LoadAndUnmarshalYAML(path, result); err != nil {
+// LoadComposedConfiguration attempts to load a project configuration composed
+// of multiple YAML-based Mutagen orchestration configuration files. Files are
+// loaded as described for LoadConfiguration and merged in order (in the same
+// manner as included files), with later files taking precedence. Variable
+// interpolation uses the environment file alongside the first configuration
+// file. The function passes through os.IsNotExist errors for the first file.
+func LoadComposedConfiguration(paths []string) (*Configuration, error) {
+	// Ensure that at least one file has been specified.
+	if len(paths) == 0 {
+		return nil, errors.New("no configuration files specified")
+	}
+
+	// Load the environment file, if any.
+	environment, err := loadEnvironmentFile(filepath.Join(filepath.Dir(paths[0]), EnvironmentFileName))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("unable to load environment file: %w", err)
+	}
+	lookup := func(name string) (string, bool) {
+		if value, ok := os.LookupEnv(name); ok {
+			return value, true
+		}
+		value, ok := environment[name]
+		return value, ok
+	}
+
+	// Load and merge the configuration documents. We pass-through
+	// os.IsNotExist errors for the first file, but annotate errors for
+	// subsequent files so that the failing file can be identified.
+	unresolved := make(map[string]bool)
+	document := make(map[any]any)
+	for p, path := range paths {
+		loaded, err := loadDocument(path, lookup, unresolved, nil)
+		if err != nil {
+			if p == 0 {
+				return nil, err
+			}
+			return nil, fmt.Errorf("unable to load configuration file (%s): %w", path, err)
+		}
+		mergeDocuments(document, loaded)
+	}
+
+	// Perform interpolation and ensure that all variables were resolved.
+	for _, key := range InterpolatedKeys {
+		if value, ok := document[key]; ok {
+			if document[key], err = interpolateTree(value, lookup, unresolved); err != nil {
+				return nil, fmt.Errorf("unable to interpolate %s configuration: %w", key, err)
+			}
+		}
+	}
+	if err := unresolvedVariablesError(unresolved); err != nil {
 		return nil, err
 	}
 
+	// Re-encode the document and decode it into the target configuration
+	// object, rejecting unknown fields.
+	data, err := yaml.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("unable to re-encode configuration: %w", err)
+	}
+	result := &Configuration{}
+	if err := yaml.UnmarshalStrict(data, result); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal data: %w", err)
+	}
+
 	// Success.
 	return result, nil
 }
@@ -1,3 +1,277 @@
 package project
 
-// TODO: Implement.
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+// writeFile is a test helper that writes a file into a directory.
+func writeFile(t *testing.T, directory, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(directory, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal("unable to create directory:", err)
+	}
+	if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatal("unable to write file:", err)
+	}
+	return path
+}
+
+// TestLoadConfigurationNotExist tests that LoadConfiguration passes through
+// os.IsNotExist errors.
+func TestLoadConfigurationNotExist(t *testing.T) {
+	if _, err := LoadConfiguration(filepath.Join(t.TempDir(), "mutagen.yml")); !os.IsNotExist(err) {
+		t.Error("unexpected error for non-existent file:", err)
+	}
+}
+
+// TestLoadConfigurationStrict tests that LoadConfiguration rejects unknown
+// fields.
+func TestLoadConfigurationStrict(t *testing.T) {
+	directory := t.TempDir()
+	path := writeFile(t, directory, "mutagen.yml", "sync:\n  code:\n    alpha: a\n    beta: b\n    unknown: true\n")
+	if _, err := LoadConfiguration(path); err == nil {
+		t.Error("configuration with unknown field loaded successfully")
+	}
+}
+
+// TestLoadConfigurationDuplicateKeys tests that LoadConfiguration rejects
+// duplicate keys, both at the session level and within sessions.
+func TestLoadConfigurationDuplicateKeys(t *testing.T) {
+	directory := t.TempDir()
+	for i, contents := range []string{
+		"sync:\n  code:\n    alpha: a\n    beta: b\n  code:\n    alpha: c\n    beta: d\n",
+		"sync:\n  code:\n    alpha: a\n    alpha: c\n    beta: b\n",
+	} {
+		path := writeFile(t, directory, "mutagen.yml", contents)
+		if _, err := LoadConfiguration(path); err == nil {
+			t.Errorf("test case %d: configuration with duplicate keys loaded successfully", i)
+		}
+	}
+}
+
+// TestLoadConfigurationInterpolation tests variable interpolation.
+func TestLoadConfigurationInterpolation(t *testing.T) {
+	// Set up the environment. The process environment takes precedence over
+	// the environment file.
+	t.Setenv("MUTAGEN_TEST_HOST", "process.example.org")
+	directory := t.TempDir()
+	writeFile(t, directory, EnvironmentFileName, "MUTAGEN_TEST_HOST=file.example.org\nMUTAGEN_TEST_FLUSH=true\nMUTAGEN_TEST_COUNT=500\n")
+	path := writeFile(t, directory, "mutagen.yml", `
+beforeCreate:
+  - echo ${MUTAGEN_TEST_UNSET}
+sync:
+  code:
+    alpha: "."
+    beta: "user@${MUTAGEN_TEST_HOST}:/code/${MUTAGEN_TEST_NAME:-app}"
+    flushOnCreate: ${MUTAGEN_TEST_FLUSH}
+    maxEntryCount: ${MUTAGEN_TEST_COUNT}
+    labels:
+      count: ${MUTAGEN_TEST_COUNT}
+      price: "$$5"
+`)
+
+	// Load the configuration.
+	configuration, err := LoadConfiguration(path)
+	if err != nil {
+		t.Fatal("unable to load configuration:", err)
+	}
+
+	// Verify results.
+	session := configuration.Synchronization["code"]
+	if session.Beta != "user@process.example.org:/code/app" {
+		t.Error("unexpected beta URL:", session.Beta)
+	}
+	if session.FlushOnCreate != FlushOnCreateBehaviorFlush {
+		t.Error("unexpected flush-on-create behavior:", session.FlushOnCreate)
+	}
+	if session.Configuration.MaximumEntryCount != 500 {
+		t.Error("unexpected maximum entry count:", session.Configuration.MaximumEntryCount)
+	}
+	if session.Labels["count"] != "500" {
+		t.Error("unexpected count label:", session.Labels["count"])
+	}
+	if session.Labels["price"] != "$5" {
+		t.Error("unexpected price label:", session.Labels["price"])
+	}
+	if len(configuration.BeforeCreate) != 1 || configuration.BeforeCreate[0] != "echo ${MUTAGEN_TEST_UNSET}" {
+		t.Error("lifecycle hook unexpectedly interpolated:", configuration.BeforeCreate)
+	}
+}
+
+// TestLoadConfigurationUnresolved tests that unresolved variables are
+// reported.
+func TestLoadConfigurationUnresolved(t *testing.T) {
+	directory := t.TempDir()
+	path := writeFile(t, directory, "mutagen.yml", `
+include: ${MUTAGEN_TEST_UNSET_C}/shared.yml
+sync:
+  code:
+    alpha: ${MUTAGEN_TEST_UNSET_B}
+    beta: ${MUTAGEN_TEST_UNSET_A}
+`)
+	_, err := LoadConfiguration(path)
+	var unresolvedErr *UnresolvedVariablesError
+	if !errors.As(err, &unresolvedErr) {
+		t.Fatal("unexpected error:", err)
+	}
+	expected := []string{"MUTAGEN_TEST_UNSET_A", "MUTAGEN_TEST_UNSET_B", "MUTAGEN_TEST_UNSET_C"}
+	if len(unresolvedErr.Variables) != len(expected) {
+		t.Fatal("unexpected unresolved variables:", unresolvedErr.Variables)
+	}
+	for i, name := range expected {
+		if unresolvedErr.Variables[i] != name {
+			t.Error("unexpected unresolved variable:", unresolvedErr.Variables[i])
+		}
+	}
+}
+
+// TestLoadConfigurationIncludes tests configuration includes.
+func TestLoadConfigurationIncludes(t *testing.T) {
+	// Create the files.
+	directory := t.TempDir()
+	writeFile(t, directory, "shared/base.yml", `
+include: ignores.yml
+sync:
+  defaults:
+    mode: two-way-resolved
+    ignore:
+      vcs: true
+  code:
+    alpha: base-alpha
+    beta: base-beta
+afterCreate:
+  - echo base
+`)
+	writeFile(t, directory, "shared/ignores.yml", `
+sync:
+  defaults:
+    ignore:
+      paths:
+        - node_modules
+`)
+	path := writeFile(t, directory, "mutagen.yml", `
+include:
+  - shared/base.yml
+sync:
+  code:
+    beta: local-beta
+afterCreate:
+  - echo local
+`)
+
+	// Load the configuration.
+	configuration, err := LoadConfiguration(path)
+	if err != nil {
+		t.Fatal("unable to load configuration:", err)
+	}
+
+	// Verify that mappings were merged recursively.
+	defaults := configuration.Synchronization["defaults"]
+	if len(defaults.Configuration.Ignore.Paths) != 1 || defaults.Configuration.Ignore.Paths[0] != "node_modules" {
+		t.Error("unexpected default ignores:", defaults.Configuration.Ignore.Paths)
+	}
+	if defaults.Configuration.Ignore.VCS != core.IgnoreVCSMode_IgnoreVCSModeIgnore {
+		t.Error("unexpected default VCS ignore mode:", defaults.Configuration.Ignore.VCS)
+	}
+	if defaults.Configuration.Mode != core.SynchronizationMode_SynchronizationModeTwoWayResolved {
+		t.Error("unexpected default synchronization mode:", defaults.Configuration.Mode)
+	}
+	code := configuration.Synchronization["code"]
+	if code.Alpha != "base-alpha" || code.Beta != "local-beta" {
+		t.Error("unexpected session URLs:", code.Alpha, code.Beta)
+	}
+
+	// Verify that lists were replaced.
+	if len(configuration.AfterCreate) != 1 || configuration.AfterCreate[0] != "echo local" {
+		t.Error("unexpected lifecycle hooks:", configuration.AfterCreate)
+	}
+}
+
+// TestLoadComposedConfiguration tests LoadComposedConfiguration.
+func TestLoadComposedConfiguration(t *testing.T) {
+	// Create the files.
+	directory := t.TempDir()
+	writeFile(t, directory, EnvironmentFileName, "BETA=env-beta\n")
+	base := writeFile(t, directory, "mutagen.yml", `
+sync:
+  code:
+    alpha: base-alpha
+    beta: ${BETA}
+    mode: two-way-resolved
+afterCreate:
+  - echo base
+`)
+	override := writeFile(t, directory, "overrides/mutagen.override.yml", `
+sync:
+  code:
+    alpha: override-alpha
+  other:
+    alpha: other-alpha
+    beta: other-beta
+`)
+
+	// Verify that loading no files fails.
+	if _, err := LoadComposedConfiguration(nil); err == nil {
+		t.Error("loading empty composition succeeded")
+	}
+
+	// Load the composed configuration.
+	configuration, err := LoadComposedConfiguration([]string{base, override})
+	if err != nil {
+		t.Fatal("unable to load composed configuration:", err)
+	}
+
+	// Verify that later files take precedence and that interpolation uses the
+	// environment file alongside the first file.
+	code := configuration.Synchronization["code"]
+	if code.Alpha != "override-alpha" || code.Beta != "env-beta" {
+		t.Error("unexpected session URLs:", code.Alpha, code.Beta)
+	}
+	if code.Configuration.Mode != core.SynchronizationMode_SynchronizationModeTwoWayResolved {
+		t.Error("unexpected synchronization mode:", code.Configuration.Mode)
+	}
+	if _, ok := configuration.Synchronization["other"]; !ok {
+		t.Error("session from override file missing")
+	}
+	if len(configuration.AfterCreate) != 1 || configuration.AfterCreate[0] != "echo base" {
+		t.Error("unexpected lifecycle hooks:", configuration.AfterCreate)
+	}
+
+	// Verify that missing override files are reported.
+	if _, err := LoadComposedConfiguration([]string{base, filepath.Join(directory, "missing.yml")}); err == nil {
+		t.Error("loading composition with missing file succeeded")
+	} else if !strings.Contains(err.Error(), "missing.yml") {
+		t.Error("missing file not identified in error:", err)
+	}
+}
+
+// TestLoadConfigurationIncludeErrors tests include error handling.
+func TestLoadConfigurationIncludeErrors(t *testing.T) {
+	// Test a missing include.
+	directory := t.TempDir()
+	path := writeFile(t, directory, "mutagen.yml", "include: missing.yml\n")
+	if _, err := LoadConfiguration(path); err == nil || os.IsNotExist(err) {
+		t.Error("unexpected error for missing include:", err)
+	}
+
+	// Test an include cycle.
+	writeFile(t, directory, "a.yml", "include: b.yml\n")
+	writeFile(t, directory, "b.yml", "include: a.yml\n")
+	path = writeFile(t, directory, "mutagen.yml", "include: a.yml\n")
+	if _, err := LoadConfiguration(path); err == nil {
+		t.Error("include cycle not detected")
+	}
+
+	// Test an invalid include specification.
+	path = writeFile(t, directory, "mutagen.yml", "include:\n  key: value\n")
+	if _, err := LoadConfiguration(path); err == nil {
+		t.Error("invalid include specification accepted")
+	}
+}
@@ -0,0 +1,192 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SessionKind identifies the kind of a project session.
+type SessionKind uint8
+
+const (
+	// SessionKindForwarding indicates a forwarding session.
+	SessionKindForwarding SessionKind = iota
+	// SessionKindSynchronization indicates a synchronization session.
+	SessionKindSynchronization
+)
+
+// String returns a human-readable representation of the session kind.
+func (k SessionKind) String() string {
+	switch k {
+	case SessionKindForwarding:
+		return "forwarding"
+	case SessionKindSynchronization:
+		return "synchronization"
+	default:
+		return "unknown"
+	}
+}
+
+// SessionReference identifies a session within a project configuration.
+type SessionReference struct {
+	// Kind is the session kind.
+	Kind SessionKind
+	// Name is the session name.
+	Name string
+}
+
+// String returns a human-readable representation of the reference.
+func (r SessionReference) String() string {
+	return fmt.Sprintf("%s session %s", r.Kind, r.Name)
+}
+
+// less determines whether or not a reference should be ordered before another
+// in the absence of dependency constraints. Forwarding sessions are ordered
+// before synchronization sessions, and sessions of the same kind are ordered
+// by name.
+func (r SessionReference) less(other SessionReference) bool {
+	if r.Kind != other.Kind {
+		return r.Kind < other.Kind
+	}
+	return r.Name < other.Name
+}
+
+// Dependencies computes the dependency graph for the sessions in the
+// configuration, mapping each session (excluding defaults) to the sessions on
+// which it depends. Dependencies are specified by name and may refer to either
+// forwarding or synchronization sessions, but a name that's used by both a
+// forwarding session and a synchronization session can't be used as a
+// dependency.
+func (c *Configuration) Dependencies() (map[SessionReference][]SessionReference, error) {
+	// Index session names.
+	kinds := make(map[string][]SessionKind)
+	for name := range c.Forwarding {
+		if name != "defaults" {
+			kinds[name] = append(kinds[name], SessionKindForwarding)
+		}
+	}
+	for name := range c.Synchronization {
+		if name != "defaults" {
+			kinds[name] = append(kinds[name], SessionKindSynchronization)
+		}
+	}
+
+	// Create the graph.
+	result := make(map[SessionReference][]SessionReference, len(c.Forwarding)+len(c.Synchronization))
+
+	// Define a function to resolve the dependencies for a session.
+	resolve := func(session SessionReference, dependencies []string) error {
+		var resolved []SessionReference
+		for _, dependency := range dependencies {
+			candidates := kinds[dependency]
+			if len(candidates) == 0 {
+				return fmt.Errorf("%s depends on unknown session: %s", session, dependency)
+			} else if len(candidates) > 1 {
+				return fmt.Errorf("%s has ambiguous dependency: %s", session, dependency)
+			}
+			reference := SessionReference{candidates[0], dependency}
+			if reference == session {
+				return fmt.Errorf("%s depends on itself", session)
+			}
+			resolved = append(resolved, reference)
+		}
+		result[session] = resolved
+		return nil
+	}
+
+	// Resolve dependencies.
+	for name, session := range c.Forwarding {
+		if name == "defaults" {
+			if len(session.DependsOn) > 0 {
+				return nil, errors.New("dependencies cannot be specified for default forwarding configuration")
+			}
+			continue
+		}
+		if err := resolve(SessionReference{SessionKindForwarding, name}, session.DependsOn); err != nil {
+			return nil, err
+		}
+	}
+	for name, session := range c.Synchronization {
+		if name == "defaults" {
+			if len(session.DependsOn) > 0 {
+				return nil, errors.New("dependencies cannot be specified for default synchronization configuration")
+			}
+			continue
+		}
+		if err := resolve(SessionReference{SessionKindSynchronization, name}, session.DependsOn); err != nil {
+			return nil, err
+		}
+	}
+
+	// Success.
+	return result, nil
+}
+
+// SessionOrder computes an order for creating the sessions in the
+// configuration (excluding defaults) such that every session is created after
+// the sessions on which it depends. Where dependencies don't constrain the
+// order, forwarding sessions are created before synchronization sessions and
+// sessions of the same kind are created in name order. An error is returned if
+// the dependencies are invalid or contain a cycle.
+func (c *Configuration) SessionOrder() ([]SessionReference, error) {
+	// Compute the dependency graph.
+	dependencies, err := c.Dependencies()
+	if err != nil {
+		return nil, err
+	}
+
+	// Compute the number of unsatisfied dependencies for each session and the
+	// dependents of each session.
+	remaining := make(map[SessionReference]int, len(dependencies))
+	dependents := make(map[SessionReference][]SessionReference, len(dependencies))
+	for session, sessionDependencies := range dependencies {
+		remaining[session] = len(sessionDependencies)
+		for _, dependency := range sessionDependencies {
+			dependents[dependency] = append(dependents[dependency], session)
+		}
+	}
+
+	// Compute the initial set of sessions whose dependencies are satisfied.
+	var ready []SessionReference
+	for session, count := range remaining {
+		if count == 0 {
+			ready = append(ready, session)
+		}
+	}
+
+	// Perform a topological sort, always selecting the first ready session.
+	result := make([]SessionReference, 0, len(dependencies))
+	for len(ready) > 0 {
+		// Select the next session.
+		sort.Slice(ready, func(i, j int) bool {
+			return ready[i].less(ready[j])
+		})
+		session := ready[0]
+		ready = ready[1:]
+		result = append(result, session)
+
+		// Update dependents.
+		for _, dependent := range dependents[session] {
+			if remaining[dependent]--; remaining[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	// If any sessions remain, then there's a dependency cycle.
+	if len(result) != len(dependencies) {
+		var cyclic []string
+		for session, count := range remaining {
+			if count > 0 {
+				cyclic = append(cyclic, session.Name)
+			}
+		}
+		sort.Strings(cyclic)
+		return nil, fmt.Errorf("dependency cycle detected between sessions: %s", strings.Join(cyclic, ", "))
+	}
+
+	// Success.
+	return result, nil
+}
@@ -0,0 +1,103 @@
+package project
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestSessionOrder tests Configuration.SessionOrder.
+func TestSessionOrder(t *testing.T) {
+	// Create a configuration with dependencies.
+	configuration := &Configuration{
+		Forwarding: map[string]ForwardingConfiguration{
+			"defaults": {},
+			"database": {},
+			"web":      {DependsOn: []string{"code"}},
+		},
+		Synchronization: map[string]SynchronizationConfiguration{
+			"defaults": {},
+			"code":     {DependsOn: []string{"database", "assets"}},
+			"assets":   {},
+			"other":    {},
+		},
+	}
+
+	// Compute the order.
+	order, err := configuration.SessionOrder()
+	if err != nil {
+		t.Fatal("unable to compute session order:", err)
+	}
+
+	// Verify the order.
+	expected := []SessionReference{
+		{SessionKindForwarding, "database"},
+		{SessionKindSynchronization, "assets"},
+		{SessionKindSynchronization, "code"},
+		{SessionKindForwarding, "web"},
+		{SessionKindSynchronization, "other"},
+	}
+	if len(order) != len(expected) {
+		t.Fatalf("session order length mismatch: %d != %d", len(order), len(expected))
+	}
+	for i, reference := range order {
+		if reference != expected[i] {
+			t.Errorf("session order mismatch at index %d: %s != %s", i, reference, expected[i])
+		}
+	}
+}
+
+// TestSessionOrderInvalid tests Configuration.SessionOrder with invalid
+// dependency specifications.
+func TestSessionOrderInvalid(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		description     string
+		forwarding      map[string]ForwardingConfiguration
+		synchronization map[string]SynchronizationConfiguration
+		expected        string
+	}{
+		{
+			"unknown dependency",
+			nil,
+			map[string]SynchronizationConfiguration{"code": {DependsOn: []string{"missing"}}},
+			"depends on unknown session: missing",
+		},
+		{
+			"ambiguous dependency",
+			map[string]ForwardingConfiguration{"shared": {}},
+			map[string]SynchronizationConfiguration{"shared": {}, "code": {DependsOn: []string{"shared"}}},
+			"ambiguous dependency: shared",
+		},
+		{
+			"self dependency",
+			map[string]ForwardingConfiguration{"web": {DependsOn: []string{"web"}}},
+			nil,
+			"depends on itself",
+		},
+		{
+			"default dependency",
+			map[string]ForwardingConfiguration{"web": {}},
+			map[string]SynchronizationConfiguration{"defaults": {DependsOn: []string{"web"}}},
+			"default synchronization configuration",
+		},
+		{
+			"cycle",
+			map[string]ForwardingConfiguration{"web": {DependsOn: []string{"code"}}, "database": {}},
+			map[string]SynchronizationConfiguration{"code": {DependsOn: []string{"web", "database"}}},
+			"dependency cycle detected between sessions: code, web",
+		},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		configuration := &Configuration{
+			Forwarding:      testCase.forwarding,
+			Synchronization: testCase.synchronization,
+		}
+		if _, err := configuration.SessionOrder(); err == nil {
+			t.Errorf("%s: session order computed successfully", testCase.description)
+		} else if !strings.Contains(err.Error(), testCase.expected) {
+			t.Errorf("%s: unexpected error: %v", testCase.description, err)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package project
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/mutagen-io/mutagen/pkg/encoding"
+)
+
+// EnvironmentFileName is the name of the optional environment file that
+// resides alongside a project configuration file and provides values for
+// variable interpolation.
+const EnvironmentFileName = ".env"
+
+// parseEnvironmentFile parses the contents of an environment file. Each
+// non-empty line that isn't a comment (i.e. doesn't start with #) must be of
+// the form NAME=VALUE, optionally preceded by "export ". Values may be wrapped
+// in single or double quotes, which are removed. No interpolation or escape
+// processing is performed on values.
+func parseEnvironmentFile(data []byte) (map[string]string, error) {
+	// Create the result.
+	result := make(map[string]string)
+
+	// Process lines.
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		// Skip empty lines and comments.
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		// Strip any export prefix.
+		line = strings.TrimPrefix(line, "export ")
+
+		// Split the assignment.
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: missing assignment", lineNumber)
+		}
+		name = strings.TrimSpace(name)
+		value = strings.TrimSpace(value)
+
+		// Validate the name.
+		if name == "" {
+			return nil, fmt.Errorf("line %d: empty variable name", lineNumber)
+		}
+		for i := 0; i < len(name); i++ {
+			if !isVariableNameCharacter(name[i], i == 0) {
+				return nil, fmt.Errorf("line %d: invalid variable name \"%s\"", lineNumber, name)
+			}
+		}
+
+		// Remove matching quotes.
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+
+		// Record the value.
+		result[name] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read lines: %w", err)
+	}
+
+	// Success.
+	return result, nil
+}
+
+// loadEnvironmentFile loads and parses an environment file. It passes through
+// os.IsNotExist errors.
+func loadEnvironmentFile(path string) (map[string]string, error) {
+	var result map[string]string
+	err := encoding.LoadAndUnmarshal(path, func(data []byte) (err error) {
+		result, err = parseEnvironmentFile(data)
+		return
+	})
+	return result, err
+}
@@ -0,0 +1,47 @@
+package project
+
+import (
+	"testing"
+)
+
+// TestParseEnvironmentFile tests parseEnvironmentFile.
+func TestParseEnvironmentFile(t *testing.T) {
+	// Parse a valid file.
+	environment, err := parseEnvironmentFile([]byte(`
+# Comment
+HOST=example.org
+export USER = developer
+QUOTED="a value # with hash"
+SINGLE='single'
+EMPTY=
+EQUALS=a=b
+`))
+	if err != nil {
+		t.Fatal("unable to parse environment file:", err)
+	}
+
+	// Verify values.
+	expected := map[string]string{
+		"HOST":   "example.org",
+		"USER":   "developer",
+		"QUOTED": "a value # with hash",
+		"SINGLE": "single",
+		"EMPTY":  "",
+		"EQUALS": "a=b",
+	}
+	if len(environment) != len(expected) {
+		t.Error("variable count mismatch:", len(environment), "!=", len(expected))
+	}
+	for name, value := range expected {
+		if environment[name] != value {
+			t.Errorf("value mismatch for %s: %q != %q", name, environment[name], value)
+		}
+	}
+
+	// Verify that invalid files are rejected.
+	for _, data := range []string{"NOASSIGNMENT", "=value", "1NAME=value", "NA-ME=value"} {
+		if _, err := parseEnvironmentFile([]byte(data)); err == nil {
+			t.Errorf("invalid environment file parsed successfully: %q", data)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package project
+
+import (
+	forwardingmodels "github.com/mutagen-io/mutagen/pkg/api/models/forwarding"
+	synchronizationmodels "github.com/mutagen-io/mutagen/pkg/api/models/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+)
+
+// exportLabels copies session labels for export, omitting the project label.
+// If no labels remain, then nil is returned.
+func exportLabels(labels map[string]string) map[string]string {
+	var result map[string]string
+	for key, value := range labels {
+		if key == LabelKey {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]string, len(labels))
+		}
+		result[key] = value
+	}
+	return result
+}
+
+// ExportForwardingSession converts a forwarding session to a forwarding session
+// specification. The session must be valid. Any project label is omitted from
+// the resulting specification, as are any Docker environment variables that
+// were captured in the session URLs.
+func ExportForwardingSession(session *forwarding.Session) ForwardingConfiguration {
+	// Convert additional destinations.
+	var additionalDestinations []string
+	for _, destination := range session.AdditionalDestinations {
+		additionalDestinations = append(additionalDestinations, destination.Format(""))
+	}
+
+	// Create the specification.
+	return ForwardingConfiguration{
+		Source:                   session.Source.Format(""),
+		Destination:              session.Destination.Format(""),
+		AdditionalDestinations:   additionalDestinations,
+		Configuration:            *forwardingmodels.ExportConfiguration(session.Configuration),
+		ConfigurationSource:      *forwardingmodels.ExportConfiguration(session.ConfigurationSource),
+		ConfigurationDestination: *forwardingmodels.ExportConfiguration(session.ConfigurationDestination),
+		Labels:                   exportLabels(session.Labels),
+	}
+}
+
+// ExportSynchronizationSession converts a synchronization session to a
+// synchronization session specification. The session must be valid. Any
+// project label is omitted from the resulting specification, as are any Docker
+// environment variables that were captured in the session URLs.
+func ExportSynchronizationSession(session *synchronization.Session) SynchronizationConfiguration {
+	return SynchronizationConfiguration{
+		Alpha:              session.Alpha.Format(""),
+		Beta:               session.Beta.Format(""),
+		Configuration:      *synchronizationmodels.ExportConfiguration(session.Configuration),
+		ConfigurationAlpha: *synchronizationmodels.ExportConfiguration(session.ConfigurationAlpha),
+		ConfigurationBeta:  *synchronizationmodels.ExportConfiguration(session.ConfigurationBeta),
+		Labels:             exportLabels(session.Labels),
+	}
+}
@@ -0,0 +1,155 @@
+package project
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// TestExportLabels tests exportLabels.
+func TestExportLabels(t *testing.T) {
+	// Verify that the project label is removed.
+	result := exportLabels(map[string]string{LabelKey: "project", "key": "value"})
+	if len(result) != 1 || result["key"] != "value" {
+		t.Error("unexpected exported labels:", result)
+	}
+
+	// Verify that an empty result is nil.
+	if result := exportLabels(map[string]string{LabelKey: "project"}); result != nil {
+		t.Error("exported labels unexpectedly non-nil:", result)
+	}
+}
+
+// TestExportSynchronizationSessionRoundTrip tests that an exported
+// synchronization session specification survives a YAML round trip.
+func TestExportSynchronizationSessionRoundTrip(t *testing.T) {
+	// Create a session to export.
+	session := &synchronization.Session{
+		Alpha: &url.URL{Kind: url.Kind_Synchronization, Protocol: url.Protocol_Local, Path: "/alpha"},
+		Beta: &url.URL{
+			Kind:     url.Kind_Synchronization,
+			Protocol: url.Protocol_SSH,
+			User:     "user",
+			Host:     "host",
+			Port:     2222,
+			Path:     "~/beta",
+		},
+		Configuration: &synchronization.Configuration{
+			SynchronizationMode:    core.SynchronizationMode_SynchronizationModeOneWaySafe,
+			MaximumStagingFileSize: 1 << 20,
+			Ignores:                []string{"node_modules", "*.log"},
+			IgnoreVCSMode:          core.IgnoreVCSMode_IgnoreVCSModeIgnore,
+			DefaultFileMode:        0644,
+		},
+		ConfigurationAlpha: &synchronization.Configuration{
+			WatchMode: synchronization.WatchMode_WatchModeNoWatch,
+		},
+		ConfigurationBeta: &synchronization.Configuration{},
+		Labels:            map[string]string{LabelKey: "project", "team": "web"},
+	}
+
+	// Export and marshal the specification.
+	exported := &Configuration{
+		Synchronization: map[string]SynchronizationConfiguration{
+			"web": ExportSynchronizationSession(session),
+		},
+	}
+	data, err := yaml.Marshal(exported)
+	if err != nil {
+		t.Fatal("unable to marshal exported configuration:", err)
+	}
+
+	// Unmarshal the specification.
+	imported := &Configuration{}
+	if err := yaml.UnmarshalStrict(data, imported); err != nil {
+		t.Fatal("unable to unmarshal exported configuration:", err)
+	}
+	specification, ok := imported.Synchronization["web"]
+	if !ok {
+		t.Fatal("session specification missing after round trip")
+	}
+
+	// Verify URLs.
+	if specification.Alpha != "/alpha" {
+		t.Error("alpha URL mismatch:", specification.Alpha)
+	}
+	if specification.Beta != "user@host:2222:~/beta" {
+		t.Error("beta URL mismatch:", specification.Beta)
+	}
+
+	// Verify configurations.
+	if !specification.Configuration.ToInternal().Equal(session.Configuration) {
+		t.Error("configuration mismatch after round trip")
+	}
+	if !specification.ConfigurationAlpha.ToInternal().Equal(session.ConfigurationAlpha) {
+		t.Error("alpha configuration mismatch after round trip")
+	}
+	if !specification.ConfigurationBeta.ToInternal().Equal(session.ConfigurationBeta) {
+		t.Error("beta configuration mismatch after round trip")
+	}
+
+	// Verify labels.
+	if len(specification.Labels) != 1 || specification.Labels["team"] != "web" {
+		t.Error("unexpected labels after round trip:", specification.Labels)
+	}
+}
+
+// TestExportForwardingSessionRoundTrip tests that an exported forwarding
+// session specification survives a YAML round trip.
+func TestExportForwardingSessionRoundTrip(t *testing.T) {
+	// Create a session to export.
+	session := &forwarding.Session{
+		Source:      &url.URL{Kind: url.Kind_Forwarding, Protocol: url.Protocol_Local, Path: "tcp:localhost:8080"},
+		Destination: &url.URL{Kind: url.Kind_Forwarding, Protocol: url.Protocol_SSH, Host: "host", Path: "tcp:localhost:80"},
+		Configuration: &forwarding.Configuration{
+			SocketOverwriteMode: forwarding.SocketOverwriteMode_SocketOverwriteModeOverwrite,
+			AllowedNetworks:     []string{"127.0.0.0/8"},
+		},
+		ConfigurationSource:      &forwarding.Configuration{},
+		ConfigurationDestination: &forwarding.Configuration{},
+	}
+
+	// Export and marshal the specification.
+	exported := &Configuration{
+		Forwarding: map[string]ForwardingConfiguration{
+			"web": ExportForwardingSession(session),
+		},
+	}
+	data, err := yaml.Marshal(exported)
+	if err != nil {
+		t.Fatal("unable to marshal exported configuration:", err)
+	}
+
+	// Unmarshal the specification.
+	imported := &Configuration{}
+	if err := yaml.UnmarshalStrict(data, imported); err != nil {
+		t.Fatal("unable to unmarshal exported configuration:", err)
+	}
+	specification, ok := imported.Forwarding["web"]
+	if !ok {
+		t.Fatal("session specification missing after round trip")
+	}
+
+	// Verify URLs.
+	if specification.Source != "tcp:localhost:8080" {
+		t.Error("source URL mismatch:", specification.Source)
+	}
+	if specification.Destination != "host:tcp:localhost:80" {
+		t.Error("destination URL mismatch:", specification.Destination)
+	}
+
+	// Verify configuration.
+	if !specification.Configuration.ToInternal().Equal(session.Configuration) {
+		t.Error("configuration mismatch after round trip")
+	}
+
+	// Verify labels.
+	if specification.Labels != nil {
+		t.Error("unexpected labels after round trip:", specification.Labels)
+	}
+}
@@ -0,0 +1,129 @@
+package project
+
+import (
+	"fmt"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+)
+
+// SessionHealth summarizes the health of a project session.
+type SessionHealth struct {
+	// Kind is the session kind.
+	Kind SessionKind
+	// Name is the session name.
+	Name string
+	// Identifier is the session identifier.
+	Identifier string
+	// Status is a human-readable description of the session status.
+	Status string
+	// Issues are human-readable descriptions of the issues affecting the
+	// session. The session is healthy if there are no issues.
+	Issues []string
+}
+
+// Healthy returns whether or not the session is healthy.
+func (h *SessionHealth) Healthy() bool {
+	return len(h.Issues) == 0
+}
+
+// ForwardingSessionHealth computes the health of a forwarding session. A
+// forwarding session is healthy if it's running, connected to both endpoints,
+// and hasn't encountered an error.
+func ForwardingSessionHealth(state *forwarding.State) *SessionHealth {
+	// Create the result.
+	result := &SessionHealth{
+		Kind:       SessionKindForwarding,
+		Name:       state.Session.Name,
+		Identifier: state.Session.Identifier,
+		Status:     state.Status.Description(),
+	}
+
+	// Check for issues.
+	if state.Session.Paused {
+		result.Status = "Paused"
+		result.Issues = append(result.Issues, "paused")
+	} else {
+		if state.SourceState == nil || !state.SourceState.Connected {
+			result.Issues = append(result.Issues, "source disconnected")
+		}
+		if state.DestinationState == nil || !state.DestinationState.Connected {
+			result.Issues = append(result.Issues, "destination disconnected")
+		}
+	}
+	if state.LastError != "" {
+		result.Issues = append(result.Issues, "error: "+state.LastError)
+	}
+
+	// Done.
+	return result
+}
+
+// synchronizationEndpointProblems computes the total number of problems
+// reported for a synchronization endpoint.
+func synchronizationEndpointProblems(state *synchronization.EndpointState) uint64 {
+	if state == nil {
+		return 0
+	}
+	return uint64(len(state.ScanProblems)) + state.ExcludedScanProblems +
+		uint64(len(state.TransitionProblems)) + state.ExcludedTransitionProblems
+}
+
+// SynchronizationSessionHealth computes the health of a synchronization
+// session. A synchronization session is healthy if it's running, connected to
+// both endpoints, not halted, free of conflicts and problems, and hasn't
+// encountered an error.
+func SynchronizationSessionHealth(state *synchronization.State) *SessionHealth {
+	// Create the result.
+	result := &SessionHealth{
+		Kind:       SessionKindSynchronization,
+		Name:       state.Session.Name,
+		Identifier: state.Session.Identifier,
+		Status:     state.Status.Description(),
+	}
+
+	// Check for issues.
+	if state.Session.Paused {
+		result.Status = "Paused"
+		result.Issues = append(result.Issues, "paused")
+	} else {
+		switch state.Status {
+		case synchronization.Status_HaltedOnRootEmptied,
+			synchronization.Status_HaltedOnRootDeletion,
+			synchronization.Status_HaltedOnRootTypeChange:
+			result.Issues = append(result.Issues, "halted")
+		}
+		if state.AlphaState == nil || !state.AlphaState.Connected {
+			result.Issues = append(result.Issues, "alpha disconnected")
+		}
+		if state.BetaState == nil || !state.BetaState.Connected {
+			result.Issues = append(result.Issues, "beta disconnected")
+		}
+	}
+	if conflicts := uint64(len(state.Conflicts)) + state.ExcludedConflicts; conflicts > 0 {
+		result.Issues = append(result.Issues, fmt.Sprintf("%d conflict(s)", conflicts))
+	}
+	if problems := synchronizationEndpointProblems(state.AlphaState); problems > 0 {
+		result.Issues = append(result.Issues, fmt.Sprintf("%d alpha problem(s)", problems))
+	}
+	if problems := synchronizationEndpointProblems(state.BetaState); problems > 0 {
+		result.Issues = append(result.Issues, fmt.Sprintf("%d beta problem(s)", problems))
+	}
+	if state.LastError != "" {
+		result.Issues = append(result.Issues, "error: "+state.LastError)
+	}
+
+	// Done.
+	return result
+}
+
+// MissingSessionHealth computes the health of a session that's defined by the
+// project configuration but doesn't exist. Such a session is always unhealthy.
+func MissingSessionHealth(reference SessionReference) *SessionHealth {
+	return &SessionHealth{
+		Kind:   reference.Kind,
+		Name:   reference.Name,
+		Status: "Missing",
+		Issues: []string{"missing"},
+	}
+}
@@ -0,0 +1,128 @@
+package project
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+// TestForwardingSessionHealth tests ForwardingSessionHealth.
+func TestForwardingSessionHealth(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		state    *forwarding.State
+		expected []string
+	}{
+		{
+			&forwarding.State{
+				Session:          &forwarding.Session{Name: "web"},
+				Status:           forwarding.Status_ForwardingConnections,
+				SourceState:      &forwarding.EndpointState{Connected: true},
+				DestinationState: &forwarding.EndpointState{Connected: true},
+			},
+			nil,
+		},
+		{
+			&forwarding.State{
+				Session:          &forwarding.Session{Name: "web"},
+				Status:           forwarding.Status_ConnectingDestination,
+				SourceState:      &forwarding.EndpointState{Connected: true},
+				DestinationState: &forwarding.EndpointState{},
+				LastError:        "connection refused",
+			},
+			[]string{"destination disconnected", "error: connection refused"},
+		},
+		{
+			&forwarding.State{
+				Session: &forwarding.Session{Name: "web", Paused: true},
+			},
+			[]string{"paused"},
+		},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		health := ForwardingSessionHealth(testCase.state)
+		if health.Kind != SessionKindForwarding || health.Name != "web" {
+			t.Errorf("test case %d: incorrect session reference: %s session %s", i, health.Kind, health.Name)
+		}
+		if !reflect.DeepEqual(health.Issues, testCase.expected) {
+			t.Errorf("test case %d: issues do not match expected: %v != %v", i, health.Issues, testCase.expected)
+		}
+		if health.Healthy() != (len(testCase.expected) == 0) {
+			t.Errorf("test case %d: incorrect health", i)
+		}
+	}
+}
+
+// TestSynchronizationSessionHealth tests SynchronizationSessionHealth.
+func TestSynchronizationSessionHealth(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		state    *synchronization.State
+		expected []string
+	}{
+		{
+			&synchronization.State{
+				Session:    &synchronization.Session{Name: "code"},
+				Status:     synchronization.Status_Watching,
+				AlphaState: &synchronization.EndpointState{Connected: true},
+				BetaState:  &synchronization.EndpointState{Connected: true},
+			},
+			nil,
+		},
+		{
+			&synchronization.State{
+				Session:           &synchronization.Session{Name: "code"},
+				Status:            synchronization.Status_HaltedOnRootDeletion,
+				Conflicts:         []*core.Conflict{{}},
+				ExcludedConflicts: 2,
+				AlphaState: &synchronization.EndpointState{
+					Connected:            true,
+					ScanProblems:         []*core.Problem{{}},
+					ExcludedScanProblems: 1,
+				},
+				BetaState: &synchronization.EndpointState{
+					TransitionProblems: []*core.Problem{{}},
+				},
+			},
+			[]string{"halted", "beta disconnected", "3 conflict(s)", "2 alpha problem(s)", "1 beta problem(s)"},
+		},
+		{
+			&synchronization.State{
+				Session:   &synchronization.Session{Name: "code", Paused: true},
+				LastError: "failure",
+			},
+			[]string{"paused", "error: failure"},
+		},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		health := SynchronizationSessionHealth(testCase.state)
+		if health.Kind != SessionKindSynchronization || health.Name != "code" {
+			t.Errorf("test case %d: incorrect session reference: %s session %s", i, health.Kind, health.Name)
+		}
+		if !reflect.DeepEqual(health.Issues, testCase.expected) {
+			t.Errorf("test case %d: issues do not match expected: %v != %v", i, health.Issues, testCase.expected)
+		}
+		if health.Healthy() != (len(testCase.expected) == 0) {
+			t.Errorf("test case %d: incorrect health", i)
+		}
+	}
+}
+
+// TestMissingSessionHealth tests MissingSessionHealth.
+func TestMissingSessionHealth(t *testing.T) {
+	reference := SessionReference{Kind: SessionKindSynchronization, Name: "code"}
+	health := MissingSessionHealth(reference)
+	if health.Kind != reference.Kind || health.Name != reference.Name {
+		t.Errorf("incorrect session reference: %s session %s", health.Kind, health.Name)
+	}
+	if health.Healthy() {
+		t.Error("missing session treated as healthy")
+	}
+}
@@ -0,0 +1,174 @@
+package project
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// UnresolvedVariablesError is returned by LoadConfiguration when a
+// configuration references variables that are neither set nor assigned a
+// default value.
+type UnresolvedVariablesError struct {
+	// Variables are the names of the unresolved variables, in sorted order.
+	Variables []string
+}
+
+// Error implements error.Error.
+func (e *UnresolvedVariablesError) Error() string {
+	return "unresolved variables: " + strings.Join(e.Variables, ", ")
+}
+
+// variableLookup is the signature for variable lookup functions.
+type variableLookup func(string) (string, bool)
+
+// isVariableNameCharacter returns whether or not a character is valid in a
+// variable name.
+func isVariableNameCharacter(c byte, first bool) bool {
+	return c == '_' ||
+		(c >= 'a' && c <= 'z') ||
+		(c >= 'A' && c <= 'Z') ||
+		(!first && c >= '0' && c <= '9')
+}
+
+// interpolate performs variable interpolation on a string. It supports
+// ${NAME} references, ${NAME:-default} references (which use the default if
+// NAME is unset or empty), and $$ as an escape sequence for a literal $. Other
+// uses of $ are left untouched. The names of variables that can't be resolved
+// are recorded in unresolved.
+func interpolate(value string, lookup variableLookup, unresolved map[string]bool) (string, error) {
+	// Fast path for strings without any potential references.
+	if !strings.Contains(value, "$") {
+		return value, nil
+	}
+
+	// Process the string.
+	var result strings.Builder
+	for i := 0; i < len(value); i++ {
+		// Handle non-reference characters.
+		if value[i] != '$' || i+1 == len(value) {
+			result.WriteByte(value[i])
+			continue
+		}
+
+		// Handle escape sequences.
+		if value[i+1] == '$' {
+			result.WriteByte('$')
+			i++
+			continue
+		}
+
+		// Leave anything other than a braced reference untouched.
+		if value[i+1] != '{' {
+			result.WriteByte('$')
+			continue
+		}
+
+		// Locate the end of the reference.
+		end := strings.IndexByte(value[i+2:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated variable reference in \"%s\"", value)
+		}
+		reference := value[i+2 : i+2+end]
+
+		// Split the reference into its name and default value.
+		name, defaultValue, hasDefault := reference, "", false
+		if index := strings.Index(reference, ":-"); index >= 0 {
+			name, defaultValue, hasDefault = reference[:index], reference[index+2:], true
+		}
+
+		// Validate the name.
+		if name == "" {
+			return "", fmt.Errorf("empty variable name in \"%s\"", value)
+		}
+		for j := 0; j < len(name); j++ {
+			if !isVariableNameCharacter(name[j], j == 0) {
+				return "", fmt.Errorf("invalid variable name \"%s\"", name)
+			}
+		}
+
+		// Resolve the reference.
+		if resolved, ok := lookup(name); ok && (resolved != "" || !hasDefault) {
+			result.WriteString(resolved)
+		} else if hasDefault {
+			result.WriteString(defaultValue)
+		} else {
+			unresolved[name] = true
+		}
+
+		// Skip past the reference.
+		i += 2 + end
+	}
+
+	// Success.
+	return result.String(), nil
+}
+
+// resolveScalar converts an interpolated string to the boolean or numeric value
+// that YAML would have resolved it to had it appeared literally, so that
+// interpolation can be used for non-string fields. Values whose YAML resolution
+// doesn't preserve their textual representation (e.g. "0800") remain strings.
+func resolveScalar(value string) any {
+	var resolved any
+	if err := yaml.Unmarshal([]byte(value), &resolved); err != nil {
+		return value
+	}
+	switch resolved.(type) {
+	case bool, int, int64, uint64, float64:
+		if fmt.Sprint(resolved) == value {
+			return resolved
+		}
+	}
+	return value
+}
+
+// interpolateTree performs interpolation on all string values within a
+// decoded YAML tree. Map keys are not interpolated.
+func interpolateTree(tree any, lookup variableLookup, unresolved map[string]bool) (any, error) {
+	switch node := tree.(type) {
+	case string:
+		interpolated, err := interpolate(node, lookup, unresolved)
+		if err != nil {
+			return nil, err
+		} else if interpolated != node {
+			return resolveScalar(interpolated), nil
+		}
+		return node, nil
+	case map[any]any:
+		for key, value := range node {
+			interpolated, err := interpolateTree(value, lookup, unresolved)
+			if err != nil {
+				return nil, err
+			}
+			node[key] = interpolated
+		}
+		return node, nil
+	case []any:
+		for i, value := range node {
+			interpolated, err := interpolateTree(value, lookup, unresolved)
+			if err != nil {
+				return nil, err
+			}
+			node[i] = interpolated
+		}
+		return node, nil
+	default:
+		return node, nil
+	}
+}
+
+// unresolvedVariablesError converts a set of unresolved variable names to an
+// error, returning nil if the set is empty.
+func unresolvedVariablesError(unresolved map[string]bool) error {
+	if len(unresolved) == 0 {
+		return nil
+	}
+	variables := make([]string, 0, len(unresolved))
+	for name := range unresolved {
+		variables = append(variables, name)
+	}
+	sort.Strings(variables)
+	return &UnresolvedVariablesError{Variables: variables}
+}
@@ -0,0 +1,98 @@
+package project
+
+import (
+	"testing"
+)
+
+// testLookup is a variable lookup function for testing.
+func testLookup(name string) (string, bool) {
+	switch name {
+	case "HOST":
+		return "example.org", true
+	case "EMPTY":
+		return "", true
+	default:
+		return "", false
+	}
+}
+
+// TestInterpolate tests interpolate.
+func TestInterpolate(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		value      string
+		expected   string
+		unresolved string
+		fail       bool
+	}{
+		{"", "", "", false},
+		{"plain", "plain", "", false},
+		{"user@${HOST}:/path", "user@example.org:/path", "", false},
+		{"${HOST}${HOST}", "example.orgexample.org", "", false},
+		{"${MISSING:-default}", "default", "", false},
+		{"${EMPTY:-default}", "default", "", false},
+		{"${EMPTY}", "", "", false},
+		{"${HOST:-default}", "example.org", "", false},
+		{"${MISSING:-}", "", "", false},
+		{"cost: $$5", "cost: $5", "", false},
+		{"$$${HOST}", "$example.org", "", false},
+		{"$HOST and $", "$HOST and $", "", false},
+		{"${MISSING}", "", "MISSING", false},
+		{"${HOST", "", "", true},
+		{"${}", "", "", true},
+		{"${1ABC}", "", "", true},
+		{"${A-B}", "", "", true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		unresolved := make(map[string]bool)
+		result, err := interpolate(testCase.value, testLookup, unresolved)
+		if testCase.fail {
+			if err == nil {
+				t.Errorf("test case %d: interpolation succeeded unexpectedly", i)
+			}
+			continue
+		} else if err != nil {
+			t.Errorf("test case %d: interpolation failed: %v", i, err)
+			continue
+		}
+		if result != testCase.expected {
+			t.Errorf("test case %d: result mismatch: %q != %q", i, result, testCase.expected)
+		}
+		if testCase.unresolved != "" && !unresolved[testCase.unresolved] {
+			t.Errorf("test case %d: variable not recorded as unresolved", i)
+		} else if testCase.unresolved == "" && len(unresolved) > 0 {
+			t.Errorf("test case %d: unexpected unresolved variables", i)
+		}
+	}
+}
+
+// TestResolveScalar tests resolveScalar.
+func TestResolveScalar(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		value    string
+		expected any
+	}{
+		{"true", true},
+		{"false", false},
+		{"123", 123},
+		{"1.5", 1.5},
+		{"0800", "0800"},
+		{"yes", "yes"},
+		{"10MB", "10MB"},
+		{"a: b", "a: b"},
+		{"- a", "- a"},
+		{"", ""},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		if result := resolveScalar(testCase.value); result != testCase.expected {
+			t.Errorf("test case %d: result mismatch: %v (%T) != %v (%T)",
+				i, result, result, testCase.expected, testCase.expected,
+			)
+		}
+	}
+}
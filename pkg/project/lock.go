@@ -0,0 +1,55 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mutagen-io/mutagen/pkg/identifier"
+)
+
+// Lock represents the contents of a project lock file for a running project.
+// It is encoded as the project identifier, optionally followed by a line
+// containing a comma-separated list of active profiles. Lock files written by
+// older versions of Mutagen contain only the project identifier.
+type Lock struct {
+	// Identifier is the project identifier.
+	Identifier string
+	// Profiles are the profiles that were active when the project was started.
+	Profiles []string
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (l *Lock) MarshalText() ([]byte, error) {
+	if len(l.Profiles) == 0 {
+		return []byte(l.Identifier), nil
+	}
+	return []byte(l.Identifier + "\n" + strings.Join(l.Profiles, ",")), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (l *Lock) UnmarshalText(text []byte) error {
+	// Split the identifier and profiles.
+	projectIdentifier, profiles, hasProfiles := strings.Cut(string(text), "\n")
+
+	// Validate the identifier.
+	if !identifier.IsValid(projectIdentifier) {
+		return errors.New("invalid project identifier found in project lock")
+	}
+
+	// Parse and validate profiles.
+	var result []string
+	if hasProfiles {
+		result = strings.Split(profiles, ",")
+		for _, profile := range result {
+			if err := EnsureProfileNameValid(profile); err != nil {
+				return fmt.Errorf("invalid profile found in project lock: %w", err)
+			}
+		}
+	}
+
+	// Success.
+	l.Identifier = projectIdentifier
+	l.Profiles = result
+	return nil
+}
@@ -0,0 +1,79 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/identifier"
+)
+
+// TestLockRoundTrip tests project lock encoding and decoding.
+func TestLockRoundTrip(t *testing.T) {
+	// Create a project identifier.
+	projectIdentifier, err := identifier.New(identifier.PrefixProject)
+	if err != nil {
+		t.Fatal("unable to generate project identifier:", err)
+	}
+
+	// Define test cases.
+	testCases := [][]string{
+		nil,
+		{"api"},
+		{"api", "web"},
+	}
+
+	// Process test cases.
+	for i, profiles := range testCases {
+		// Encode the lock.
+		data, err := (&Lock{Identifier: projectIdentifier, Profiles: profiles}).MarshalText()
+		if err != nil {
+			t.Errorf("test case %d: unable to encode lock: %v", i, err)
+			continue
+		}
+
+		// Decode the lock.
+		lock := &Lock{}
+		if err := lock.UnmarshalText(data); err != nil {
+			t.Errorf("test case %d: unable to decode lock: %v", i, err)
+			continue
+		}
+
+		// Verify the result.
+		if lock.Identifier != projectIdentifier {
+			t.Errorf("test case %d: identifier mismatch: %s != %s", i, lock.Identifier, projectIdentifier)
+		}
+		if len(lock.Profiles) != len(profiles) {
+			t.Errorf("test case %d: profile count mismatch: %d != %d", i, len(lock.Profiles), len(profiles))
+			continue
+		}
+		for p, profile := range profiles {
+			if lock.Profiles[p] != profile {
+				t.Errorf("test case %d: profile mismatch: %s != %s", i, lock.Profiles[p], profile)
+			}
+		}
+	}
+}
+
+// TestLockUnmarshalInvalid tests that invalid project locks are rejected.
+func TestLockUnmarshalInvalid(t *testing.T) {
+	// Create a project identifier.
+	projectIdentifier, err := identifier.New(identifier.PrefixProject)
+	if err != nil {
+		t.Fatal("unable to generate project identifier:", err)
+	}
+
+	// Define test cases.
+	testCases := []string{
+		"",
+		"invalid",
+		projectIdentifier + "\n",
+		projectIdentifier + "\napi,,web",
+		projectIdentifier + "\napi web",
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		if err := (&Lock{}).UnmarshalText([]byte(testCase)); err == nil {
+			t.Errorf("test case %d: invalid lock decoded successfully", i)
+		}
+	}
+}
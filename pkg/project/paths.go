@@ -1,10 +1,52 @@
 package project
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
 const (
 	// DefaultConfigurationFileName is the name of the Mutagen project
 	// configuration file.
 	DefaultConfigurationFileName = "mutagen.yml"
-	// LockFileExtension is the extension added to a configuration file path in
-	// order to compute the corresponding lock file.
+	// LockFileExtension is the extension added to a state file base path (see
+	// StateFileBase) in order to compute the corresponding lock file.
 	LockFileExtension = ".lock"
+	// ServicesLogFileExtension is the extension added to a state file base path
+	// in order to compute the corresponding service log file.
+	ServicesLogFileExtension = ".services.log"
+	// ServicesPIDFileExtension is the extension added to a state file base path
+	// in order to compute the file that records the process identifier of the
+	// corresponding service supervisor.
+	ServicesPIDFileExtension = ".services.pid"
+	// ServicesLockFileExtension is the extension added to a state file base
+	// path in order to compute the file that's locked by the corresponding
+	// service supervisor for the duration of its execution.
+	ServicesLockFileExtension = ".services.lock"
 )
+
+const (
+	// compositionIdentifierLength is the number of hexadecimal characters from
+	// the composition hash that are used in state file base paths.
+	compositionIdentifierLength = 12
+)
+
+// StateFileBase computes the base path for the state files (such as the lock
+// file) of a project composed of the specified configuration files. For a
+// single configuration file, the base path is the configuration file path
+// itself. For multiple configuration files, the base path is the first
+// configuration file path with a suffix derived from the full (ordered) list of
+// paths, so that each combination of files acts as a distinct project. The
+// paths should be specified consistently (e.g. relative to the same directory)
+// across invocations.
+func StateFileBase(paths []string) string {
+	// Handle the single file case.
+	if len(paths) == 1 {
+		return paths[0]
+	}
+
+	// Compute the composition hash.
+	hash := sha256.Sum256([]byte(strings.Join(paths, "\n")))
+	return paths[0] + "." + hex.EncodeToString(hash[:])[:compositionIdentifierLength]
+}
@@ -0,0 +1,31 @@
+package project
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestStateFileBase tests StateFileBase.
+func TestStateFileBase(t *testing.T) {
+	// Verify that single files use their own path.
+	if base := StateFileBase([]string{"mutagen.yml"}); base != "mutagen.yml" {
+		t.Error("unexpected state file base for single file:", base)
+	}
+
+	// Verify that compositions use a suffixed path based on the first file.
+	composed := StateFileBase([]string{"mutagen.yml", "mutagen.override.yml"})
+	if !strings.HasPrefix(composed, "mutagen.yml.") || len(composed) != len("mutagen.yml.")+compositionIdentifierLength {
+		t.Error("unexpected state file base for composition:", composed)
+	}
+
+	// Verify that compositions are distinguished by their files and ordering.
+	if StateFileBase([]string{"mutagen.yml", "mutagen.override.yml"}) != composed {
+		t.Error("state file base not deterministic")
+	}
+	if StateFileBase([]string{"mutagen.yml", "mutagen.other.yml"}) == composed {
+		t.Error("distinct compositions have the same state file base")
+	}
+	if StateFileBase([]string{"mutagen.yml", "a.yml", "b.yml"}) == StateFileBase([]string{"mutagen.yml", "b.yml", "a.yml"}) {
+		t.Error("differently ordered compositions have the same state file base")
+	}
+}
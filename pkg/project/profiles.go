@@ -0,0 +1,89 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"unicode"
+)
+
+// EnsureProfileNameValid ensures that a profile name is valid. Profile names
+// must be non-empty and consist only of Unicode letters, numbers, dashes, and
+// underscores.
+func EnsureProfileNameValid(name string) error {
+	if name == "" {
+		return errors.New("empty profile name")
+	}
+	for i, r := range name {
+		if !(unicode.IsLetter(r) || unicode.IsNumber(r) || r == '-' || r == '_') {
+			return fmt.Errorf("invalid profile name character at index %d: '%c'", i, r)
+		}
+	}
+	return nil
+}
+
+// ProfilesActive determines whether or not a session with the specified
+// profiles should be active given a set of active profiles. Sessions that
+// don't specify any profiles are always active, while sessions that do are
+// only active if at least one of their profiles is active.
+func ProfilesActive(profiles, active []string) bool {
+	if len(profiles) == 0 {
+		return true
+	}
+	for _, profile := range profiles {
+		for _, a := range active {
+			if profile == a {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Profiles returns a sorted list of the unique profile names referenced by
+// sessions in the configuration.
+func (c *Configuration) Profiles() []string {
+	// Collect the profiles.
+	set := make(map[string]bool)
+	for _, session := range c.Forwarding {
+		for _, profile := range session.Profiles {
+			set[profile] = true
+		}
+	}
+	for _, session := range c.Synchronization {
+		for _, profile := range session.Profiles {
+			set[profile] = true
+		}
+	}
+
+	// Sort the profiles.
+	result := make([]string, 0, len(set))
+	for profile := range set {
+		result = append(result, profile)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// EnsureProfilesValid ensures that a list of requested profiles is valid and
+// that each profile is referenced by at least one session in the
+// configuration.
+func (c *Configuration) EnsureProfilesValid(profiles []string) error {
+	// Compute the known profiles.
+	known := make(map[string]bool)
+	for _, profile := range c.Profiles() {
+		known[profile] = true
+	}
+
+	// Validate the requested profiles.
+	for _, profile := range profiles {
+		if err := EnsureProfileNameValid(profile); err != nil {
+			return fmt.Errorf("invalid profile name (%s): %w", profile, err)
+		} else if !known[profile] {
+			return fmt.Errorf("unknown profile: %s", profile)
+		}
+	}
+
+	// Success.
+	return nil
+}
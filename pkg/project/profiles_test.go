@@ -0,0 +1,93 @@
+package project
+
+import (
+	"testing"
+)
+
+// TestEnsureProfileNameValid tests EnsureProfileNameValid.
+func TestEnsureProfileNameValid(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		name  string
+		valid bool
+	}{
+		{"", false},
+		{"api", true},
+		{"web-frontend", true},
+		{"db_2", true},
+		{"api,web", false},
+		{"api web", false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if err := EnsureProfileNameValid(testCase.name); err == nil && !testCase.valid {
+			t.Errorf("invalid profile name (%s) treated as valid", testCase.name)
+		} else if err != nil && testCase.valid {
+			t.Errorf("valid profile name (%s) treated as invalid: %v", testCase.name, err)
+		}
+	}
+}
+
+// TestProfilesActive tests ProfilesActive.
+func TestProfilesActive(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		profiles []string
+		active   []string
+		expected bool
+	}{
+		{nil, nil, true},
+		{nil, []string{"api"}, true},
+		{[]string{"api"}, nil, false},
+		{[]string{"api"}, []string{"web"}, false},
+		{[]string{"api"}, []string{"web", "api"}, true},
+		{[]string{"api", "web"}, []string{"web"}, true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		if active := ProfilesActive(testCase.profiles, testCase.active); active != testCase.expected {
+			t.Errorf("test case %d: activity mismatch: %t != %t", i, active, testCase.expected)
+		}
+	}
+}
+
+// TestConfigurationProfiles tests Configuration.Profiles and
+// Configuration.EnsureProfilesValid.
+func TestConfigurationProfiles(t *testing.T) {
+	// Create a configuration.
+	configuration := &Configuration{
+		Forwarding: map[string]ForwardingConfiguration{
+			"database": {Profiles: []string{"api"}},
+		},
+		Synchronization: map[string]SynchronizationConfiguration{
+			"api":    {Profiles: []string{"api"}},
+			"web":    {Profiles: []string{"web", "frontend"}},
+			"shared": {},
+		},
+	}
+
+	// Verify the profile listing.
+	expected := []string{"api", "frontend", "web"}
+	profiles := configuration.Profiles()
+	if len(profiles) != len(expected) {
+		t.Fatal("unexpected profiles:", profiles)
+	}
+	for i, profile := range expected {
+		if profiles[i] != profile {
+			t.Error("unexpected profile:", profiles[i])
+		}
+	}
+
+	// Verify profile validation.
+	if err := configuration.EnsureProfilesValid([]string{"api", "web"}); err != nil {
+		t.Error("valid profiles rejected:", err)
+	}
+	if err := configuration.EnsureProfilesValid([]string{"mobile"}); err == nil {
+		t.Error("unknown profile accepted")
+	}
+	if err := configuration.EnsureProfilesValid([]string{"api web"}); err == nil {
+		t.Error("invalid profile accepted")
+	}
+}
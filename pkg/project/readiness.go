@@ -0,0 +1,257 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/agent"
+	"github.com/mutagen-io/mutagen/pkg/agent/transport/docker"
+	"github.com/mutagen-io/mutagen/pkg/agent/transport/ssh"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+const (
+	// DefaultWaitTimeout is the default timeout for wait conditions.
+	DefaultWaitTimeout = time.Minute
+	// waitProbeInterval is the interval between wait condition probes.
+	waitProbeInterval = time.Second
+	// waitDialTimeout is the timeout for individual endpoint connection
+	// attempts when probing wait conditions.
+	waitDialTimeout = 5 * time.Second
+)
+
+// WaitCondition encodes a readiness condition. Exactly one of the TCP, Unix,
+// File, or Session fields must be specified.
+type WaitCondition struct {
+	// TCP is a TCP address (in host:port format) that must accept connections.
+	TCP string `yaml:"tcp,omitempty"`
+	// Unix is the path to a Unix domain socket that must accept connections.
+	Unix string `yaml:"unix,omitempty"`
+	// File is a URL, in synchronization endpoint format, identifying a file or
+	// directory that must exist. Files on SSH and Docker endpoints are probed
+	// using the test command and thus require a POSIX environment. Docker file
+	// paths must not contain whitespace.
+	File string `yaml:"file,omitempty"`
+	// Session is the name of a synchronization session that must complete a
+	// successful synchronization cycle.
+	Session string `yaml:"session,omitempty"`
+	// Timeout is the maximum amount of time to wait for the condition to be
+	// satisfied. If zero, then DefaultWaitTimeout is used.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// EnsureValid ensures that WaitCondition's invariants are respected.
+func (c *WaitCondition) EnsureValid() error {
+	// Ensure that exactly one condition is specified.
+	var specified int
+	for _, value := range []string{c.TCP, c.Unix, c.File, c.Session} {
+		if value != "" {
+			specified++
+		}
+	}
+	if specified != 1 {
+		return errors.New("exactly one of tcp, unix, file, or session must be specified")
+	}
+
+	// Validate the condition.
+	if c.TCP != "" {
+		if _, _, err := net.SplitHostPort(c.TCP); err != nil {
+			return fmt.Errorf("invalid TCP address: %w", err)
+		}
+	} else if c.File != "" {
+		u, err := url.Parse(c.File, url.Kind_Synchronization, true)
+		if err != nil {
+			return fmt.Errorf("invalid file URL: %w", err)
+		} else if u.Protocol == url.Protocol_Docker && strings.ContainsAny(u.Path, " \t\n") {
+			return errors.New("Docker file paths must not contain whitespace")
+		}
+	} else if c.Session != "" {
+		if err := selection.EnsureNameValid(c.Session); err != nil {
+			return fmt.Errorf("invalid session name: %w", err)
+		}
+	}
+
+	// Validate the timeout. YAML decodes bare integers as nanosecond counts,
+	// so we reject sub-second timeouts to catch missing units.
+	if c.Timeout < 0 {
+		return errors.New("negative timeout")
+	} else if c.Timeout > 0 && c.Timeout < time.Second {
+		return errors.New("timeout less than one second (durations require units, e.g. 30s)")
+	}
+
+	// Success.
+	return nil
+}
+
+// EffectiveTimeout returns the timeout for the condition, taking defaults into
+// account.
+func (c *WaitCondition) EffectiveTimeout() time.Duration {
+	if c.Timeout == 0 {
+		return DefaultWaitTimeout
+	}
+	return c.Timeout
+}
+
+// Description returns a human-readable description of the condition.
+func (c *WaitCondition) Description() string {
+	if c.TCP != "" {
+		return fmt.Sprintf("TCP endpoint %s", c.TCP)
+	} else if c.Unix != "" {
+		return fmt.Sprintf("Unix domain socket %s", c.Unix)
+	} else if c.File != "" {
+		return fmt.Sprintf("file %s", c.File)
+	}
+	return fmt.Sprintf("synchronization session %s", c.Session)
+}
+
+// probeEndpoint attempts to connect to a network endpoint.
+func probeEndpoint(ctx context.Context, network, address string) error {
+	dialer := &net.Dialer{Timeout: waitDialTimeout}
+	connection, err := dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return err
+	}
+	connection.Close()
+	return nil
+}
+
+// shellQuote quotes a value for use as a single word in a POSIX shell command.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// fileProbeCommand generates the command used to probe for the existence of
+// the file identified by a remote URL. Commands for SSH endpoints are
+// interpreted by the remote shell, so the path is quoted, though any leading
+// home directory reference is left unquoted so that it's still expanded.
+// Commands for Docker endpoints are split on spaces and executed without a
+// shell, so the path is used as-is (paths containing whitespace are rejected
+// during validation).
+func fileProbeCommand(u *url.URL) string {
+	if u.Protocol == url.Protocol_Docker {
+		return "test -e " + u.Path
+	}
+	path := u.Path
+	var home string
+	if path == "~" {
+		return "test -e ~"
+	} else if strings.HasPrefix(path, "~/") {
+		home, path = "~/", path[2:]
+	}
+	return "test -e " + home + shellQuote(path)
+}
+
+// runProbeCommand runs a probe command, killing it if the context is cancelled
+// before it completes.
+func runProbeCommand(ctx context.Context, process *exec.Cmd) error {
+	// Start the process.
+	if err := process.Start(); err != nil {
+		return err
+	}
+
+	// Wait for the process to exit in the background.
+	done := make(chan error, 1)
+	go func() {
+		done <- process.Wait()
+	}()
+
+	// Wait for completion or cancellation.
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		process.Process.Kill()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// probeFile determines whether or not the file identified by a URL exists.
+func probeFile(ctx context.Context, u *url.URL) error {
+	// Handle local files.
+	if u.Protocol == url.Protocol_Local {
+		_, err := os.Stat(u.Path)
+		return err
+	}
+
+	// Create a transport for the remote.
+	var transport agent.Transport
+	var err error
+	switch u.Protocol {
+	case url.Protocol_SSH:
+		transport, err = ssh.NewTransport(u.User, u.Host, uint16(u.Port), "")
+	case url.Protocol_Docker:
+		transport, err = docker.NewTransport(u.Host, u.User, u.Environment, u.Parameters, "")
+	default:
+		return fmt.Errorf("unsupported protocol: %s", u.Protocol)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to create transport: %w", err)
+	}
+
+	// Probe the file.
+	process, err := transport.Command(fileProbeCommand(u))
+	if err != nil {
+		return fmt.Errorf("unable to create probe command: %w", err)
+	} else if err = runProbeCommand(ctx, process); err != nil {
+		return fmt.Errorf("file not found: %w", err)
+	}
+	return nil
+}
+
+// Probe checks whether or not a TCP, Unix, or File condition is currently
+// satisfied, returning an error describing why it isn't. It can't be used with
+// Session conditions, which must be checked using the daemon. Probing is
+// aborted if the context is cancelled.
+func (c *WaitCondition) Probe(ctx context.Context) error {
+	if c.TCP != "" {
+		return probeEndpoint(ctx, "tcp", c.TCP)
+	} else if c.Unix != "" {
+		return probeEndpoint(ctx, "unix", c.Unix)
+	} else if c.File != "" {
+		u, err := url.Parse(c.File, url.Kind_Synchronization, true)
+		if err != nil {
+			return fmt.Errorf("invalid file URL: %w", err)
+		}
+		return probeFile(ctx, u)
+	}
+	return errors.New("condition can't be probed directly")
+}
+
+// Wait repeatedly invokes a probe function until it succeeds, the context is
+// cancelled, or the timeout elapses. The probe function is provided with a
+// context that's cancelled when the timeout elapses, and it should abort if
+// that occurs. On failure, the most recent probe error is included in the
+// returned error.
+func Wait(ctx context.Context, timeout time.Duration, probe func(context.Context) error) error {
+	// Create a timeout context.
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Create a ticker to regulate probing.
+	ticker := time.NewTicker(waitProbeInterval)
+	defer ticker.Stop()
+
+	// Loop until the probe succeeds or we're cancelled.
+	for {
+		err := probe(ctx)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("timed out after %s: %w", timeout, err)
+			}
+			return fmt.Errorf("wait cancelled: %w", err)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// TestWaitConditionEnsureValid tests WaitCondition.EnsureValid.
+func TestWaitConditionEnsureValid(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		condition WaitCondition
+		valid     bool
+	}{
+		{WaitCondition{}, false},
+		{WaitCondition{TCP: "localhost:5432"}, true},
+		{WaitCondition{TCP: "localhost"}, false},
+		{WaitCondition{Unix: "/var/run/app.sock", Timeout: 30 * time.Second}, true},
+		{WaitCondition{File: "/tmp/ready"}, true},
+		{WaitCondition{File: "docker://web/app/ready"}, true},
+		{WaitCondition{File: "docker://web/app/is ready"}, false},
+		{WaitCondition{File: "user@host:/app/is ready"}, true},
+		{WaitCondition{Session: "code"}, true},
+		{WaitCondition{Session: "defaults"}, false},
+		{WaitCondition{TCP: "localhost:5432", Session: "code"}, false},
+		{WaitCondition{TCP: "localhost:5432", Timeout: -time.Second}, false},
+		{WaitCondition{TCP: "localhost:5432", Timeout: 30}, false},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		if err := testCase.condition.EnsureValid(); err == nil && !testCase.valid {
+			t.Errorf("test case %d: invalid condition treated as valid", i)
+		} else if err != nil && testCase.valid {
+			t.Errorf("test case %d: valid condition treated as invalid: %v", i, err)
+		}
+	}
+}
+
+// TestFileProbeCommand tests fileProbeCommand.
+func TestFileProbeCommand(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		url      *url.URL
+		expected string
+	}{
+		{&url.URL{Protocol: url.Protocol_SSH, Path: "/app/ready"}, `test -e '/app/ready'`},
+		{&url.URL{Protocol: url.Protocol_SSH, Path: "/app/is ready"}, `test -e '/app/is ready'`},
+		{&url.URL{Protocol: url.Protocol_SSH, Path: "/app/ready; rm -rf /"}, `test -e '/app/ready; rm -rf /'`},
+		{&url.URL{Protocol: url.Protocol_SSH, Path: "/app/$HOME/*"}, `test -e '/app/$HOME/*'`},
+		{&url.URL{Protocol: url.Protocol_SSH, Path: "/app/it's ready"}, `test -e '/app/it'\''s ready'`},
+		{&url.URL{Protocol: url.Protocol_SSH, Path: "~"}, `test -e ~`},
+		{&url.URL{Protocol: url.Protocol_SSH, Path: "~/app/ready"}, `test -e ~/'app/ready'`},
+		{&url.URL{Protocol: url.Protocol_SSH, Path: "~user/ready"}, `test -e '~user/ready'`},
+		{&url.URL{Protocol: url.Protocol_Docker, Path: "/app/ready;id"}, `test -e /app/ready;id`},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		if command := fileProbeCommand(testCase.url); command != testCase.expected {
+			t.Errorf("test case %d: command does not match expected: %s != %s",
+				i, command, testCase.expected,
+			)
+		}
+	}
+}
+
+// TestRunProbeCommandCancellation tests that runProbeCommand terminates probe
+// commands when its context is cancelled.
+func TestRunProbeCommandCancellation(t *testing.T) {
+	// Skip this test on Windows, where sleep isn't available.
+	if runtime.GOOS == "windows" {
+		t.Skip()
+	}
+
+	// Run a long-running command with a short-lived context.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := runProbeCommand(ctx, exec.Command("sleep", "60")); !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("unexpected probe error:", err)
+	} else if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Error("probe command not terminated on cancellation:", elapsed)
+	}
+}
+
+// TestWaitConditionEffectiveTimeout tests WaitCondition.EffectiveTimeout.
+func TestWaitConditionEffectiveTimeout(t *testing.T) {
+	if timeout := (&WaitCondition{}).EffectiveTimeout(); timeout != DefaultWaitTimeout {
+		t.Error("unexpected default timeout:", timeout)
+	}
+	if timeout := (&WaitCondition{Timeout: time.Hour}).EffectiveTimeout(); timeout != time.Hour {
+		t.Error("unexpected explicit timeout:", timeout)
+	}
+}
+
+// TestWaitConditionProbe tests WaitCondition.Probe for local conditions.
+func TestWaitConditionProbe(t *testing.T) {
+	// Create a TCP listener.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("unable to create listener:", err)
+	}
+	address := listener.Addr().String()
+
+	// Verify that the TCP condition is satisfied while the listener is open
+	// and unsatisfied once it's closed.
+	condition := &WaitCondition{TCP: address}
+	if err := condition.Probe(context.Background()); err != nil {
+		t.Error("TCP condition not satisfied:", err)
+	}
+	listener.Close()
+	if err := condition.Probe(context.Background()); err == nil {
+		t.Error("TCP condition satisfied after listener closure")
+	}
+
+	// Verify file conditions.
+	path := filepath.Join(t.TempDir(), "ready")
+	condition = &WaitCondition{File: path}
+	if err := condition.Probe(context.Background()); err == nil {
+		t.Error("file condition satisfied before file creation")
+	}
+	if err := os.WriteFile(path, nil, 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+	if err := condition.Probe(context.Background()); err != nil {
+		t.Error("file condition not satisfied:", err)
+	}
+
+	// Verify that session conditions can't be probed directly.
+	if err := (&WaitCondition{Session: "code"}).Probe(context.Background()); err == nil {
+		t.Error("session condition probed directly")
+	}
+}
+
+// TestWait tests Wait.
+func TestWait(t *testing.T) {
+	// Verify that a probe that eventually succeeds is waited for.
+	var attempts int
+	err := Wait(context.Background(), 10*time.Second, func(_ context.Context) error {
+		if attempts++; attempts < 2 {
+			return errors.New("not ready")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Error("wait failed:", err)
+	}
+
+	// Verify that timeouts include the most recent probe error.
+	probeErr := errors.New("not ready")
+	err = Wait(context.Background(), 100*time.Millisecond, func(_ context.Context) error {
+		return probeErr
+	})
+	if !errors.Is(err, probeErr) {
+		t.Error("unexpected timeout error:", err)
+	}
+
+	// Verify that blocking probes are cancelled when the timeout elapses.
+	start := time.Now()
+	err = Wait(context.Background(), 100*time.Millisecond, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if err == nil {
+		t.Error("blocking probe treated as successful")
+	} else if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Error("blocking probe not cancelled:", elapsed)
+	}
+}
@@ -0,0 +1,91 @@
+package project
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// labelsEqual determines whether or not two label sets are equal.
+func labelsEqual(first, second map[string]string) bool {
+	if len(first) != len(second) {
+		return false
+	}
+	for key, value := range first {
+		if other, ok := second[key]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
+
+// urlsEqual determines whether or not two URL lists are equal.
+func urlsEqual(first, second []*url.URL) bool {
+	if len(first) != len(second) {
+		return false
+	}
+	for i, u := range first {
+		if !proto.Equal(u, second[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// forwardingConfigurationsEqual determines whether or not two forwarding
+// configurations are equal, treating nil configurations as empty.
+func forwardingConfigurationsEqual(first, second *forwarding.Configuration) bool {
+	if first == nil {
+		first = &forwarding.Configuration{}
+	}
+	if second == nil {
+		second = &forwarding.Configuration{}
+	}
+	return proto.Equal(first, second)
+}
+
+// synchronizationConfigurationsEqual determines whether or not two
+// synchronization configurations are equal, treating nil configurations as
+// empty.
+func synchronizationConfigurationsEqual(first, second *synchronization.Configuration) bool {
+	if first == nil {
+		first = &synchronization.Configuration{}
+	}
+	if second == nil {
+		second = &synchronization.Configuration{}
+	}
+	return proto.Equal(first, second)
+}
+
+// ForwardingSessionMatches determines whether or not an existing forwarding
+// session matches a creation specification, i.e. whether or not the session's
+// URLs, configurations, and labels are identical to those that would result
+// from creating a session with the specification. The session name and paused
+// state are not considered.
+func ForwardingSessionMatches(session *forwarding.Session, specification *forwardingsvc.CreationSpecification) bool {
+	return proto.Equal(session.Source, specification.Source) &&
+		proto.Equal(session.Destination, specification.Destination) &&
+		urlsEqual(session.AdditionalDestinations, specification.AdditionalDestinations) &&
+		forwardingConfigurationsEqual(session.Configuration, specification.Configuration) &&
+		forwardingConfigurationsEqual(session.ConfigurationSource, specification.ConfigurationSource) &&
+		forwardingConfigurationsEqual(session.ConfigurationDestination, specification.ConfigurationDestination) &&
+		labelsEqual(session.Labels, specification.Labels)
+}
+
+// SynchronizationSessionMatches determines whether or not an existing
+// synchronization session matches a creation specification, i.e. whether or
+// not the session's URLs, configurations, and labels are identical to those
+// that would result from creating a session with the specification. The
+// session name and paused state are not considered.
+func SynchronizationSessionMatches(session *synchronization.Session, specification *synchronizationsvc.CreationSpecification) bool {
+	return proto.Equal(session.Alpha, specification.Alpha) &&
+		proto.Equal(session.Beta, specification.Beta) &&
+		synchronizationConfigurationsEqual(session.Configuration, specification.Configuration) &&
+		synchronizationConfigurationsEqual(session.ConfigurationAlpha, specification.ConfigurationAlpha) &&
+		synchronizationConfigurationsEqual(session.ConfigurationBeta, specification.ConfigurationBeta) &&
+		labelsEqual(session.Labels, specification.Labels)
+}
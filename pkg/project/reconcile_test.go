@@ -0,0 +1,112 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// mustParseURL is a test helper that parses a URL.
+func mustParseURL(t *testing.T, raw string, kind url.Kind, first bool) *url.URL {
+	t.Helper()
+	result, err := url.Parse(raw, kind, first)
+	if err != nil {
+		t.Fatal("unable to parse URL:", err)
+	}
+	return result
+}
+
+// TestForwardingSessionMatches tests ForwardingSessionMatches.
+func TestForwardingSessionMatches(t *testing.T) {
+	// Create a session.
+	session := &forwarding.Session{
+		Source:        mustParseURL(t, "tcp:localhost:8080", url.Kind_Forwarding, true),
+		Destination:   mustParseURL(t, "tcp:localhost:80", url.Kind_Forwarding, false),
+		Configuration: &forwarding.Configuration{SocketOverwriteMode: forwarding.SocketOverwriteMode_SocketOverwriteModeOverwrite},
+		Labels:        map[string]string{LabelKey: "project"},
+	}
+
+	// Verify that an equivalent specification matches, including with empty
+	// (rather than nil) endpoint-specific configurations.
+	specification := &forwardingsvc.CreationSpecification{
+		Source:                   mustParseURL(t, "tcp:localhost:8080", url.Kind_Forwarding, true),
+		Destination:              mustParseURL(t, "tcp:localhost:80", url.Kind_Forwarding, false),
+		Configuration:            &forwarding.Configuration{SocketOverwriteMode: forwarding.SocketOverwriteMode_SocketOverwriteModeOverwrite},
+		ConfigurationSource:      &forwarding.Configuration{},
+		ConfigurationDestination: &forwarding.Configuration{},
+		Name:                     "web",
+		Labels:                   map[string]string{LabelKey: "project"},
+		Paused:                   true,
+	}
+	if !ForwardingSessionMatches(session, specification) {
+		t.Error("equivalent specification does not match")
+	}
+
+	// Verify that URL changes are detected.
+	specification.Destination = mustParseURL(t, "tcp:localhost:81", url.Kind_Forwarding, false)
+	if ForwardingSessionMatches(session, specification) {
+		t.Error("URL change not detected")
+	}
+	specification.Destination = session.Destination
+	specification.AdditionalDestinations = []*url.URL{mustParseURL(t, "tcp:localhost:82", url.Kind_Forwarding, false)}
+	if ForwardingSessionMatches(session, specification) {
+		t.Error("additional destination change not detected")
+	}
+	specification.AdditionalDestinations = nil
+
+	// Verify that configuration changes are detected.
+	specification.Configuration = &forwarding.Configuration{}
+	if ForwardingSessionMatches(session, specification) {
+		t.Error("configuration change not detected")
+	}
+	specification.Configuration = session.Configuration
+
+	// Verify that label changes are detected.
+	specification.Labels = map[string]string{LabelKey: "project", "tier": "web"}
+	if ForwardingSessionMatches(session, specification) {
+		t.Error("label change not detected")
+	}
+}
+
+// TestSynchronizationSessionMatches tests SynchronizationSessionMatches.
+func TestSynchronizationSessionMatches(t *testing.T) {
+	// Create a session.
+	session := &synchronization.Session{
+		Alpha:         mustParseURL(t, "/tmp/alpha", url.Kind_Synchronization, true),
+		Beta:          mustParseURL(t, "user@example.org:/tmp/beta", url.Kind_Synchronization, false),
+		Configuration: &synchronization.Configuration{SynchronizationMode: core.SynchronizationMode_SynchronizationModeOneWaySafe},
+		Labels:        map[string]string{LabelKey: "project"},
+	}
+
+	// Verify that an equivalent specification matches.
+	specification := &synchronizationsvc.CreationSpecification{
+		Alpha:              mustParseURL(t, "/tmp/alpha", url.Kind_Synchronization, true),
+		Beta:               mustParseURL(t, "user@example.org:/tmp/beta", url.Kind_Synchronization, false),
+		Configuration:      &synchronization.Configuration{SynchronizationMode: core.SynchronizationMode_SynchronizationModeOneWaySafe},
+		ConfigurationAlpha: &synchronization.Configuration{},
+		ConfigurationBeta:  &synchronization.Configuration{},
+		Name:               "code",
+		Labels:             map[string]string{LabelKey: "project"},
+	}
+	if !SynchronizationSessionMatches(session, specification) {
+		t.Error("equivalent specification does not match")
+	}
+
+	// Verify that URL changes are detected.
+	specification.Beta = mustParseURL(t, "user@example.org:/tmp/other", url.Kind_Synchronization, false)
+	if SynchronizationSessionMatches(session, specification) {
+		t.Error("URL change not detected")
+	}
+	specification.Beta = session.Beta
+
+	// Verify that endpoint-specific configuration changes are detected.
+	specification.ConfigurationBeta = &synchronization.Configuration{DefaultFileMode: 0644}
+	if SynchronizationSessionMatches(session, specification) {
+		t.Error("configuration change not detected")
+	}
+}
@@ -0,0 +1,139 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// RestartPolicy is a custom YAML type that encodes the restart policy for a
+// project service, including a lack of specification.
+type RestartPolicy uint8
+
+const (
+	// RestartPolicyDefault indicates that the restart policy is unspecified,
+	// in which case RestartPolicyOnFailure is used.
+	RestartPolicyDefault RestartPolicy = iota
+	// RestartPolicyNo indicates that a service should never be restarted.
+	RestartPolicyNo
+	// RestartPolicyOnFailure indicates that a service should be restarted if
+	// it exits with a failure status.
+	RestartPolicyOnFailure
+	// RestartPolicyAlways indicates that a service should always be restarted
+	// when it exits.
+	RestartPolicyAlways
+)
+
+// IsDefault indicates whether or not the restart policy is
+// RestartPolicyDefault.
+func (p RestartPolicy) IsDefault() bool {
+	return p == RestartPolicyDefault
+}
+
+// ShouldRestart determines whether or not a service should be restarted given
+// whether or not its most recent run failed.
+func (p RestartPolicy) ShouldRestart(failed bool) bool {
+	switch p {
+	case RestartPolicyDefault:
+		return failed
+	case RestartPolicyNo:
+		return false
+	case RestartPolicyOnFailure:
+		return failed
+	case RestartPolicyAlways:
+		return true
+	default:
+		panic("unhandled restart policy")
+	}
+}
+
+// MarshalYAML implements Marshaler.MarshalYAML.
+func (p RestartPolicy) MarshalYAML() (any, error) {
+	switch p {
+	case RestartPolicyDefault:
+		return nil, nil
+	case RestartPolicyNo:
+		return "no", nil
+	case RestartPolicyOnFailure:
+		return "on-failure", nil
+	case RestartPolicyAlways:
+		return "always", nil
+	default:
+		return nil, errors.New("unhandled restart policy")
+	}
+}
+
+// UnmarshalYAML implements Unmarshaler.UnmarshalYAML.
+func (p *RestartPolicy) UnmarshalYAML(unmarshal func(any) error) error {
+	// Call the underlying unmarshaling function. We decode into a string so
+	// that an unquoted "no" (which YAML would otherwise treat as a boolean) is
+	// handled correctly.
+	var policy string
+	if err := unmarshal(&policy); err != nil {
+		return err
+	}
+
+	// Set the policy.
+	switch policy {
+	case "no":
+		*p = RestartPolicyNo
+	case "on-failure":
+		*p = RestartPolicyOnFailure
+	case "always":
+		*p = RestartPolicyAlways
+	default:
+		return fmt.Errorf("unknown restart policy: %s", policy)
+	}
+
+	// Success.
+	return nil
+}
+
+const (
+	// DefaultServiceRestartDelay is the default delay before restarting a
+	// project service.
+	DefaultServiceRestartDelay = time.Second
+)
+
+// ServiceConfiguration encodes a long-running project service.
+type ServiceConfiguration struct {
+	// Command is the command to run for the service. It is executed using the
+	// system shell.
+	Command string `yaml:"command"`
+	// Restart is the restart policy for the service.
+	Restart RestartPolicy `yaml:"restart,omitempty"`
+	// RestartDelay is the delay before restarting the service. If zero, then
+	// DefaultServiceRestartDelay is used.
+	RestartDelay time.Duration `yaml:"restartDelay,omitempty"`
+	// MaximumRestarts is the maximum number of times that the service will be
+	// restarted. If zero, then the number of restarts is unlimited.
+	MaximumRestarts uint `yaml:"maxRestarts,omitempty"`
+}
+
+// EnsureValid ensures that ServiceConfiguration's invariants are respected.
+func (c *ServiceConfiguration) EnsureValid() error {
+	// Ensure that a command has been specified.
+	if c.Command == "" {
+		return errors.New("empty command")
+	}
+
+	// Validate the restart delay. YAML decodes bare integers as nanosecond
+	// counts, so we reject sub-millisecond delays to catch missing units.
+	if c.RestartDelay < 0 {
+		return errors.New("negative restart delay")
+	} else if c.RestartDelay > 0 && c.RestartDelay < time.Millisecond {
+		return errors.New("restart delay less than one millisecond (durations require units, e.g. 5s)")
+	}
+
+	// Success.
+	return nil
+}
+
+// EffectiveRestartDelay returns the restart delay for the service, taking
+// defaults into account.
+func (c *ServiceConfiguration) EffectiveRestartDelay() time.Duration {
+	if c.RestartDelay == 0 {
+		return DefaultServiceRestartDelay
+	}
+	return c.RestartDelay
+}
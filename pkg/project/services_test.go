@@ -0,0 +1,107 @@
+package project
+
+import (
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// TestRestartPolicyUnmarshal tests RestartPolicy YAML decoding.
+func TestRestartPolicyUnmarshal(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		text     string
+		expected RestartPolicy
+		fail     bool
+	}{
+		{"restart: no", RestartPolicyNo, false},
+		{"restart: \"no\"", RestartPolicyNo, false},
+		{"restart: on-failure", RestartPolicyOnFailure, false},
+		{"restart: always", RestartPolicyAlways, false},
+		{"restart: sometimes", RestartPolicyDefault, true},
+		{"restart: [always]", RestartPolicyDefault, true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		var value struct {
+			Restart RestartPolicy `yaml:"restart"`
+		}
+		if err := yaml.Unmarshal([]byte(testCase.text), &value); err != nil {
+			if !testCase.fail {
+				t.Errorf("test case %d: unable to decode restart policy: %v", i, err)
+			}
+		} else if testCase.fail {
+			t.Errorf("test case %d: invalid restart policy decoded successfully", i)
+		} else if value.Restart != testCase.expected {
+			t.Errorf("test case %d: restart policy mismatch: %d != %d", i, value.Restart, testCase.expected)
+		}
+	}
+}
+
+// TestRestartPolicyRoundTrip tests RestartPolicy YAML encoding and decoding.
+func TestRestartPolicyRoundTrip(t *testing.T) {
+	for _, policy := range []RestartPolicy{RestartPolicyNo, RestartPolicyOnFailure, RestartPolicyAlways} {
+		data, err := yaml.Marshal(&ServiceConfiguration{Command: "true", Restart: policy})
+		if err != nil {
+			t.Errorf("unable to encode restart policy %d: %v", policy, err)
+			continue
+		}
+		var decoded ServiceConfiguration
+		if err := yaml.UnmarshalStrict(data, &decoded); err != nil {
+			t.Errorf("unable to decode restart policy %d: %v", policy, err)
+		} else if decoded.Restart != policy {
+			t.Errorf("restart policy mismatch: %d != %d", decoded.Restart, policy)
+		}
+	}
+}
+
+// TestRestartPolicyShouldRestart tests RestartPolicy.ShouldRestart.
+func TestRestartPolicyShouldRestart(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		policy           RestartPolicy
+		restartOnSuccess bool
+		restartOnFailure bool
+	}{
+		{RestartPolicyDefault, false, true},
+		{RestartPolicyNo, false, false},
+		{RestartPolicyOnFailure, false, true},
+		{RestartPolicyAlways, true, true},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if testCase.policy.ShouldRestart(false) != testCase.restartOnSuccess {
+			t.Errorf("unexpected restart-on-success behavior for policy %d", testCase.policy)
+		}
+		if testCase.policy.ShouldRestart(true) != testCase.restartOnFailure {
+			t.Errorf("unexpected restart-on-failure behavior for policy %d", testCase.policy)
+		}
+	}
+}
+
+// TestServiceConfigurationEnsureValid tests ServiceConfiguration.EnsureValid.
+func TestServiceConfigurationEnsureValid(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		service ServiceConfiguration
+		valid   bool
+	}{
+		{ServiceConfiguration{}, false},
+		{ServiceConfiguration{Command: "npm run dev"}, true},
+		{ServiceConfiguration{Command: "npm run dev", RestartDelay: 5 * time.Second}, true},
+		{ServiceConfiguration{Command: "npm run dev", RestartDelay: -time.Second}, false},
+		{ServiceConfiguration{Command: "npm run dev", RestartDelay: 5}, false},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		if err := testCase.service.EnsureValid(); err == nil && !testCase.valid {
+			t.Errorf("test case %d: invalid service treated as valid", i)
+		} else if err != nil && testCase.valid {
+			t.Errorf("test case %d: valid service treated as invalid: %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package project
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// serviceStopTimeout is the amount of time that a service is given to exit
+	// after being asked to terminate before it is forcibly killed.
+	serviceStopTimeout = 10 * time.Second
+)
+
+// prefixWriter is an io.Writer that prefixes each line written to an
+// underlying writer. Writes to the underlying writer are serialized using a
+// shared lock so that lines from different writers aren't interleaved.
+type prefixWriter struct {
+	// lock serializes writes to output.
+	lock *sync.Mutex
+	// output is the underlying writer.
+	output io.Writer
+	// prefix is the line prefix.
+	prefix string
+	// buffer stores any incomplete line.
+	buffer []byte
+}
+
+// Write implements io.Writer.Write.
+func (w *prefixWriter) Write(data []byte) (int, error) {
+	// Add the data to the buffer.
+	w.buffer = append(w.buffer, data...)
+
+	// Write out any complete lines.
+	for {
+		index := bytes.IndexByte(w.buffer, '\n')
+		if index < 0 {
+			break
+		}
+		w.writeLine(string(w.buffer[:index]))
+		w.buffer = w.buffer[index+1:]
+	}
+
+	// Success.
+	return len(data), nil
+}
+
+// writeLine writes a single prefixed line to the underlying writer. Write
+// errors are ignored, since there's nowhere to report them.
+func (w *prefixWriter) writeLine(line string) {
+	w.lock.Lock()
+	fmt.Fprintf(w.output, "%s%s\n", w.prefix, strings.TrimSuffix(line, "\r"))
+	w.lock.Unlock()
+}
+
+// flush writes out any incomplete line.
+func (w *prefixWriter) flush() {
+	if len(w.buffer) > 0 {
+		w.writeLine(string(w.buffer))
+		w.buffer = nil
+	}
+}
+
+// printf writes a formatted supervisor message.
+func (w *prefixWriter) printf(format string, arguments ...any) {
+	w.writeLine(fmt.Sprintf(format, arguments...))
+}
+
+// superviseService runs a single service until it exits without requiring a
+// restart or until the context is cancelled.
+func superviseService(ctx context.Context, service ServiceConfiguration, output *prefixWriter) {
+	for restarts := uint(0); ; restarts++ {
+		// Start the service.
+		process := serviceCommand(service.Command)
+		process.Stdout = output
+		process.Stderr = output
+		var err error
+		if err = process.Start(); err != nil {
+			output.printf("unable to start service: %v", err)
+		} else {
+			// Wait for the service to exit. If we're cancelled, then ask it to
+			// terminate and forcibly kill it if it doesn't exit in time.
+			done := make(chan error, 1)
+			go func() {
+				done <- process.Wait()
+			}()
+			select {
+			case err = <-done:
+			case <-ctx.Done():
+				terminateServiceProcess(process.Process)
+				select {
+				case <-done:
+				case <-time.After(serviceStopTimeout):
+					killServiceProcess(process.Process)
+					<-done
+				}
+				output.flush()
+				output.printf("service stopped")
+				return
+			}
+			output.flush()
+			if err != nil {
+				output.printf("service exited with error: %v", err)
+			} else {
+				output.printf("service exited successfully")
+			}
+		}
+
+		// Determine whether or not to restart the service.
+		if !service.Restart.ShouldRestart(err != nil) {
+			return
+		} else if service.MaximumRestarts != 0 && restarts >= service.MaximumRestarts {
+			output.printf("maximum restart count (%d) reached", service.MaximumRestarts)
+			return
+		}
+
+		// Wait before restarting the service.
+		delay := service.EffectiveRestartDelay()
+		output.printf("restarting service in %s", delay)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Supervise runs project services until they exit (subject to their restart
+// policies) or until the context is cancelled, in which case any running
+// services are stopped. Services are executed using the system shell, and
+// their output (along with supervisor status messages) is written to output,
+// with each line prefixed by the service name. The services must be valid.
+func Supervise(ctx context.Context, services map[string]ServiceConfiguration, output io.Writer) {
+	// Compute the prefix width so that output is aligned.
+	var names []string
+	var width int
+	for name := range services {
+		names = append(names, name)
+		if len(name) > width {
+			width = len(name)
+		}
+	}
+	sort.Strings(names)
+
+	// Start services.
+	lock := &sync.Mutex{}
+	var group sync.WaitGroup
+	for _, name := range names {
+		writer := &prefixWriter{
+			lock:   lock,
+			output: output,
+			prefix: fmt.Sprintf("%-*s | ", width, name),
+		}
+		group.Add(1)
+		go func(service ServiceConfiguration) {
+			superviseService(ctx, service, writer)
+			group.Done()
+		}(services[name])
+	}
+
+	// Wait for services to exit.
+	group.Wait()
+}
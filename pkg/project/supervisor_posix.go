@@ -0,0 +1,31 @@
+//go:build !windows
+
+package project
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// serviceCommand creates a process that runs a service command using the
+// system shell. On POSIX systems, this is /bin/sh. The process is placed in its
+// own process group so that any processes that it spawns can be signaled
+// together.
+func serviceCommand(command string) *exec.Cmd {
+	process := exec.Command("/bin/sh", "-c", command)
+	process.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	return process
+}
+
+// terminateServiceProcess requests that a service process and its process
+// group terminate.
+func terminateServiceProcess(process *os.Process) {
+	syscall.Kill(-process.Pid, syscall.SIGTERM)
+}
+
+// killServiceProcess forcibly terminates a service process and its process
+// group.
+func killServiceProcess(process *os.Process) {
+	syscall.Kill(-process.Pid, syscall.SIGKILL)
+}
@@ -0,0 +1,93 @@
+package project
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestPrefixWriter tests prefixWriter.
+func TestPrefixWriter(t *testing.T) {
+	// Create the writer.
+	output := &bytes.Buffer{}
+	writer := &prefixWriter{lock: &sync.Mutex{}, output: output, prefix: "web | "}
+
+	// Perform writes, including partial lines.
+	writer.Write([]byte("first\nsec"))
+	writer.Write([]byte("ond\r\nthi"))
+	writer.flush()
+
+	// Verify the output.
+	expected := "web | first\nweb | second\nweb | thi\n"
+	if output.String() != expected {
+		t.Errorf("output mismatch: %q != %q", output.String(), expected)
+	}
+}
+
+// TestSupervise tests Supervise with services that exit on their own.
+func TestSupervise(t *testing.T) {
+	// Create services.
+	services := map[string]ServiceConfiguration{
+		"hello": {
+			Command: "echo hello",
+			Restart: RestartPolicyNo,
+		},
+		"failing": {
+			Command:         "exit 1",
+			RestartDelay:    time.Millisecond,
+			MaximumRestarts: 2,
+		},
+	}
+
+	// Run the services.
+	output := &bytes.Buffer{}
+	Supervise(context.Background(), services, output)
+
+	// Verify the output.
+	result := output.String()
+	if !strings.Contains(result, "hello   | hello\n") {
+		t.Error("service output not prefixed:", result)
+	}
+	if count := strings.Count(result, "failing | service exited with error"); count != 3 {
+		t.Error("unexpected number of failing service runs:", count)
+	}
+	if !strings.Contains(result, "failing | maximum restart count (2) reached\n") {
+		t.Error("maximum restart count not reported:", result)
+	}
+}
+
+// TestSuperviseCancellation tests that Supervise stops services when
+// cancelled.
+func TestSuperviseCancellation(t *testing.T) {
+	// Skip this test on Windows, which lacks a standard sleep command.
+	if runtime.GOOS == "windows" {
+		t.Skip()
+	}
+
+	// Create services.
+	services := map[string]ServiceConfiguration{
+		"sleeper": {
+			Command: "sleep 60",
+			Restart: RestartPolicyAlways,
+		},
+	}
+
+	// Run the services and cancel them after a short delay.
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+	output := &bytes.Buffer{}
+	start := time.Now()
+	Supervise(ctx, services, output)
+
+	// Verify that the service was stopped promptly.
+	if duration := time.Since(start); duration > serviceStopTimeout {
+		t.Error("service not stopped promptly:", duration)
+	}
+	if !strings.Contains(output.String(), "sleeper | service stopped\n") {
+		t.Error("service stop not reported:", output.String())
+	}
+}
@@ -0,0 +1,29 @@
+package project
+
+import (
+	"os"
+	"os/exec"
+)
+
+// serviceCommand creates a process that runs a service command using the
+// system shell. On Windows systems, this is %COMSPEC% (with a fallback to
+// cmd.exe if unspecified).
+func serviceCommand(command string) *exec.Cmd {
+	shell := os.Getenv("COMSPEC")
+	if shell == "" {
+		shell = "cmd.exe"
+	}
+	return exec.Command(shell, "/c", command)
+}
+
+// terminateServiceProcess requests that a service process terminate. Windows
+// doesn't support termination requests for console processes, so the process
+// is killed.
+func terminateServiceProcess(process *os.Process) {
+	process.Kill()
+}
+
+// killServiceProcess forcibly terminates a service process.
+func killServiceProcess(process *os.Process) {
+	process.Kill()
+}
@@ -3,9 +3,11 @@ package forwarding
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 
 	"github.com/mutagen-io/mutagen/pkg/selection"
 	"github.com/mutagen-io/mutagen/pkg/url"
+	forwardingurl "github.com/mutagen-io/mutagen/pkg/url/forwarding"
 )
 
 // ensureValid verifies that a CreationSpecification is valid.
@@ -29,6 +31,29 @@ func (s *CreationSpecification) ensureValid() error {
 		return errors.New("destination URL is not a forwarding URL")
 	}
 
+	// Verify that the destination URL doesn't specify a port range, which is
+	// only supported for listeners.
+	if protocol, address, err := forwardingurl.Parse(s.Destination.Path); err != nil {
+		return fmt.Errorf("invalid destination URL: %w", err)
+	} else if forwardingurl.IsPortRange(protocol, address) {
+		return errors.New("port ranges are not supported for destination URLs")
+	}
+
+	// Verify that any additional destination URLs are valid forwarding URLs
+	// that don't specify port ranges.
+	for _, destination := range s.AdditionalDestinations {
+		if err := destination.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid additional destination URL: %w", err)
+		} else if destination.Kind != url.Kind_Forwarding {
+			return errors.New("additional destination URL is not a forwarding URL")
+		}
+		if protocol, address, err := forwardingurl.Parse(destination.Path); err != nil {
+			return fmt.Errorf("invalid additional destination URL: %w", err)
+		} else if forwardingurl.IsPortRange(protocol, address) {
+			return errors.New("port ranges are not supported for destination URLs")
+		}
+	}
+
 	// Verify that the configuration is valid.
 	if err := s.Configuration.EnsureValid(false); err != nil {
 		return fmt.Errorf("invalid session configuration: %w", err)
@@ -201,6 +226,93 @@ func (r *ResumeResponse) EnsureValid() error {
 	return nil
 }
 
+// ensureValid verifies that a CaptureRequest is valid.
+func (r *CaptureRequest) ensureValid() error {
+	// A nil capture request is not valid.
+	if r == nil {
+		return errors.New("nil capture request")
+	}
+
+	// Ensure that the session selection is valid.
+	if err := r.Selection.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid selection specification: %w", err)
+	}
+
+	// If capture is being enabled, then ensure that the capture parameters are
+	// valid.
+	if r.Enable {
+		if r.Directory == "" {
+			return errors.New("empty capture directory")
+		} else if !filepath.IsAbs(r.Directory) {
+			return errors.New("capture directory is not absolute")
+		}
+		if !(r.Format.IsDefault() || r.Format.Supported()) {
+			return errors.New("unknown or unsupported capture format")
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+// EnsureValid verifies that a CaptureResponse is valid.
+func (r *CaptureResponse) EnsureValid() error {
+	// A nil capture response is not valid.
+	if r == nil {
+		return errors.New("nil capture response")
+	}
+
+	// Success.
+	return nil
+}
+
+// ensureValid verifies that an UpdateRequest is valid.
+func (r *UpdateRequest) ensureValid() error {
+	// A nil update request is not valid.
+	if r == nil {
+		return errors.New("nil update request")
+	}
+
+	// Ensure that a prompter has been specified.
+	if r.Prompter == "" {
+		return errors.New("no prompter specified")
+	}
+
+	// Ensure that a session has been specified.
+	if r.Session == "" {
+		return errors.New("no session specified")
+	}
+
+	// Verify that the configuration is valid.
+	if err := r.Configuration.EnsureValid(false); err != nil {
+		return fmt.Errorf("invalid session configuration: %w", err)
+	}
+
+	// Verify that the source-specific configuration is valid.
+	if err := r.ConfigurationSource.EnsureValid(true); err != nil {
+		return fmt.Errorf("invalid source-specific configuration: %w", err)
+	}
+
+	// Verify that the destination-specific configuration is valid.
+	if err := r.ConfigurationDestination.EnsureValid(true); err != nil {
+		return fmt.Errorf("invalid destination-specific configuration: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
+// EnsureValid verifies that an UpdateResponse is valid.
+func (r *UpdateResponse) EnsureValid() error {
+	// A nil update response is not valid.
+	if r == nil {
+		return errors.New("nil update response")
+	}
+
+	// Success.
+	return nil
+}
+
 // ensureValid verifies that a TerminateRequest is valid.
 func (r *TerminateRequest) ensureValid() error {
 	// A nil terminate request is not valid.
@@ -232,3 +344,35 @@ func (r *TerminateResponse) EnsureValid() error {
 	// Success.
 	return nil
 }
+
+// ensureValid verifies that a WatchRequest is valid.
+func (r *WatchRequest) ensureValid() error {
+	// A nil watch request is not valid.
+	if r == nil {
+		return errors.New("nil watch request")
+	}
+
+	// Ensure that the session selection is valid.
+	if err := r.Selection.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid selection specification: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
+// EnsureValid verifies that a WatchResponse is valid.
+func (r *WatchResponse) EnsureValid() error {
+	// A nil watch response is not valid.
+	if r == nil {
+		return errors.New("nil watch response")
+	}
+
+	// Ensure that the event is valid.
+	if err := r.Event.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+
+	// Success.
+	return nil
+}
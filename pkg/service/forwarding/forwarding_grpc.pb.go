@@ -30,8 +30,14 @@ type ForwardingClient interface {
 	Pause(ctx context.Context, in *PauseRequest, opts ...grpc.CallOption) (*PauseResponse, error)
 	// Resume resumes paused or disconnected sessions.
 	Resume(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*ResumeResponse, error)
+	// Update replaces a session's configuration.
+	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
 	// Terminate terminates sessions.
 	Terminate(ctx context.Context, in *TerminateRequest, opts ...grpc.CallOption) (*TerminateResponse, error)
+	// Watch streams events for sessions.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Forwarding_WatchClient, error)
+	// Capture enables or disables traffic capture for sessions.
+	Capture(ctx context.Context, in *CaptureRequest, opts ...grpc.CallOption) (*CaptureResponse, error)
 }
 
 type forwardingClient struct {
@@ -78,6 +84,15 @@ func (c *forwardingClient) Resume(ctx context.Context, in *ResumeRequest, opts .
 	return out, nil
 }
 
+func (c *forwardingClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error) {
+	out := new(UpdateResponse)
+	err := c.cc.Invoke(ctx, "/forwarding.Forwarding/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *forwardingClient) Terminate(ctx context.Context, in *TerminateRequest, opts ...grpc.CallOption) (*TerminateResponse, error) {
 	out := new(TerminateResponse)
 	err := c.cc.Invoke(ctx, "/forwarding.Forwarding/Terminate", in, out, opts...)
@@ -87,6 +102,47 @@ func (c *forwardingClient) Terminate(ctx context.Context, in *TerminateRequest,
 	return out, nil
 }
 
+func (c *forwardingClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Forwarding_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Forwarding_ServiceDesc.Streams[0], "/forwarding.Forwarding/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &forwardingWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Forwarding_WatchClient interface {
+	Recv() (*WatchResponse, error)
+	grpc.ClientStream
+}
+
+type forwardingWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *forwardingWatchClient) Recv() (*WatchResponse, error) {
+	m := new(WatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *forwardingClient) Capture(ctx context.Context, in *CaptureRequest, opts ...grpc.CallOption) (*CaptureResponse, error) {
+	out := new(CaptureResponse)
+	err := c.cc.Invoke(ctx, "/forwarding.Forwarding/Capture", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ForwardingServer is the server API for Forwarding service.
 // All implementations must embed UnimplementedForwardingServer
 // for forward compatibility
@@ -99,8 +155,14 @@ type ForwardingServer interface {
 	Pause(context.Context, *PauseRequest) (*PauseResponse, error)
 	// Resume resumes paused or disconnected sessions.
 	Resume(context.Context, *ResumeRequest) (*ResumeResponse, error)
+	// Update replaces a session's configuration.
+	Update(context.Context, *UpdateRequest) (*UpdateResponse, error)
 	// Terminate terminates sessions.
 	Terminate(context.Context, *TerminateRequest) (*TerminateResponse, error)
+	// Watch streams events for sessions.
+	Watch(*WatchRequest, Forwarding_WatchServer) error
+	// Capture enables or disables traffic capture for sessions.
+	Capture(context.Context, *CaptureRequest) (*CaptureResponse, error)
 	mustEmbedUnimplementedForwardingServer()
 }
 
@@ -120,9 +182,18 @@ func (UnimplementedForwardingServer) Pause(context.Context, *PauseRequest) (*Pau
 func (UnimplementedForwardingServer) Resume(context.Context, *ResumeRequest) (*ResumeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Resume not implemented")
 }
+func (UnimplementedForwardingServer) Update(context.Context, *UpdateRequest) (*UpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
 func (UnimplementedForwardingServer) Terminate(context.Context, *TerminateRequest) (*TerminateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Terminate not implemented")
 }
+func (UnimplementedForwardingServer) Watch(*WatchRequest, Forwarding_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedForwardingServer) Capture(context.Context, *CaptureRequest) (*CaptureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Capture not implemented")
+}
 func (UnimplementedForwardingServer) mustEmbedUnimplementedForwardingServer() {}
 
 // UnsafeForwardingServer may be embedded to opt out of forward compatibility for this service.
@@ -208,6 +279,24 @@ func _Forwarding_Resume_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Forwarding_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForwardingServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/forwarding.Forwarding/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForwardingServer).Update(ctx, req.(*UpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Forwarding_Terminate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TerminateRequest)
 	if err := dec(in); err != nil {
@@ -226,6 +315,45 @@ func _Forwarding_Terminate_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Forwarding_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ForwardingServer).Watch(m, &forwardingWatchServer{stream})
+}
+
+type Forwarding_WatchServer interface {
+	Send(*WatchResponse) error
+	grpc.ServerStream
+}
+
+type forwardingWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *forwardingWatchServer) Send(m *WatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Forwarding_Capture_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CaptureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForwardingServer).Capture(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/forwarding.Forwarding/Capture",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForwardingServer).Capture(ctx, req.(*CaptureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Forwarding_ServiceDesc is the grpc.ServiceDesc for Forwarding service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -249,11 +377,25 @@ var Forwarding_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Resume",
 			Handler:    _Forwarding_Resume_Handler,
 		},
+		{
+			MethodName: "Update",
+			Handler:    _Forwarding_Update_Handler,
+		},
 		{
 			MethodName: "Terminate",
 			Handler:    _Forwarding_Terminate_Handler,
 		},
+		{
+			MethodName: "Capture",
+			Handler:    _Forwarding_Capture_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _Forwarding_Watch_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "service/forwarding/forwarding.proto",
 }
@@ -34,6 +34,7 @@ func (s *Server) Create(ctx context.Context, request *CreateRequest) (*CreateRes
 		ctx,
 		request.Specification.Source,
 		request.Specification.Destination,
+		request.Specification.AdditionalDestinations,
 		request.Specification.Configuration,
 		request.Specification.ConfigurationSource,
 		request.Specification.ConfigurationDestination,
@@ -102,6 +103,50 @@ func (s *Server) Resume(ctx context.Context, request *ResumeRequest) (*ResumeRes
 	return &ResumeResponse{}, nil
 }
 
+// Capture enables or disables traffic capture for existing sessions.
+func (s *Server) Capture(ctx context.Context, request *CaptureRequest) (*CaptureResponse, error) {
+	// Validate the request.
+	if err := request.ensureValid(); err != nil {
+		return nil, fmt.Errorf("invalid capture request: %w", err)
+	}
+
+	// Perform capture configuration.
+	if err := s.manager.Capture(ctx,
+		request.Selection,
+		request.Enable,
+		request.Directory,
+		request.Format,
+		request.MaximumFileSize,
+		request.MaximumFileCount,
+	); err != nil {
+		return nil, err
+	}
+
+	// Success.
+	return &CaptureResponse{}, nil
+}
+
+// Update updates the configuration of an existing session.
+func (s *Server) Update(ctx context.Context, request *UpdateRequest) (*UpdateResponse, error) {
+	// Validate the request.
+	if err := request.ensureValid(); err != nil {
+		return nil, fmt.Errorf("invalid update request: %w", err)
+	}
+
+	// Perform the update.
+	if err := s.manager.Update(
+		ctx,
+		request.Session,
+		request.Configuration, request.ConfigurationSource, request.ConfigurationDestination,
+		request.Prompter,
+	); err != nil {
+		return nil, err
+	}
+
+	// Success.
+	return &UpdateResponse{}, nil
+}
+
 // Terminate terminates existing sessions.
 func (s *Server) Terminate(ctx context.Context, request *TerminateRequest) (*TerminateResponse, error) {
 	// Validate the request.
@@ -117,3 +162,16 @@ func (s *Server) Terminate(ctx context.Context, request *TerminateRequest) (*Ter
 	// Success.
 	return &TerminateResponse{}, nil
 }
+
+// Watch streams session events.
+func (s *Server) Watch(request *WatchRequest, server Forwarding_WatchServer) error {
+	// Validate the request.
+	if err := request.ensureValid(); err != nil {
+		return fmt.Errorf("invalid watch request: %w", err)
+	}
+
+	// Stream events until the client disconnects or the stream fails.
+	return s.manager.Watch(server.Context(), request.Selection, func(event *forwarding.Event) error {
+		return server.Send(&WatchResponse{Event: event})
+	})
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 
+	"google.golang.org/protobuf/proto"
+
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
 	"github.com/mutagen-io/mutagen/pkg/synchronization"
 )
 
@@ -40,6 +43,7 @@ func (s *Server) Create(ctx context.Context, request *CreateRequest) (*CreateRes
 		request.Specification.Name,
 		request.Specification.Labels,
 		request.Specification.Paused,
+		request.Specification.Archive,
 		request.Prompter,
 	)
 	if err != nil {
@@ -134,6 +138,27 @@ func (s *Server) Reset(ctx context.Context, request *ResetRequest) (*ResetRespon
 	return &ResetResponse{}, nil
 }
 
+// Update updates a session's configuration.
+func (s *Server) Update(ctx context.Context, request *UpdateRequest) (*UpdateResponse, error) {
+	// Validate the request.
+	if err := request.ensureValid(); err != nil {
+		return nil, fmt.Errorf("invalid update request: %w", err)
+	}
+
+	// Perform the update.
+	if err := s.manager.Update(
+		ctx,
+		request.Session,
+		request.Configuration, request.ConfigurationAlpha, request.ConfigurationBeta,
+		request.Prompter,
+	); err != nil {
+		return nil, err
+	}
+
+	// Success.
+	return &UpdateResponse{}, nil
+}
+
 // Terminate terminates sessions.
 func (s *Server) Terminate(ctx context.Context, request *TerminateRequest) (*TerminateResponse, error) {
 	// Validate the request.
@@ -149,3 +174,57 @@ func (s *Server) Terminate(ctx context.Context, request *TerminateRequest) (*Ter
 	// Success.
 	return &TerminateResponse{}, nil
 }
+
+// Watch streams session events.
+func (s *Server) Watch(request *WatchRequest, server Synchronization_WatchServer) error {
+	// Validate the request.
+	if err := request.ensureValid(); err != nil {
+		return fmt.Errorf("invalid watch request: %w", err)
+	}
+
+	// Stream events until the client disconnects or the stream fails.
+	return s.manager.Watch(server.Context(), request.Selection, func(event *synchronization.Event) error {
+		return server.Send(&WatchResponse{Event: event})
+	})
+}
+
+// History queries session cycle histories.
+func (s *Server) History(ctx context.Context, request *HistoryRequest) (*HistoryResponse, error) {
+	// Validate the request.
+	if err := request.ensureValid(); err != nil {
+		return nil, fmt.Errorf("invalid history request: %w", err)
+	}
+
+	// Perform the query.
+	histories, err := s.manager.History(ctx, request.Selection)
+	if err != nil {
+		return nil, err
+	}
+
+	// Success.
+	return &HistoryResponse{Histories: histories}, nil
+}
+
+// Archive queries a session's ancestor archive.
+func (s *Server) Archive(ctx context.Context, request *ArchiveRequest) (*ArchiveResponse, error) {
+	// Validate the request.
+	if err := request.ensureValid(); err != nil {
+		return nil, fmt.Errorf("invalid archive request: %w", err)
+	}
+
+	// Perform the query.
+	archive, err := s.manager.Archive(ctx, request.Session)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure that the archive can be transmitted. Archives are sent in a single
+	// message, so they're subject to the maximum message size.
+	response := &ArchiveResponse{Archive: archive}
+	if size := proto.Size(response); size > grpcutil.MaximumMessageSize {
+		return nil, fmt.Errorf("archive too large to transmit (%d bytes, maximum %d bytes)", size, grpcutil.MaximumMessageSize)
+	}
+
+	// Success.
+	return response, nil
+}
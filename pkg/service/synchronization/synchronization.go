@@ -60,6 +60,13 @@ func (s *CreationSpecification) ensureValid() error {
 
 	// There's no need to validate the Paused field - either value is valid.
 
+	// Verify that the archive, if any, is valid.
+	if s.Archive != nil {
+		if err := s.Archive.EnsureValid(true); err != nil {
+			return fmt.Errorf("invalid archive: %w", err)
+		}
+	}
+
 	// Success.
 	return nil
 }
@@ -267,6 +274,53 @@ func (r *ResetResponse) EnsureValid() error {
 	return nil
 }
 
+// ensureValid verifies that an UpdateRequest is valid.
+func (r *UpdateRequest) ensureValid() error {
+	// A nil update request is not valid.
+	if r == nil {
+		return errors.New("nil update request")
+	}
+
+	// Ensure that a prompter has been specified.
+	if r.Prompter == "" {
+		return errors.New("no prompter specified")
+	}
+
+	// Ensure that a session has been specified.
+	if r.Session == "" {
+		return errors.New("no session specified")
+	}
+
+	// Verify that the configuration is valid.
+	if err := r.Configuration.EnsureValid(false); err != nil {
+		return fmt.Errorf("invalid session configuration: %w", err)
+	}
+
+	// Verify that the alpha-specific configuration is valid.
+	if err := r.ConfigurationAlpha.EnsureValid(true); err != nil {
+		return fmt.Errorf("invalid alpha-specific configuration: %w", err)
+	}
+
+	// Verify that the beta-specific configuration is valid.
+	if err := r.ConfigurationBeta.EnsureValid(true); err != nil {
+		return fmt.Errorf("invalid beta-specific configuration: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
+// EnsureValid verifies that an UpdateResponse is valid.
+func (r *UpdateResponse) EnsureValid() error {
+	// A nil update response is not valid.
+	if r == nil {
+		return errors.New("nil update response")
+	}
+
+	// Success.
+	return nil
+}
+
 // ensureValid verifies that a TerminateRequest is valid.
 func (r *TerminateRequest) ensureValid() error {
 	// A nil terminate request is not valid.
@@ -298,3 +352,101 @@ func (r *TerminateResponse) EnsureValid() error {
 	// Success.
 	return nil
 }
+
+// ensureValid verifies that a WatchRequest is valid.
+func (r *WatchRequest) ensureValid() error {
+	// A nil watch request is not valid.
+	if r == nil {
+		return errors.New("nil watch request")
+	}
+
+	// Ensure that the session selection is valid.
+	if err := r.Selection.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid selection specification: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
+// EnsureValid verifies that a WatchResponse is valid.
+func (r *WatchResponse) EnsureValid() error {
+	// A nil watch response is not valid.
+	if r == nil {
+		return errors.New("nil watch response")
+	}
+
+	// Ensure that the event is valid.
+	if err := r.Event.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid event: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
+// ensureValid verifies that a HistoryRequest is valid.
+func (r *HistoryRequest) ensureValid() error {
+	// A nil history request is not valid.
+	if r == nil {
+		return errors.New("nil history request")
+	}
+
+	// Ensure that the session selection is valid.
+	if err := r.Selection.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid selection specification: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
+// EnsureValid verifies that a HistoryResponse is valid.
+func (r *HistoryResponse) EnsureValid() error {
+	// A nil history response is not valid.
+	if r == nil {
+		return errors.New("nil history response")
+	}
+
+	// Ensure that all histories are valid.
+	for _, h := range r.Histories {
+		if err := h.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid session history: %w", err)
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+// ensureValid verifies that an ArchiveRequest is valid.
+func (r *ArchiveRequest) ensureValid() error {
+	// A nil archive request is not valid.
+	if r == nil {
+		return errors.New("nil archive request")
+	}
+
+	// Ensure that a session has been specified.
+	if r.Session == "" {
+		return errors.New("no session specified")
+	}
+
+	// Success.
+	return nil
+}
+
+// EnsureValid verifies that an ArchiveResponse is valid.
+func (r *ArchiveResponse) EnsureValid() error {
+	// A nil archive response is not valid.
+	if r == nil {
+		return errors.New("nil archive response")
+	}
+
+	// Ensure that the archive is valid.
+	if err := r.Archive.EnsureValid(true); err != nil {
+		return fmt.Errorf("invalid archive: %w", err)
+	}
+
+	// Success.
+	return nil
+}
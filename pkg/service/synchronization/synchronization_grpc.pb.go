@@ -34,8 +34,16 @@ type SynchronizationClient interface {
 	Resume(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*ResumeResponse, error)
 	// Reset resets sessions' histories.
 	Reset(ctx context.Context, in *ResetRequest, opts ...grpc.CallOption) (*ResetResponse, error)
+	// Update replaces a session's configuration.
+	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
 	// Terminate terminates sessions.
 	Terminate(ctx context.Context, in *TerminateRequest, opts ...grpc.CallOption) (*TerminateResponse, error)
+	// Watch streams events for sessions.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Synchronization_WatchClient, error)
+	// History returns cycle histories for sessions.
+	History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error)
+	// Archive returns a session's current ancestor archive.
+	Archive(ctx context.Context, in *ArchiveRequest, opts ...grpc.CallOption) (*ArchiveResponse, error)
 }
 
 type synchronizationClient struct {
@@ -100,6 +108,15 @@ func (c *synchronizationClient) Reset(ctx context.Context, in *ResetRequest, opt
 	return out, nil
 }
 
+func (c *synchronizationClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error) {
+	out := new(UpdateResponse)
+	err := c.cc.Invoke(ctx, "/synchronization.Synchronization/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *synchronizationClient) Terminate(ctx context.Context, in *TerminateRequest, opts ...grpc.CallOption) (*TerminateResponse, error) {
 	out := new(TerminateResponse)
 	err := c.cc.Invoke(ctx, "/synchronization.Synchronization/Terminate", in, out, opts...)
@@ -109,6 +126,56 @@ func (c *synchronizationClient) Terminate(ctx context.Context, in *TerminateRequ
 	return out, nil
 }
 
+func (c *synchronizationClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Synchronization_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Synchronization_ServiceDesc.Streams[0], "/synchronization.Synchronization/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &synchronizationWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Synchronization_WatchClient interface {
+	Recv() (*WatchResponse, error)
+	grpc.ClientStream
+}
+
+type synchronizationWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *synchronizationWatchClient) Recv() (*WatchResponse, error) {
+	m := new(WatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *synchronizationClient) History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error) {
+	out := new(HistoryResponse)
+	err := c.cc.Invoke(ctx, "/synchronization.Synchronization/History", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *synchronizationClient) Archive(ctx context.Context, in *ArchiveRequest, opts ...grpc.CallOption) (*ArchiveResponse, error) {
+	out := new(ArchiveResponse)
+	err := c.cc.Invoke(ctx, "/synchronization.Synchronization/Archive", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SynchronizationServer is the server API for Synchronization service.
 // All implementations must embed UnimplementedSynchronizationServer
 // for forward compatibility
@@ -125,8 +192,16 @@ type SynchronizationServer interface {
 	Resume(context.Context, *ResumeRequest) (*ResumeResponse, error)
 	// Reset resets sessions' histories.
 	Reset(context.Context, *ResetRequest) (*ResetResponse, error)
+	// Update replaces a session's configuration.
+	Update(context.Context, *UpdateRequest) (*UpdateResponse, error)
 	// Terminate terminates sessions.
 	Terminate(context.Context, *TerminateRequest) (*TerminateResponse, error)
+	// Watch streams events for sessions.
+	Watch(*WatchRequest, Synchronization_WatchServer) error
+	// History returns cycle histories for sessions.
+	History(context.Context, *HistoryRequest) (*HistoryResponse, error)
+	// Archive returns a session's current ancestor archive.
+	Archive(context.Context, *ArchiveRequest) (*ArchiveResponse, error)
 	mustEmbedUnimplementedSynchronizationServer()
 }
 
@@ -152,9 +227,21 @@ func (UnimplementedSynchronizationServer) Resume(context.Context, *ResumeRequest
 func (UnimplementedSynchronizationServer) Reset(context.Context, *ResetRequest) (*ResetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Reset not implemented")
 }
+func (UnimplementedSynchronizationServer) Update(context.Context, *UpdateRequest) (*UpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
 func (UnimplementedSynchronizationServer) Terminate(context.Context, *TerminateRequest) (*TerminateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Terminate not implemented")
 }
+func (UnimplementedSynchronizationServer) Watch(*WatchRequest, Synchronization_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedSynchronizationServer) History(context.Context, *HistoryRequest) (*HistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method History not implemented")
+}
+func (UnimplementedSynchronizationServer) Archive(context.Context, *ArchiveRequest) (*ArchiveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Archive not implemented")
+}
 func (UnimplementedSynchronizationServer) mustEmbedUnimplementedSynchronizationServer() {}
 
 // UnsafeSynchronizationServer may be embedded to opt out of forward compatibility for this service.
@@ -276,6 +363,24 @@ func _Synchronization_Reset_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Synchronization_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SynchronizationServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/synchronization.Synchronization/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SynchronizationServer).Update(ctx, req.(*UpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Synchronization_Terminate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TerminateRequest)
 	if err := dec(in); err != nil {
@@ -294,6 +399,63 @@ func _Synchronization_Terminate_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Synchronization_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SynchronizationServer).Watch(m, &synchronizationWatchServer{stream})
+}
+
+type Synchronization_WatchServer interface {
+	Send(*WatchResponse) error
+	grpc.ServerStream
+}
+
+type synchronizationWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *synchronizationWatchServer) Send(m *WatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Synchronization_History_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SynchronizationServer).History(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/synchronization.Synchronization/History",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SynchronizationServer).History(ctx, req.(*HistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Synchronization_Archive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SynchronizationServer).Archive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/synchronization.Synchronization/Archive",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SynchronizationServer).Archive(ctx, req.(*ArchiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Synchronization_ServiceDesc is the grpc.ServiceDesc for Synchronization service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -325,11 +487,29 @@ var Synchronization_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Reset",
 			Handler:    _Synchronization_Reset_Handler,
 		},
+		{
+			MethodName: "Update",
+			Handler:    _Synchronization_Update_Handler,
+		},
 		{
 			MethodName: "Terminate",
 			Handler:    _Synchronization_Terminate_Handler,
 		},
+		{
+			MethodName: "History",
+			Handler:    _Synchronization_History_Handler,
+		},
+		{
+			MethodName: "Archive",
+			Handler:    _Synchronization_Archive_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _Synchronization_Watch_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "service/synchronization/synchronization.proto",
 }
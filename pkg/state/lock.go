@@ -11,6 +11,8 @@ type TrackingLock struct {
 	lock sync.Mutex
 	// tracker is the underlying tracker.
 	tracker *Tracker
+	// observer is the optional change observer.
+	observer func()
 }
 
 // NewTrackingLock creates a new tracking lock with the specified tracker.
@@ -20,6 +22,18 @@ func NewTrackingLock(tracker *Tracker) *TrackingLock {
 	}
 }
 
+// NewObservedTrackingLock creates a new tracking lock with the specified
+// tracker and change observer. The observer is invoked by Unlock (but not by
+// UnlockWithoutNotify) while the lock is still held, allowing it to inspect the
+// guarded state immediately after each modification. The observer must not
+// block or attempt to acquire the lock.
+func NewObservedTrackingLock(tracker *Tracker, observer func()) *TrackingLock {
+	return &TrackingLock{
+		tracker:  tracker,
+		observer: observer,
+	}
+}
+
 // Lock locks the tracking lock.
 func (l *TrackingLock) Lock() {
 	l.lock.Lock()
@@ -27,6 +41,9 @@ func (l *TrackingLock) Lock() {
 
 // Unlock unlocks the tracking lock and triggers a state update notification.
 func (l *TrackingLock) Unlock() {
+	if l.observer != nil {
+		l.observer()
+	}
 	l.lock.Unlock()
 	l.tracker.NotifyOfChange()
 }
@@ -61,3 +61,29 @@ func TestTrackingLock(t *testing.T) {
 		t.Fatal("timeout failure on tracking termination")
 	}
 }
+
+func TestObservedTrackingLock(t *testing.T) {
+	// Create a tracker and defer its termination.
+	tracker := NewTracker()
+	defer tracker.Terminate()
+
+	// Create an observed tracking lock that counts observations.
+	var observations int
+	lock := NewObservedTrackingLock(tracker, func() {
+		observations++
+	})
+
+	// Verify that notifying unlocks are observed.
+	lock.Lock()
+	lock.Unlock()
+	if observations != 1 {
+		t.Error("notifying unlock not observed")
+	}
+
+	// Verify that non-notifying unlocks aren't observed.
+	lock.Lock()
+	lock.UnlockWithoutNotify()
+	if observations != 1 {
+		t.Error("non-notifying unlock observed")
+	}
+}
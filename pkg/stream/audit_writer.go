@@ -33,3 +33,36 @@ func (w *auditWriter) Write(buffer []byte) (int, error) {
 	w.auditor(uint64(result))
 	return result, err
 }
+
+// Recorder is a callback type that receives the data from write operations.
+// It is a companion to Auditor for cases where the written content (and not
+// just its size) is of interest. Recorder implementations must not retain or
+// modify the provided buffer and, like Auditor implementations, should be fast.
+type Recorder func([]byte)
+
+// recordingWriter is an io.Writer that implements write operation recording.
+type recordingWriter struct {
+	// writer is the underlying writer.
+	writer io.Writer
+	// recorder is the recording callback.
+	recorder Recorder
+}
+
+// NewRecordingWriter creates a new io.Writer that invokes a recording callback
+// with successfully written data. If recorder is nil, then this function will
+// return writer unmodified.
+func NewRecordingWriter(writer io.Writer, recorder Recorder) io.Writer {
+	if recorder == nil {
+		return writer
+	}
+	return &recordingWriter{writer, recorder}
+}
+
+// Write implements io.Writer.Write.
+func (w *recordingWriter) Write(buffer []byte) (int, error) {
+	result, err := w.writer.Write(buffer)
+	if result > 0 {
+		w.recorder(buffer[:result])
+	}
+	return result, err
+}
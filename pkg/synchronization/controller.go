@@ -13,10 +13,12 @@ import (
 
 	"github.com/mutagen-io/mutagen/pkg/encoding"
 	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/metrics"
 	"github.com/mutagen-io/mutagen/pkg/mutagen"
 	"github.com/mutagen-io/mutagen/pkg/prompting"
 	"github.com/mutagen-io/mutagen/pkg/state"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/hashing"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/rsync"
 	"github.com/mutagen-io/mutagen/pkg/url"
 )
@@ -38,27 +40,57 @@ type controller struct {
 	sessionPath string
 	// archivePath is the path to the serialized archive.
 	archivePath string
+	// historyPath is the path to the serialized cycle history.
+	historyPath string
 	// stateLock guards and tracks changes to session's Paused field, state, and
 	// synchronizing. Previous holders may continue to poll on synchronizing if
 	// they store it in a separate variable before releasing the lock.
 	stateLock *state.TrackingLock
 	// session encodes the associated session metadata. It is considered static
-	// and safe for concurrent access except for its Paused field, for which
-	// stateLock should be held. It should be saved to disk any time it is
+	// and safe for concurrent access except for its Paused and configuration
+	// fields, for which stateLock should be held. The configuration fields may
+	// only be modified while holding the lifecycle lock with no
+	// synchronization loop running. It should be saved to disk any time it is
 	// modified.
 	session *Session
 	// mergedAlphaConfiguration is the alpha-specific configuration object
 	// (computed from the core configuration and alpha-specific overrides). It
-	// is considered static and safe for concurrent access. It is a derived
-	// field and not saved to disk.
+	// is subject to the same rules as the session's configuration fields. It
+	// is a derived field and not saved to disk.
 	mergedAlphaConfiguration *Configuration
 	// mergedBetaConfiguration is the beta-specific configuration object
-	// (computed from the core configuration and beta-specific overrides). It is
-	// considered static and safe for concurrent access. It is a derived field
-	// and not saved to disk.
+	// (computed from the core configuration and beta-specific overrides). It
+	// is subject to the same rules as the session's configuration fields. It
+	// is a derived field and not saved to disk.
 	mergedBetaConfiguration *Configuration
 	// state represents the current synchronization state.
 	state *State
+	// events is the broadcaster used to publish session events.
+	events *eventBroadcaster
+	// eventSummary is a summary of state at the time of the last state
+	// observation. It is guarded by stateLock.
+	eventSummary eventSummary
+	// cycleAlphaChanges is the number of changes applied to alpha during the
+	// most recent synchronization cycle. It is guarded by stateLock.
+	cycleAlphaChanges uint64
+	// cycleBetaChanges is the number of changes applied to beta during the
+	// most recent synchronization cycle. It is guarded by stateLock.
+	cycleBetaChanges uint64
+	// phaseDurations are histograms of synchronization cycle phase durations,
+	// keyed by phase name. The map is static and the histograms are safe for
+	// concurrent usage. They are not saved to disk.
+	phaseDurations map[string]*metrics.Histogram
+	// alphaStagedBytes is the total number of bytes staged on alpha. It is
+	// guarded by stateLock. It is not saved to disk.
+	alphaStagedBytes uint64
+	// betaStagedBytes is the total number of bytes staged on beta. It is
+	// guarded by stateLock. It is not saved to disk.
+	betaStagedBytes uint64
+	// historyLock guards history.
+	historyLock sync.Mutex
+	// history is the session's cycle history. It is saved to disk after each
+	// synchronization cycle.
+	history *History
 	// synchronizing is used to track whether or not the synchronization loop is
 	// currently in a state where it is capable of performing synchronization.
 	// It is non-nil if and only if the synchronization loop is connected and in
@@ -92,17 +124,20 @@ type controller struct {
 	done chan struct{}
 }
 
-// newSession creates a new session and corresponding controller.
+// newSession creates a new session and corresponding controller. If archive is
+// non-nil, then it's used as the session's initial ancestor archive.
 func newSession(
 	ctx context.Context,
 	logger *logging.Logger,
 	tracker *state.Tracker,
+	events *eventBroadcaster,
 	identifier string,
 	alpha, beta *url.URL,
 	configuration, configurationAlpha, configurationBeta *Configuration,
 	name string,
 	labels map[string]string,
 	paused bool,
+	archive *core.Archive,
 	prompter string,
 ) (*controller, error) {
 	// Update status.
@@ -186,7 +221,9 @@ func newSession(
 		Labels:               labels,
 		Paused:               paused,
 	}
-	archive := &core.Archive{}
+	if archive == nil {
+		archive = &core.Archive{}
+	}
 
 	// Compute the session and archive paths.
 	sessionPath, err := pathForSession(session.Identifier)
@@ -197,6 +234,10 @@ func newSession(
 	if err != nil {
 		return nil, fmt.Errorf("unable to compute archive path: %w", err)
 	}
+	historyPath, err := pathForHistory(session.Identifier)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compute history path: %w", err)
+	}
 
 	// Save components to disk.
 	if err := encoding.MarshalAndSaveProtobuf(sessionPath, session); err != nil {
@@ -212,7 +253,7 @@ func newSession(
 		logger:                   logger,
 		sessionPath:              sessionPath,
 		archivePath:              archivePath,
-		stateLock:                state.NewTrackingLock(tracker),
+		historyPath:              historyPath,
 		session:                  session,
 		mergedAlphaConfiguration: mergedAlphaConfiguration,
 		mergedBetaConfiguration:  mergedBetaConfiguration,
@@ -221,7 +262,11 @@ func newSession(
 			AlphaState: &EndpointState{},
 			BetaState:  &EndpointState{},
 		},
+		events:         events,
+		phaseDurations: newPhaseDurations(),
+		history:        &History{Session: session.Identifier},
 	}
+	controller.stateLock = state.NewObservedTrackingLock(tracker, controller.observeState)
 
 	// If the session isn't being created paused, then start a synchronization
 	// loop and mark the endpoints as handed off to that loop so that we don't
@@ -242,7 +287,7 @@ func newSession(
 }
 
 // loadSession loads an existing session and creates a corresponding controller.
-func loadSession(logger *logging.Logger, tracker *state.Tracker, identifier string) (*controller, error) {
+func loadSession(logger *logging.Logger, tracker *state.Tracker, events *eventBroadcaster, identifier string) (*controller, error) {
 	// Compute session and archive paths.
 	sessionPath, err := pathForSession(identifier)
 	if err != nil {
@@ -252,6 +297,10 @@ func loadSession(logger *logging.Logger, tracker *state.Tracker, identifier stri
 	if err != nil {
 		return nil, fmt.Errorf("unable to compute archive path: %w", err)
 	}
+	historyPath, err := pathForHistory(identifier)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compute history path: %w", err)
+	}
 
 	// Load and validate the session. We have to populate a few optional fields
 	// before validation if they're not set. We can't do this in the Session
@@ -271,12 +320,27 @@ func loadSession(logger *logging.Logger, tracker *state.Tracker, identifier stri
 		return nil, fmt.Errorf("invalid session found on disk: %w", err)
 	}
 
+	// Load the cycle history. History is purely informational, so if it's
+	// missing (e.g. for sessions created by older versions or that haven't
+	// completed a cycle) or invalid, then we just start with an empty history.
+	history := &History{}
+	if err := encoding.LoadAndUnmarshalProtobuf(historyPath, history); err != nil {
+		if !os.IsNotExist(err) {
+			logger.Warn("Unable to load cycle history:", err)
+		}
+		history = &History{}
+	} else if err = history.EnsureValid(); err != nil {
+		logger.Warn("Invalid cycle history found on disk:", err)
+		history = &History{}
+	}
+	history.Session = identifier
+
 	// Create the controller.
 	controller := &controller{
 		logger:      logger,
 		sessionPath: sessionPath,
 		archivePath: archivePath,
-		stateLock:   state.NewTrackingLock(tracker),
+		historyPath: historyPath,
 		session:     session,
 		mergedAlphaConfiguration: MergeConfigurations(
 			session.Configuration,
@@ -291,7 +355,11 @@ func loadSession(logger *logging.Logger, tracker *state.Tracker, identifier stri
 			AlphaState: &EndpointState{},
 			BetaState:  &EndpointState{},
 		},
+		events:         events,
+		phaseDurations: newPhaseDurations(),
+		history:        history,
 	}
+	controller.stateLock = state.NewObservedTrackingLock(tracker, controller.observeState)
 
 	// If the session isn't marked as paused, start a synchronization loop.
 	if !session.Paused {
@@ -307,6 +375,80 @@ func loadSession(logger *logging.Logger, tracker *state.Tracker, identifier stri
 	return controller, nil
 }
 
+// newEvent creates a new event of the specified kind for the session. The
+// caller must hold the state lock.
+func (c *controller) newEvent(kind EventKind) *Event {
+	return &Event{
+		Kind:        kind,
+		Time:        timestamppb.Now(),
+		Session:     c.session.Identifier,
+		SessionName: c.session.Name,
+		Status:      c.state.Status,
+	}
+}
+
+// observeState is the state lock observer. It compares the current state with
+// the state seen at the last observation and publishes any resulting events.
+// It is invoked with the state lock held.
+func (c *controller) observeState() {
+	// Summarize the current state and swap it in.
+	current := summarizeState(c.state)
+	previous := c.eventSummary
+	c.eventSummary = current
+
+	// Publish status changes.
+	if current.status != previous.status {
+		event := c.newEvent(EventKind_EventKindStatusChanged)
+		event.PreviousStatus = previous.status
+		c.events.publish(c.session, event)
+	}
+
+	// Publish errors.
+	if current.lastError != previous.lastError && current.lastError != "" {
+		event := c.newEvent(EventKind_EventKindError)
+		event.Error = current.lastError
+		c.events.publish(c.session, event)
+	}
+
+	// Publish conflict changes.
+	if current.conflicts != previous.conflicts {
+		kind := EventKind_EventKindConflictsAppeared
+		if current.conflicts == 0 {
+			kind = EventKind_EventKindConflictsCleared
+		}
+		event := c.newEvent(kind)
+		event.Conflicts = current.conflicts
+		c.events.publish(c.session, event)
+	}
+
+	// Publish problem changes.
+	if current.alphaProblems != previous.alphaProblems || current.betaProblems != previous.betaProblems {
+		event := c.newEvent(EventKind_EventKindProblemsChanged)
+		event.AlphaProblems = current.alphaProblems
+		event.BetaProblems = current.betaProblems
+		c.events.publish(c.session, event)
+	}
+
+	// Publish cycle completions. The cycle count is reset when the state is
+	// replaced, so we only look for increments.
+	if current.successfulCycles > previous.successfulCycles {
+		event := c.newEvent(EventKind_EventKindCycleCompleted)
+		event.AlphaChanges = c.cycleAlphaChanges
+		event.BetaChanges = c.cycleBetaChanges
+		event.Conflicts = current.conflicts
+		c.events.publish(c.session, event)
+	}
+}
+
+// publishLifecycleEvent publishes a session lifecycle event (i.e. creation or
+// termination).
+func (c *controller) publishLifecycleEvent(kind EventKind) {
+	c.stateLock.Lock()
+	event := c.newEvent(kind)
+	c.stateLock.UnlockWithoutNotify()
+	c.events.publish(c.session, event)
+}
+
 // currentState creates a static snapshot of the current session state.
 func (c *controller) currentState() *State {
 	// Lock the session state and defer its release. It's very important that we
@@ -319,6 +461,41 @@ func (c *controller) currentState() *State {
 	return proto.Clone(c.state).(*State)
 }
 
+// currentHistory creates a snapshot of the current cycle history.
+func (c *controller) currentHistory() *History {
+	// Lock the history and defer its release.
+	c.historyLock.Lock()
+	defer c.historyLock.Unlock()
+
+	// Create a static copy of the history.
+	return proto.Clone(c.history).(*History)
+}
+
+// currentArchive loads the session's current ancestor archive from disk.
+func (c *controller) currentArchive() (*core.Archive, error) {
+	// Lock the controller's lifecycle and defer its release. This ensures that
+	// the archive isn't concurrently reset or removed.
+	c.lifecycleLock.Lock()
+	defer c.lifecycleLock.Unlock()
+
+	// Don't allow any archive operations if the controller is disabled.
+	if c.disabled {
+		return nil, errors.New("controller disabled")
+	}
+
+	// Load and validate the archive. The synchronization loop saves the archive
+	// atomically, so it's safe to read while the loop is running.
+	archive := &core.Archive{}
+	if err := encoding.LoadAndUnmarshalProtobuf(c.archivePath, archive); err != nil {
+		return nil, fmt.Errorf("unable to load archive: %w", err)
+	} else if err = archive.EnsureValid(true); err != nil {
+		return nil, fmt.Errorf("invalid archive found on disk: %w", err)
+	}
+
+	// Success.
+	return archive, nil
+}
+
 // flush attempts to force a synchronization cycle for the session. If wait is
 // specified, then the method will wait until a post-flush synchronization cycle
 // has completed. The provided context (which must be non-nil) can terminate
@@ -616,6 +793,9 @@ func (c *controller) halt(_ context.Context, mode controllerHaltMode, prompter s
 		// Wipe the session information from disk.
 		sessionRemoveErr := os.Remove(c.sessionPath)
 		archiveRemoveErr := os.Remove(c.archivePath)
+		if err := os.Remove(c.historyPath); err != nil && !os.IsNotExist(err) {
+			c.logger.Warn("Unable to remove cycle history from disk:", err)
+		}
 		if sessionRemoveErr != nil {
 			return fmt.Errorf("unable to remove session from disk: %w", sessionRemoveErr)
 		} else if archiveRemoveErr != nil {
@@ -665,6 +845,92 @@ func (c *controller) reset(ctx context.Context, prompter string) error {
 	return nil
 }
 
+// effectiveHashingAlgorithm computes the hashing algorithm that will be used by
+// a session with the specified version and configuration.
+func effectiveHashingAlgorithm(version Version, configuration *Configuration) hashing.Algorithm {
+	if configuration.HashingAlgorithm.IsDefault() {
+		return version.DefaultHashingAlgorithm()
+	}
+	return configuration.HashingAlgorithm
+}
+
+// update replaces the session configuration by pausing the session (if it's
+// running), saving the new configuration to disk, and then resuming the
+// session (if it was previously running). The ancestor archive is preserved,
+// so configuration changes that would invalidate it are rejected.
+func (c *controller) update(
+	ctx context.Context,
+	configuration, configurationAlpha, configurationBeta *Configuration,
+	prompter string,
+) error {
+	// Lock the controller's lifecycle and defer its release.
+	c.lifecycleLock.Lock()
+	defer c.lifecycleLock.Unlock()
+
+	// Don't allow any update operations if the controller is disabled.
+	if c.disabled {
+		return errors.New("controller disabled")
+	}
+
+	// Ensure that the effective hashing algorithm is unchanged. The digests
+	// stored in the ancestor archive and in endpoint scan caches are computed
+	// using this algorithm, and the latter can't be invalidated from here, so
+	// even a reset wouldn't be sufficient to make this change safe.
+	if effectiveHashingAlgorithm(c.session.Version, configuration) !=
+		effectiveHashingAlgorithm(c.session.Version, c.session.Configuration) {
+		return errors.New("hashing algorithm cannot be changed for an existing session")
+	}
+
+	// Check if the session is currently running.
+	running := c.cancel != nil
+
+	// If the session is running, pause it.
+	if running {
+		if err := c.halt(ctx, controllerHaltModePause, prompter, true); err != nil {
+			return fmt.Errorf("unable to pause session: %w", err)
+		}
+	}
+
+	// Update status.
+	prompting.Message(prompter, fmt.Sprintf("Updating session %s...", c.session.Identifier))
+
+	// Save the updated session to disk and, only if that succeeds, update the
+	// in-memory configuration.
+	c.logger.Infof("Updating configuration")
+	c.stateLock.Lock()
+	updated := proto.Clone(c.session).(*Session)
+	updated.Configuration = configuration
+	updated.ConfigurationAlpha = configurationAlpha
+	updated.ConfigurationBeta = configurationBeta
+	saveErr := encoding.MarshalAndSaveProtobuf(c.sessionPath, updated)
+	if saveErr == nil {
+		c.session.Configuration = configuration
+		c.session.ConfigurationAlpha = configurationAlpha
+		c.session.ConfigurationBeta = configurationBeta
+		c.mergedAlphaConfiguration = MergeConfigurations(configuration, configurationAlpha)
+		c.mergedBetaConfiguration = MergeConfigurations(configuration, configurationBeta)
+	}
+	c.stateLock.Unlock()
+
+	// Resume the session if it was previously running. We do this even if
+	// saving failed, in which case the session will continue to run with its
+	// previous configuration.
+	var resumeErr error
+	if running {
+		resumeErr = c.resume(ctx, prompter, true)
+	}
+
+	// Report any errors.
+	if saveErr != nil {
+		return fmt.Errorf("unable to save session: %w", saveErr)
+	} else if resumeErr != nil {
+		return fmt.Errorf("unable to resume session: %w", resumeErr)
+	}
+
+	// Success.
+	return nil
+}
+
 var (
 	// errHaltedForSafety is a sentinel error indicating that a safety check
 	// wants the synchronization loop to be halted until manually resumed.
@@ -907,6 +1173,10 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 	// Create variables to track our reasons for skipping polling.
 	var skippingPollingDueToScanError, skippingPollingDueToMissingFiles bool
 
+	// Track information about the current cycle for history recording. The
+	// first cycle is attributed to startup, since we skip polling.
+	record := &cycleRecord{trigger: CycleTrigger_CycleTriggerStartup}
+
 	// Loop until there is a synchronization error.
 	for {
 		// Unless we've been requested to skip polling, wait for a dirty state
@@ -976,10 +1246,12 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 			select {
 			case αPollErr = <-αPollResults:
 				c.logger.Debug("Triggered by alpha endpoint")
+				record.trigger = CycleTrigger_CycleTriggerAlpha
 				pollCancel()
 				βPollErr = <-βPollResults
 			case βPollErr = <-βPollResults:
 				c.logger.Debug("Triggered by beta endpoint")
+				record.trigger = CycleTrigger_CycleTriggerBeta
 				pollCancel()
 				αPollErr = <-αPollResults
 			case flushRequest = <-c.flushRequests:
@@ -987,6 +1259,7 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 					panic("unbuffered flush request")
 				}
 				c.logger.Debug("Triggered by flush request")
+				record.trigger = CycleTrigger_CycleTriggerFlush
 				pollCancel()
 				αPollErr = <-αPollResults
 				βPollErr = <-βPollResults
@@ -1017,6 +1290,7 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 		c.stateLock.Lock()
 		c.state.Status = Status_Scanning
 		c.stateLock.Unlock()
+		scanStart := time.Now()
 		forceFullScan := flushRequest != nil
 		var αSnapshot, βSnapshot *core.Snapshot
 		var αScanErr, βScanErr error
@@ -1032,6 +1306,8 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 			scanDone.Done()
 		}()
 		scanDone.Wait()
+		record.scanDuration = time.Since(scanStart)
+		c.phaseDurations[cyclePhaseScan].Observe(record.scanDuration.Seconds())
 
 		// Check if cancellation occurred during scanning.
 		select {
@@ -1089,9 +1365,11 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 				}
 			}
 
-			// Retry.
+			// Retry. We reset cycle tracking so that the retried cycle's
+			// history entry doesn't reflect the failed scan.
 			skipPolling = true
 			skippingPollingDueToScanError = true
+			record = &cycleRecord{trigger: CycleTrigger_CycleTriggerRetry}
 			continue
 		}
 		skippingPollingDueToScanError = false
@@ -1129,6 +1407,7 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 		c.state.BetaState.ScanProblems = βContent.Problems()
 		c.state.Status = Status_Reconciling
 		c.stateLock.Unlock()
+		reconcileStart := time.Now()
 
 		// If we're propagating executability bits and one endpoint preserves
 		// executability information while the the other does not, then
@@ -1227,10 +1506,14 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 			return errHaltedForSafety
 		}
 
+		record.reconcileDuration = time.Since(reconcileStart)
+		c.phaseDurations[cyclePhaseReconcile].Observe(record.reconcileDuration.Seconds())
+
 		// Stage files on alpha.
 		c.stateLock.Lock()
 		c.state.Status = Status_StagingAlpha
 		c.stateLock.Unlock()
+		stageStart := time.Now()
 		if paths, digests := core.TransitionDependencies(αTransitions); len(paths) > 0 {
 			c.logger.Debugf("Staging %d file(s) on alpha", len(paths))
 			filteredPaths, signatures, receiver, err := alpha.Stage(paths, digests)
@@ -1247,6 +1530,9 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 				monitor := func(state *rsync.ReceiverState) error {
 					c.stateLock.Lock()
 					if state == nil {
+						if c.state.AlphaState.StagingProgress != nil {
+							c.alphaStagedBytes += c.state.AlphaState.StagingProgress.TotalReceivedSize
+						}
 						c.state.AlphaState.StagingProgress = nil
 					} else {
 						if c.state.AlphaState.StagingProgress == nil {
@@ -1285,6 +1571,9 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 				monitor := func(state *rsync.ReceiverState) error {
 					c.stateLock.Lock()
 					if state == nil {
+						if c.state.BetaState.StagingProgress != nil {
+							c.betaStagedBytes += c.state.BetaState.StagingProgress.TotalReceivedSize
+						}
 						c.state.BetaState.StagingProgress = nil
 					} else {
 						if c.state.BetaState.StagingProgress == nil {
@@ -1303,6 +1592,9 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 			}
 		}
 
+		record.stageDuration = time.Since(stageStart)
+		c.phaseDurations[cyclePhaseStage].Observe(record.stageDuration.Seconds())
+
 		// Perform transitions on both endpoints in parallel. For each side that
 		// doesn't completely error out, convert its results to ancestor
 		// changes. Transition errors are checked later, once the ancestor has
@@ -1310,6 +1602,7 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 		c.stateLock.Lock()
 		c.state.Status = Status_Transitioning
 		c.stateLock.Unlock()
+		transitionStart := time.Now()
 		var αResults, βResults []*core.Entry
 		var αProblems, βProblems []*core.Problem
 		var αMissingFiles, βMissingFiles bool
@@ -1347,6 +1640,8 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 			}()
 		}
 		transitionDone.Wait()
+		record.transitionDuration = time.Since(transitionStart)
+		c.phaseDurations[cyclePhaseTransition].Observe(record.transitionDuration.Seconds())
 
 		// Record transition problems.
 		c.stateLock.Lock()
@@ -1409,8 +1704,27 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 			skippingPollingDueToMissingFiles = false
 		}
 
-		// Increment the synchronization cycle count.
+		// Record the cycle in the session history and save it. History is
+		// purely informational, so a failure to save it isn't terminal.
+		c.historyLock.Lock()
+		appendHistoryEntry(c.history, record.entry(αTransitions, αResults, βTransitions, βResults))
+		if err := encoding.MarshalAndSaveProtobuf(c.historyPath, c.history); err != nil {
+			c.logger.Warn("Unable to save cycle history:", err)
+		}
+		c.historyLock.Unlock()
+
+		// Reset cycle tracking. If we're skipping polling, then the next cycle
+		// is a retry.
+		record = &cycleRecord{}
+		if skipPolling {
+			record.trigger = CycleTrigger_CycleTriggerRetry
+		}
+
+		// Record the cycle's change counts and increment the synchronization
+		// cycle count.
 		c.stateLock.Lock()
+		c.cycleAlphaChanges = uint64(len(αChanges))
+		c.cycleBetaChanges = uint64(len(βChanges))
 		c.state.SuccessfulCycles++
 		c.stateLock.Unlock()
 
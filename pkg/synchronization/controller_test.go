@@ -1,3 +1,362 @@
 package synchronization
 
-// TODO: Implement.
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/comparison"
+	"github.com/mutagen-io/mutagen/pkg/encoding"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/state"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/hashing"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/rsync"
+	urlpkg "github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// testEndpoint is an in-memory Endpoint implementation for controller tests.
+// Both endpoints of a session are always empty, so synchronization cycles never
+// require staging or transitions.
+type testEndpoint struct {
+	// scan, if non-nil, is invoked before each scan and may return a scan
+	// error to simulate scan failures.
+	scan func() (error, bool)
+}
+
+// Poll implements Endpoint.Poll.
+func (e *testEndpoint) Poll(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+// Scan implements Endpoint.Scan.
+func (e *testEndpoint) Scan(_ context.Context, _ *core.Entry, _ bool) (*core.Snapshot, error, bool) {
+	if e.scan != nil {
+		if err, tryAgain := e.scan(); err != nil {
+			return nil, err, tryAgain
+		}
+	}
+	return &core.Snapshot{}, nil, false
+}
+
+// Stage implements Endpoint.Stage.
+func (e *testEndpoint) Stage(_ []string, _ [][]byte) ([]string, []*rsync.Signature, rsync.Receiver, error) {
+	return nil, nil, nil, errors.New("staging not supported")
+}
+
+// Supply implements Endpoint.Supply.
+func (e *testEndpoint) Supply(_ []string, _ []*rsync.Signature, _ rsync.Receiver) error {
+	return errors.New("supplying not supported")
+}
+
+// Transition implements Endpoint.Transition.
+func (e *testEndpoint) Transition(_ context.Context, _ []*core.Change) ([]*core.Entry, []*core.Problem, bool, error) {
+	return nil, nil, false, errors.New("transitions not supported")
+}
+
+// Shutdown implements Endpoint.Shutdown.
+func (e *testEndpoint) Shutdown() error {
+	return nil
+}
+
+// testProtocolHandler is a ProtocolHandler that creates testEndpoint instances
+// and records the configurations used to connect to them.
+type testProtocolHandler struct {
+	// alpha and beta are the endpoints to return for alpha and beta.
+	alpha, beta *testEndpoint
+	// lock guards configurations.
+	lock sync.Mutex
+	// configurations are the configurations provided to Connect, in order.
+	configurations []*Configuration
+}
+
+// Connect implements ProtocolHandler.Connect.
+func (h *testProtocolHandler) Connect(
+	_ context.Context,
+	_ *logging.Logger,
+	_ *urlpkg.URL,
+	_ string,
+	_ string,
+	_ Version,
+	configuration *Configuration,
+	alpha bool,
+) (Endpoint, error) {
+	h.lock.Lock()
+	h.configurations = append(h.configurations, configuration)
+	h.lock.Unlock()
+	if alpha {
+		return h.alpha, nil
+	}
+	return h.beta, nil
+}
+
+// connectionCount returns the number of connections that have been made.
+func (h *testProtocolHandler) connectionCount() int {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return len(h.configurations)
+}
+
+// lastConfiguration returns the configuration used for the most recent
+// connection.
+func (h *testProtocolHandler) lastConfiguration() *Configuration {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return h.configurations[len(h.configurations)-1]
+}
+
+var (
+	// testAlphaURL is the alpha URL used for test sessions.
+	testAlphaURL = &urlpkg.URL{Kind: urlpkg.Kind_Synchronization, Protocol: urlpkg.Protocol_Local, Path: "/alpha"}
+	// testBetaURL is the beta URL used for test sessions.
+	testBetaURL = &urlpkg.URL{Kind: urlpkg.Kind_Synchronization, Protocol: urlpkg.Protocol_Local, Path: "/beta"}
+)
+
+// setupTestEnvironment registers the specified protocol handler for the local
+// protocol and redirects the Mutagen data directory to a temporary directory
+// for the duration of the test.
+func setupTestEnvironment(t *testing.T, handler *testProtocolHandler) {
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+	ProtocolHandlers[urlpkg.Protocol_Local] = handler
+	t.Cleanup(func() {
+		delete(ProtocolHandlers, urlpkg.Protocol_Local)
+	})
+}
+
+// newTestController creates a controller for a session whose endpoints are
+// handled by the specified protocol handler.
+func newTestController(t *testing.T, handler *testProtocolHandler, configuration *Configuration, paused bool) *controller {
+	// Set up the environment.
+	setupTestEnvironment(t, handler)
+
+	// Create the controller.
+	controller, err := newSession(
+		context.Background(),
+		nil,
+		state.NewTracker(),
+		newEventBroadcaster(),
+		"sync_test",
+		testAlphaURL, testBetaURL,
+		configuration, &Configuration{}, &Configuration{},
+		"",
+		nil,
+		paused,
+		nil,
+		"",
+	)
+	if err != nil {
+		t.Fatal("unable to create session:", err)
+	}
+	t.Cleanup(func() {
+		controller.halt(context.Background(), controllerHaltModeShutdown, "", false)
+	})
+
+	// Success.
+	return controller
+}
+
+// waitForHistory waits for the controller to record the specified number of
+// history entries.
+func waitForHistory(t *testing.T, controller *controller, count int) *History {
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		if history := controller.currentHistory(); len(history.Entries) >= count {
+			return history
+		} else if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for synchronization cycle")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestControllerScanRetryHistory tests that cycles retried after a scan failure
+// are recorded as retries in the session history.
+func TestControllerScanRetryHistory(t *testing.T) {
+	// Create a session whose first alpha scan fails with a retry
+	// recommendation.
+	var failed bool
+	handler := &testProtocolHandler{
+		alpha: &testEndpoint{scan: func() (error, bool) {
+			if !failed {
+				failed = true
+				return errors.New("concurrent modification"), true
+			}
+			return nil, false
+		}},
+		beta: &testEndpoint{},
+	}
+	controller := newTestController(t, handler, &Configuration{}, false)
+
+	// Wait for the first cycle to be recorded and verify its trigger.
+	history := waitForHistory(t, controller, 1)
+	if trigger := history.Entries[0].Trigger; trigger != CycleTrigger_CycleTriggerRetry {
+		t.Error("retried cycle has incorrect trigger:", trigger)
+	}
+}
+
+// loadTestSession loads the session stored on disk for a controller.
+func loadTestSession(t *testing.T, controller *controller) *Session {
+	session := &Session{}
+	if err := encoding.LoadAndUnmarshalProtobuf(controller.sessionPath, session); err != nil {
+		t.Fatal("unable to load session:", err)
+	}
+	return session
+}
+
+// TestEffectiveHashingAlgorithm tests effectiveHashingAlgorithm.
+func TestEffectiveHashingAlgorithm(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		configuration *Configuration
+		expected      hashing.Algorithm
+	}{
+		{&Configuration{}, hashing.Algorithm_AlgorithmSHA1},
+		{&Configuration{HashingAlgorithm: hashing.Algorithm_AlgorithmSHA1}, hashing.Algorithm_AlgorithmSHA1},
+		{&Configuration{HashingAlgorithm: hashing.Algorithm_AlgorithmSHA256}, hashing.Algorithm_AlgorithmSHA256},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		if algorithm := effectiveHashingAlgorithm(Version_Version1, testCase.configuration); algorithm != testCase.expected {
+			t.Errorf("test case %d: hashing algorithm does not match expected: %s != %s", i, algorithm, testCase.expected)
+		}
+	}
+}
+
+// TestControllerUpdateRunning tests updating the configuration of a running
+// session.
+func TestControllerUpdateRunning(t *testing.T) {
+	// Create a running session and wait for it to complete a cycle.
+	handler := &testProtocolHandler{alpha: &testEndpoint{}, beta: &testEndpoint{}}
+	controller := newTestController(t, handler, &Configuration{}, false)
+	waitForHistory(t, controller, 1)
+
+	// Update the configuration. Explicitly specifying the default hashing
+	// algorithm is allowed since it doesn't change the effective algorithm.
+	configuration := &Configuration{
+		HashingAlgorithm: hashing.Algorithm_AlgorithmSHA1,
+		Ignores:          []string{"node_modules"},
+	}
+	if err := controller.update(context.Background(), configuration, &Configuration{}, &Configuration{}, ""); err != nil {
+		t.Fatal("unable to update session:", err)
+	}
+
+	// Verify that the session was reconnected using the new configuration and
+	// that it's still running.
+	if count := handler.connectionCount(); count != 4 {
+		t.Error("unexpected connection count:", count)
+	} else if !comparison.StringSlicesEqual(handler.lastConfiguration().Ignores, configuration.Ignores) {
+		t.Error("session not reconnected with updated configuration")
+	}
+	if controller.cancel == nil {
+		t.Error("session not resumed after update")
+	}
+
+	// Verify that the updated configuration was persisted and that the session
+	// isn't marked as paused.
+	if session := loadTestSession(t, controller); !session.Configuration.Equal(configuration) {
+		t.Error("updated configuration not saved")
+	} else if session.Paused {
+		t.Error("session saved as paused")
+	}
+}
+
+// TestControllerUpdatePaused tests updating the configuration of a paused
+// session.
+func TestControllerUpdatePaused(t *testing.T) {
+	// Create a paused session.
+	handler := &testProtocolHandler{alpha: &testEndpoint{}, beta: &testEndpoint{}}
+	controller := newTestController(t, handler, &Configuration{}, true)
+
+	// Update the configuration.
+	configuration := &Configuration{Ignores: []string{"node_modules"}}
+	if err := controller.update(context.Background(), configuration, &Configuration{}, &Configuration{}, ""); err != nil {
+		t.Fatal("unable to update session:", err)
+	}
+
+	// Verify that the session wasn't resumed.
+	if count := handler.connectionCount(); count != 0 {
+		t.Error("paused session connected during update")
+	}
+	if controller.cancel != nil {
+		t.Error("paused session resumed during update")
+	}
+
+	// Verify that the updated configuration was applied and persisted.
+	if !controller.session.Configuration.Equal(configuration) {
+		t.Error("updated configuration not applied")
+	}
+	if session := loadTestSession(t, controller); !session.Configuration.Equal(configuration) {
+		t.Error("updated configuration not saved")
+	} else if !session.Paused {
+		t.Error("session not saved as paused")
+	}
+}
+
+// TestControllerUpdateSaveFailure tests that a failure to save the updated
+// session leaves the existing configuration and state in place.
+func TestControllerUpdateSaveFailure(t *testing.T) {
+	// Create a paused session and then redirect its session path to a location
+	// where it can't be saved.
+	handler := &testProtocolHandler{alpha: &testEndpoint{}, beta: &testEndpoint{}}
+	original := &Configuration{Ignores: []string{"build"}}
+	controller := newTestController(t, handler, original, true)
+	sessionPath := controller.sessionPath
+	controller.sessionPath = filepath.Join(t.TempDir(), "missing", "session")
+
+	// Attempt to update the configuration.
+	configuration := &Configuration{Ignores: []string{"node_modules"}}
+	if err := controller.update(context.Background(), configuration, &Configuration{}, &Configuration{}, ""); err == nil {
+		t.Fatal("update succeeded despite save failure")
+	}
+
+	// Verify that the existing configuration and state are unchanged.
+	if !controller.session.Configuration.Equal(original) {
+		t.Error("configuration changed despite save failure")
+	}
+	if !comparison.StringSlicesEqual(controller.mergedAlphaConfiguration.Ignores, original.Ignores) {
+		t.Error("merged alpha configuration changed despite save failure")
+	}
+	if !controller.session.Paused || controller.cancel != nil {
+		t.Error("session state changed despite save failure")
+	}
+	controller.sessionPath = sessionPath
+	if session := loadTestSession(t, controller); !session.Configuration.Equal(original) {
+		t.Error("saved configuration changed despite save failure")
+	}
+}
+
+// TestControllerUpdateHashingAlgorithmChange tests that updates that change the
+// effective hashing algorithm are rejected without interrupting the session.
+func TestControllerUpdateHashingAlgorithmChange(t *testing.T) {
+	// Create a running session and wait for it to complete a cycle.
+	handler := &testProtocolHandler{alpha: &testEndpoint{}, beta: &testEndpoint{}}
+	original := &Configuration{Ignores: []string{"build"}}
+	controller := newTestController(t, handler, original, false)
+	waitForHistory(t, controller, 1)
+
+	// Attempt to change the hashing algorithm.
+	configuration := &Configuration{HashingAlgorithm: hashing.Algorithm_AlgorithmSHA256}
+	if err := controller.update(context.Background(), configuration, &Configuration{}, &Configuration{}, ""); err == nil {
+		t.Fatal("hashing algorithm change allowed")
+	}
+
+	// Verify that the session wasn't interrupted and that its configuration is
+	// unchanged.
+	if count := handler.connectionCount(); count != 2 {
+		t.Error("session reconnected despite rejected update")
+	}
+	if controller.cancel == nil {
+		t.Error("session halted despite rejected update")
+	}
+	if !controller.session.Configuration.Equal(original) {
+		t.Error("configuration changed despite rejected update")
+	}
+	if session := loadTestSession(t, controller); !session.Configuration.Equal(original) {
+		t.Error("saved configuration changed despite rejected update")
+	}
+}
@@ -27,6 +27,20 @@ func (m IgnoreVCSMode) MarshalJSON() ([]byte, error) {
 	return []byte(result), nil
 }
 
+// MarshalYAML implements gopkg.in/yaml.v2.Marshaler.MarshalYAML.
+func (m IgnoreVCSMode) MarshalYAML() (any, error) {
+	switch m {
+	case IgnoreVCSMode_IgnoreVCSModeDefault:
+		return nil, errors.New("default VCS ignore mode has no YAML representation")
+	case IgnoreVCSMode_IgnoreVCSModeIgnore:
+		return true, nil
+	case IgnoreVCSMode_IgnoreVCSModePropagate:
+		return false, nil
+	default:
+		return nil, fmt.Errorf("invalid VCS ignore mode: %d", m)
+	}
+}
+
 // UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
 func (m *IgnoreVCSMode) UnmarshalText(textBytes []byte) error {
 	// Convert the bytes to a string.
@@ -28,6 +28,34 @@ func TestIgnoreVCSModeIsDefault(t *testing.T) {
 	}
 }
 
+// TestIgnoreVCSModeMarshalYAML tests IgnoreVCSMode.MarshalYAML.
+func TestIgnoreVCSModeMarshalYAML(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		mode          IgnoreVCSMode
+		expected      any
+		expectFailure bool
+	}{
+		{IgnoreVCSMode_IgnoreVCSModeDefault, nil, true},
+		{IgnoreVCSMode_IgnoreVCSModeIgnore, true, false},
+		{IgnoreVCSMode_IgnoreVCSModePropagate, false, false},
+		{IgnoreVCSMode_IgnoreVCSModePropagate + 1, nil, true},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		if result, err := test.mode.MarshalYAML(); err != nil {
+			if !test.expectFailure {
+				t.Errorf("test index %d: unable to marshal mode: %v", i, err)
+			}
+		} else if test.expectFailure {
+			t.Errorf("test index %d: marshaling succeeded unexpectedly", i)
+		} else if result != test.expected {
+			t.Errorf("test index %d: marshaled value (%v) does not match expected (%v)", i, result, test.expected)
+		}
+	}
+}
+
 // TestIgnoreVCSModeUnmarshalText tests IgnoreVCSMode.UnmarshalText.
 func TestIgnoreVCSModeUnmarshalText(t *testing.T) {
 	// Define test cases.
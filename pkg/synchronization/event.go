@@ -0,0 +1,219 @@
+package synchronization
+
+import (
+	"errors"
+	"sync"
+)
+
+const (
+	// eventSubscriptionBufferSize is the number of events that can be buffered
+	// for a single event subscription before the subscription is considered to
+	// have fallen behind.
+	eventSubscriptionBufferSize = 256
+)
+
+var (
+	// ErrEventSubscriberFellBehind indicates that an event subscriber failed
+	// to receive events quickly enough and was disconnected.
+	ErrEventSubscriberFellBehind = errors.New("event subscriber fell behind")
+	// ErrEventBroadcastingTerminated indicates that event broadcasting was
+	// terminated (e.g. due to the manager shutting down).
+	ErrEventBroadcastingTerminated = errors.New("event broadcasting terminated")
+)
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (k EventKind) MarshalText() ([]byte, error) {
+	var result string
+	switch k {
+	case EventKind_EventKindSessionCreated:
+		result = "session-created"
+	case EventKind_EventKindSessionTerminated:
+		result = "session-terminated"
+	case EventKind_EventKindStatusChanged:
+		result = "status-changed"
+	case EventKind_EventKindCycleCompleted:
+		result = "cycle-completed"
+	case EventKind_EventKindConflictsAppeared:
+		result = "conflicts-appeared"
+	case EventKind_EventKindConflictsCleared:
+		result = "conflicts-cleared"
+	case EventKind_EventKindProblemsChanged:
+		result = "problems-changed"
+	case EventKind_EventKindError:
+		result = "error"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// Description returns a human-readable description of an event kind.
+func (k EventKind) Description() string {
+	switch k {
+	case EventKind_EventKindSessionCreated:
+		return "Session created"
+	case EventKind_EventKindSessionTerminated:
+		return "Session terminated"
+	case EventKind_EventKindStatusChanged:
+		return "Status changed"
+	case EventKind_EventKindCycleCompleted:
+		return "Cycle completed"
+	case EventKind_EventKindConflictsAppeared:
+		return "Conflicts appeared"
+	case EventKind_EventKindConflictsCleared:
+		return "Conflicts cleared"
+	case EventKind_EventKindProblemsChanged:
+		return "Problems changed"
+	case EventKind_EventKindError:
+		return "Error"
+	default:
+		return "Unknown"
+	}
+}
+
+// EnsureValid ensures that Event's invariants are respected.
+func (e *Event) EnsureValid() error {
+	// A nil event is not valid.
+	if e == nil {
+		return errors.New("nil event")
+	}
+
+	// Ensure that the event kind is known.
+	if e.Kind == EventKind_EventKindUnknown {
+		return errors.New("unknown event kind")
+	}
+
+	// Ensure that the event time is valid.
+	if err := e.Time.CheckValid(); err != nil {
+		return errors.New("invalid event time")
+	}
+
+	// Ensure that a session identifier is present.
+	if e.Session == "" {
+		return errors.New("empty session identifier")
+	}
+
+	// Success.
+	return nil
+}
+
+// eventSubscription represents a single subscription to an eventBroadcaster.
+type eventSubscription struct {
+	// filter determines whether or not events for a particular session should
+	// be delivered to the subscription.
+	filter func(*Session) bool
+	// events is the event delivery channel. It is closed when the subscription
+	// is terminated by the broadcaster.
+	events chan *Event
+	// err records the reason for termination by the broadcaster. It is only
+	// safe to read once events has been closed.
+	err error
+}
+
+// eventBroadcaster distributes session events to subscribers.
+type eventBroadcaster struct {
+	// lock guards subscriptions and terminated.
+	lock sync.Mutex
+	// subscriptions is the set of active subscriptions.
+	subscriptions map[*eventSubscription]bool
+	// terminated indicates whether or not broadcasting has been terminated.
+	terminated bool
+}
+
+// newEventBroadcaster creates a new event broadcaster.
+func newEventBroadcaster() *eventBroadcaster {
+	return &eventBroadcaster{
+		subscriptions: make(map[*eventSubscription]bool),
+	}
+}
+
+// subscribe registers a new subscription with the specified filter.
+func (b *eventBroadcaster) subscribe(filter func(*Session) bool) *eventSubscription {
+	// Create the subscription.
+	subscription := &eventSubscription{
+		filter: filter,
+		events: make(chan *Event, eventSubscriptionBufferSize),
+	}
+
+	// Register the subscription, unless broadcasting has been terminated.
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.terminated {
+		subscription.err = ErrEventBroadcastingTerminated
+		close(subscription.events)
+	} else {
+		b.subscriptions[subscription] = true
+	}
+
+	// Done.
+	return subscription
+}
+
+// unsubscribe deregisters a subscription. It is safe to call even if the
+// subscription has already been terminated by the broadcaster.
+func (b *eventBroadcaster) unsubscribe(subscription *eventSubscription) {
+	b.lock.Lock()
+	delete(b.subscriptions, subscription)
+	b.lock.Unlock()
+}
+
+// publish distributes an event for the specified session to all matching
+// subscriptions. It never blocks: subscriptions that can't accept the event are
+// terminated with ErrEventSubscriberFellBehind.
+func (b *eventBroadcaster) publish(session *Session, event *Event) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for subscription := range b.subscriptions {
+		if !subscription.filter(session) {
+			continue
+		}
+		select {
+		case subscription.events <- event:
+		default:
+			subscription.err = ErrEventSubscriberFellBehind
+			close(subscription.events)
+			delete(b.subscriptions, subscription)
+		}
+	}
+}
+
+// terminate terminates all subscriptions and prevents new subscriptions.
+func (b *eventBroadcaster) terminate() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.terminated = true
+	for subscription := range b.subscriptions {
+		subscription.err = ErrEventBroadcastingTerminated
+		close(subscription.events)
+		delete(b.subscriptions, subscription)
+	}
+}
+
+// eventSummary captures the portions of session state that are relevant for
+// event generation.
+type eventSummary struct {
+	// status is the session status.
+	status Status
+	// lastError is the last session error.
+	lastError string
+	// successfulCycles is the number of successful synchronization cycles.
+	successfulCycles uint64
+	// conflicts is the number of conflicts.
+	conflicts uint64
+	// alphaProblems is the number of alpha scan and transition problems.
+	alphaProblems uint64
+	// betaProblems is the number of beta scan and transition problems.
+	betaProblems uint64
+}
+
+// summarizeState computes an event summary for a session state.
+func summarizeState(state *State) eventSummary {
+	return eventSummary{
+		status:           state.Status,
+		lastError:        state.LastError,
+		successfulCycles: state.SuccessfulCycles,
+		conflicts:        uint64(len(state.Conflicts)),
+		alphaProblems:    uint64(len(state.AlphaState.ScanProblems) + len(state.AlphaState.TransitionProblems)),
+		betaProblems:     uint64(len(state.BetaState.ScanProblems) + len(state.BetaState.TransitionProblems)),
+	}
+}
@@ -0,0 +1,399 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.12
+// source: synchronization/event.proto
+
+package synchronization
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// EventKind encodes the kind of a synchronization session event.
+type EventKind int32
+
+const (
+	// EventKind_EventKindUnknown indicates an unknown or unspecified event
+	// kind. It is never emitted by the daemon.
+	EventKind_EventKindUnknown EventKind = 0
+	// EventKind_EventKindSessionCreated indicates that a session was created.
+	EventKind_EventKindSessionCreated EventKind = 1
+	// EventKind_EventKindSessionTerminated indicates that a session was
+	// terminated.
+	EventKind_EventKindSessionTerminated EventKind = 2
+	// EventKind_EventKindStatusChanged indicates that a session's status
+	// changed.
+	EventKind_EventKindStatusChanged EventKind = 3
+	// EventKind_EventKindCycleCompleted indicates that a session completed a
+	// synchronization cycle.
+	EventKind_EventKindCycleCompleted EventKind = 4
+	// EventKind_EventKindConflictsAppeared indicates that a session's conflict
+	// count became non-zero or changed.
+	EventKind_EventKindConflictsAppeared EventKind = 5
+	// EventKind_EventKindConflictsCleared indicates that a session's conflicts
+	// were resolved.
+	EventKind_EventKindConflictsCleared EventKind = 6
+	// EventKind_EventKindProblemsChanged indicates that a session's scan or
+	// transition problem counts changed.
+	EventKind_EventKindProblemsChanged EventKind = 7
+	// EventKind_EventKindError indicates that a session encountered an error.
+	EventKind_EventKindError EventKind = 8
+)
+
+// Enum value maps for EventKind.
+var (
+	EventKind_name = map[int32]string{
+		0: "EventKindUnknown",
+		1: "EventKindSessionCreated",
+		2: "EventKindSessionTerminated",
+		3: "EventKindStatusChanged",
+		4: "EventKindCycleCompleted",
+		5: "EventKindConflictsAppeared",
+		6: "EventKindConflictsCleared",
+		7: "EventKindProblemsChanged",
+		8: "EventKindError",
+	}
+	EventKind_value = map[string]int32{
+		"EventKindUnknown":           0,
+		"EventKindSessionCreated":    1,
+		"EventKindSessionTerminated": 2,
+		"EventKindStatusChanged":     3,
+		"EventKindCycleCompleted":    4,
+		"EventKindConflictsAppeared": 5,
+		"EventKindConflictsCleared":  6,
+		"EventKindProblemsChanged":   7,
+		"EventKindError":             8,
+	}
+)
+
+func (x EventKind) Enum() *EventKind {
+	p := new(EventKind)
+	*p = x
+	return p
+}
+
+func (x EventKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_synchronization_event_proto_enumTypes[0].Descriptor()
+}
+
+func (EventKind) Type() protoreflect.EnumType {
+	return &file_synchronization_event_proto_enumTypes[0]
+}
+
+func (x EventKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventKind.Descriptor instead.
+func (EventKind) EnumDescriptor() ([]byte, []int) {
+	return file_synchronization_event_proto_rawDescGZIP(), []int{0}
+}
+
+// Event represents a synchronization session event.
+type Event struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Kind is the event kind.
+	Kind EventKind `protobuf:"varint,1,opt,name=kind,proto3,enum=synchronization.EventKind" json:"kind,omitempty"`
+	// Time is the time at which the event occurred.
+	Time *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	// Session is the identifier of the session associated with the event.
+	Session string `protobuf:"bytes,3,opt,name=session,proto3" json:"session,omitempty"`
+	// SessionName is the name of the session associated with the event, if
+	// any.
+	SessionName string `protobuf:"bytes,4,opt,name=sessionName,proto3" json:"sessionName,omitempty"`
+	// PreviousStatus is the previous session status. It is only set for
+	// EventKindStatusChanged events.
+	PreviousStatus Status `protobuf:"varint,5,opt,name=previousStatus,proto3,enum=synchronization.Status" json:"previousStatus,omitempty"`
+	// Status is the session status at the time of the event.
+	Status Status `protobuf:"varint,6,opt,name=status,proto3,enum=synchronization.Status" json:"status,omitempty"`
+	// AlphaChanges is the number of changes applied to alpha during a cycle. It
+	// is only set for EventKindCycleCompleted events.
+	AlphaChanges uint64 `protobuf:"varint,7,opt,name=alphaChanges,proto3" json:"alphaChanges,omitempty"`
+	// BetaChanges is the number of changes applied to beta during a cycle. It
+	// is only set for EventKindCycleCompleted events.
+	BetaChanges uint64 `protobuf:"varint,8,opt,name=betaChanges,proto3" json:"betaChanges,omitempty"`
+	// Conflicts is the number of conflicts at the time of the event. It is only
+	// set for EventKindCycleCompleted, EventKindConflictsAppeared, and
+	// EventKindConflictsCleared events.
+	Conflicts uint64 `protobuf:"varint,9,opt,name=conflicts,proto3" json:"conflicts,omitempty"`
+	// AlphaProblems is the total number of alpha scan and transition problems
+	// at the time of the event. It is only set for EventKindProblemsChanged
+	// events.
+	AlphaProblems uint64 `protobuf:"varint,10,opt,name=alphaProblems,proto3" json:"alphaProblems,omitempty"`
+	// BetaProblems is the total number of beta scan and transition problems at
+	// the time of the event. It is only set for EventKindProblemsChanged
+	// events.
+	BetaProblems uint64 `protobuf:"varint,11,opt,name=betaProblems,proto3" json:"betaProblems,omitempty"`
+	// Error is the error message. It is only set for EventKindError events.
+	Error string `protobuf:"bytes,12,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_synchronization_event_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_synchronization_event_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_synchronization_event_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Event) GetKind() EventKind {
+	if x != nil {
+		return x.Kind
+	}
+	return EventKind_EventKindUnknown
+}
+
+func (x *Event) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Event) GetSession() string {
+	if x != nil {
+		return x.Session
+	}
+	return ""
+}
+
+func (x *Event) GetSessionName() string {
+	if x != nil {
+		return x.SessionName
+	}
+	return ""
+}
+
+func (x *Event) GetPreviousStatus() Status {
+	if x != nil {
+		return x.PreviousStatus
+	}
+	return Status_Disconnected
+}
+
+func (x *Event) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_Disconnected
+}
+
+func (x *Event) GetAlphaChanges() uint64 {
+	if x != nil {
+		return x.AlphaChanges
+	}
+	return 0
+}
+
+func (x *Event) GetBetaChanges() uint64 {
+	if x != nil {
+		return x.BetaChanges
+	}
+	return 0
+}
+
+func (x *Event) GetConflicts() uint64 {
+	if x != nil {
+		return x.Conflicts
+	}
+	return 0
+}
+
+func (x *Event) GetAlphaProblems() uint64 {
+	if x != nil {
+		return x.AlphaProblems
+	}
+	return 0
+}
+
+func (x *Event) GetBetaProblems() uint64 {
+	if x != nil {
+		return x.BetaProblems
+	}
+	return 0
+}
+
+func (x *Event) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+var File_synchronization_event_proto protoreflect.FileDescriptor
+
+var file_synchronization_event_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x73,
+	0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x1f,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1b, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd9, 0x03, 0x0a,
+	0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4b, 0x69, 0x6e, 0x64,
+	0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x20, 0x0a, 0x0b, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x3f, 0x0a, 0x0e, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x73, 0x79, 0x6e,
+	0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x0e, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x2f, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x65, 0x74, 0x61,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62,
+	0x65, 0x74, 0x61, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f,
+	0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x63,
+	0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0d, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x73, 0x12, 0x22,
+	0x0a, 0x0c, 0x62, 0x65, 0x74, 0x61, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x73, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x62, 0x65, 0x74, 0x61, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65,
+	0x6d, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x2a, 0x88, 0x02, 0x0a, 0x09, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x10, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4b,
+	0x69, 0x6e, 0x64, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x4b, 0x69, 0x6e, 0x64, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x10, 0x01, 0x12, 0x1e, 0x0a, 0x1a, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x4b, 0x69, 0x6e, 0x64, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x65, 0x72,
+	0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x10, 0x02, 0x12, 0x1a, 0x0a, 0x16, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x4b, 0x69, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x64, 0x10, 0x03, 0x12, 0x1b, 0x0a, 0x17, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4b, 0x69,
+	0x6e, 0x64, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64,
+	0x10, 0x04, 0x12, 0x1e, 0x0a, 0x1a, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4b, 0x69, 0x6e, 0x64, 0x43,
+	0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x73, 0x41, 0x70, 0x70, 0x65, 0x61, 0x72, 0x65, 0x64,
+	0x10, 0x05, 0x12, 0x1d, 0x0a, 0x19, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4b, 0x69, 0x6e, 0x64, 0x43,
+	0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x73, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x65, 0x64, 0x10,
+	0x06, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4b, 0x69, 0x6e, 0x64, 0x50, 0x72,
+	0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x10, 0x07, 0x12,
+	0x12, 0x0a, 0x0e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x4b, 0x69, 0x6e, 0x64, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x10, 0x08, 0x42, 0x33, 0x5a, 0x31, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74,
+	0x61, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f,
+	0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_synchronization_event_proto_rawDescOnce sync.Once
+	file_synchronization_event_proto_rawDescData = file_synchronization_event_proto_rawDesc
+)
+
+func file_synchronization_event_proto_rawDescGZIP() []byte {
+	file_synchronization_event_proto_rawDescOnce.Do(func() {
+		file_synchronization_event_proto_rawDescData = protoimpl.X.CompressGZIP(file_synchronization_event_proto_rawDescData)
+	})
+	return file_synchronization_event_proto_rawDescData
+}
+
+var file_synchronization_event_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_synchronization_event_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_synchronization_event_proto_goTypes = []interface{}{
+	(EventKind)(0),                // 0: synchronization.EventKind
+	(*Event)(nil),                 // 1: synchronization.Event
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+	(Status)(0),                   // 3: synchronization.Status
+}
+var file_synchronization_event_proto_depIdxs = []int32{
+	0, // 0: synchronization.Event.kind:type_name -> synchronization.EventKind
+	2, // 1: synchronization.Event.time:type_name -> google.protobuf.Timestamp
+	3, // 2: synchronization.Event.previousStatus:type_name -> synchronization.Status
+	3, // 3: synchronization.Event.status:type_name -> synchronization.Status
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_synchronization_event_proto_init() }
+func file_synchronization_event_proto_init() {
+	if File_synchronization_event_proto != nil {
+		return
+	}
+	file_synchronization_state_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_synchronization_event_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Event); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_synchronization_event_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_synchronization_event_proto_goTypes,
+		DependencyIndexes: file_synchronization_event_proto_depIdxs,
+		EnumInfos:         file_synchronization_event_proto_enumTypes,
+		MessageInfos:      file_synchronization_event_proto_msgTypes,
+	}.Build()
+	File_synchronization_event_proto = out.File
+	file_synchronization_event_proto_rawDesc = nil
+	file_synchronization_event_proto_goTypes = nil
+	file_synchronization_event_proto_depIdxs = nil
+}
@@ -0,0 +1,162 @@
+package synchronization
+
+import (
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/state"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+// TestEventBroadcasterFiltering tests that event subscriptions only receive
+// events for matching sessions.
+func TestEventBroadcasterFiltering(t *testing.T) {
+	// Create a broadcaster and a filtered subscription.
+	broadcaster := newEventBroadcaster()
+	subscription := broadcaster.subscribe(func(session *Session) bool {
+		return session.Identifier == "match"
+	})
+	defer broadcaster.unsubscribe(subscription)
+
+	// Publish events for a matching and non-matching session.
+	broadcaster.publish(&Session{Identifier: "other"}, &Event{Session: "other"})
+	broadcaster.publish(&Session{Identifier: "match"}, &Event{Session: "match"})
+
+	// Verify that only the matching event was delivered.
+	select {
+	case event := <-subscription.events:
+		if event.Session != "match" {
+			t.Error("received event for non-matching session")
+		}
+	default:
+		t.Fatal("no event delivered")
+	}
+	select {
+	case <-subscription.events:
+		t.Error("unexpected additional event delivered")
+	default:
+	}
+}
+
+// TestEventBroadcasterOverflow tests that subscriptions that fall behind are
+// terminated.
+func TestEventBroadcasterOverflow(t *testing.T) {
+	// Create a broadcaster and an unfiltered subscription.
+	broadcaster := newEventBroadcaster()
+	subscription := broadcaster.subscribe(func(_ *Session) bool { return true })
+	defer broadcaster.unsubscribe(subscription)
+
+	// Publish more events than the subscription can buffer.
+	session := &Session{Identifier: "session"}
+	for i := 0; i < eventSubscriptionBufferSize+1; i++ {
+		broadcaster.publish(session, &Event{Session: session.Identifier})
+	}
+
+	// Drain the subscription and verify that it was terminated.
+	var count int
+	for range subscription.events {
+		count++
+	}
+	if count != eventSubscriptionBufferSize {
+		t.Error("unexpected number of buffered events:", count)
+	}
+	if subscription.err != ErrEventSubscriberFellBehind {
+		t.Error("unexpected subscription termination error:", subscription.err)
+	}
+}
+
+// TestEventBroadcasterTerminate tests broadcaster termination.
+func TestEventBroadcasterTerminate(t *testing.T) {
+	// Create a broadcaster and a subscription, then terminate broadcasting.
+	broadcaster := newEventBroadcaster()
+	subscription := broadcaster.subscribe(func(_ *Session) bool { return true })
+	broadcaster.terminate()
+
+	// Verify that the existing subscription was terminated.
+	if _, ok := <-subscription.events; ok {
+		t.Error("subscription not terminated")
+	} else if subscription.err != ErrEventBroadcastingTerminated {
+		t.Error("unexpected subscription termination error:", subscription.err)
+	}
+
+	// Verify that new subscriptions are terminated immediately.
+	subscription = broadcaster.subscribe(func(_ *Session) bool { return true })
+	if _, ok := <-subscription.events; ok {
+		t.Error("subscription not terminated")
+	}
+}
+
+// TestControllerStateObservation tests that controllers generate events based
+// on state changes.
+func TestControllerStateObservation(t *testing.T) {
+	// Create a tracker and defer its termination.
+	tracker := state.NewTracker()
+	defer tracker.Terminate()
+
+	// Create a broadcaster and an unfiltered subscription.
+	broadcaster := newEventBroadcaster()
+	subscription := broadcaster.subscribe(func(_ *Session) bool { return true })
+	defer broadcaster.unsubscribe(subscription)
+
+	// Create a minimal controller.
+	session := &Session{Identifier: "session"}
+	c := &controller{
+		session: session,
+		state: &State{
+			Session:    session,
+			AlphaState: &EndpointState{},
+			BetaState:  &EndpointState{},
+		},
+		events: broadcaster,
+	}
+	c.stateLock = state.NewObservedTrackingLock(tracker, c.observeState)
+
+	// Perform a series of state changes.
+	c.stateLock.Lock()
+	c.state.Status = Status_Scanning
+	c.stateLock.Unlock()
+	c.stateLock.Lock()
+	c.state.Conflicts = []*core.Conflict{{Root: "conflict"}}
+	c.state.AlphaState.ScanProblems = []*core.Problem{{Path: "problem"}}
+	c.stateLock.Unlock()
+	c.stateLock.Lock()
+	c.cycleAlphaChanges = 3
+	c.state.SuccessfulCycles++
+	c.stateLock.Unlock()
+	c.stateLock.Lock()
+	c.state.LastError = "failure"
+	c.state.Conflicts = nil
+	c.stateLock.Unlock()
+	c.stateLock.Lock()
+	c.stateLock.Unlock()
+
+	// Verify the resulting events.
+	expected := []EventKind{
+		EventKind_EventKindStatusChanged,
+		EventKind_EventKindConflictsAppeared,
+		EventKind_EventKindProblemsChanged,
+		EventKind_EventKindCycleCompleted,
+		EventKind_EventKindError,
+		EventKind_EventKindConflictsCleared,
+	}
+	for _, kind := range expected {
+		select {
+		case event := <-subscription.events:
+			if event.Kind != kind {
+				t.Fatalf("unexpected event kind: %s != %s", event.Kind.Description(), kind.Description())
+			}
+			if event.Kind == EventKind_EventKindCycleCompleted && event.AlphaChanges != 3 {
+				t.Error("unexpected alpha change count:", event.AlphaChanges)
+			}
+			if err := event.EnsureValid(); err != nil {
+				t.Error("invalid event:", err)
+			}
+		default:
+			t.Fatal("missing expected event:", kind.Description())
+		}
+	}
+	select {
+	case event := <-subscription.events:
+		t.Error("unexpected additional event:", event.Kind.Description())
+	default:
+	}
+}
@@ -0,0 +1,254 @@
+package synchronization
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+const (
+	// maximumHistoryEntries is the maximum number of cycles recorded in a
+	// session's history.
+	maximumHistoryEntries = 100
+	// maximumHistoryChangesPerEndpoint is the maximum number of changes
+	// recorded for each endpoint in a single history entry.
+	maximumHistoryChangesPerEndpoint = 500
+)
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (t CycleTrigger) MarshalText() ([]byte, error) {
+	var result string
+	switch t {
+	case CycleTrigger_CycleTriggerStartup:
+		result = "startup"
+	case CycleTrigger_CycleTriggerAlpha:
+		result = "alpha"
+	case CycleTrigger_CycleTriggerBeta:
+		result = "beta"
+	case CycleTrigger_CycleTriggerFlush:
+		result = "flush"
+	case CycleTrigger_CycleTriggerRetry:
+		result = "retry"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// Description returns a human-readable description of a cycle trigger.
+func (t CycleTrigger) Description() string {
+	switch t {
+	case CycleTrigger_CycleTriggerStartup:
+		return "Startup"
+	case CycleTrigger_CycleTriggerAlpha:
+		return "Alpha changes"
+	case CycleTrigger_CycleTriggerBeta:
+		return "Beta changes"
+	case CycleTrigger_CycleTriggerFlush:
+		return "Flush"
+	case CycleTrigger_CycleTriggerRetry:
+		return "Retry"
+	default:
+		return "Unknown"
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (k HistoryChangeKind) MarshalText() ([]byte, error) {
+	var result string
+	switch k {
+	case HistoryChangeKind_HistoryChangeKindCreated:
+		result = "created"
+	case HistoryChangeKind_HistoryChangeKindModified:
+		result = "modified"
+	case HistoryChangeKind_HistoryChangeKindDeleted:
+		result = "deleted"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// Description returns a human-readable description of a history change kind.
+func (k HistoryChangeKind) Description() string {
+	switch k {
+	case HistoryChangeKind_HistoryChangeKindCreated:
+		return "Created"
+	case HistoryChangeKind_HistoryChangeKindModified:
+		return "Modified"
+	case HistoryChangeKind_HistoryChangeKindDeleted:
+		return "Deleted"
+	default:
+		return "Unknown"
+	}
+}
+
+// EnsureValid ensures that HistoryChange's invariants are respected.
+func (c *HistoryChange) EnsureValid() error {
+	// A nil change is not valid.
+	if c == nil {
+		return errors.New("nil change")
+	}
+
+	// Ensure that the change kind is known.
+	if c.Kind == HistoryChangeKind_HistoryChangeKindUnknown {
+		return errors.New("unknown change kind")
+	}
+
+	// Success.
+	return nil
+}
+
+// EnsureValid ensures that HistoryEntry's invariants are respected.
+func (e *HistoryEntry) EnsureValid() error {
+	// A nil entry is not valid.
+	if e == nil {
+		return errors.New("nil entry")
+	}
+
+	// Ensure that the entry time is valid.
+	if err := e.Time.CheckValid(); err != nil {
+		return fmt.Errorf("invalid entry time: %w", err)
+	}
+
+	// Ensure that phase durations are valid.
+	for _, duration := range []*durationpb.Duration{
+		e.ScanDuration, e.ReconcileDuration, e.StageDuration, e.TransitionDuration,
+	} {
+		if err := duration.CheckValid(); err != nil {
+			return fmt.Errorf("invalid phase duration: %w", err)
+		}
+	}
+
+	// Ensure that changes are valid and that truncation is sane.
+	for _, c := range e.AlphaChanges {
+		if err := c.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid alpha change: %w", err)
+		}
+	}
+	if e.ExcludedAlphaChanges > 0 && len(e.AlphaChanges) == 0 {
+		return errors.New("excluded alpha changes reported with no alpha changes reported")
+	}
+	for _, c := range e.BetaChanges {
+		if err := c.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid beta change: %w", err)
+		}
+	}
+	if e.ExcludedBetaChanges > 0 && len(e.BetaChanges) == 0 {
+		return errors.New("excluded beta changes reported with no beta changes reported")
+	}
+
+	// Success.
+	return nil
+}
+
+// EnsureValid ensures that History's invariants are respected.
+func (h *History) EnsureValid() error {
+	// A nil history is not valid.
+	if h == nil {
+		return errors.New("nil history")
+	}
+
+	// Ensure that a session identifier is present.
+	if h.Session == "" {
+		return errors.New("empty session identifier")
+	}
+
+	// Ensure that all entries are valid.
+	for _, e := range h.Entries {
+		if err := e.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid history entry: %w", err)
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+// historyChanges computes the list of changes applied by a set of transitions,
+// given the corresponding transition results. Transitions that didn't modify
+// content (e.g. due to failure) are omitted. The result is truncated to the
+// maximum number of per-endpoint history changes, with the number of excluded
+// changes returned.
+func historyChanges(transitions []*core.Change, results []*core.Entry) ([]*HistoryChange, uint64) {
+	var changes []*HistoryChange
+	var excluded uint64
+	for t, transition := range transitions {
+		// Ensure that we have a corresponding result. If the transition
+		// operation failed completely, then we won't.
+		if t >= len(results) {
+			break
+		}
+		result := results[t]
+
+		// Determine the change kind, ignoring transitions that didn't apply.
+		var kind HistoryChangeKind
+		if transition.Old.Equal(result, true) {
+			continue
+		} else if transition.Old == nil {
+			kind = HistoryChangeKind_HistoryChangeKindCreated
+		} else if result == nil {
+			kind = HistoryChangeKind_HistoryChangeKindDeleted
+		} else {
+			kind = HistoryChangeKind_HistoryChangeKindModified
+		}
+
+		// Record the change, subject to truncation.
+		if len(changes) < maximumHistoryChangesPerEndpoint {
+			changes = append(changes, &HistoryChange{Path: transition.Path, Kind: kind})
+		} else {
+			excluded++
+		}
+	}
+	return changes, excluded
+}
+
+// cycleRecord tracks information about an in-progress synchronization cycle for
+// the purposes of recording history.
+type cycleRecord struct {
+	// trigger is the cycle trigger.
+	trigger CycleTrigger
+	// scanDuration is the duration of the scan phase.
+	scanDuration time.Duration
+	// reconcileDuration is the duration of the reconcile phase.
+	reconcileDuration time.Duration
+	// stageDuration is the duration of the staging phase.
+	stageDuration time.Duration
+	// transitionDuration is the duration of the transition phase.
+	transitionDuration time.Duration
+}
+
+// entry creates a history entry for a completed cycle.
+func (r *cycleRecord) entry(
+	αTransitions []*core.Change, αResults []*core.Entry,
+	βTransitions []*core.Change, βResults []*core.Entry,
+) *HistoryEntry {
+	alphaChanges, excludedAlphaChanges := historyChanges(αTransitions, αResults)
+	betaChanges, excludedBetaChanges := historyChanges(βTransitions, βResults)
+	return &HistoryEntry{
+		Time:                 timestamppb.Now(),
+		Trigger:              r.trigger,
+		ScanDuration:         durationpb.New(r.scanDuration),
+		ReconcileDuration:    durationpb.New(r.reconcileDuration),
+		StageDuration:        durationpb.New(r.stageDuration),
+		TransitionDuration:   durationpb.New(r.transitionDuration),
+		AlphaChanges:         alphaChanges,
+		ExcludedAlphaChanges: excludedAlphaChanges,
+		BetaChanges:          betaChanges,
+		ExcludedBetaChanges:  excludedBetaChanges,
+	}
+}
+
+// appendHistoryEntry appends an entry to a history, discarding the oldest
+// entries if the maximum history length is exceeded.
+func appendHistoryEntry(history *History, entry *HistoryEntry) {
+	history.Entries = append(history.Entries, entry)
+	if excess := len(history.Entries) - maximumHistoryEntries; excess > 0 {
+		history.Entries = history.Entries[excess:]
+	}
+}
@@ -0,0 +1,601 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.12
+// source: synchronization/history.proto
+
+package synchronization
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CycleTrigger encodes the reason that a synchronization cycle was performed.
+type CycleTrigger int32
+
+const (
+	// CycleTrigger_CycleTriggerUnknown indicates an unknown or unspecified
+	// trigger.
+	CycleTrigger_CycleTriggerUnknown CycleTrigger = 0
+	// CycleTrigger_CycleTriggerStartup indicates that the cycle was performed
+	// when the synchronization loop started (e.g. after creation, resumption,
+	// or reconnection).
+	CycleTrigger_CycleTriggerStartup CycleTrigger = 1
+	// CycleTrigger_CycleTriggerAlpha indicates that the cycle was triggered by
+	// changes on alpha.
+	CycleTrigger_CycleTriggerAlpha CycleTrigger = 2
+	// CycleTrigger_CycleTriggerBeta indicates that the cycle was triggered by
+	// changes on beta.
+	CycleTrigger_CycleTriggerBeta CycleTrigger = 3
+	// CycleTrigger_CycleTriggerFlush indicates that the cycle was triggered by
+	// a flush request.
+	CycleTrigger_CycleTriggerFlush CycleTrigger = 4
+	// CycleTrigger_CycleTriggerRetry indicates that the cycle was performed
+	// immediately after a previous cycle due to suspected concurrent
+	// modifications.
+	CycleTrigger_CycleTriggerRetry CycleTrigger = 5
+)
+
+// Enum value maps for CycleTrigger.
+var (
+	CycleTrigger_name = map[int32]string{
+		0: "CycleTriggerUnknown",
+		1: "CycleTriggerStartup",
+		2: "CycleTriggerAlpha",
+		3: "CycleTriggerBeta",
+		4: "CycleTriggerFlush",
+		5: "CycleTriggerRetry",
+	}
+	CycleTrigger_value = map[string]int32{
+		"CycleTriggerUnknown": 0,
+		"CycleTriggerStartup": 1,
+		"CycleTriggerAlpha":   2,
+		"CycleTriggerBeta":    3,
+		"CycleTriggerFlush":   4,
+		"CycleTriggerRetry":   5,
+	}
+)
+
+func (x CycleTrigger) Enum() *CycleTrigger {
+	p := new(CycleTrigger)
+	*p = x
+	return p
+}
+
+func (x CycleTrigger) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CycleTrigger) Descriptor() protoreflect.EnumDescriptor {
+	return file_synchronization_history_proto_enumTypes[0].Descriptor()
+}
+
+func (CycleTrigger) Type() protoreflect.EnumType {
+	return &file_synchronization_history_proto_enumTypes[0]
+}
+
+func (x CycleTrigger) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CycleTrigger.Descriptor instead.
+func (CycleTrigger) EnumDescriptor() ([]byte, []int) {
+	return file_synchronization_history_proto_rawDescGZIP(), []int{0}
+}
+
+// HistoryChangeKind encodes the kind of a change applied to an endpoint.
+type HistoryChangeKind int32
+
+const (
+	// HistoryChangeKind_HistoryChangeKindUnknown indicates an unknown or
+	// unspecified change kind.
+	HistoryChangeKind_HistoryChangeKindUnknown HistoryChangeKind = 0
+	// HistoryChangeKind_HistoryChangeKindCreated indicates that content was
+	// created at a path where none previously existed.
+	HistoryChangeKind_HistoryChangeKindCreated HistoryChangeKind = 1
+	// HistoryChangeKind_HistoryChangeKindModified indicates that content at a
+	// path was replaced or modified.
+	HistoryChangeKind_HistoryChangeKindModified HistoryChangeKind = 2
+	// HistoryChangeKind_HistoryChangeKindDeleted indicates that content at a
+	// path was removed.
+	HistoryChangeKind_HistoryChangeKindDeleted HistoryChangeKind = 3
+)
+
+// Enum value maps for HistoryChangeKind.
+var (
+	HistoryChangeKind_name = map[int32]string{
+		0: "HistoryChangeKindUnknown",
+		1: "HistoryChangeKindCreated",
+		2: "HistoryChangeKindModified",
+		3: "HistoryChangeKindDeleted",
+	}
+	HistoryChangeKind_value = map[string]int32{
+		"HistoryChangeKindUnknown":  0,
+		"HistoryChangeKindCreated":  1,
+		"HistoryChangeKindModified": 2,
+		"HistoryChangeKindDeleted":  3,
+	}
+)
+
+func (x HistoryChangeKind) Enum() *HistoryChangeKind {
+	p := new(HistoryChangeKind)
+	*p = x
+	return p
+}
+
+func (x HistoryChangeKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HistoryChangeKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_synchronization_history_proto_enumTypes[1].Descriptor()
+}
+
+func (HistoryChangeKind) Type() protoreflect.EnumType {
+	return &file_synchronization_history_proto_enumTypes[1]
+}
+
+func (x HistoryChangeKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HistoryChangeKind.Descriptor instead.
+func (HistoryChangeKind) EnumDescriptor() ([]byte, []int) {
+	return file_synchronization_history_proto_rawDescGZIP(), []int{1}
+}
+
+// HistoryChange records a single change applied to an endpoint.
+type HistoryChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Path is the path of the change root, relative to the synchronization
+	// root.
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Kind is the change kind.
+	Kind HistoryChangeKind `protobuf:"varint,2,opt,name=kind,proto3,enum=synchronization.HistoryChangeKind" json:"kind,omitempty"`
+}
+
+func (x *HistoryChange) Reset() {
+	*x = HistoryChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_synchronization_history_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HistoryChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryChange) ProtoMessage() {}
+
+func (x *HistoryChange) ProtoReflect() protoreflect.Message {
+	mi := &file_synchronization_history_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryChange.ProtoReflect.Descriptor instead.
+func (*HistoryChange) Descriptor() ([]byte, []int) {
+	return file_synchronization_history_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *HistoryChange) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *HistoryChange) GetKind() HistoryChangeKind {
+	if x != nil {
+		return x.Kind
+	}
+	return HistoryChangeKind_HistoryChangeKindUnknown
+}
+
+// HistoryEntry records a single completed synchronization cycle.
+type HistoryEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Time is the time at which the cycle completed.
+	Time *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	// Trigger is the reason that the cycle was performed.
+	Trigger CycleTrigger `protobuf:"varint,2,opt,name=trigger,proto3,enum=synchronization.CycleTrigger" json:"trigger,omitempty"`
+	// ScanDuration is the duration of the scan phase.
+	ScanDuration *durationpb.Duration `protobuf:"bytes,3,opt,name=scanDuration,proto3" json:"scanDuration,omitempty"`
+	// ReconcileDuration is the duration of the reconcile phase.
+	ReconcileDuration *durationpb.Duration `protobuf:"bytes,4,opt,name=reconcileDuration,proto3" json:"reconcileDuration,omitempty"`
+	// StageDuration is the duration of the staging phase.
+	StageDuration *durationpb.Duration `protobuf:"bytes,5,opt,name=stageDuration,proto3" json:"stageDuration,omitempty"`
+	// TransitionDuration is the duration of the transition phase.
+	TransitionDuration *durationpb.Duration `protobuf:"bytes,6,opt,name=transitionDuration,proto3" json:"transitionDuration,omitempty"`
+	// AlphaChanges are the changes applied to alpha. This list may be a
+	// truncated version of the full list if too many changes were applied, in
+	// which case ExcludedAlphaChanges will be non-zero.
+	AlphaChanges []*HistoryChange `protobuf:"bytes,7,rep,name=alphaChanges,proto3" json:"alphaChanges,omitempty"`
+	// ExcludedAlphaChanges is the number of changes that have been excluded
+	// from AlphaChanges due to truncation.
+	ExcludedAlphaChanges uint64 `protobuf:"varint,8,opt,name=excludedAlphaChanges,proto3" json:"excludedAlphaChanges,omitempty"`
+	// BetaChanges are the changes applied to beta. This list may be a truncated
+	// version of the full list if too many changes were applied, in which case
+	// ExcludedBetaChanges will be non-zero.
+	BetaChanges []*HistoryChange `protobuf:"bytes,9,rep,name=betaChanges,proto3" json:"betaChanges,omitempty"`
+	// ExcludedBetaChanges is the number of changes that have been excluded from
+	// BetaChanges due to truncation.
+	ExcludedBetaChanges uint64 `protobuf:"varint,10,opt,name=excludedBetaChanges,proto3" json:"excludedBetaChanges,omitempty"`
+}
+
+func (x *HistoryEntry) Reset() {
+	*x = HistoryEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_synchronization_history_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryEntry) ProtoMessage() {}
+
+func (x *HistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_synchronization_history_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryEntry.ProtoReflect.Descriptor instead.
+func (*HistoryEntry) Descriptor() ([]byte, []int) {
+	return file_synchronization_history_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HistoryEntry) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *HistoryEntry) GetTrigger() CycleTrigger {
+	if x != nil {
+		return x.Trigger
+	}
+	return CycleTrigger_CycleTriggerUnknown
+}
+
+func (x *HistoryEntry) GetScanDuration() *durationpb.Duration {
+	if x != nil {
+		return x.ScanDuration
+	}
+	return nil
+}
+
+func (x *HistoryEntry) GetReconcileDuration() *durationpb.Duration {
+	if x != nil {
+		return x.ReconcileDuration
+	}
+	return nil
+}
+
+func (x *HistoryEntry) GetStageDuration() *durationpb.Duration {
+	if x != nil {
+		return x.StageDuration
+	}
+	return nil
+}
+
+func (x *HistoryEntry) GetTransitionDuration() *durationpb.Duration {
+	if x != nil {
+		return x.TransitionDuration
+	}
+	return nil
+}
+
+func (x *HistoryEntry) GetAlphaChanges() []*HistoryChange {
+	if x != nil {
+		return x.AlphaChanges
+	}
+	return nil
+}
+
+func (x *HistoryEntry) GetExcludedAlphaChanges() uint64 {
+	if x != nil {
+		return x.ExcludedAlphaChanges
+	}
+	return 0
+}
+
+func (x *HistoryEntry) GetBetaChanges() []*HistoryChange {
+	if x != nil {
+		return x.BetaChanges
+	}
+	return nil
+}
+
+func (x *HistoryEntry) GetExcludedBetaChanges() uint64 {
+	if x != nil {
+		return x.ExcludedBetaChanges
+	}
+	return 0
+}
+
+// History records recent synchronization cycles for a session.
+type History struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Session is the identifier of the session to which the history belongs.
+	Session string `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	// Entries are the recorded cycles, ordered from oldest to newest.
+	Entries []*HistoryEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *History) Reset() {
+	*x = History{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_synchronization_history_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *History) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*History) ProtoMessage() {}
+
+func (x *History) ProtoReflect() protoreflect.Message {
+	mi := &file_synchronization_history_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use History.ProtoReflect.Descriptor instead.
+func (*History) Descriptor() ([]byte, []int) {
+	return file_synchronization_history_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *History) GetSession() string {
+	if x != nil {
+		return x.Session
+	}
+	return ""
+}
+
+func (x *History) GetEntries() []*HistoryEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+var File_synchronization_history_proto protoreflect.FileDescriptor
+
+var file_synchronization_history_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2f, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x0f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x5b, 0x0a, 0x0d, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x36, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0xf7,
+	0x04, 0x0a, 0x0c, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12,
+	0x37, 0x0a, 0x07, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1d, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x52,
+	0x07, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x12, 0x3d, 0x0a, 0x0c, 0x73, 0x63, 0x61, 0x6e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x73, 0x63, 0x61, 0x6e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x47, 0x0a, 0x11, 0x72, 0x65, 0x63, 0x6f, 0x6e,
+	0x63, 0x69, 0x6c, 0x65, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x11, 0x72,
+	0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x3f, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x67, 0x65, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x67, 0x65, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x49, 0x0a, 0x12, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x12, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x42, 0x0a, 0x0c,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x52, 0x0c, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73,
+	0x12, 0x32, 0x0a, 0x14, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x41, 0x6c, 0x70, 0x68,
+	0x61, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x14,
+	0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x73, 0x12, 0x40, 0x0a, 0x0b, 0x62, 0x65, 0x74, 0x61, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x79, 0x6e, 0x63,
+	0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0b, 0x62, 0x65, 0x74, 0x61, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x13, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x64, 0x42, 0x65, 0x74, 0x61, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x13, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x42, 0x65, 0x74,
+	0x61, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0x5c, 0x0a, 0x07, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x37, 0x0a,
+	0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65,
+	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x2a, 0x9b, 0x01, 0x0a, 0x0c, 0x43, 0x79, 0x63, 0x6c, 0x65,
+	0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x79, 0x63, 0x6c, 0x65,
+	0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00,
+	0x12, 0x17, 0x0a, 0x13, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x75, 0x70, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x79, 0x63,
+	0x6c, 0x65, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x10, 0x02,
+	0x12, 0x14, 0x0a, 0x10, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72,
+	0x42, 0x65, 0x74, 0x61, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x54,
+	0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x46, 0x6c, 0x75, 0x73, 0x68, 0x10, 0x04, 0x12, 0x15, 0x0a,
+	0x11, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x52, 0x65, 0x74,
+	0x72, 0x79, 0x10, 0x05, 0x2a, 0x8c, 0x01, 0x0a, 0x11, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x1c, 0x0a, 0x18, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x55,
+	0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12, 0x1c, 0x0a, 0x18, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72,
+	0x79, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x4d, 0x6f, 0x64, 0x69, 0x66,
+	0x69, 0x65, 0x64, 0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x64, 0x10, 0x03, 0x42, 0x33, 0x5a, 0x31, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74,
+	0x61, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f,
+	0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_synchronization_history_proto_rawDescOnce sync.Once
+	file_synchronization_history_proto_rawDescData = file_synchronization_history_proto_rawDesc
+)
+
+func file_synchronization_history_proto_rawDescGZIP() []byte {
+	file_synchronization_history_proto_rawDescOnce.Do(func() {
+		file_synchronization_history_proto_rawDescData = protoimpl.X.CompressGZIP(file_synchronization_history_proto_rawDescData)
+	})
+	return file_synchronization_history_proto_rawDescData
+}
+
+var file_synchronization_history_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_synchronization_history_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_synchronization_history_proto_goTypes = []interface{}{
+	(CycleTrigger)(0),             // 0: synchronization.CycleTrigger
+	(HistoryChangeKind)(0),        // 1: synchronization.HistoryChangeKind
+	(*HistoryChange)(nil),         // 2: synchronization.HistoryChange
+	(*HistoryEntry)(nil),          // 3: synchronization.HistoryEntry
+	(*History)(nil),               // 4: synchronization.History
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),   // 6: google.protobuf.Duration
+}
+var file_synchronization_history_proto_depIdxs = []int32{
+	1,  // 0: synchronization.HistoryChange.kind:type_name -> synchronization.HistoryChangeKind
+	5,  // 1: synchronization.HistoryEntry.time:type_name -> google.protobuf.Timestamp
+	0,  // 2: synchronization.HistoryEntry.trigger:type_name -> synchronization.CycleTrigger
+	6,  // 3: synchronization.HistoryEntry.scanDuration:type_name -> google.protobuf.Duration
+	6,  // 4: synchronization.HistoryEntry.reconcileDuration:type_name -> google.protobuf.Duration
+	6,  // 5: synchronization.HistoryEntry.stageDuration:type_name -> google.protobuf.Duration
+	6,  // 6: synchronization.HistoryEntry.transitionDuration:type_name -> google.protobuf.Duration
+	2,  // 7: synchronization.HistoryEntry.alphaChanges:type_name -> synchronization.HistoryChange
+	2,  // 8: synchronization.HistoryEntry.betaChanges:type_name -> synchronization.HistoryChange
+	3,  // 9: synchronization.History.entries:type_name -> synchronization.HistoryEntry
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_synchronization_history_proto_init() }
+func file_synchronization_history_proto_init() {
+	if File_synchronization_history_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_synchronization_history_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HistoryChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_synchronization_history_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HistoryEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_synchronization_history_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*History); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_synchronization_history_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_synchronization_history_proto_goTypes,
+		DependencyIndexes: file_synchronization_history_proto_depIdxs,
+		EnumInfos:         file_synchronization_history_proto_enumTypes,
+		MessageInfos:      file_synchronization_history_proto_msgTypes,
+	}.Build()
+	File_synchronization_history_proto = out.File
+	file_synchronization_history_proto_rawDesc = nil
+	file_synchronization_history_proto_goTypes = nil
+	file_synchronization_history_proto_depIdxs = nil
+}
@@ -0,0 +1,168 @@
+package synchronization
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+// TestHistoryChanges tests that historyChanges classifies and filters
+// transitions correctly.
+func TestHistoryChanges(t *testing.T) {
+	// Create test entries.
+	file := &core.Entry{Kind: core.EntryKind_File, Digest: []byte{0}}
+	modified := &core.Entry{Kind: core.EntryKind_File, Digest: []byte{1}}
+
+	// Create transitions and results, including one transition that failed to
+	// apply (i.e. whose result matches its original contents) and one without a
+	// corresponding result.
+	transitions := []*core.Change{
+		{Path: "created", New: file},
+		{Path: "modified", Old: file, New: modified},
+		{Path: "deleted", Old: file},
+		{Path: "failed", Old: file, New: modified},
+		{Path: "missing", New: file},
+	}
+	results := []*core.Entry{file, modified, nil, file}
+
+	// Compute changes.
+	changes, excluded := historyChanges(transitions, results)
+	if excluded != 0 {
+		t.Error("unexpected excluded changes:", excluded)
+	}
+
+	// Verify changes.
+	expected := []*HistoryChange{
+		{Path: "created", Kind: HistoryChangeKind_HistoryChangeKindCreated},
+		{Path: "modified", Kind: HistoryChangeKind_HistoryChangeKindModified},
+		{Path: "deleted", Kind: HistoryChangeKind_HistoryChangeKindDeleted},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("change count (%d) does not match expected (%d)", len(changes), len(expected))
+	}
+	for c, change := range changes {
+		if change.Path != expected[c].Path || change.Kind != expected[c].Kind {
+			t.Errorf("change (%s, %s) does not match expected (%s, %s)",
+				change.Path, change.Kind, expected[c].Path, expected[c].Kind,
+			)
+		}
+	}
+}
+
+// TestHistoryChangesTruncation tests that historyChanges truncates changes.
+func TestHistoryChangesTruncation(t *testing.T) {
+	// Create more transitions than can be recorded.
+	file := &core.Entry{Kind: core.EntryKind_File, Digest: []byte{0}}
+	count := maximumHistoryChangesPerEndpoint + 10
+	transitions := make([]*core.Change, count)
+	results := make([]*core.Entry, count)
+	for i := 0; i < count; i++ {
+		transitions[i] = &core.Change{Path: fmt.Sprintf("file%d", i), New: file}
+		results[i] = file
+	}
+
+	// Compute changes and verify truncation.
+	changes, excluded := historyChanges(transitions, results)
+	if len(changes) != maximumHistoryChangesPerEndpoint {
+		t.Error("unexpected change count:", len(changes))
+	}
+	if excluded != 10 {
+		t.Error("unexpected excluded change count:", excluded)
+	}
+}
+
+// TestAppendHistoryEntry tests that appendHistoryEntry bounds history length.
+func TestAppendHistoryEntry(t *testing.T) {
+	// Create a history and overfill it.
+	history := &History{Session: "session"}
+	record := &cycleRecord{trigger: CycleTrigger_CycleTriggerAlpha}
+	var last *HistoryEntry
+	for i := 0; i < maximumHistoryEntries+5; i++ {
+		last = record.entry(nil, nil, nil, nil)
+		appendHistoryEntry(history, last)
+	}
+
+	// Verify the history length and that the newest entry was retained.
+	if len(history.Entries) != maximumHistoryEntries {
+		t.Fatal("unexpected history length:", len(history.Entries))
+	}
+	if history.Entries[maximumHistoryEntries-1] != last {
+		t.Error("newest entry not retained")
+	}
+
+	// Verify that the history is valid.
+	if err := history.EnsureValid(); err != nil {
+		t.Error("history invalid:", err)
+	}
+}
+
+// TestHistoryEnsureValid tests History.EnsureValid.
+func TestHistoryEnsureValid(t *testing.T) {
+	// Create a valid entry.
+	entry := (&cycleRecord{}).entry(nil, nil, nil, nil)
+
+	// Set up test cases.
+	testCases := []struct {
+		history     *History
+		expectValid bool
+	}{
+		{nil, false},
+		{&History{}, false},
+		{&History{Session: "session"}, true},
+		{&History{Session: "session", Entries: []*HistoryEntry{entry}}, true},
+		{&History{Session: "session", Entries: []*HistoryEntry{nil}}, false},
+		{&History{Session: "session", Entries: []*HistoryEntry{{}}}, false},
+		{&History{Session: "session", Entries: []*HistoryEntry{{
+			Time:                 entry.Time,
+			ScanDuration:         entry.ScanDuration,
+			ReconcileDuration:    entry.ReconcileDuration,
+			StageDuration:        entry.StageDuration,
+			TransitionDuration:   entry.TransitionDuration,
+			ExcludedAlphaChanges: 1,
+		}}}, false},
+		{&History{Session: "session", Entries: []*HistoryEntry{{
+			Time:               entry.Time,
+			ScanDuration:       entry.ScanDuration,
+			ReconcileDuration:  entry.ReconcileDuration,
+			StageDuration:      entry.StageDuration,
+			TransitionDuration: entry.TransitionDuration,
+			BetaChanges:        []*HistoryChange{{Path: "path"}},
+		}}}, false},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		if err := testCase.history.EnsureValid(); err == nil && !testCase.expectValid {
+			t.Errorf("test case %d: history incorrectly classified as valid", i)
+		} else if err != nil && testCase.expectValid {
+			t.Errorf("test case %d: history incorrectly classified as invalid: %v", i, err)
+		}
+	}
+}
+
+// TestCycleTriggerMarshalText tests that CycleTrigger text marshaling works as
+// expected.
+func TestCycleTriggerMarshalText(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		trigger      CycleTrigger
+		expectedText string
+	}{
+		{CycleTrigger_CycleTriggerUnknown, "unknown"},
+		{CycleTrigger_CycleTriggerStartup, "startup"},
+		{CycleTrigger_CycleTriggerAlpha, "alpha"},
+		{CycleTrigger_CycleTriggerBeta, "beta"},
+		{CycleTrigger_CycleTriggerFlush, "flush"},
+		{CycleTrigger_CycleTriggerRetry, "retry"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if text, err := testCase.trigger.MarshalText(); err != nil {
+			t.Errorf("unable to marshal trigger (%s): %v", testCase.trigger, err)
+		} else if string(text) != testCase.expectedText {
+			t.Errorf("marshaled text (%s) does not match expected (%s)", text, testCase.expectedText)
+		}
+	}
+}
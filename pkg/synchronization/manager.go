@@ -42,6 +42,8 @@ type Manager struct {
 	sessionsLock *state.TrackingLock
 	// sessions maps sessions to their respective controllers.
 	sessions map[string]*controller
+	// events is the broadcaster used to publish session events.
+	events *eventBroadcaster
 }
 
 // NewManager creates a new Manager instance.
@@ -50,6 +52,9 @@ func NewManager(logger *logging.Logger) (*Manager, error) {
 	tracker := state.NewTracker()
 	sessionsLock := state.NewTrackingLock(tracker)
 
+	// Create an event broadcaster.
+	events := newEventBroadcaster()
+
 	// Create the session registry.
 	sessions := make(map[string]*controller)
 
@@ -70,7 +75,7 @@ func NewManager(logger *logging.Logger) (*Manager, error) {
 			continue
 		}
 		logger.Info("Loading session", id)
-		if controller, err := loadSession(logger.Sublogger(identifier.Truncated(id)), tracker, id); err != nil {
+		if controller, err := loadSession(logger.Sublogger(identifier.Truncated(id)).WithSession(id), tracker, events, id); err != nil {
 			logger.Warnf("Failed to load session %s: %v", id, err)
 			continue
 		} else {
@@ -85,6 +90,7 @@ func NewManager(logger *logging.Logger) (*Manager, error) {
 		tracker:      tracker,
 		sessionsLock: sessionsLock,
 		sessions:     sessions,
+		events:       events,
 	}, nil
 }
 
@@ -180,6 +186,37 @@ func (m *Manager) selectControllers(selection *selection.Selection) ([]*controll
 	}
 }
 
+// sessionFilter creates a session filter function using the mechanism specified
+// by the provided selection. Unlike selectControllers, it doesn't require that
+// specifications match existing sessions, since it's used to filter events for
+// sessions that may not exist yet.
+func sessionFilter(criteria *selection.Selection) (func(*Session) bool, error) {
+	// Dispatch filter creation based on the requested mechanism.
+	if criteria.All {
+		return func(_ *Session) bool { return true }, nil
+	} else if len(criteria.Specifications) > 0 {
+		specifications := criteria.Specifications
+		return func(session *Session) bool {
+			for _, specification := range specifications {
+				if session.Identifier == specification || session.Name == specification {
+					return true
+				}
+			}
+			return false
+		}, nil
+	} else if criteria.LabelSelector != "" {
+		selector, err := selection.ParseLabelSelector(criteria.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse label selector: %w", err)
+		}
+		return func(session *Session) bool {
+			return selector.Matches(session.Labels)
+		}, nil
+	} else {
+		return nil, errors.New("invalid session selection")
+	}
+}
+
 // Shutdown tells the manager to gracefully halt sessions.
 func (m *Manager) Shutdown() {
 	// Log the shutdown.
@@ -188,6 +225,9 @@ func (m *Manager) Shutdown() {
 	// Terminate state tracking to terminate monitoring.
 	m.tracker.Terminate()
 
+	// Terminate event broadcasting to terminate event streams.
+	m.events.terminate()
+
 	// Grab the registry lock and defer its release.
 	m.sessionsLock.Lock()
 	defer m.sessionsLock.UnlockWithoutNotify()
@@ -202,7 +242,8 @@ func (m *Manager) Shutdown() {
 	}
 }
 
-// Create tells the manager to create a new session.
+// Create tells the manager to create a new session. If archive is non-nil, then
+// it's used as the session's initial ancestor archive.
 func (m *Manager) Create(
 	ctx context.Context,
 	alpha, beta *url.URL,
@@ -210,6 +251,7 @@ func (m *Manager) Create(
 	name string,
 	labels map[string]string,
 	paused bool,
+	archive *core.Archive,
 	prompter string,
 ) (string, error) {
 	// Create a unique session identifier.
@@ -221,14 +263,16 @@ func (m *Manager) Create(
 	// Attempt to create a session.
 	controller, err := newSession(
 		ctx,
-		m.logger.Sublogger(identifier.Truncated(id)),
+		m.logger.Sublogger(identifier.Truncated(id)).WithSession(id),
 		m.tracker,
+		m.events,
 		id,
 		alpha, beta,
 		configuration, configurationAlpha, configurationBeta,
 		name,
 		labels,
 		paused,
+		archive,
 		prompter,
 	)
 	if err != nil {
@@ -240,6 +284,9 @@ func (m *Manager) Create(
 	m.sessions[controller.session.Identifier] = controller
 	m.sessionsLock.Unlock()
 
+	// Publish a creation event.
+	controller.publishLifecycleEvent(EventKind_EventKindSessionCreated)
+
 	// Done.
 	return controller.session.Identifier, nil
 }
@@ -344,6 +391,85 @@ func (m *Manager) List(ctx context.Context, selection *selection.Selection, prev
 	return stateIndex, states, nil
 }
 
+// History requests cycle histories for the specified sessions. Histories will
+// be ordered by session creation time, from oldest to newest.
+func (m *Manager) History(_ context.Context, selection *selection.Selection) ([]*History, error) {
+	// Extract the controllers for the sessions of interest.
+	controllers, err := m.selectControllers(selection)
+	if err != nil {
+		return nil, fmt.Errorf("unable to locate requested sessions: %w", err)
+	}
+
+	// Sort controllers by session creation time.
+	sort.Slice(controllers, func(i, j int) bool {
+		iTime := controllers[i].session.CreationTime
+		jTime := controllers[j].session.CreationTime
+		return iTime.Seconds < jTime.Seconds ||
+			(iTime.Seconds == jTime.Seconds && iTime.Nanos < jTime.Nanos)
+	})
+
+	// Create history snapshots.
+	histories := make([]*History, len(controllers))
+	for i, controller := range controllers {
+		histories[i] = controller.currentHistory()
+	}
+
+	// Success.
+	return histories, nil
+}
+
+// Archive loads the current ancestor archive for the session matching the given
+// specification.
+func (m *Manager) Archive(_ context.Context, specification string) (*core.Archive, error) {
+	// Extract the controller for the session of interest.
+	controllers, err := m.findControllersBySpecification([]string{specification})
+	if err != nil {
+		return nil, fmt.Errorf("unable to locate requested session: %w", err)
+	} else if len(controllers) > 1 {
+		return nil, fmt.Errorf("specification \"%s\" matched multiple sessions", specification)
+	}
+
+	// Load the archive.
+	archive, err := controllers[0].currentArchive()
+	if err != nil {
+		return nil, fmt.Errorf("unable to load session archive: %w", err)
+	}
+
+	// Success.
+	return archive, nil
+}
+
+// Watch streams events for sessions matching the given specifications,
+// invoking the specified handler for each event. It returns when the context is
+// cancelled, when the handler returns an error, or when the event stream is
+// terminated (e.g. due to the subscriber falling behind or manager shutdown).
+func (m *Manager) Watch(ctx context.Context, selection *selection.Selection, handler func(*Event) error) error {
+	// Create the session filter.
+	filter, err := sessionFilter(selection)
+	if err != nil {
+		return err
+	}
+
+	// Subscribe to events and defer unsubscription.
+	subscription := m.events.subscribe(filter)
+	defer m.events.unsubscribe(subscription)
+
+	// Forward events until cancellation or failure.
+	for {
+		select {
+		case <-ctx.Done():
+			return context.Canceled
+		case event, ok := <-subscription.events:
+			if !ok {
+				return subscription.err
+			}
+			if err := handler(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
 // Flush tells the manager to flush sessions matching the given specifications.
 func (m *Manager) Flush(ctx context.Context, selection *selection.Selection, prompter string, skipWait bool) error {
 	// Extract the controllers for the sessions of interest.
@@ -422,6 +548,31 @@ func (m *Manager) Reset(ctx context.Context, selection *selection.Selection, pro
 	return nil
 }
 
+// Update tells the manager to replace the configuration of the session matching
+// the given specification. The specification must match exactly one session.
+func (m *Manager) Update(
+	ctx context.Context,
+	specification string,
+	configuration, configurationAlpha, configurationBeta *Configuration,
+	prompter string,
+) error {
+	// Extract the controller for the session of interest.
+	controllers, err := m.findControllersBySpecification([]string{specification})
+	if err != nil {
+		return fmt.Errorf("unable to locate requested session: %w", err)
+	} else if len(controllers) > 1 {
+		return fmt.Errorf("specification \"%s\" matched multiple sessions", specification)
+	}
+
+	// Attempt to update the session.
+	if err := controllers[0].update(ctx, configuration, configurationAlpha, configurationBeta, prompter); err != nil {
+		return fmt.Errorf("unable to update session: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
 // Terminate tells the manager to terminate sessions matching the given
 // specifications.
 func (m *Manager) Terminate(ctx context.Context, selection *selection.Selection, prompter string) error {
@@ -440,6 +591,7 @@ func (m *Manager) Terminate(ctx context.Context, selection *selection.Selection,
 		m.sessionsLock.Lock()
 		delete(m.sessions, controller.session.Identifier)
 		m.sessionsLock.Unlock()
+		controller.publishLifecycleEvent(EventKind_EventKindSessionTerminated)
 	}
 
 	// Success.
@@ -0,0 +1,190 @@
+package synchronization
+
+import (
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/mutagen-io/mutagen/pkg/metrics"
+)
+
+const (
+	// cyclePhaseScan is the name of the scan phase of a synchronization cycle.
+	cyclePhaseScan = "scan"
+	// cyclePhaseReconcile is the name of the reconcile phase of a
+	// synchronization cycle.
+	cyclePhaseReconcile = "reconcile"
+	// cyclePhaseStage is the name of the staging phase of a synchronization
+	// cycle.
+	cyclePhaseStage = "stage"
+	// cyclePhaseTransition is the name of the transition phase of a
+	// synchronization cycle.
+	cyclePhaseTransition = "transition"
+)
+
+// cyclePhases is the ordered list of synchronization cycle phases.
+var cyclePhases = []string{
+	cyclePhaseScan,
+	cyclePhaseReconcile,
+	cyclePhaseStage,
+	cyclePhaseTransition,
+}
+
+// newPhaseDurations creates a new set of cycle phase duration histograms.
+func newPhaseDurations() map[string]*metrics.Histogram {
+	result := make(map[string]*metrics.Histogram, len(cyclePhases))
+	for _, phase := range cyclePhases {
+		result[phase] = metrics.NewHistogram(metrics.DefaultDurationBuckets)
+	}
+	return result
+}
+
+// metricsSnapshot is a snapshot of a controller's metrics.
+type metricsSnapshot struct {
+	// state is the session state.
+	state *State
+	// alphaStagedBytes is the total number of bytes staged on alpha.
+	alphaStagedBytes uint64
+	// betaStagedBytes is the total number of bytes staged on beta.
+	betaStagedBytes uint64
+	// phaseDurations are the cycle phase duration histograms.
+	phaseDurations map[string]*metrics.Histogram
+}
+
+// metricsSnapshot captures a snapshot of the controller's metrics.
+func (c *controller) metricsSnapshot() metricsSnapshot {
+	// Grab the state lock and defer its release.
+	c.stateLock.Lock()
+	defer c.stateLock.UnlockWithoutNotify()
+
+	// Create the snapshot.
+	return metricsSnapshot{
+		state:            proto.Clone(c.state).(*State),
+		alphaStagedBytes: c.alphaStagedBytes,
+		betaStagedBytes:  c.betaStagedBytes,
+		phaseDurations:   c.phaseDurations,
+	}
+}
+
+// sessionLabels computes the metric labels identifying a session.
+func sessionLabels(session *Session, extra ...metrics.Label) []metrics.Label {
+	labels := make([]metrics.Label, 0, 2+len(extra))
+	labels = append(labels,
+		metrics.Label{Name: "session", Value: session.Identifier},
+		metrics.Label{Name: "name", Value: session.Name},
+	)
+	return append(labels, extra...)
+}
+
+// endpointSample is a function that extracts a sample value from an endpoint
+// state.
+type endpointSample func(*EndpointState) float64
+
+// CollectMetrics implements metrics.Collector.CollectMetrics.
+func (m *Manager) CollectMetrics(encoder *metrics.Encoder) {
+	// Capture snapshots for all sessions and sort them by creation time so that
+	// output ordering is stable.
+	controllers := m.allControllers()
+	snapshots := make([]metricsSnapshot, 0, len(controllers))
+	for _, controller := range controllers {
+		snapshots = append(snapshots, controller.metricsSnapshot())
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		iTime := snapshots[i].state.Session.CreationTime
+		jTime := snapshots[j].state.Session.CreationTime
+		return iTime.Seconds < jTime.Seconds ||
+			(iTime.Seconds == jTime.Seconds && iTime.Nanos < jTime.Nanos)
+	})
+
+	// Write the session count.
+	encoder.Family("mutagen_synchronization_sessions", "Number of synchronization sessions.", metrics.TypeGauge)
+	encoder.Sample("mutagen_synchronization_sessions", nil, float64(len(snapshots)))
+
+	// Write pause state.
+	encoder.Family("mutagen_synchronization_session_paused", "Whether or not the session is paused.", metrics.TypeGauge)
+	for _, s := range snapshots {
+		encoder.Sample("mutagen_synchronization_session_paused", sessionLabels(s.state.Session), metrics.Bool(s.state.Session.Paused))
+	}
+
+	// Write status information.
+	encoder.Family("mutagen_synchronization_session_status", "Current session status (1 for the active status, 0 otherwise).", metrics.TypeGauge)
+	for _, s := range snapshots {
+		for value := int32(0); value < int32(len(Status_name)); value++ {
+			status := Status(value)
+			name, _ := status.MarshalText()
+			labels := sessionLabels(s.state.Session, metrics.Label{Name: "status", Value: string(name)})
+			encoder.Sample("mutagen_synchronization_session_status", labels, metrics.Bool(s.state.Status == status))
+		}
+	}
+
+	// Write cycle counts.
+	encoder.Family("mutagen_synchronization_successful_cycles_total", "Number of successful synchronization cycles.", metrics.TypeCounter)
+	for _, s := range snapshots {
+		encoder.Sample("mutagen_synchronization_successful_cycles_total", sessionLabels(s.state.Session), float64(s.state.SuccessfulCycles))
+	}
+
+	// Write conflict counts.
+	encoder.Family("mutagen_synchronization_conflicts", "Number of conflicts.", metrics.TypeGauge)
+	for _, s := range snapshots {
+		conflicts := uint64(len(s.state.Conflicts)) + s.state.ExcludedConflicts
+		encoder.Sample("mutagen_synchronization_conflicts", sessionLabels(s.state.Session), float64(conflicts))
+	}
+
+	// Write per-endpoint gauges.
+	endpointGauges := []struct {
+		name   string
+		help   string
+		sample endpointSample
+	}{
+		{"mutagen_synchronization_endpoint_connected", "Whether or not the endpoint is connected.", func(e *EndpointState) float64 {
+			return metrics.Bool(e.Connected)
+		}},
+		{"mutagen_synchronization_endpoint_directories", "Number of synchronizable directories on the endpoint.", func(e *EndpointState) float64 {
+			return float64(e.Directories)
+		}},
+		{"mutagen_synchronization_endpoint_files", "Number of synchronizable files on the endpoint.", func(e *EndpointState) float64 {
+			return float64(e.Files)
+		}},
+		{"mutagen_synchronization_endpoint_symbolic_links", "Number of synchronizable symbolic links on the endpoint.", func(e *EndpointState) float64 {
+			return float64(e.SymbolicLinks)
+		}},
+		{"mutagen_synchronization_endpoint_total_file_size_bytes", "Total size of synchronizable files on the endpoint.", func(e *EndpointState) float64 {
+			return float64(e.TotalFileSize)
+		}},
+		{"mutagen_synchronization_endpoint_scan_problems", "Number of scan problems on the endpoint.", func(e *EndpointState) float64 {
+			return float64(uint64(len(e.ScanProblems)) + e.ExcludedScanProblems)
+		}},
+		{"mutagen_synchronization_endpoint_transition_problems", "Number of transition problems on the endpoint.", func(e *EndpointState) float64 {
+			return float64(uint64(len(e.TransitionProblems)) + e.ExcludedTransitionProblems)
+		}},
+		{"mutagen_synchronization_endpoint_staging_received_bytes", "Number of bytes received by the endpoint in the current staging operation.", func(e *EndpointState) float64 {
+			if e.StagingProgress == nil {
+				return 0
+			}
+			return float64(e.StagingProgress.TotalReceivedSize)
+		}},
+	}
+	for _, gauge := range endpointGauges {
+		encoder.Family(gauge.name, gauge.help, metrics.TypeGauge)
+		for _, s := range snapshots {
+			encoder.Sample(gauge.name, sessionLabels(s.state.Session, metrics.Label{Name: "endpoint", Value: "alpha"}), gauge.sample(s.state.AlphaState))
+			encoder.Sample(gauge.name, sessionLabels(s.state.Session, metrics.Label{Name: "endpoint", Value: "beta"}), gauge.sample(s.state.BetaState))
+		}
+	}
+
+	// Write staged byte counts.
+	encoder.Family("mutagen_synchronization_endpoint_staged_bytes_total", "Total number of bytes staged on the endpoint.", metrics.TypeCounter)
+	for _, s := range snapshots {
+		encoder.Sample("mutagen_synchronization_endpoint_staged_bytes_total", sessionLabels(s.state.Session, metrics.Label{Name: "endpoint", Value: "alpha"}), float64(s.alphaStagedBytes))
+		encoder.Sample("mutagen_synchronization_endpoint_staged_bytes_total", sessionLabels(s.state.Session, metrics.Label{Name: "endpoint", Value: "beta"}), float64(s.betaStagedBytes))
+	}
+
+	// Write cycle phase duration histograms.
+	encoder.Family("mutagen_synchronization_cycle_phase_duration_seconds", "Duration of synchronization cycle phases.", metrics.TypeHistogram)
+	for _, s := range snapshots {
+		for _, phase := range cyclePhases {
+			labels := sessionLabels(s.state.Session, metrics.Label{Name: "phase", Value: phase})
+			encoder.Histogram("mutagen_synchronization_cycle_phase_duration_seconds", labels, s.phaseDurations[phase])
+		}
+	}
+}
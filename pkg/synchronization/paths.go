@@ -33,3 +33,16 @@ func pathForArchive(session string) (string, error) {
 	// Success.
 	return filepath.Join(archivesDirectoryPath, session), nil
 }
+
+// pathForHistory computes the path to the serialized cycle history for the
+// given session identifier.
+func pathForHistory(session string) (string, error) {
+	// Compute/create the histories directory.
+	historiesDirectoryPath, err := filesystem.Mutagen(true, filesystem.MutagenSynchronizationHistoriesDirectoryName)
+	if err != nil {
+		return "", fmt.Errorf("unable to compute/create histories directory: %w", err)
+	}
+
+	// Success.
+	return filepath.Join(historiesDirectoryPath, session), nil
+}
@@ -3,9 +3,110 @@ package forwarding
 import (
 	"errors"
 	"fmt"
+	"net"
+	"strconv"
 	"strings"
 )
 
+const (
+	// maximumPortRangeSize is the maximum number of ports that can be covered
+	// by a port range specification.
+	maximumPortRangeSize = 1024
+)
+
+// isTCPProtocol returns whether or not the specified protocol is a TCP-based
+// protocol.
+func isTCPProtocol(protocol string) bool {
+	return protocol == "tcp" || protocol == "tcp4" || protocol == "tcp6"
+}
+
+// parsePortRange parses the port component of a TCP address and determines
+// whether or not it's a port range specification (e.g. "9000-9010"). If it's
+// not a range specification, then it returns false and the remaining return
+// values should be ignored.
+func parsePortRange(port string) (uint16, uint16, bool, error) {
+	// Check if this is a range specification.
+	components := strings.SplitN(port, "-", 2)
+	if len(components) != 2 {
+		return 0, 0, false, nil
+	}
+
+	// Parse the range bounds.
+	first, err := strconv.ParseUint(components[0], 10, 16)
+	if err != nil {
+		return 0, 0, true, fmt.Errorf("invalid first port: %w", err)
+	}
+	last, err := strconv.ParseUint(components[1], 10, 16)
+	if err != nil {
+		return 0, 0, true, fmt.Errorf("invalid last port: %w", err)
+	}
+
+	// Validate the range.
+	if first == 0 {
+		return 0, 0, true, errors.New("port ranges cannot include port 0")
+	} else if first > last {
+		return 0, 0, true, errors.New("first port greater than last port")
+	} else if last-first+1 > maximumPortRangeSize {
+		return 0, 0, true, fmt.Errorf("port range exceeds %d ports", maximumPortRangeSize)
+	}
+
+	// Success.
+	return uint16(first), uint16(last), true, nil
+}
+
+// IsPortRange returns whether or not the specified address is a TCP port range
+// specification (e.g. ":9000-9010"). It does not validate the range.
+func IsPortRange(protocol, address string) bool {
+	// Only TCP addresses can specify port ranges.
+	if !isTCPProtocol(protocol) {
+		return false
+	}
+
+	// Split the address.
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+
+	// Check for a range.
+	return strings.ContainsRune(port, '-')
+}
+
+// ExpandAddress expands a TCP port range specification (e.g. ":9000-9010")
+// into a list of individual addresses, one for each port in the range. For
+// non-TCP protocols or addresses that don't specify a port range, it returns
+// a single-element list containing the original address.
+func ExpandAddress(protocol, address string) ([]string, error) {
+	// Only TCP addresses can specify port ranges.
+	if !isTCPProtocol(protocol) {
+		return []string{address}, nil
+	}
+
+	// Split the address. If it can't be split, then we leave it to the
+	// networking stack to report an appropriate error when it's used.
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return []string{address}, nil
+	}
+
+	// Parse any port range.
+	first, last, isRange, err := parsePortRange(port)
+	if err != nil {
+		return nil, err
+	} else if !isRange {
+		return []string{address}, nil
+	}
+
+	// Expand the range.
+	result := make([]string, 0, int(last)-int(first)+1)
+	for p := int(first); p <= int(last); p++ {
+		result = append(result, net.JoinHostPort(host, strconv.Itoa(p)))
+	}
+
+	// Success.
+	return result, nil
+}
+
 // Parse parses a forwarding sub-URL (which is stored as the Path component of
 // an endpoint URL) into protocol and address components.
 func Parse(url string) (string, string, error) {
@@ -31,6 +132,11 @@ func Parse(url string) (string, string, error) {
 		return "", "", errors.New("empty address")
 	}
 
+	// If the address specifies a port range, then ensure that it's valid.
+	if _, err := ExpandAddress(components[0], components[1]); err != nil {
+		return "", "", fmt.Errorf("invalid port range: %w", err)
+	}
+
 	// Success.
 	return components[0], components[1], nil
 }
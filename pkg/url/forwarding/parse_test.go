@@ -20,6 +20,12 @@ func TestParse(t *testing.T) {
 		{"invalid::3992", "", "", true},
 		{"tcp::3992", "tcp", ":3992", false},
 		{"tcp4:localhost:3992", "tcp4", "localhost:3992", false},
+		{"tcp::9000-9010", "tcp", ":9000-9010", false},
+		{"tcp:localhost:0", "tcp", "localhost:0", false},
+		{"tcp::9010-9000", "", "", true},
+		{"tcp::0-10", "", "", true},
+		{"tcp::1-65535", "", "", true},
+		{"tcp::9000-abc", "", "", true},
 		{"tcp6:[::1]:3992", "tcp6", "[::1]:3992", false},
 		{"unix:/some/socket.sock", "unix", "/some/socket.sock", false},
 		{`npipe:\\.\pipe\pipe_name`, "npipe", `\\.\pipe\pipe_name`, false},
@@ -50,3 +56,57 @@ func TestParse(t *testing.T) {
 		}
 	}
 }
+
+// TestExpandAddress tests that the ExpandAddress function behaves as expected
+// for a variety of test cases.
+func TestExpandAddress(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		protocol          string
+		address           string
+		expectedAddresses []string
+		expectRange       bool
+		expectFailure     bool
+	}{
+		{"tcp", ":3992", []string{":3992"}, false, false},
+		{"tcp", "localhost:0", []string{"localhost:0"}, false, false},
+		{"tcp", ":9000-9002", []string{":9000", ":9001", ":9002"}, true, false},
+		{"tcp4", "127.0.0.1:9000-9000", []string{"127.0.0.1:9000"}, true, false},
+		{"tcp6", "[::1]:80-81", []string{"[::1]:80", "[::1]:81"}, true, false},
+		{"tcp", ":9002-9000", nil, true, true},
+		{"unix", "/some/socket-1-2", []string{"/some/socket-1-2"}, false, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		// Check range detection.
+		if isRange := IsPortRange(testCase.protocol, testCase.address); isRange != testCase.expectRange {
+			t.Errorf("range detection (%t) does not match expected (%t) for address: %s",
+				isRange, testCase.expectRange, testCase.address,
+			)
+		}
+
+		// Perform expansion and ensure that failure behavior is as expected.
+		addresses, err := ExpandAddress(testCase.protocol, testCase.address)
+		if err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("expansion failed for address (%s): %v", testCase.address, err)
+			}
+			continue
+		} else if testCase.expectFailure {
+			t.Error("expansion succeeded unexpectedly for address:", testCase.address)
+			continue
+		}
+
+		// Check that the addresses are what's expected.
+		if len(addresses) != len(testCase.expectedAddresses) {
+			t.Error("address count does not match expected:", len(addresses), "!=", len(testCase.expectedAddresses))
+			continue
+		}
+		for i, address := range addresses {
+			if address != testCase.expectedAddresses[i] {
+				t.Error("address does not match expected:", address, "!=", testCase.expectedAddresses[i])
+			}
+		}
+	}
+}